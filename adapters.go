@@ -2,6 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/commands"
@@ -13,9 +19,60 @@ import (
 // defaultConfigFile is the unified tool's default config+state path.
 const defaultConfigFile = "cherry-picker.yaml"
 
+// configOutFile is the global --config-out path. When set, commands still read
+// from --config but every write lands here instead.
+var configOutFile string
+
+// outputSeeded records whether configOutFile has been seeded from --config
+// during this process, so later writes in the same run build on earlier ones.
+var outputSeeded bool
+
+// savePath returns the path writers persist to for the given --config path.
+func savePath(configFile string) string {
+	if configOutFile == "" {
+		return configFile
+	}
+	return configOutFile
+}
+
+// updateState is the state.Update every writer in this package goes through.
+// With --config-out, the first write of the run copies the --config state to
+// the output path so sections the command does not touch are carried over.
+// Under --dry-run nothing is written.
+func updateState(configFile string, mutate func(*state.Config) error) error {
+	out := savePath(configFile)
+	if commands.DryRun() {
+		slog.Info("Dry run: not saving config", "file", out)
+		return nil
+	}
+	if out != configFile && !outputSeeded {
+		if err := seedOutput(configFile, out); err != nil {
+			return err
+		}
+		outputSeeded = true
+	}
+	return state.Update(out, mutate)
+}
+
+// seedOutput overwrites out with the current contents of configFile. A missing
+// source (e.g. first run of 'config') leaves out untouched.
+func seedOutput(configFile, out string) error {
+	src, err := state.Load(configFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return state.Update(out, func(cur *state.Config) error {
+		*cur = *src
+		return nil
+	})
+}
+
 // The load/save adapters bridge the single unified state file to the
 // per-subsystem in-memory types the existing commands operate on. Loads project
-// a view; saves reconcile a (possibly mutated) view back through state.Update, so
+// a view; saves reconcile a (possibly mutated) view back through updateState, so
 // a concurrent daemon writer is never clobbered (see internal/state merge).
 
 func loadCherry(f string) (*cmd.Config, error) {
@@ -27,26 +84,42 @@ func loadCherry(f string) (*cmd.Config, error) {
 }
 
 func saveCherry(f string, v *cmd.Config) error {
-	return state.Update(f, func(cur *state.Config) error {
+	return updateState(f, func(cur *state.Config) error {
 		cur.MergeCherryView(v)
 		return nil
 	})
 }
 
+// replaceCherry writes v over the cherry-pick section instead of merging it, for
+// the few edits that remove keys a merge would keep (rename-branch) or move a
+// branch back to an earlier status (abort resetting picked to failed).
+func replaceCherry(f string, v *cmd.Config) error {
+	return updateState(f, func(cur *state.Config) error {
+		cur.ApplyCherryView(v)
+		return nil
+	})
+}
+
 func saveDep(f string, v *depmerger.Config) error {
-	return state.Update(f, func(cur *state.Config) error {
+	return updateState(f, func(cur *state.Config) error {
 		cur.MergeDepView(v)
 		return nil
 	})
 }
 
 // loadStateAndClient loads the unified state and builds a GitHub client for the
-// configured repository.
+// configured repository. Like BaseCommand.Init, it fails on an incoherent
+// cherry_picks section before any GitHub call.
 func loadStateAndClient(ctx context.Context, configFile string) (*github.Client, *state.Config, error) {
 	st, err := state.Load(configFile)
 	if err != nil {
 		return nil, nil, err
 	}
+	if st.CherryPicks.Configured() {
+		if err := st.CherryView().Validate(); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", configFile, err)
+		}
+	}
 	client, _, err := commands.InitializeGitHubClient(ctx, st.CherryView())
 	if err != nil {
 		return nil, nil, err
@@ -54,12 +127,59 @@ func loadStateAndClient(ctx context.Context, configFile string) (*github.Client,
 	return client, st, nil
 }
 
-// prTrackedInCherry reports whether prNumber is tracked in the cherry-pick section.
-func prTrackedInCherry(st *state.Config, prNumber int) bool {
-	for i := range st.CherryPicks.TrackedPRs {
-		if st.CherryPicks.TrackedPRs[i].Number == prNumber {
-			return true
+// cherryRepoBases returns a BaseCommand per cherry-pick repository in st: the
+// top-level org/repo, then each entry of cherry_picks.repositories, with a client
+// for that repository and a SaveConfig that writes the view back into its own
+// entry. A non-empty repo ("org/repo") selects just that repository.
+func cherryRepoBases(client *github.Client, st *state.Config, configFile *string, repo string) ([]commands.BaseCommand, error) {
+	root := st.CherryView()
+	if _, ok := root.FindRepositoryView(repo); !ok {
+		return nil, fmt.Errorf("repository %s is not tracked in %s", repo, *configFile)
+	}
+
+	var bases []commands.BaseCommand
+	for i, view := range root.RepositoryViews() {
+		if repo != "" && view.Org+"/"+view.Repo != repo {
+			continue
+		}
+		base := commands.BaseCommand{
+			ConfigFile:   configFile,
+			LoadConfig:   loadCherry,
+			SaveConfig:   saveCherry,
+			GitHubClient: client,
+			Config:       view,
+		}
+		if i > 0 {
+			base.GitHubClient = client.WithRepository(view.Org, view.Repo)
+			base.SaveConfig = func(f string, v *cmd.Config) error {
+				root.ApplyRepositoryView(i, v)
+				return saveCherry(f, root)
+			}
+		}
+		bases = append(bases, base)
+	}
+	return bases, nil
+}
+
+// cherryBaseForPR returns the base of the repository tracking prNumber. A PR
+// number tracked in several repositories is an error, to be narrowed with --repo.
+func cherryBaseForPR(bases []commands.BaseCommand, prNumber int) (commands.BaseCommand, bool, error) {
+	var matches []commands.BaseCommand
+	for _, base := range bases {
+		if slices.ContainsFunc(base.Config.TrackedPRs, func(pr cmd.TrackedPR) bool { return pr.Number == prNumber }) {
+			matches = append(matches, base)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return commands.BaseCommand{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	default:
+		repos := make([]string, 0, len(matches))
+		for _, base := range matches {
+			repos = append(repos, base.Config.Org+"/"+base.Config.Repo)
 		}
+		return commands.BaseCommand{}, false, fmt.Errorf("PR #%d is tracked in %s; pass --repo to choose one", prNumber, strings.Join(repos, ", "))
 	}
-	return false
 }
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/cmd/abort"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/depmerger"
+	"github.com/alan/cherry-picker/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeState saves a state file built by mutate in a temporary directory
+func writeState(t *testing.T, mutate func(*state.Config)) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cherry-picker.yaml")
+	require.NoError(t, state.Update(path, func(c *state.Config) error {
+		mutate(c)
+		return nil
+	}))
+	return path
+}
+
+func TestLoadStateAndClient_ValidatesCherryPicks(t *testing.T) {
+	path := writeState(t, func(c *state.Config) {
+		c.Org, c.Repo = "acme", "widget"
+		c.CherryPicks.SourceBranch = "main"
+		c.CherryPicks.TrackedPRs = []cmd.TrackedPR{{Number: 1}, {Number: 1}}
+	})
+
+	_, _, err := loadStateAndClient(t.Context(), path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PR #1 is tracked more than once")
+}
+
+func TestLoadStateAndClient_DependencyOnlyConfigSkipsValidation(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	path := writeState(t, func(c *state.Config) {
+		c.Org, c.Repo = "acme", "widget"
+	})
+
+	_, _, err := loadStateAndClient(t.Context(), path)
+	require.Error(t, err, "the missing token still fails")
+	assert.NotContains(t, err.Error(), "invalid config")
+}
+
+// trackedState writes a state file with one cherry-pick PR picked for two
+// branches and one dependency PR
+func trackedState(t *testing.T) string {
+	t.Helper()
+	return writeState(t, func(c *state.Config) {
+		c.Org, c.Repo = "acme", "widget"
+		c.CherryPicks.SourceBranch = "main"
+		c.CherryPicks.TrackedPRs = []cmd.TrackedPR{{Number: 1, Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPending},
+			"release-1.1": {Status: cmd.BranchStatusPending},
+		}}}
+		c.Dependencies.TrackedPRs = []depmerger.TrackedPR{{Number: 7, Title: "Bump foo"}}
+	})
+}
+
+// resetConfigOut restores the --config-out globals once the test is done
+func resetConfigOut(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		configOutFile = ""
+		outputSeeded = false
+	})
+}
+
+func TestSaveCherry_RoundTrip(t *testing.T) {
+	path := trackedState(t)
+
+	view, err := loadCherry(path)
+	require.NoError(t, err)
+	view.TrackedPRs[0].Branches["release-1.0"] = cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 11}}
+	delete(view.TrackedPRs[0].Branches, "release-1.1")
+	require.NoError(t, saveCherry(path, view))
+
+	reloaded, err := loadCherry(path)
+	require.NoError(t, err)
+	branches := reloaded.TrackedPRs[0].Branches
+	assert.Equal(t, cmd.BranchStatusPicked, branches["release-1.0"].Status)
+	assert.Equal(t, 11, branches["release-1.0"].PR.Number)
+	assert.Contains(t, branches, "release-1.1", "a merge doesn't drop branches")
+
+	st, err := state.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Bump foo", st.Dependencies.TrackedPRs[0].Title, "the dependency section is kept")
+}
+
+func TestReplaceCherry_DropsRemovedKeys(t *testing.T) {
+	path := trackedState(t)
+
+	view, err := loadCherry(path)
+	require.NoError(t, err)
+	delete(view.TrackedPRs[0].Branches, "release-1.1")
+	require.NoError(t, replaceCherry(path, view))
+
+	reloaded, err := loadCherry(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"release-1.0"}, slices.Collect(maps.Keys(reloaded.TrackedPRs[0].Branches)))
+
+	st, err := state.Load(path)
+	require.NoError(t, err)
+	assert.Len(t, st.Dependencies.TrackedPRs, 1)
+}
+
+func TestUpdateState_DryRun(t *testing.T) {
+	path := trackedState(t)
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	commands.SetDryRun(true)
+	t.Cleanup(func() { commands.SetDryRun(false) })
+	resetConfigOut(t)
+	configOutFile = filepath.Join(t.TempDir(), "out.yaml")
+
+	view, err := loadCherry(path)
+	require.NoError(t, err)
+	view.TrackedPRs[0].Branches["release-1.0"] = cmd.BranchStatus{Status: cmd.BranchStatusPicked}
+	require.NoError(t, saveCherry(path, view))
+	require.NoError(t, replaceCherry(path, view))
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+	assert.NoFileExists(t, configOutFile, "--config-out isn't written either")
+}
+
+func TestUpdateState_ConfigOut(t *testing.T) {
+	path := trackedState(t)
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	resetConfigOut(t)
+	configOutFile = filepath.Join(t.TempDir(), "out.yaml")
+
+	view, err := loadCherry(path)
+	require.NoError(t, err)
+	view.TrackedPRs[0].Branches["release-1.0"] = cmd.BranchStatus{Status: cmd.BranchStatusPicked}
+	require.NoError(t, saveCherry(path, view))
+	view.TrackedPRs[0].Branches["release-1.1"] = cmd.BranchStatus{Status: cmd.BranchStatusFailed}
+	require.NoError(t, saveCherry(path, view))
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after), "--config is only read")
+
+	out, err := state.Load(configOutFile)
+	require.NoError(t, err)
+	branches := out.CherryPicks.TrackedPRs[0].Branches
+	assert.Equal(t, cmd.BranchStatusPicked, branches["release-1.0"].Status, "the second write builds on the first")
+	assert.Equal(t, cmd.BranchStatusFailed, branches["release-1.1"].Status)
+	assert.Equal(t, "main", out.CherryPicks.SourceBranch)
+	assert.Equal(t, "Bump foo", out.Dependencies.TrackedPRs[0].Title, "sections the command doesn't touch are seeded from --config")
+}
+
+func TestAbort_ResetIsSaved(t *testing.T) {
+	path := writeState(t, func(c *state.Config) {
+		c.Org, c.Repo = "acme", "widget"
+		c.CherryPicks.SourceBranch = "main"
+		c.CherryPicks.TrackedPRs = []cmd.TrackedPR{{Number: 1, Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPicked},
+		}}}
+	})
+	t.Chdir(t.TempDir())
+	require.NoError(t, exec.Command("git", "init", "-b", "main").Run())
+
+	abortCmd := abort.NewAbortCmd(&path, loadCherry, replaceCherry)
+	abortCmd.SetArgs([]string{"1"})
+	require.NoError(t, abortCmd.Execute())
+
+	reloaded, err := loadCherry(path)
+	require.NoError(t, err)
+	assert.Equal(t, cmd.BranchStatusFailed, reloaded.TrackedPRs[0].Branches["release-1.0"].Status,
+		"the downgrade from picked survives the save")
+}
@@ -0,0 +1,207 @@
+// Package abort implements the abort command for cleaning up after a pick that was interrupted mid-conflict.
+package abort
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the abort command with common functionality
+type command struct {
+	commands.BaseCommand
+	PRNumber     int
+	TargetBranch string
+}
+
+// NewAbortCmd creates the abort command
+func NewAbortCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	abortCmd := &command{}
+
+	return &cobra.Command{
+		Use:   "abort <pr-number> [target-branch]",
+		Short: "Clean up a pick that was interrupted",
+		Long: `Clean up after a pick that stopped half way, e.g. when it was interrupted
+during conflict resolution.
+
+If a cherry-pick of the PR is still in progress it is aborted, the source
+branch is checked out again and the local cherry-pick-<pr>-<branch> branches
+are deleted. A branch marked picked without a cherry-pick PR goes back to
+failed so it can be picked again. Nothing is changed on GitHub.
+
+Without a target branch, all of the PR's branches are cleaned up.
+
+Examples:
+  cherry-picker abort 123
+  cherry-picker abort 123 release-1.0`,
+		Args:         cobra.RangeArgs(1, 2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+			if err != nil {
+				return err
+			}
+			abortCmd.PRNumber = prNumber
+			abortCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+
+			// abort only touches the local repository and the config, so it doesn't need GitHub
+			abortCmd.ConfigFile = globalConfigFile
+			abortCmd.LoadConfig = loadConfig
+			abortCmd.SaveConfig = saveConfig
+			config, err := loadConfig(*globalConfigFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			abortCmd.Config = config
+
+			return abortCmd.Run()
+		},
+	}
+}
+
+// Run aborts the PR's in-progress cherry-pick, removes its local pick branches
+// and resets branches left marked picked without a cherry-pick PR
+func (ac *command) Run() error {
+	pr, err := commands.FindAndValidatePR(ac.Config, ac.PRNumber)
+	if err != nil {
+		return err
+	}
+	if err := commands.ValidateTargetBranch(pr, ac.TargetBranch); err != nil {
+		return err
+	}
+	if !commands.IsGitRepository() {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	branches := commands.DetermineBranchesToUpdate(pr, ac.TargetBranch)
+	slices.Sort(branches)
+	pickBranches := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		pickBranches = append(pickBranches, cmd.PickBranchName(pr.Number, branch))
+	}
+
+	current, err := currentBranch()
+	if err != nil {
+		return err
+	}
+	onPickBranch := slices.Contains(pickBranches, current)
+	cleaned := false
+
+	inProgress, err := cherryPickInProgress()
+	if err != nil {
+		return err
+	}
+	if inProgress {
+		if !onPickBranch {
+			return fmt.Errorf("the cherry-pick in progress on %s is not a pick of PR #%d; run 'git cherry-pick --abort' yourself to drop it", describeBranch(current), pr.Number)
+		}
+		if err := runGit("cherry-pick", "--abort"); err != nil {
+			return fmt.Errorf("failed to abort cherry-pick: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "🛑 Aborted the cherry-pick in progress on %s\n", current)
+		cleaned = true
+	}
+
+	if onPickBranch {
+		if ac.Config.SourceBranch == "" {
+			return fmt.Errorf("no source branch configured to switch back to from %s", current)
+		}
+		if err := runGit("checkout", ac.Config.SourceBranch); err != nil {
+			return fmt.Errorf("failed to checkout %s: %w", ac.Config.SourceBranch, err)
+		}
+		fmt.Fprintf(os.Stderr, "↩️  Switched back to %s\n", ac.Config.SourceBranch)
+	}
+
+	for _, pickBranch := range pickBranches {
+		if !localBranchExists(pickBranch) {
+			continue
+		}
+		if err := runGit("branch", "-D", pickBranch); err != nil {
+			return fmt.Errorf("failed to delete branch %s: %w", pickBranch, err)
+		}
+		fmt.Fprintf(os.Stderr, "🗑️  Deleted local branch %s\n", pickBranch)
+		cleaned = true
+	}
+
+	if reset := resetUnfinishedPicks(pr, branches); len(reset) > 0 {
+		if err := ac.SaveConfig(*ac.ConfigFile, ac.Config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "🔁 Reset %s to failed for PR #%d\n", strings.Join(reset, ", "), pr.Number)
+		cleaned = true
+	}
+
+	if !cleaned {
+		fmt.Fprintf(os.Stderr, "Nothing to abort for PR #%d\n", pr.Number)
+	}
+	return nil
+}
+
+// resetUnfinishedPicks moves branches marked picked without a cherry-pick PR
+// back to failed and returns them. A branch with a PR is left alone: the PR
+// exists on GitHub whatever happened locally.
+func resetUnfinishedPicks(pr *cmd.TrackedPR, branches []string) []string {
+	var reset []string
+	for _, branch := range branches {
+		status, exists := pr.Branches[branch]
+		if !exists || status.Status != cmd.BranchStatusPicked || status.PR != nil {
+			continue
+		}
+		pr.Branches[branch] = cmd.BranchStatus{Status: cmd.BranchStatusFailed}
+		slog.Info("Reset unfinished pick to failed", "pr", pr.Number, "branch", branch)
+		reset = append(reset, branch)
+	}
+	return reset
+}
+
+// cherryPickInProgress reports whether git has a cherry-pick stopped on conflicts (CHERRY_PICK_HEAD exists)
+func cherryPickInProgress() (bool, error) {
+	output, err := exec.Command("git", "rev-parse", "--git-path", "CHERRY_PICK_HEAD").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to locate CHERRY_PICK_HEAD: %w", err)
+	}
+	if _, err := os.Stat(strings.TrimSpace(string(output))); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// currentBranch returns the checked out branch, or "" when HEAD is detached
+func currentBranch() (string, error) {
+	output, err := exec.Command("git", "branch", "--show-current").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read current branch: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// describeBranch names a branch for messages, including a detached HEAD
+func describeBranch(branch string) string {
+	if branch == "" {
+		return "a detached HEAD"
+	}
+	return branch
+}
+
+// localBranchExists reports whether the repository has a local branch with this name
+func localBranchExists(branch string) bool {
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch).Run() == nil //nolint:gosec // Branch name is from tracked config
+}
+
+// runGit runs a git command with its output on stderr
+func runGit(args ...string) error {
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Stdout = os.Stderr
+	gitCmd.Stderr = os.Stderr
+	return gitCmd.Run()
+}
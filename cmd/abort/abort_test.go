@@ -0,0 +1,141 @@
+package abort
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// git runs a git command in the current directory and returns its trimmed output
+func git(t *testing.T, args ...string) string {
+	t.Helper()
+	output, err := exec.Command("git", args...).CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), output)
+	return strings.TrimSpace(string(output))
+}
+
+// setupConflictedPick creates a repository on main with release-1.0 and leaves
+// a cherry-pick of main's last commit stopped on conflicts on cherry-pick-123-release-1.0
+func setupConflictedPick(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	git(t, "init", "-b", "main")
+	git(t, "config", "user.name", "Test User")
+	git(t, "config", "user.email", "test@example.com")
+	git(t, "config", "commit.gpgsign", "false")
+
+	require.NoError(t, os.WriteFile("file.txt", []byte("base\n"), 0o644))
+	git(t, "add", "file.txt")
+	git(t, "commit", "-m", "Base")
+	git(t, "branch", "release-1.0")
+
+	require.NoError(t, os.WriteFile("file.txt", []byte("main change\n"), 0o644))
+	git(t, "commit", "-am", "Change on main")
+	sha := git(t, "rev-parse", "HEAD")
+
+	git(t, "checkout", "release-1.0")
+	require.NoError(t, os.WriteFile("file.txt", []byte("release change\n"), 0o644))
+	git(t, "commit", "-am", "Change on release")
+
+	git(t, "checkout", "-b", "cherry-pick-123-release-1.0")
+	require.Error(t, exec.Command("git", "cherry-pick", sha).Run(), "the cherry-pick should stop on conflicts")
+}
+
+func newTestCommand(t *testing.T, status cmd.BranchStatus) (*command, *int) {
+	t.Helper()
+	configFile := filepath.Join(t.TempDir(), "cherry-picks.yaml")
+	saves := 0
+
+	ac := &command{PRNumber: 123}
+	ac.ConfigFile = &configFile
+	ac.SaveConfig = func(string, *cmd.Config) error {
+		saves++
+		return nil
+	}
+	ac.Config = &cmd.Config{
+		SourceBranch: "main",
+		TrackedPRs: []cmd.TrackedPR{{
+			Number:   123,
+			Branches: map[string]cmd.BranchStatus{"release-1.0": status},
+		}},
+	}
+	return ac, &saves
+}
+
+func TestNewAbortCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(string) (*cmd.Config, error) { return &cmd.Config{}, nil }
+	saveConfig := func(string, *cmd.Config) error { return nil }
+
+	cobraCmd := NewAbortCmd(&configFile, loadConfig, saveConfig)
+
+	assert.Equal(t, "abort", cobraCmd.Name())
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{}))
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"123", "release-1.0"}))
+	require.Error(t, cobraCmd.RunE(cobraCmd, []string{"invalid"}))
+}
+
+func TestCommand_Run_AbortsConflictedPick(t *testing.T) {
+	setupConflictedPick(t)
+	ac, saves := newTestCommand(t, cmd.BranchStatus{Status: cmd.BranchStatusPicked})
+
+	require.NoError(t, ac.Run())
+
+	inProgress, err := cherryPickInProgress()
+	require.NoError(t, err)
+	assert.False(t, inProgress, "the cherry-pick is aborted")
+	assert.Equal(t, "main", git(t, "branch", "--show-current"))
+	assert.False(t, localBranchExists("cherry-pick-123-release-1.0"), "the pick branch is deleted")
+	assert.Equal(t, cmd.BranchStatusFailed, ac.Config.TrackedPRs[0].Branches["release-1.0"].Status)
+	assert.Equal(t, 1, *saves)
+}
+
+func TestCommand_Run_KeepsPickedWithPR(t *testing.T) {
+	setupConflictedPick(t)
+	picked := cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 456}}
+	ac, saves := newTestCommand(t, picked)
+
+	require.NoError(t, ac.Run())
+
+	assert.Equal(t, picked, ac.Config.TrackedPRs[0].Branches["release-1.0"])
+	assert.Zero(t, *saves)
+}
+
+func TestCommand_Run_RefusesOtherCherryPick(t *testing.T) {
+	setupConflictedPick(t)
+	git(t, "branch", "-m", "my-work")
+	ac, _ := newTestCommand(t, cmd.BranchStatus{Status: cmd.BranchStatusFailed})
+
+	err := ac.Run()
+	require.ErrorContains(t, err, "not a pick of PR #123")
+
+	inProgress, err := cherryPickInProgress()
+	require.NoError(t, err)
+	assert.True(t, inProgress, "someone else's cherry-pick is left alone")
+}
+
+func TestCommand_Run_NothingToAbort(t *testing.T) {
+	setupConflictedPick(t)
+	git(t, "cherry-pick", "--abort")
+	git(t, "checkout", "main")
+	git(t, "branch", "-D", "cherry-pick-123-release-1.0")
+	ac, saves := newTestCommand(t, cmd.BranchStatus{Status: cmd.BranchStatusFailed})
+
+	require.NoError(t, ac.Run())
+	assert.Zero(t, *saves)
+}
+
+func TestCommand_Run_UntrackedBranch(t *testing.T) {
+	ac, _ := newTestCommand(t, cmd.BranchStatus{Status: cmd.BranchStatusFailed})
+	ac.TargetBranch = "release-2.0"
+
+	require.ErrorContains(t, ac.Run(), "no status for branch")
+}
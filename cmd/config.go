@@ -2,6 +2,10 @@
 package cmd
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/alan/cherry-picker/internal/types"
@@ -12,10 +16,11 @@ type CIStatus = types.CIStatus
 
 // CI status constants from internal/types package
 const (
-	CIStatusPassing = types.CIStatusPassing
-	CIStatusFailing = types.CIStatusFailing
-	CIStatusPending = types.CIStatusPending
-	CIStatusUnknown = types.CIStatusUnknown
+	CIStatusPassing  = types.CIStatusPassing
+	CIStatusFailing  = types.CIStatusFailing
+	CIStatusPending  = types.CIStatusPending
+	CIStatusUnknown  = types.CIStatusUnknown
+	CIStatusNoChecks = types.CIStatusNoChecks
 )
 
 // ParseCIStatus is an alias for types.ParseCIStatus
@@ -31,6 +36,8 @@ const (
 	BranchStatusFailed BranchStatusType = "failed"
 	// BranchStatusPicked indicates bot or manual pick successfully created cherry-pick PR
 	BranchStatusPicked BranchStatusType = "picked"
+	// BranchStatusQueued indicates cherry-pick PR has been added to GitHub's merge queue
+	BranchStatusQueued BranchStatusType = "queued"
 	// BranchStatusMerged indicates cherry-pick PR has been merged
 	BranchStatusMerged BranchStatusType = "merged"
 	// BranchStatusReleased indicates cherry-pick PR has been included in a release
@@ -46,6 +53,8 @@ func ParseBranchStatus(s string) BranchStatusType {
 		return BranchStatusFailed
 	case "picked":
 		return BranchStatusPicked
+	case "queued":
+		return BranchStatusQueued
 	case "merged":
 		return BranchStatusMerged
 	case "released":
@@ -55,36 +64,438 @@ func ParseBranchStatus(s string) BranchStatusType {
 	}
 }
 
+// ResolutionMethod records how a cherry-pick PR created or amended by the pick command was produced
+type ResolutionMethod string
+
+const (
+	// ResolutionClean indicates the cherry-pick applied without conflicts
+	ResolutionClean ResolutionMethod = "clean"
+	// ResolutionAIAssisted indicates conflicts were resolved in an AI assistant session
+	ResolutionAIAssisted ResolutionMethod = "ai-assisted"
+	// ResolutionForceAmend indicates an existing cherry-pick PR was amended with pick --force
+	ResolutionForceAmend ResolutionMethod = "force-amend"
+	// ResolutionAutoPicked indicates fetch --auto-pick-clean applied the cherry-pick without conflicts
+	ResolutionAutoPicked ResolutionMethod = "auto-picked"
+	// ResolutionRerere indicates conflicts were resolved by replaying recorded git rerere resolutions
+	ResolutionRerere ResolutionMethod = "rerere"
+	// ResolutionAutoResolved indicates conflicts were resolved by conflict_auto_resolve path rules
+	ResolutionAutoResolved ResolutionMethod = "auto-resolved"
+	// ResolutionManual indicates conflicts were resolved by hand at pick's manual step
+	ResolutionManual ResolutionMethod = "manual"
+)
+
+// LabelRemovedPolicy controls what fetch does with a pending or failed branch
+// whose cherry-pick label has been removed from the original PR
+type LabelRemovedPolicy string
+
+const (
+	// LabelRemovedRemove drops the branch from tracking (default)
+	LabelRemovedRemove LabelRemovedPolicy = "remove"
+	// LabelRemovedKeep keeps tracking the branch as if the label were still present
+	LabelRemovedKeep LabelRemovedPolicy = "keep"
+	// LabelRemovedWarn drops the branch from tracking and logs a warning
+	LabelRemovedWarn LabelRemovedPolicy = "warn"
+)
+
+// ParseLabelRemovedPolicy converts a string to LabelRemovedPolicy, returning
+// false for unknown values. An empty string selects the default policy.
+func ParseLabelRemovedPolicy(s string) (LabelRemovedPolicy, bool) {
+	switch s {
+	case "", "remove":
+		return LabelRemovedRemove, true
+	case "keep":
+		return LabelRemovedKeep, true
+	case "warn":
+		return LabelRemovedWarn, true
+	default:
+		return LabelRemovedRemove, false
+	}
+}
+
+// NewBranchBasePolicy controls which tag summary diffs a release branch against
+// before the branch has any release tags of its own
+type NewBranchBasePolicy string
+
+const (
+	// NewBranchBaseVersion diffs against the branch's own "v<version>.0" (default)
+	NewBranchBaseVersion NewBranchBasePolicy = "version"
+	// NewBranchBasePrevious diffs against the latest tag of the previous release line
+	NewBranchBasePrevious NewBranchBasePolicy = "previous"
+)
+
+// ParseNewBranchBasePolicy converts a string to NewBranchBasePolicy, returning
+// false for unknown values. An empty string selects the default policy.
+func ParseNewBranchBasePolicy(s string) (NewBranchBasePolicy, bool) {
+	switch s {
+	case "", "version":
+		return NewBranchBaseVersion, true
+	case "previous":
+		return NewBranchBasePrevious, true
+	default:
+		return NewBranchBaseVersion, false
+	}
+}
+
+// ConflictStep is one way pick tries to resolve cherry-pick conflicts
+type ConflictStep string
+
+const (
+	// ConflictStepRerere replays resolutions git rerere recorded for the same conflicts
+	ConflictStepRerere ConflictStep = "rerere"
+	// ConflictStepAutoResolve checks out one side of conflicted files matching conflict_auto_resolve
+	ConflictStepAutoResolve ConflictStep = "auto-resolve"
+	// ConflictStepAI launches the configured AI assistant (default)
+	ConflictStepAI ConflictStep = "ai"
+	// ConflictStepManual waits for the user to resolve the conflicts by hand
+	ConflictStepManual ConflictStep = "manual"
+)
+
+// ConflictSide is the version of a conflicted file the auto-resolve step keeps
+type ConflictSide string
+
+const (
+	// ConflictSideOurs keeps the target branch's version
+	ConflictSideOurs ConflictSide = "ours"
+	// ConflictSideTheirs keeps the cherry-picked commit's version
+	ConflictSideTheirs ConflictSide = "theirs"
+)
+
+// ConflictSteps returns the conflict_strategy steps in order, [ai] when unset.
+// It fails on unknown or repeated steps and on auto-resolve rules with a side
+// other than ours or theirs.
+func (c *Config) ConflictSteps() ([]ConflictStep, error) {
+	for pattern, side := range c.ConflictAutoResolve {
+		if side != ConflictSideOurs && side != ConflictSideTheirs {
+			return nil, fmt.Errorf("invalid conflict_auto_resolve side %q for %q (want ours or theirs)", side, pattern)
+		}
+	}
+	if len(c.ConflictStrategy) == 0 {
+		return []ConflictStep{ConflictStepAI}, nil
+	}
+
+	seen := make(map[ConflictStep]bool)
+	for _, step := range c.ConflictStrategy {
+		switch step {
+		case ConflictStepRerere, ConflictStepAutoResolve, ConflictStepAI, ConflictStepManual:
+		default:
+			return nil, fmt.Errorf("invalid conflict_strategy step %q (want rerere, auto-resolve, ai or manual)", step)
+		}
+		if seen[step] {
+			return nil, fmt.Errorf("conflict_strategy lists %q more than once", step)
+		}
+		seen[step] = true
+	}
+	return c.ConflictStrategy, nil
+}
+
 // Config represents the structure of cherry-picks.yaml
 type Config struct {
-	Org                string            `yaml:"org"`
-	Repo               string            `yaml:"repo"`
-	SourceBranch       string            `yaml:"source_branch"`
-	AIAssistantCommand string            `yaml:"ai_assistant_command"`
-	LastFetchDate      *time.Time        `yaml:"last_fetch_date,omitempty"`
-	LastCheckedRelease map[string]string `yaml:"last_checked_release,omitempty"` // branch -> last checked release tag
-	TrackerIssues      map[string]int    `yaml:"tracker_issues,omitempty"`       // branch -> tracker issue number
-	TrackedPRs         []TrackedPR       `yaml:"tracked_prs,omitempty"`
+	Org                       string                    `yaml:"org"`
+	Repo                      string                    `yaml:"repo"`
+	SourceBranch              string                    `yaml:"source_branch"`
+	AIAssistantCommand        string                    `yaml:"ai_assistant_command"`
+	OnLabelRemoved            LabelRemovedPolicy        `yaml:"on_label_removed,omitempty"`             // what to do with pending/failed branches whose label vanished
+	UseMergeQueue             bool                      `yaml:"use_merge_queue,omitempty"`              // merge by adding PRs to GitHub's merge queue
+	MergeMethod               MergeMethod               `yaml:"merge_method,omitempty"`                 // squash (default), merge or rebase
+	DeleteMergedBranches      bool                      `yaml:"delete_merged_branches,omitempty"`       // merge deletes a cherry-pick PR's head branch once it merges
+	StaleAfter                time.Duration             `yaml:"stale_after,omitempty"`                  // how long a picked or queued cherry-pick may keep its status before summary flags it as stale (0 = never)
+	ManualSearchMaxCandidates int                       `yaml:"manual_search_max_candidates,omitempty"` // cap on the title search results fetch inspects per tracked PR for manual cherry-picks (default github.DefaultManualSearchMaxCandidates)
+	TokenEnvVar               string                    `yaml:"token_env_var,omitempty"`                // env var holding this repo's GitHub token (default GITHUB_TOKEN)
+	BaseURL                   string                    `yaml:"base_url,omitempty"`                     // GitHub Enterprise Server API root (e.g. https://ghe.example.com/api/v3); github.com if unset
+	HTTPTimeout               time.Duration             `yaml:"http_timeout,omitempty"`                 // per-attempt connect/response header timeout for GitHub requests (no limit if unset)
+	HTTPRetries               int                       `yaml:"http_retries,omitempty"`                 // retries of GET/HEAD requests that fail to connect or get a 5xx
+	RateLimitRetries          int                       `yaml:"rate_limit_retries,omitempty"`           // retries of rate limited requests (default github.DefaultMaxRetries)
+	RateLimitDelay            time.Duration             `yaml:"rate_limit_delay,omitempty"`             // first backoff of a rate limit retry when GitHub gives no wait (default github.DefaultRetryBaseDelay)
+	IgnoredCIContexts         []string                  `yaml:"ignored_ci_contexts,omitempty"`          // status contexts/check runs left out of the CI read (e.g. CLA bots)
+	GraphQLCIStatus           bool                      `yaml:"graphql_ci_status,omitempty"`            // read PR CI with one GraphQL rollup query instead of three REST calls
+	CommitTrailers            []string                  `yaml:"commit_trailers,omitempty"`              // trailer templates appended to backport commits (e.g. "Backport-of: #{{.OriginalPR}}")
+	InitialHistoryMaxCommits  int                       `yaml:"initial_history_max_commits,omitempty"`  // cap on the first remote history scan of a branch with no release yet (default 1000)
+	InitialHistorySince       *time.Time                `yaml:"initial_history_since,omitempty"`        // start date for that scan (whole history if unset)
+	NewBranchBase             NewBranchBasePolicy       `yaml:"new_branch_base,omitempty"`              // summary diff base for a release branch with no tags yet
+	HeadBranchPattern         string                    `yaml:"head_branch_pattern,omitempty"`          // head ref template of bot cherry-pick PRs (e.g. "cherry-pick-{{.OriginalPR}}-to-{{.Branch}}")
+	LabelPrefix               string                    `yaml:"label_prefix,omitempty"`                 // cherry-pick label prefix (default "cherry-pick/")
+	BranchTemplate            string                    `yaml:"branch_template,omitempty"`              // release branch a label's version targets (default "release-{version}")
+	TitleFormat               string                    `yaml:"title_format,omitempty"`                 // cherry-pick PR title template (default DefaultTitleFormat)
+	PostFetchCommand          string                    `yaml:"post_fetch_command,omitempty"`           // shell command run after a successful fetch, with CHERRY_PICKER_* counts in its env
+	FetchConcurrency          int                       `yaml:"fetch_concurrency,omitempty"`            // tracked PRs fetch checks at once (default 4)
+	IgnoredChecks             []string                  `yaml:"ignored_checks,omitempty"`               // check name substrings ignored like DCO checks (e.g. "changelog"); adds to IgnoredCIContexts
+	PendingGracePeriod        time.Duration             `yaml:"pending_grace_period,omitempty"`         // how long after the original PR merged a pending branch is expected (status flags older ones as stale)
+	AIAssistantAutoLaunch     bool                      `yaml:"ai_assistant_auto_launch,omitempty"`     // launch the AI assistant without waiting for Enter
+	Signoff                   *bool                     `yaml:"signoff,omitempty"`                      // add Signed-off-by to pick commits (default true; false for repos without DCO)
+	SignoffIdentity           string                    `yaml:"signoff_identity,omitempty"`             // sign off as "Name <email>" instead of git's committer identity
+	ReleaseLabel              string                    `yaml:"release_label,omitempty"`                // release effort status and summary are scoped to by default, e.g. v3.7.5 (see TrackedPR.Release)
+	SlackWebhookURL           string                    `yaml:"slack_webhook_url,omitempty"`            // Slack incoming webhook fetch --notify and merge --notify post to
+	RequiredChecks            map[string][]string       `yaml:"required_checks,omitempty"`              // Per-branch CI checks that alone decide whether a cherry-pick PR's CI passes
+	ConflictStrategy          []ConflictStep            `yaml:"conflict_strategy,omitempty"`            // Ordered steps pick tries on conflicts: rerere, auto-resolve, ai, manual (default [ai])
+	ConflictAutoResolve       map[string]ConflictSide   `yaml:"conflict_auto_resolve,omitempty"`        // Path glob -> side (ours or theirs) the auto-resolve step checks out
+	LastFetchDate             *time.Time                `yaml:"last_fetch_date,omitempty"`
+	LastCheckedRelease        map[string]string         `yaml:"last_checked_release,omitempty"` // branch -> last checked release tag
+	UnscannedReleases         map[string][]ReleaseRange `yaml:"unscanned_releases,omitempty"`   // branch -> release ranges a scan failed on
+	TrackerIssues             map[string]int            `yaml:"tracker_issues,omitempty"`       // branch -> tracker issue number
+	TrackedPRs                []TrackedPR               `yaml:"tracked_prs,omitempty"`
+	Repositories              []RepoConfig              `yaml:"repositories,omitempty"` // further repos tracked with the settings above
+}
+
+// RepoConfig is a further repository tracked in the same config as the
+// top-level org/repo. It has its own source branch and tracking state; all
+// other settings are shared with the top level.
+type RepoConfig struct {
+	Org                string                    `yaml:"org"`
+	Repo               string                    `yaml:"repo"`
+	SourceBranch       string                    `yaml:"source_branch"`
+	LastCheckedRelease map[string]string         `yaml:"last_checked_release,omitempty"`
+	UnscannedReleases  map[string][]ReleaseRange `yaml:"unscanned_releases,omitempty"`
+	TrackerIssues      map[string]int            `yaml:"tracker_issues,omitempty"`
+	TrackedPRs         []TrackedPR               `yaml:"tracked_prs,omitempty"`
+}
+
+// RepositoryViews returns one Config per tracked repository: c itself for the
+// top-level org/repo, then a copy of c for each entry of Repositories with that
+// entry's org, repo, source branch and tracking state. Write a mutated view of
+// a further repository back with ApplyRepositoryView.
+func (c *Config) RepositoryViews() []*Config {
+	views := make([]*Config, 0, 1+len(c.Repositories))
+	views = append(views, c)
+	for _, repo := range c.Repositories {
+		view := *c
+		view.Org = repo.Org
+		view.Repo = repo.Repo
+		view.SourceBranch = repo.SourceBranch
+		view.LastCheckedRelease = repo.LastCheckedRelease
+		view.UnscannedReleases = repo.UnscannedReleases
+		view.TrackerIssues = repo.TrackerIssues
+		view.TrackedPRs = repo.TrackedPRs
+		view.Repositories = nil
+		views = append(views, &view)
+	}
+	return views
+}
+
+// ApplyRepositoryView writes the tracking state of view, the i-th entry of
+// RepositoryViews, back into c. The top-level view is c itself, so i 0 is a no-op.
+func (c *Config) ApplyRepositoryView(i int, view *Config) {
+	if i == 0 || view == c {
+		return
+	}
+	repo := &c.Repositories[i-1]
+	repo.LastCheckedRelease = view.LastCheckedRelease
+	repo.UnscannedReleases = view.UnscannedReleases
+	repo.TrackerIssues = view.TrackerIssues
+	repo.TrackedPRs = view.TrackedPRs
+}
+
+// FindRepositoryView returns the index into RepositoryViews of the repository
+// named "org/repo". An empty name selects the top-level repository.
+func (c *Config) FindRepositoryView(name string) (int, bool) {
+	if name == "" {
+		return 0, true
+	}
+	for i, view := range c.RepositoryViews() {
+		if view.Org+"/"+view.Repo == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// MergeMethod is how merge merges a cherry-pick PR into its release branch
+type MergeMethod string
+
+const (
+	// MergeMethodSquash squashes the PR into one commit (default)
+	MergeMethodSquash MergeMethod = "squash"
+	// MergeMethodMerge creates a merge commit
+	MergeMethodMerge MergeMethod = "merge"
+	// MergeMethodRebase rebases the PR's commits onto the branch
+	MergeMethodRebase MergeMethod = "rebase"
+)
+
+// ParseMergeMethod converts a string to MergeMethod, returning false for
+// unknown values. An empty string selects the default method.
+func ParseMergeMethod(s string) (MergeMethod, bool) {
+	switch s {
+	case "", "squash":
+		return MergeMethodSquash, true
+	case "merge":
+		return MergeMethodMerge, true
+	case "rebase":
+		return MergeMethodRebase, true
+	default:
+		return MergeMethodSquash, false
+	}
+}
+
+// SignoffEnabled reports whether pick adds a Signed-off-by trailer to its
+// commits; it does unless signoff is set to false
+func (c *Config) SignoffEnabled() bool {
+	return c.Signoff == nil || *c.Signoff
+}
+
+// DefaultTitleFormat is the cherry-pick PR title template used when the config
+// doesn't set title_format; it matches the titles the cherry-pick bot writes
+const DefaultTitleFormat = "{{.Title}} (cherry-pick #{{.OriginalPR}} for {{.Version}})"
+
+// titlePlaceholders rewrites the {title}, {pr}, {version} and {branch}
+// shorthands title_format accepts (like branch_template's {version}) into the
+// template fields they stand for
+var titlePlaceholders = strings.NewReplacer(
+	"{title}", "{{.Title}}",
+	"{pr}", "{{.OriginalPR}}",
+	"{version}", "{{.Version}}",
+	"{branch}", "{{.Branch}}",
+)
+
+// CherryPickTitle renders the cherry-pick PR title for a backport of originalPR
+// (titled title) onto branch, whose release version is version
+func (c *Config) CherryPickTitle(title string, originalPR int, version, branch string) (string, error) {
+	format := c.TitleFormat
+	if format == "" {
+		format = DefaultTitleFormat
+	}
+
+	tmpl, err := template.New("title_format").Option("missingkey=error").Parse(titlePlaceholders.Replace(format))
+	if err != nil {
+		return "", fmt.Errorf("invalid title_format %q: %w", format, err)
+	}
+	data := struct {
+		Title      string
+		OriginalPR int
+		Version    string
+		Branch     string
+	}{Title: title, OriginalPR: originalPR, Version: version, Branch: branch}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render title_format %q: %w", format, err)
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
+
+// ReleaseRange is a pair of release tags whose commits the release scan
+// could not fetch; the next fetch rescans it before moving on.
+type ReleaseRange struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
 }
 
 // TrackedPR represents a PR that we're tracking for cherry-picking
 type TrackedPR struct {
-	Number   int                     `yaml:"number"`
-	Title    string                  `yaml:"title"`
-	Branches map[string]BranchStatus `yaml:"branches,omitempty"`
+	Number          int                     `yaml:"number"`
+	Title           string                  `yaml:"title"`
+	Author          string                  `yaml:"author,omitempty"`    // login of the original PR's author; set by fetch
+	MergedAt        *time.Time              `yaml:"merged_at,omitempty"` // when the original PR merged; set by fetch
+	Branches        map[string]BranchStatus `yaml:"branches,omitempty"`
+	IgnoredBranches []string                `yaml:"ignored_branches,omitempty"` // branches deliberately not backported to; fetch won't re-add them
+	Release         string                  `yaml:"release,omitempty"`          // release effort the PR belongs to, e.g. v3.7.5; set by set-release
+	// When IgnoredBranches and Release last changed. The state merge only takes
+	// a newer list or annotation, so a command that loaded the state earlier
+	// can't undo a concurrent ignore, unignore or set-release.
+	IgnoredBranchesUpdated time.Time `yaml:"ignored_branches_updated,omitempty"`
+	ReleaseUpdated         time.Time `yaml:"release_updated,omitempty"`
+}
+
+// IsBranchIgnored reports whether the branch was explicitly ignored for this PR
+func (pr *TrackedPR) IsBranchIgnored(branch string) bool {
+	return slices.Contains(pr.IgnoredBranches, branch)
+}
+
+// IsPendingStale reports whether a pending branch of this PR has waited longer
+// than gracePeriod since the PR merged, i.e. the bot should have acted by now.
+// Without a grace period or a recorded merge time nothing is stale.
+func (pr *TrackedPR) IsPendingStale(gracePeriod time.Duration, now time.Time) bool {
+	if gracePeriod <= 0 || pr.MergedAt == nil {
+		return false
+	}
+	return now.Sub(*pr.MergedAt) > gracePeriod
+}
+
+// ReleaseScope returns the release effort a command is scoped to: the
+// --release-label value when the flag was passed (flag non-nil; "" then means
+// every PR), else the config's release_label
+func (c *Config) ReleaseScope(flag *string) string {
+	if flag != nil {
+		return *flag
+	}
+	return c.ReleaseLabel
+}
+
+// ScopedToRelease returns a copy of the config whose tracked PRs, in every
+// repository, are only those annotated with release. With release empty it
+// returns the config itself.
+func (c *Config) ScopedToRelease(release string) *Config {
+	if release == "" {
+		return c
+	}
+	scoped := *c
+	scoped.TrackedPRs = forRelease(c.TrackedPRs, release)
+	scoped.Repositories = nil
+	for _, repo := range c.Repositories {
+		repo.TrackedPRs = forRelease(repo.TrackedPRs, release)
+		scoped.Repositories = append(scoped.Repositories, repo)
+	}
+	return &scoped
+}
+
+// forRelease returns the tracked PRs annotated with release
+func forRelease(prs []TrackedPR, release string) []TrackedPR {
+	var kept []TrackedPR
+	for _, pr := range prs {
+		if pr.Release == release {
+			kept = append(kept, pr)
+		}
+	}
+	return kept
+}
+
+// PickBranchName returns the local branch pick cherry-picks prNumber onto for branch
+func PickBranchName(prNumber int, branch string) string {
+	return fmt.Sprintf("cherry-pick-%d-%s", prNumber, branch)
 }
 
 // BranchStatus represents the status of a PR for a specific target branch
 type BranchStatus struct {
-	Status BranchStatusType `yaml:"status"`
-	PR     *PickPR          `yaml:"pr,omitempty"` // Details of the cherry-pick PR (if picked or merged)
+	Status      BranchStatusType `yaml:"status"`
+	PR          *PickPR          `yaml:"pr,omitempty"`           // Details of the cherry-pick PR (if picked or merged)
+	LastUpdated time.Time        `yaml:"last_updated,omitempty"` // When Status last changed; zero for branches tracked before this was recorded
+}
+
+// StatusAge returns how long the branch has had its current status at now.
+// The bool is false when LastUpdated wasn't recorded, so the age is unknown.
+func (b BranchStatus) StatusAge(now time.Time) (time.Duration, bool) {
+	if b.LastUpdated.IsZero() {
+		return 0, false
+	}
+	return now.Sub(b.LastUpdated), true
+}
+
+// FormatAge renders a duration coarsely for display: days, hours or minutes
+func FormatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
 }
 
 // PickPR represents the PR that was cherry-picked
 type PickPR struct {
-	Number        int      `yaml:"number"`
-	CIStatus      CIStatus `yaml:"ci_status"`
-	Title         string   `yaml:"title"`
-	RunAttempt    int      `yaml:"run_attempt,omitempty"`    // Maximum run_attempt from workflow runs (1 = first run, 2 = one retry, etc.)
-	FailingChecks []string `yaml:"failing_checks,omitempty"` // Names of failing CI checks (only populated when CI is failing)
+	Number           int              `yaml:"number"`
+	CIStatus         CIStatus         `yaml:"ci_status"`
+	Title            string           `yaml:"title"`
+	URL              string           `yaml:"url,omitempty"`               // The PR's web page as GitHub reports it; status links there when set
+	RunAttempt       int              `yaml:"run_attempt,omitempty"`       // Maximum run_attempt from workflow runs (1 = first run, 2 = one retry, etc.)
+	FailingChecks    []string         `yaml:"failing_checks,omitempty"`    // Names of failing CI checks (only populated when CI is failing)
+	HeadSHA          string           `yaml:"head_sha,omitempty"`          // Head commit CIStatus was read for; fetch reports new_commits when it moves
+	ResolutionMethod ResolutionMethod `yaml:"resolution_method,omitempty"` // How pick (or fetch --auto-pick-clean) produced this PR (empty for bot-created PRs)
+	MergedAt         *time.Time       `yaml:"merged_at,omitempty"`         // When the cherry-pick PR merged, as read by fetch or set by merge
+	Mergeable        *bool            `yaml:"mergeable,omitempty"`         // Whether GitHub can merge the PR into its base; unset while unknown
+}
+
+// HasConflicts reports whether GitHub found the cherry-pick PR not mergeable,
+// i.e. it has conflicts with its base branch
+func (p *PickPR) HasConflicts() bool {
+	return p.Mergeable != nil && !*p.Mergeable
 }
@@ -2,14 +2,23 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
 	"github.com/alan/cherry-picker/internal/git"
 	"github.com/spf13/cobra"
 )
 
+// fallbackSourceBranch is the source branch when neither git nor GitHub tells
+const fallbackSourceBranch = "main"
+
+// defaultBranchLookup reads the default branch of config's repository
+type defaultBranchLookup func(ctx context.Context, config *cmd.Config) (string, error)
+
 // NewConfigCmd creates and returns the config command
 func NewConfigCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
 	var (
@@ -17,17 +26,18 @@ func NewConfigCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config
 		repo               string
 		sourceBranch       string
 		aiAssistantCommand string
+		onLabelRemoved     string
 	)
 
-	cobraCmd := createConfigCommand(globalConfigFile, &org, &repo, &sourceBranch, &aiAssistantCommand, loadConfig, saveConfig)
-	addConfigFlags(cobraCmd, &org, &repo, &sourceBranch, &aiAssistantCommand)
+	cobraCmd := createConfigCommand(globalConfigFile, &org, &repo, &sourceBranch, &aiAssistantCommand, &onLabelRemoved, loadConfig, saveConfig)
+	addConfigFlags(cobraCmd, &org, &repo, &sourceBranch, &aiAssistantCommand, &onLabelRemoved)
 	// Note: org and repo are no longer marked as required since they can be auto-detected from git
 
 	return cobraCmd
 }
 
 // createConfigCommand creates the basic config command structure
-func createConfigCommand(globalConfigFile *string, org, repo, sourceBranch, aiAssistantCommand *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+func createConfigCommand(globalConfigFile *string, org, repo, sourceBranch, aiAssistantCommand, onLabelRemoved *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
 	return &cobra.Command{
 		Use:   "config",
 		Short: "Initialize a new cherry-picks.yaml configuration file",
@@ -37,26 +47,34 @@ organization, repository, and source branch configuration.
 When run from a git repository root, it will automatically detect the organization,
 repository, and current branch from the git remote origin.
 
-The source branch defaults to 'main' if not specified and not detected from git.
+When the source branch is neither specified nor detected from git (e.g. a
+detached HEAD, or running outside the checkout with --org and --repo), it
+defaults to the repository's default branch as GitHub reports it, and to 'main'
+when that can't be read (no token or no network).
 Target branches are determined automatically from cherry-pick/* labels on PRs.
-AI assistant command is required for conflict resolution (e.g., 'cursor-agent' or 'claude').`,
+AI assistant command is required for conflict resolution (e.g., 'cursor-agent' or 'claude').
+
+--on-label-removed controls what fetch does with pending/failed branches whose
+cherry-pick label was removed: 'remove' (default), 'keep', or 'warn' (remove
+and log a warning). Picked and merged branches are always kept.`,
 		SilenceUsage: true,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return runConfigWithGitDetection(*globalConfigFile, *org, *repo, *sourceBranch, *aiAssistantCommand, loadConfig, saveConfig)
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return runConfigWithGitDetection(cobraCmd.Context(), *globalConfigFile, *org, *repo, *sourceBranch, *aiAssistantCommand, *onLabelRemoved, loadConfig, saveConfig, githubDefaultBranch)
 		},
 	}
 }
 
 // addConfigFlags adds all flags to the config command
-func addConfigFlags(cobraCmd *cobra.Command, org, repo, sourceBranch, aiAssistantCommand *string) {
+func addConfigFlags(cobraCmd *cobra.Command, org, repo, sourceBranch, aiAssistantCommand, onLabelRemoved *string) {
 	cobraCmd.Flags().StringVarP(org, "org", "o", "", "GitHub organization or username (auto-detected from git if available)")
 	cobraCmd.Flags().StringVarP(repo, "repo", "r", "", "GitHub repository name (auto-detected from git if available)")
-	cobraCmd.Flags().StringVarP(sourceBranch, "source-branch", "s", "", "Source branch name (auto-detected from git if available, defaults to 'main')")
+	cobraCmd.Flags().StringVarP(sourceBranch, "source-branch", "s", "", "Source branch name (auto-detected from git if available, else the repository's default branch on GitHub)")
 	cobraCmd.Flags().StringVarP(aiAssistantCommand, "ai-assistant", "a", "", "AI assistant command for conflict resolution (e.g., 'cursor-agent', 'claude')")
+	cobraCmd.Flags().StringVar(onLabelRemoved, "on-label-removed", "", "Policy for pending/failed branches whose label was removed: remove, keep, or warn (default 'remove')")
 }
 
 // runConfigWithGitDetection handles config creation with git auto-detection
-func runConfigWithGitDetection(configFile, org, repo, sourceBranch, aiAssistantCommand string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) error {
+func runConfigWithGitDetection(ctx context.Context, configFile, org, repo, sourceBranch, aiAssistantCommand, onLabelRemoved string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error, lookupDefaultBranch defaultBranchLookup) error {
 	// Load existing config first to see what we already have
 	config, _ := loadOrCreateConfig(configFile, loadConfig)
 
@@ -92,15 +110,10 @@ func runConfigWithGitDetection(configFile, org, repo, sourceBranch, aiAssistantC
 				finalRepo = gitInfo.Repo
 				slog.Info("Auto-detected repository", "repo", finalRepo)
 			}
-			if finalSourceBranch == "" {
+			if finalSourceBranch == "" && gitInfo.SourceBranch != "" {
 				finalSourceBranch = gitInfo.SourceBranch
 				slog.Info("Auto-detected source branch", "branch", finalSourceBranch)
 			}
-		} else {
-			// Fall back to defaults for source branch
-			if finalSourceBranch == "" {
-				finalSourceBranch = "main"
-			}
 		}
 	}
 
@@ -115,14 +128,22 @@ func runConfigWithGitDetection(configFile, org, repo, sourceBranch, aiAssistantC
 		return fmt.Errorf("AI assistant command is required (use --ai-assistant flag, e.g., 'cursor-agent' or 'claude')")
 	}
 
-	return runConfig(configFile, finalOrg, finalRepo, finalSourceBranch, finalAIAssistant, loadConfig, saveConfig)
+	if finalSourceBranch == "" {
+		finalSourceBranch = defaultSourceBranch(ctx, config, finalOrg, finalRepo, lookupDefaultBranch)
+	}
+
+	return runConfig(configFile, finalOrg, finalRepo, finalSourceBranch, finalAIAssistant, onLabelRemoved, loadConfig, saveConfig)
 }
 
-func runConfig(configFile, org, repo, sourceBranch, aiAssistantCommand string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) error {
+func runConfig(configFile, org, repo, sourceBranch, aiAssistantCommand, onLabelRemoved string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) error {
+	if _, ok := cmd.ParseLabelRemovedPolicy(onLabelRemoved); !ok {
+		return fmt.Errorf("invalid --on-label-removed value %q (must be remove, keep, or warn)", onLabelRemoved)
+	}
+
 	config, isUpdate := loadOrCreateConfig(configFile, loadConfig)
 
 	// Update config with provided values
-	updateConfigWithProvidedValues(config, org, repo, sourceBranch, aiAssistantCommand)
+	updateConfigWithProvidedValues(config, org, repo, sourceBranch, aiAssistantCommand, onLabelRemoved)
 
 	if err := saveConfig(configFile, config); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
@@ -138,11 +159,13 @@ func displayConfigSuccess(configFile string, config *cmd.Config, isUpdate bool)
 	if isUpdate {
 		action = "updated"
 	}
-	fmt.Printf("Successfully %s %s with:\n", action, configFile)
-	fmt.Printf("  Organization: %s\n", config.Org)
-	fmt.Printf("  Repository: %s\n", config.Repo)
-	fmt.Printf("  Source Branch: %s\n", config.SourceBranch)
-	fmt.Printf("  AI Assistant: %s\n", config.AIAssistantCommand)
+	fmt.Fprintf(os.Stderr, "Successfully %s %s with:\n", action, configFile)
+	fmt.Fprintf(os.Stderr, "  Organization: %s\n", config.Org)
+	fmt.Fprintf(os.Stderr, "  Repository: %s\n", config.Repo)
+	fmt.Fprintf(os.Stderr, "  Source Branch: %s\n", config.SourceBranch)
+	fmt.Fprintf(os.Stderr, "  AI Assistant: %s\n", config.AIAssistantCommand)
+	policy, _ := cmd.ParseLabelRemovedPolicy(string(config.OnLabelRemoved))
+	fmt.Fprintf(os.Stderr, "  On Label Removed: %s\n", policy)
 }
 
 // loadOrCreateConfig loads existing config or creates a new one
@@ -157,7 +180,7 @@ func loadOrCreateConfig(configFile string, loadConfig func(string) (*cmd.Config,
 }
 
 // updateConfigWithProvidedValues updates config with any non-empty provided values
-func updateConfigWithProvidedValues(config *cmd.Config, org, repo, sourceBranch, aiAssistantCommand string) {
+func updateConfigWithProvidedValues(config *cmd.Config, org, repo, sourceBranch, aiAssistantCommand, onLabelRemoved string) {
 	if org != "" {
 		config.Org = org
 	}
@@ -170,6 +193,33 @@ func updateConfigWithProvidedValues(config *cmd.Config, org, repo, sourceBranch,
 	if aiAssistantCommand != "" {
 		config.AIAssistantCommand = aiAssistantCommand
 	}
+	if onLabelRemoved != "" {
+		config.OnLabelRemoved = cmd.LabelRemovedPolicy(onLabelRemoved)
+	}
+}
+
+// defaultSourceBranch returns the default branch of org/repo, looked up with
+// config's GitHub settings (token, base URL), or fallbackSourceBranch when it
+// can't be read
+func defaultSourceBranch(ctx context.Context, config *cmd.Config, org, repo string, lookup defaultBranchLookup) string {
+	repoConfig := *config
+	repoConfig.Org, repoConfig.Repo = org, repo
+	branch, err := lookup(ctx, &repoConfig)
+	if err != nil || branch == "" {
+		slog.Warn("Could not read the repository's default branch from GitHub, using "+fallbackSourceBranch, "org", org, "repo", repo, "error", err)
+		return fallbackSourceBranch
+	}
+	slog.Info("Detected default branch", "branch", branch)
+	return branch
+}
+
+// githubDefaultBranch reads the default branch of config's repository from GitHub
+func githubDefaultBranch(ctx context.Context, config *cmd.Config) (string, error) {
+	client, ctx, err := commands.InitializeGitHubClient(ctx, config)
+	if err != nil {
+		return "", err
+	}
+	return client.GetDefaultBranch(ctx)
 }
 
 // detectGitRepoInfo attempts to detect git repository information
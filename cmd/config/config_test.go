@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,6 +19,7 @@ func TestRunConfig(t *testing.T) {
 		repo               string
 		sourceBranch       string
 		aiAssistantCommand string
+		onLabelRemoved     string
 		fileExists         bool
 		saveError          bool
 		wantErr            bool
@@ -68,6 +70,31 @@ func TestRunConfig(t *testing.T) {
 			wantErr:            true,
 			wantErrMsg:         "failed to save configuration: save error",
 		},
+		{
+			name:               "keep policy for removed labels",
+			configFile:         "keep-policy.yaml",
+			org:                "testorg",
+			repo:               "testrepo",
+			sourceBranch:       "main",
+			aiAssistantCommand: "cursor-agent",
+			onLabelRemoved:     "keep",
+			fileExists:         false,
+			saveError:          false,
+			wantErr:            false,
+		},
+		{
+			name:               "invalid policy for removed labels",
+			configFile:         "bad-policy.yaml",
+			org:                "testorg",
+			repo:               "testrepo",
+			sourceBranch:       "main",
+			aiAssistantCommand: "cursor-agent",
+			onLabelRemoved:     "ignore",
+			fileExists:         false,
+			saveError:          false,
+			wantErr:            true,
+			wantErrMsg:         "invalid --on-label-removed value",
+		},
 		{
 			name:               "partial update existing config",
 			configFile:         "partial-update.yaml",
@@ -120,7 +147,7 @@ func TestRunConfig(t *testing.T) {
 			}
 
 			// Run the function
-			err := runConfig(configPath, tt.org, tt.repo, tt.sourceBranch, tt.aiAssistantCommand, loadConfig, saveConfig)
+			err := runConfig(configPath, tt.org, tt.repo, tt.sourceBranch, tt.aiAssistantCommand, tt.onLabelRemoved, loadConfig, saveConfig)
 
 			// Check error
 			if tt.wantErr {
@@ -171,6 +198,9 @@ func TestRunConfig(t *testing.T) {
 					t.Errorf("runConfig() saved sourceBranch = %v, want %v", savedConfig.SourceBranch, tt.sourceBranch)
 				}
 			}
+			if string(savedConfig.OnLabelRemoved) != tt.onLabelRemoved {
+				t.Errorf("runConfig() saved onLabelRemoved = %v, want %v", savedConfig.OnLabelRemoved, tt.onLabelRemoved)
+			}
 		})
 	}
 }
@@ -256,3 +286,52 @@ func TestNewConfigCmd(t *testing.T) {
 		t.Logf("Autodetected: org=%s, repo=%s, source=%s", savedConfig.Org, savedConfig.Repo, savedConfig.SourceBranch)
 	}
 }
+
+func TestDefaultSourceBranch(t *testing.T) {
+	config := &cmd.Config{BaseURL: "https://ghe.example.com"}
+
+	var looked *cmd.Config
+	lookup := func(_ context.Context, c *cmd.Config) (string, error) {
+		looked = c
+		return "develop", nil
+	}
+	if got := defaultSourceBranch(t.Context(), config, "acme", "widget", lookup); got != "develop" {
+		t.Errorf("defaultSourceBranch() = %q, want the repository's default branch", got)
+	}
+	if looked.Org != "acme" || looked.Repo != "widget" || looked.BaseURL != "https://ghe.example.com" {
+		t.Errorf("defaultSourceBranch() looked up %s/%s at %q, want acme/widget with the config's base URL", looked.Org, looked.Repo, looked.BaseURL)
+	}
+	if config.Org != "" {
+		t.Error("defaultSourceBranch() modified the loaded config")
+	}
+
+	failing := func(_ context.Context, _ *cmd.Config) (string, error) {
+		return "", fmt.Errorf("GITHUB_TOKEN environment variable is required")
+	}
+	if got := defaultSourceBranch(t.Context(), config, "acme", "widget", failing); got != "main" {
+		t.Errorf("defaultSourceBranch() = %q, want main when GitHub can't be read", got)
+	}
+}
+
+func TestRunConfigWithGitDetection_PrefersGivenBranch(t *testing.T) {
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return nil, fmt.Errorf("file not found")
+	}
+	var savedConfig *cmd.Config
+	saveConfig := func(_ string, config *cmd.Config) error {
+		savedConfig = config
+		return nil
+	}
+	lookup := func(_ context.Context, _ *cmd.Config) (string, error) {
+		t.Error("default branch looked up although the source branch was given")
+		return "", nil
+	}
+
+	err := runConfigWithGitDetection(t.Context(), "test.yaml", "acme", "widget", "release-main", "claude", "", loadConfig, saveConfig, lookup)
+	if err != nil {
+		t.Fatalf("runConfigWithGitDetection() unexpected error = %v", err)
+	}
+	if savedConfig.SourceBranch != "release-main" {
+		t.Errorf("runConfigWithGitDetection() saved sourceBranch = %v, want release-main", savedConfig.SourceBranch)
+	}
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestConfig(t *testing.T) {
@@ -42,3 +45,229 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_CherryPickTitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default format matches the bot",
+			want: "fix: crash on start (cherry-pick #1234 for 3.7)",
+		},
+		{
+			name:   "custom format",
+			format: "[backport {{.Version}}] {{.Title}}",
+			want:   "[backport 3.7] fix: crash on start",
+		},
+		{
+			name:   "branch and original PR",
+			format: "{{.Title}} (#{{.OriginalPR}} -> {{.Branch}})",
+			want:   "fix: crash on start (#1234 -> release-3.7)",
+		},
+		{
+			name:   "placeholders",
+			format: "[backport {version}] {title} (#{pr} -> {branch})",
+			want:   "[backport 3.7] fix: crash on start (#1234 -> release-3.7)",
+		},
+		{
+			name:   "placeholders mixed with template fields",
+			format: "{{.Title}} [{version}]",
+			want:   "fix: crash on start [3.7]",
+		},
+		{
+			name:    "unknown field",
+			format:  "{{.Release}} {{.Title}}",
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			format:  "{{.Title",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{TitleFormat: tt.format}
+			got, err := config.CherryPickTitle("fix: crash on start", 1234, "3.7", "release-3.7")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CherryPickTitle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CherryPickTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_RepositoryViews(t *testing.T) {
+	config := &Config{
+		Org:          "acme",
+		Repo:         "widget",
+		SourceBranch: "main",
+		LabelPrefix:  "backport/",
+		TrackedPRs:   []TrackedPR{{Number: 1}},
+		Repositories: []RepoConfig{{Org: "acme", Repo: "gadget", SourceBranch: "trunk", TrackedPRs: []TrackedPR{{Number: 2}}}},
+	}
+
+	views := config.RepositoryViews()
+	if len(views) != 2 {
+		t.Fatalf("RepositoryViews() returned %d views, want 2", len(views))
+	}
+	if views[0] != config {
+		t.Error("the first view should be the config itself")
+	}
+	gadget := views[1]
+	if gadget.Repo != "gadget" || gadget.SourceBranch != "trunk" || gadget.TrackedPRs[0].Number != 2 {
+		t.Errorf("second view = %s/%s on %s, want acme/gadget on trunk with its own PRs", gadget.Org, gadget.Repo, gadget.SourceBranch)
+	}
+	if gadget.LabelPrefix != "backport/" {
+		t.Errorf("second view LabelPrefix = %q, want the shared top-level setting", gadget.LabelPrefix)
+	}
+	if gadget.Repositories != nil {
+		t.Error("a repository view should not nest further repositories")
+	}
+
+	gadget.TrackedPRs = append(gadget.TrackedPRs, TrackedPR{Number: 3})
+	config.ApplyRepositoryView(1, gadget)
+	if len(config.Repositories[0].TrackedPRs) != 2 {
+		t.Errorf("ApplyRepositoryView kept %d PRs, want 2", len(config.Repositories[0].TrackedPRs))
+	}
+	if len(config.TrackedPRs) != 1 {
+		t.Error("ApplyRepositoryView must not touch the top-level PRs")
+	}
+}
+
+func TestConfig_FindRepositoryView(t *testing.T) {
+	config := &Config{Org: "acme", Repo: "widget", Repositories: []RepoConfig{{Org: "acme", Repo: "gadget"}}}
+
+	tests := []struct {
+		name   string
+		wantI  int
+		wantOK bool
+	}{
+		{name: "", wantI: 0, wantOK: true},
+		{name: "acme/widget", wantI: 0, wantOK: true},
+		{name: "acme/gadget", wantI: 1, wantOK: true},
+		{name: "acme/other", wantI: 0, wantOK: false},
+	}
+	for _, tt := range tests {
+		if i, ok := config.FindRepositoryView(tt.name); i != tt.wantI || ok != tt.wantOK {
+			t.Errorf("FindRepositoryView(%q) = %d, %v, want %d, %v", tt.name, i, ok, tt.wantI, tt.wantOK)
+		}
+	}
+}
+
+func TestTrackedPR_IsPendingStale(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	mergedAt := now.Add(-50 * time.Hour)
+	pr := TrackedPR{Number: 1, MergedAt: &mergedAt}
+
+	if !pr.IsPendingStale(48*time.Hour, now) {
+		t.Error("IsPendingStale() = false for a PR merged longer ago than the grace period")
+	}
+	if pr.IsPendingStale(72*time.Hour, now) {
+		t.Error("IsPendingStale() = true for a PR merged within the grace period")
+	}
+	if pr.IsPendingStale(0, now) {
+		t.Error("IsPendingStale() = true without a grace period")
+	}
+	if (&TrackedPR{Number: 2}).IsPendingStale(time.Hour, now) {
+		t.Error("IsPendingStale() = true without a recorded merge time")
+	}
+}
+
+func TestBranchStatus_StatusAge(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	age, ok := BranchStatus{Status: BranchStatusFailed, LastUpdated: now.Add(-72 * time.Hour)}.StatusAge(now)
+	if !ok || age != 72*time.Hour {
+		t.Errorf("StatusAge() = %v, %v, want 72h, true", age, ok)
+	}
+	if _, ok := (BranchStatus{Status: BranchStatusFailed}).StatusAge(now); ok {
+		t.Error("StatusAge() = true without a recorded LastUpdated")
+	}
+}
+
+func TestConfig_ScopedToRelease(t *testing.T) {
+	config := &Config{
+		ReleaseLabel: "v3.7.5",
+		TrackedPRs:   []TrackedPR{{Number: 1, Release: "v3.7.5"}, {Number: 2, Release: "v3.6.9"}, {Number: 3}},
+		Repositories: []RepoConfig{{Org: "acme", Repo: "gadget", TrackedPRs: []TrackedPR{{Number: 4}, {Number: 5, Release: "v3.7.5"}}}},
+	}
+
+	if got := config.ScopedToRelease(""); got != config {
+		t.Error("ScopedToRelease(\"\") should return the config itself")
+	}
+
+	scoped := config.ScopedToRelease(config.ReleaseScope(nil))
+	if len(scoped.TrackedPRs) != 1 || scoped.TrackedPRs[0].Number != 1 {
+		t.Errorf("scoped TrackedPRs = %+v, want only #1", scoped.TrackedPRs)
+	}
+	if len(scoped.Repositories[0].TrackedPRs) != 1 || scoped.Repositories[0].TrackedPRs[0].Number != 5 {
+		t.Errorf("scoped repository TrackedPRs = %+v, want only #5", scoped.Repositories[0].TrackedPRs)
+	}
+	if len(config.TrackedPRs) != 3 || len(config.Repositories[0].TrackedPRs) != 2 {
+		t.Error("ScopedToRelease modified the original config")
+	}
+
+	all := ""
+	if got := config.ReleaseScope(&all); got != "" {
+		t.Errorf("ReleaseScope(\"\") = %q, want the flag to override release_label", got)
+	}
+}
+
+func TestConfig_ConflictSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		want    []ConflictStep
+		wantErr string
+	}{
+		{
+			name: "defaults to the AI assistant",
+			want: []ConflictStep{ConflictStepAI},
+		},
+		{
+			name:   "configured order",
+			config: Config{ConflictStrategy: []ConflictStep{ConflictStepRerere, ConflictStepAutoResolve, ConflictStepAI, ConflictStepManual}},
+			want:   []ConflictStep{ConflictStepRerere, ConflictStepAutoResolve, ConflictStepAI, ConflictStepManual},
+		},
+		{
+			name:    "unknown step",
+			config:  Config{ConflictStrategy: []ConflictStep{"magic"}},
+			wantErr: `invalid conflict_strategy step "magic"`,
+		},
+		{
+			name:    "repeated step",
+			config:  Config{ConflictStrategy: []ConflictStep{ConflictStepAI, ConflictStepAI}},
+			wantErr: `conflict_strategy lists "ai" more than once`,
+		},
+		{
+			name:    "invalid auto-resolve side",
+			config:  Config{ConflictAutoResolve: map[string]ConflictSide{"go.sum": "mine"}},
+			wantErr: `invalid conflict_auto_resolve side "mine"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.ConflictSteps()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ConflictSteps() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConflictSteps() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ConflictSteps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package diff implements the diff command for previewing the changes a cherry-pick would bring to a branch.
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the diff command with common functionality
+type command struct {
+	commands.BaseCommand
+	PRNumber     int
+	TargetBranch string
+	Stat         bool
+	dir          string // working tree git commands run in; "" for the current directory
+}
+
+// NewDiffCmd creates the diff command
+func NewDiffCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error)) *cobra.Command {
+	diffCmd := &command{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "diff <pr-number> <target-branch>",
+		Short: "Preview the changes a cherry-pick of a PR would bring to a branch",
+		Long: `Show the patch pick would cherry-pick onto a branch: the changes of the
+PR's merge commit, looked up on GitHub, after fetching origin.
+
+Nothing is checked out or modified, so the working tree doesn't have to be
+clean. A note on stderr says when the commit is already on origin/<branch>.
+Use --stat for a per-file summary instead of the full patch.
+
+Examples:
+  cherry-picker diff 123 release-1.0
+  cherry-picker diff 123 release-1.0 --stat`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+			if err != nil {
+				return err
+			}
+			diffCmd.PRNumber = prNumber
+			diffCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+
+			diffCmd.ConfigFile = globalConfigFile
+			diffCmd.LoadConfig = loadConfig
+			if err := diffCmd.Init(cobraCmd.Context()); err != nil {
+				return err
+			}
+
+			return diffCmd.Run(cobraCmd.Context(), os.Stdout)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&diffCmd.Stat, "stat", false, "Show only a per-file summary of the changes")
+
+	return cobraCmd
+}
+
+// Run looks up the PR's commit, fetches origin and writes the patch to w
+func (dc *command) Run(ctx context.Context, w io.Writer) error {
+	trackedPR, err := commands.FindAndValidatePR(dc.Config, dc.PRNumber)
+	if err != nil {
+		return err
+	}
+	if err := commands.ValidateTargetBranch(trackedPR, dc.TargetBranch); err != nil {
+		return err
+	}
+	if !commands.IsGitRepository() {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	pr, err := dc.GitHubClient.GetPR(ctx, dc.PRNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get PR details: %w", err)
+	}
+	if pr.SHA == "" {
+		return fmt.Errorf("PR #%d has no merge commit SHA", dc.PRNumber)
+	}
+
+	slog.Info("Fetching latest changes from remote")
+	if err := dc.git(os.Stderr, "fetch", "origin"); err != nil {
+		return fmt.Errorf("failed to fetch from remote: %w", err)
+	}
+
+	return dc.showDiff(pr.SHA, w)
+}
+
+// showDiff writes the changes of commit sha to w, against its first parent for a
+// merge commit, after checking that it and origin/<target-branch> exist locally
+func (dc *command) showDiff(sha string, w io.Writer) error {
+	remoteBranch := "origin/" + dc.TargetBranch
+	if err := dc.git(io.Discard, "rev-parse", "--verify", "--quiet", remoteBranch); err != nil {
+		return fmt.Errorf("branch %s does not exist on origin", dc.TargetBranch)
+	}
+	if err := dc.git(io.Discard, "rev-parse", "--verify", "--quiet", sha+"^{commit}"); err != nil {
+		return fmt.Errorf("commit %s of PR #%d not found locally after fetching origin", sha, dc.PRNumber)
+	}
+	if dc.git(io.Discard, "merge-base", "--is-ancestor", sha, remoteBranch) == nil {
+		fmt.Fprintf(os.Stderr, "ℹ️  Commit %s is already on %s\n", sha, remoteBranch)
+	}
+
+	// A merge commit's default combined diff is empty for a clean merge; the
+	// first-parent diff is what the PR brought in
+	args := []string{"show", "--format=", "--diff-merges=first-parent"}
+	if dc.Stat {
+		args = append(args, "--stat")
+	}
+	if err := dc.git(w, append(args, sha)...); err != nil {
+		return fmt.Errorf("failed to show commit %s: %w", sha, err)
+	}
+	return nil
+}
+
+// git runs a git command in the command's directory, writing its output to w
+func (dc *command) git(w io.Writer, args ...string) error {
+	gitCmd := exec.Command("git", args...) //nolint:gosec // Arguments are a SHA from GitHub and a branch from tracked config
+	gitCmd.Dir = dc.dir
+	gitCmd.Stdout = w
+	gitCmd.Stderr = os.Stderr
+	return gitCmd.Run()
+}
@@ -0,0 +1,118 @@
+package diff
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runGitIn runs a git command in dir and returns its trimmed output
+func runGitIn(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Dir = dir
+	output, err := gitCmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, output)
+	return strings.TrimSpace(string(output))
+}
+
+// setupRepo creates a repository whose origin/release-1.0 is the first commit
+// and whose main has a second one, returned as the PR's commit
+func setupRepo(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	runGitIn(t, dir, "init", "-b", "main")
+	runGitIn(t, dir, "config", "user.email", "test@example.com")
+	runGitIn(t, dir, "config", "user.name", "Test User")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.go"), []byte("timeout := 5\n"), 0600))
+	runGitIn(t, dir, "add", ".")
+	runGitIn(t, dir, "commit", "-m", "Initial commit")
+	runGitIn(t, dir, "update-ref", "refs/remotes/origin/release-1.0", "HEAD")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.go"), []byte("timeout := 30\n"), 0600))
+	runGitIn(t, dir, "commit", "-am", "Raise timeout")
+	return dir, runGitIn(t, dir, "rev-parse", "HEAD")
+}
+
+// TestNewDiffCmd tests command creation and argument validation
+func TestNewDiffCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+
+	cobraCmd := NewDiffCmd(&configFile, loadConfig)
+
+	assert.Equal(t, "diff", cobraCmd.Name())
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"123", "release-1.0"}))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+	assert.NotNil(t, cobraCmd.Flags().Lookup("stat"))
+}
+
+// TestShowDiff_Integration tests the patch and --stat output and the checks before them
+func TestShowDiff_Integration(t *testing.T) {
+	dir, sha := setupRepo(t)
+	dc := &command{PRNumber: 123, TargetBranch: "release-1.0", dir: dir}
+
+	var out bytes.Buffer
+	require.NoError(t, dc.showDiff(sha, &out))
+	assert.Contains(t, out.String(), "diff --git a/server.go b/server.go")
+	assert.Contains(t, out.String(), "-timeout := 5\n+timeout := 30\n")
+	assert.NotContains(t, out.String(), "Raise timeout", "only the patch is shown")
+
+	out.Reset()
+	dc.Stat = true
+	require.NoError(t, dc.showDiff(sha, &out))
+	assert.Contains(t, out.String(), "server.go | 2 +-")
+	assert.NotContains(t, out.String(), "diff --git")
+
+	assert.Equal(t, "", runGitIn(t, dir, "status", "--porcelain"), "nothing is modified")
+
+	dc.TargetBranch = "release-9.9"
+	require.ErrorContains(t, dc.showDiff(sha, &out), "branch release-9.9 does not exist on origin")
+
+	dc.TargetBranch = "release-1.0"
+	require.ErrorContains(t, dc.showDiff("0123456789abcdef0123456789abcdef01234567", &out), "not found locally after fetching origin")
+}
+
+// TestShowDiff_MergeCommit tests that a merge-committed PR shows the changes it brought in
+func TestShowDiff_MergeCommit(t *testing.T) {
+	dir, _ := setupRepo(t)
+	runGitIn(t, dir, "checkout", "-b", "feature", "HEAD~1")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client.go"), []byte("retries := 3\n"), 0600))
+	runGitIn(t, dir, "add", ".")
+	runGitIn(t, dir, "commit", "-m", "Add retries")
+	runGitIn(t, dir, "checkout", "main")
+	runGitIn(t, dir, "merge", "--no-ff", "-m", "Merge pull request #123", "feature")
+	sha := runGitIn(t, dir, "rev-parse", "HEAD")
+
+	dc := &command{PRNumber: 123, TargetBranch: "release-1.0", dir: dir}
+	var out bytes.Buffer
+	require.NoError(t, dc.showDiff(sha, &out))
+	assert.Contains(t, out.String(), "diff --git a/client.go b/client.go")
+	assert.Contains(t, out.String(), "+retries := 3\n")
+	assert.NotContains(t, out.String(), "server.go", "changes already on the first parent aren't shown")
+}
+
+// TestRun_Validation tests that untracked PRs and branches fail before GitHub is asked
+func TestRun_Validation(t *testing.T) {
+	dc := &command{PRNumber: 123, TargetBranch: "release-2.0"}
+	dc.Config = &cmd.Config{TrackedPRs: []cmd.TrackedPR{{
+		Number:   123,
+		Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusFailed}},
+	}}}
+
+	// No GitHub client is set, so reaching the API would panic
+	require.ErrorContains(t, dc.Run(t.Context(), &bytes.Buffer{}), "release-2.0")
+
+	dc.PRNumber = 999
+	require.ErrorContains(t, dc.Run(t.Context(), &bytes.Buffer{}), "PR #999 not found")
+}
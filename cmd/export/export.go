@@ -0,0 +1,178 @@
+// Package export implements the export command for dumping the tracking model as a flat stream for analytics.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// Format selects how export writes the tracking model
+type Format string
+
+// Export formats
+const (
+	FormatNDJSON Format = "ndjson" // one record per line
+)
+
+// ParseFormat converts a --format value to a Format
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatNDJSON:
+		return FormatNDJSON, nil
+	default:
+		return "", fmt.Errorf("invalid format %q (want ndjson)", s)
+	}
+}
+
+// command encapsulates the export command with common functionality
+type command struct {
+	commands.BaseCommand
+	Format Format
+}
+
+// record is one tracked PR-branch combination. Every field is always written,
+// null or empty when it doesn't apply, so consumers can rely on a fixed schema;
+// the names are part of the command's interface.
+type record struct {
+	Org              string               `json:"org"`
+	Repo             string               `json:"repo"`
+	OriginalPR       int                  `json:"original_pr"`
+	OriginalTitle    string               `json:"original_title"`
+	Author           string               `json:"author"`
+	MergedAt         *time.Time           `json:"merged_at"`
+	Branch           string               `json:"branch"`
+	Status           cmd.BranchStatusType `json:"status"`
+	LastUpdated      *time.Time           `json:"last_updated"`
+	CherryPickPR     *int                 `json:"cherry_pick_pr"`
+	CherryPickTitle  string               `json:"cherry_pick_title"`
+	CherryPickMerged *time.Time           `json:"cherry_pick_merged_at"`
+	CIStatus         cmd.CIStatus         `json:"ci_status"`
+	RunAttempt       int                  `json:"run_attempt"`
+	FailingChecks    []string             `json:"failing_checks"`
+	HeadSHA          string               `json:"head_sha"`
+	ResolutionMethod cmd.ResolutionMethod `json:"resolution_method"`
+}
+
+// NewExportCmd creates the export command
+func NewExportCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error)) *cobra.Command {
+	exportCmd := &command{}
+	var format string
+
+	exportCobraCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write every tracked PR branch as a flat record for analytics",
+		Long: `Write one JSON object per tracked PR and target branch to stdout, for
+loading into analytics tools. Unlike status --output json, records are flat
+and carry a fixed set of fields: org, repo, original_pr, original_title,
+author, merged_at, branch, status, last_updated, cherry_pick_pr,
+cherry_pick_title, cherry_pick_merged_at, ci_status, run_attempt,
+failing_checks, head_sha and resolution_method.
+
+Records cover every repository of the config, released branches included, in
+PR and branch order. Nothing is read from GitHub; run fetch first for fresh
+data. The original PR's author is recorded by fetch, so PRs tracked before
+that are exported with an empty author until the next fetch.
+
+Examples:
+  cherry-picker export > cherry-picks.ndjson
+  cherry-picker export --format ndjson | jq 'select(.status == "failed")'`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			parsed, err := ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			exportCmd.Format = parsed
+
+			config, err := loadConfig(*globalConfigFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			exportCmd.ConfigFile = globalConfigFile
+			exportCmd.LoadConfig = loadConfig
+			exportCmd.Config = config
+
+			return exportCmd.Run(os.Stdout)
+		},
+	}
+
+	exportCobraCmd.Flags().StringVar(&format, "format", string(FormatNDJSON), "Output format: ndjson")
+
+	return exportCobraCmd
+}
+
+// Run writes the records of the loaded config to w
+func (ec *command) Run(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, rec := range buildRecords(ec.Config) {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildRecords flattens the tracked PRs of every repository in config into
+// records, in repository, PR number and branch order
+func buildRecords(config *cmd.Config) []record {
+	var records []record
+	for _, view := range config.RepositoryViews() {
+		prs := slices.Clone(view.TrackedPRs)
+		slices.SortFunc(prs, func(a, b cmd.TrackedPR) int { return a.Number - b.Number })
+
+		for _, pr := range prs {
+			branches := make([]string, 0, len(pr.Branches))
+			for branch := range pr.Branches {
+				branches = append(branches, branch)
+			}
+			slices.Sort(branches)
+
+			for _, branch := range branches {
+				records = append(records, newRecord(view, pr, branch, pr.Branches[branch]))
+			}
+		}
+	}
+	return records
+}
+
+// newRecord builds the record of one branch of a tracked PR in view's repository
+func newRecord(view *cmd.Config, pr cmd.TrackedPR, branch string, status cmd.BranchStatus) record {
+	rec := record{
+		Org:           view.Org,
+		Repo:          view.Repo,
+		OriginalPR:    pr.Number,
+		OriginalTitle: pr.Title,
+		Author:        pr.Author,
+		MergedAt:      pr.MergedAt,
+		Branch:        branch,
+		Status:        status.Status,
+		FailingChecks: []string{},
+	}
+	if !status.LastUpdated.IsZero() {
+		lastUpdated := status.LastUpdated
+		rec.LastUpdated = &lastUpdated
+	}
+	if status.PR != nil {
+		number := status.PR.Number
+		rec.CherryPickPR = &number
+		rec.CherryPickTitle = status.PR.Title
+		rec.CherryPickMerged = status.PR.MergedAt
+		rec.CIStatus = status.PR.CIStatus
+		rec.RunAttempt = status.PR.RunAttempt
+		rec.HeadSHA = status.PR.HeadSHA
+		rec.ResolutionMethod = status.PR.ResolutionMethod
+		if len(status.PR.FailingChecks) > 0 {
+			rec.FailingChecks = status.PR.FailingChecks
+		}
+	}
+	return rec
+}
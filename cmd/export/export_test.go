@@ -0,0 +1,105 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewExportCmd tests command creation and argument validation
+func TestNewExportCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+
+	cobraCmd := NewExportCmd(&configFile, loadConfig)
+
+	assert.Equal(t, "export", cobraCmd.Name())
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{}))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+	assert.Equal(t, "ndjson", cobraCmd.Flag("format").DefValue)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, FormatNDJSON, format)
+
+	_, err = ParseFormat("csv")
+	require.Error(t, err)
+}
+
+// TestCommand_Run tests that every PR-branch combination is written as one flat line
+func TestCommand_Run(t *testing.T) {
+	mergedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	pickMergedAt := time.Date(2026, 3, 4, 16, 0, 0, 0, time.UTC)
+	lastUpdated := time.Date(2026, 3, 4, 16, 5, 0, 0, time.UTC)
+	config := &cmd.Config{
+		Org:  "acme",
+		Repo: "widget",
+		TrackedPRs: []cmd.TrackedPR{
+			{
+				Number:   200,
+				Title:    "Fix crash",
+				Author:   "octocat",
+				MergedAt: &mergedAt,
+				Branches: map[string]cmd.BranchStatus{
+					"release-1.1": {Status: cmd.BranchStatusPending},
+					"release-1.0": {Status: cmd.BranchStatusPicked, LastUpdated: lastUpdated, PR: &cmd.PickPR{
+						Number:        201,
+						Title:         "[release-1.0] Fix crash",
+						CIStatus:      cmd.CIStatusFailing,
+						RunAttempt:    2,
+						FailingChecks: []string{"e2e"},
+						HeadSHA:       "abc123",
+						MergedAt:      &pickMergedAt,
+					}},
+				},
+			},
+			{Number: 100, Title: "Add flag", Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusReleased}}},
+		},
+		Repositories: []cmd.RepoConfig{{
+			Org:        "acme",
+			Repo:       "gadget",
+			TrackedPRs: []cmd.TrackedPR{{Number: 7, Branches: map[string]cmd.BranchStatus{"release-2.0": {Status: cmd.BranchStatusFailed}}}},
+		}},
+	}
+
+	exportCmd := &command{Format: FormatNDJSON}
+	exportCmd.Config = config
+
+	var out bytes.Buffer
+	require.NoError(t, exportCmd.Run(&out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+
+	var keys []string
+	for _, line := range lines {
+		var rec map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		keys = append(keys, rec["repo"].(string)+"#"+rec["branch"].(string))
+		assert.Len(t, rec, 17, "every record carries the full field set")
+	}
+	assert.Equal(t, []string{"widget#release-1.0", "widget#release-1.0", "widget#release-1.1", "gadget#release-2.0"}, keys)
+
+	assert.JSONEq(t, `{
+		"org": "acme", "repo": "widget", "original_pr": 100, "original_title": "Add flag",
+		"author": "", "merged_at": null, "branch": "release-1.0", "status": "released", "last_updated": null,
+		"cherry_pick_pr": null, "cherry_pick_title": "", "cherry_pick_merged_at": null, "ci_status": "", "run_attempt": 0,
+		"failing_checks": [], "head_sha": "", "resolution_method": ""
+	}`, lines[0])
+	assert.JSONEq(t, `{
+		"org": "acme", "repo": "widget", "original_pr": 200, "original_title": "Fix crash",
+		"author": "octocat", "merged_at": "2026-03-01T09:30:00Z", "branch": "release-1.0", "status": "picked", "last_updated": "2026-03-04T16:05:00Z",
+		"cherry_pick_pr": 201, "cherry_pick_title": "[release-1.0] Fix crash", "cherry_pick_merged_at": "2026-03-04T16:00:00Z", "ci_status": "failing", "run_attempt": 2,
+		"failing_checks": ["e2e"], "head_sha": "abc123", "resolution_method": ""
+	}`, lines[1])
+}
@@ -3,8 +3,13 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/commands"
@@ -15,6 +20,7 @@ import (
 type command struct {
 	commands.BaseCommand
 	SinceDate       string
+	SinceTag        string
 	RecheckReleases bool
 }
 
@@ -28,6 +34,9 @@ func NewFetchCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config,
 		Long: `Fetch new merged PRs from the source branch since the last fetch date
 (or a specified date) and interactively ask whether to pick or ignore each one.
 
+With --since-tag, the window starts at the commit date of a tag instead, e.g.
+the release just cut.
+
 Requires GITHUB_TOKEN environment variable to be set.`,
 		SilenceUsage: true,
 		RunE: func(cobraCmd *cobra.Command, _ []string) error {
@@ -44,6 +53,7 @@ Requires GITHUB_TOKEN environment variable to be set.`,
 	}
 
 	command.Flags().StringVarP(&fetchCmd.SinceDate, "since", "s", "", "Fetch PRs since this date (YYYY-MM-DD), defaults to last fetch date")
+	command.Flags().StringVar(&fetchCmd.SinceTag, "since-tag", "", "Fetch PRs merged since this tag's commit date (e.g. the last release)")
 	command.Flags().BoolVar(&fetchCmd.RecheckReleases, "recheck-releases", false, "Force recheck of all releases (clears last_checked_release)")
 
 	return command
@@ -51,15 +61,16 @@ Requires GITHUB_TOKEN environment variable to be set.`,
 
 // Run executes the fetch command
 func (fc *command) Run(ctx context.Context) error {
-	since, err := determineSinceDate(fc.SinceDate, fc.Config.LastFetchDate)
+	since, err := ResolveSince(ctx, fc.GitHubClient, fc.SinceDate, fc.SinceTag, fc.Config.LastFetchDate)
 	if err != nil {
 		return err
 	}
 
 	// Clear last checked releases if recheck flag is set
 	if fc.RecheckReleases {
-		fmt.Println("Forcing recheck of all releases")
+		fmt.Fprintln(os.Stderr, "Forcing recheck of all releases")
 		fc.Config.LastCheckedRelease = nil
+		fc.Config.UnscannedReleases = nil
 	}
 
 	if err := RefreshCherry(ctx, fc.GitHubClient, fc.Config, since); err != nil {
@@ -97,6 +108,50 @@ func SinceForFetch(config *cmd.Config) (time.Time, error) {
 	return determineSinceDate("", config.LastFetchDate)
 }
 
+// tagDater resolves a tag to the date of its commit
+type tagDater interface {
+	GetTagDate(ctx context.Context, tag string) (time.Time, error)
+}
+
+// ResolveSince determines the date to fetch PRs from: sinceDate (YYYY-MM-DD),
+// the commit date of sinceTag, or else the last fetch date. Giving both
+// sinceDate and sinceTag is an error.
+func ResolveSince(ctx context.Context, client tagDater, sinceDate, sinceTag string, lastFetchDate *time.Time) (time.Time, error) {
+	if sinceTag == "" {
+		return determineSinceDate(sinceDate, lastFetchDate)
+	}
+	if sinceDate != "" {
+		return time.Time{}, errors.New("--since and --since-tag can't be used together")
+	}
+	since, err := client.GetTagDate(ctx, sinceTag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	slog.Info("Fetching PRs merged since tag", "tag", sinceTag, "since", since.Format(time.RFC3339))
+	return since, nil
+}
+
+// SearchQualifiers returns the search qualifiers that narrow fetch to PRs by
+// author (a GitHub login, with or without a leading @) and to extraQuery, raw
+// qualifiers such as "label:area/ui -label:wip"
+func SearchQualifiers(author, extraQuery string) ([]string, error) {
+	var qualifiers []string
+	if author != "" {
+		login := strings.TrimPrefix(author, "@")
+		if login == "" || strings.ContainsFunc(login, unicode.IsSpace) {
+			return nil, fmt.Errorf("invalid --author %q: want a single GitHub login", author)
+		}
+		qualifiers = append(qualifiers, "author:"+login)
+	}
+	if strings.ContainsAny(extraQuery, "\r\n") {
+		return nil, errors.New("--extra-query can't contain newlines")
+	}
+	if extra := strings.TrimSpace(extraQuery); extra != "" {
+		qualifiers = append(qualifiers, extra)
+	}
+	return qualifiers, nil
+}
+
 // determineSinceDate determines the date to fetch PRs from
 func determineSinceDate(sinceDate string, lastFetchDate *time.Time) (time.Time, error) {
 	if sinceDate != "" {
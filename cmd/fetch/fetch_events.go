@@ -0,0 +1,81 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+)
+
+// EventType names a fetch progress event
+type EventType string
+
+// Fetch progress events
+const (
+	EventNewPR         EventType = "new_pr"         // a merged PR with cherry-pick labels was tracked for the first time
+	EventPRSynced      EventType = "pr_synced"      // a tracked PR was checked against GitHub
+	EventStatusChanged EventType = "status_changed" // a tracked branch moved to a new status
+	EventReleased      EventType = "released"       // a merged cherry-pick was found in a release
+	EventNewCommits    EventType = "new_commits"    // new commits were pushed to a cherry-pick PR since the last fetch
+	EventDone          EventType = "done"           // the fetch finished (successfully or not)
+)
+
+// Event is a single fetch progress event, as emitted by fetch --jsonl
+type Event struct {
+	Type       EventType            `json:"event"`
+	Time       time.Time            `json:"time"`
+	Repo       string               `json:"repo,omitempty"` // org/repo, set when the config tracks several repositories
+	PR         int                  `json:"pr,omitempty"`
+	Branch     string               `json:"branch,omitempty"`
+	From       cmd.BranchStatusType `json:"from,omitempty"`
+	To         cmd.BranchStatusType `json:"to,omitempty"`
+	PickPR     int                  `json:"cherry_pick_pr,omitempty"`
+	TrackedPRs int                  `json:"tracked_prs,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// EventSink receives fetch progress events as they happen
+type EventSink func(Event)
+
+type eventSinkKey struct{}
+
+type eventRepoKey struct{}
+
+// WithEventSink returns a context whose fetch reports progress events to sink
+func WithEventSink(ctx context.Context, sink EventSink) context.Context {
+	return context.WithValue(ctx, eventSinkKey{}, sink)
+}
+
+// WithEventRepo returns a context whose events are stamped with repo ("org/repo")
+func WithEventRepo(ctx context.Context, repo string) context.Context {
+	return context.WithValue(ctx, eventRepoKey{}, repo)
+}
+
+// Emit sends e to the context's event sink, if any, stamping the time and repository
+func Emit(ctx context.Context, e Event) {
+	sink, ok := ctx.Value(eventSinkKey{}).(EventSink)
+	if !ok || sink == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Repo == "" {
+		e.Repo, _ = ctx.Value(eventRepoKey{}).(string)
+	}
+	sink(e)
+}
+
+// NewJSONLSink returns a sink writing each event to w as one line of JSON
+func NewJSONLSink(w io.Writer) EventSink {
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+	return func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = enc.Encode(e)
+	}
+}
@@ -0,0 +1,56 @@
+package fetch
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitWithoutSink(t *testing.T) {
+	// No sink configured: emitting is a no-op
+	assert.NotPanics(t, func() {
+		Emit(t.Context(), Event{Type: EventDone})
+	})
+}
+
+func TestJSONLSink(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := WithEventSink(t.Context(), NewJSONLSink(&buf))
+
+	Emit(ctx, Event{Type: EventPRSynced, PR: 14944})
+	Emit(ctx, Event{Type: EventStatusChanged, PR: 14944, Branch: "release-3.7", From: cmd.BranchStatusPending, To: cmd.BranchStatusPicked})
+	Emit(ctx, Event{Type: EventDone, TrackedPRs: 3, Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var changed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &changed))
+	assert.Equal(t, "status_changed", changed["event"])
+	assert.InDelta(t, 14944, changed["pr"], 0)
+	assert.Equal(t, "release-3.7", changed["branch"])
+	assert.Equal(t, "pending", changed["from"])
+	assert.Equal(t, "picked", changed["to"])
+	assert.NotEmpty(t, changed["time"])
+	assert.NotContains(t, changed, "error")
+
+	assert.JSONEq(t, `{"event":"done","time":"2024-01-02T03:04:05Z","tracked_prs":3}`, lines[2])
+}
+
+func TestEmitStampsRepository(t *testing.T) {
+	var events []Event
+	ctx := WithEventSink(t.Context(), func(e Event) { events = append(events, e) })
+
+	Emit(ctx, Event{Type: EventPRSynced, PR: 1})
+	Emit(WithEventRepo(ctx, "acme/gadget"), Event{Type: EventPRSynced, PR: 2})
+
+	require.Len(t, events, 2)
+	assert.Empty(t, events[0].Repo, "single-repository events carry no repo")
+	assert.Equal(t, "acme/gadget", events[1].Repo)
+}
@@ -0,0 +1,79 @@
+package fetch
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+
+	"github.com/alan/cherry-picker/internal/commands"
+)
+
+// Counts tallies what a fetch changed, from its progress events
+type Counts struct {
+	NewPRs      int // PRs tracked for the first time
+	Transitions int // tracked branches that moved to a new status
+	Released    int // merged cherry-picks found in a release
+}
+
+// CountEvents returns a context whose fetch events are tallied into the
+// returned Counts. Events still reach any sink ctx already had.
+func CountEvents(ctx context.Context) (context.Context, *Counts) {
+	counts := &Counts{}
+	next, _ := ctx.Value(eventSinkKey{}).(EventSink)
+	var mu sync.Mutex
+	return WithEventSink(ctx, func(e Event) {
+		mu.Lock()
+		switch e.Type {
+		case EventNewPR:
+			counts.NewPRs++
+		case EventStatusChanged:
+			counts.Transitions++
+		case EventReleased:
+			counts.Released++
+		}
+		mu.Unlock()
+		if next != nil {
+			next(e)
+		}
+	}), counts
+}
+
+// Env returns the counts as CHERRY_PICKER_* environment variables
+func (c Counts) Env() []string {
+	return []string{
+		"CHERRY_PICKER_NEW_PRS=" + strconv.Itoa(c.NewPRs),
+		"CHERRY_PICKER_TRANSITIONS=" + strconv.Itoa(c.Transitions),
+		"CHERRY_PICKER_RELEASED=" + strconv.Itoa(c.Released),
+	}
+}
+
+// RunPostFetchCommand runs the configured post_fetch_command through sh after
+// a successful fetch, with the counts and the state file path in its
+// environment. Its output goes to stderr so it never mixes with --jsonl
+// events. A failure is only logged: the fetch itself has already been saved.
+// Under --dry-run the command is only logged, never run.
+func RunPostFetchCommand(ctx context.Context, command, configFile string, counts Counts) {
+	if command == "" {
+		return
+	}
+	if commands.DryRun() {
+		slog.Info("Dry run: would run post-fetch command", "command", command)
+		return
+	}
+
+	slog.Info("Running post-fetch command", "command", command, "new_prs", counts.NewPRs,
+		"transitions", counts.Transitions, "released", counts.Released)
+
+	hook := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // post-fetch command is user-configured
+	hook.Env = append(os.Environ(), counts.Env()...)
+	hook.Env = append(hook.Env, "CHERRY_PICKER_CONFIG="+configFile)
+	hook.Stdout = os.Stderr
+	hook.Stderr = os.Stderr
+
+	if err := hook.Run(); err != nil {
+		slog.Warn("Post-fetch command failed", "command", command, "error", err)
+	}
+}
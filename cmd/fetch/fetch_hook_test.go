@@ -0,0 +1,53 @@
+package fetch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountEvents(t *testing.T) {
+	var forwarded []EventType
+	ctx := WithEventSink(t.Context(), func(e Event) { forwarded = append(forwarded, e.Type) })
+	ctx, counts := CountEvents(ctx)
+
+	Emit(ctx, Event{Type: EventNewPR, PR: 1})
+	Emit(ctx, Event{Type: EventNewPR, PR: 2})
+	Emit(ctx, Event{Type: EventPRSynced, PR: 1})
+	Emit(ctx, Event{Type: EventStatusChanged, PR: 1})
+	Emit(ctx, Event{Type: EventReleased, PR: 1})
+
+	assert.Equal(t, Counts{NewPRs: 2, Transitions: 1, Released: 1}, *counts)
+	assert.Len(t, forwarded, 5, "events still reach the existing sink")
+}
+
+func TestRunPostFetchCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "env.txt")
+	command := `echo "$CHERRY_PICKER_NEW_PRS $CHERRY_PICKER_TRANSITIONS $CHERRY_PICKER_RELEASED $CHERRY_PICKER_CONFIG" > ` + out
+
+	RunPostFetchCommand(t.Context(), command, "state.yaml", Counts{NewPRs: 3, Transitions: 2, Released: 1})
+
+	got, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "3 2 1 state.yaml\n", string(got))
+}
+
+func TestRunPostFetchCommand_FailureOnlyWarns(t *testing.T) {
+	assert.NotPanics(t, func() {
+		RunPostFetchCommand(t.Context(), "exit 3", "state.yaml", Counts{})
+	})
+}
+
+func TestRunPostFetchCommand_DryRun(t *testing.T) {
+	commands.SetDryRun(true)
+	t.Cleanup(func() { commands.SetDryRun(false) })
+
+	out := filepath.Join(t.TempDir(), "ran.txt")
+	RunPostFetchCommand(t.Context(), "touch "+out, "state.yaml", Counts{})
+
+	assert.NoFileExists(t, out)
+}
@@ -0,0 +1,105 @@
+package fetch
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// DefaultConcurrency is how many tracked PRs fetch updates at once when the
+// config doesn't set fetch_concurrency
+const DefaultConcurrency = 4
+
+// concurrency returns the configured number of fetch workers, at least one
+func concurrency(configured int) int {
+	if configured <= 0 {
+		return DefaultConcurrency
+	}
+	return configured
+}
+
+// itemOutput holds the logs and events of one work item until it is its turn to be written
+type itemOutput struct {
+	records []bufferedRecord
+	events  []Event
+	done    chan struct{}
+}
+
+type bufferedRecord struct {
+	handler slog.Handler
+	record  slog.Record
+}
+
+// bufferHandler is a slog.Handler that holds records in an itemOutput instead of writing them
+type bufferHandler struct {
+	base slog.Handler
+	out  *itemOutput
+}
+
+func (h *bufferHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *bufferHandler) Handle(_ context.Context, r slog.Record) error {
+	h.out.records = append(h.out.records, bufferedRecord{handler: h.base, record: r.Clone()})
+	return nil
+}
+
+func (h *bufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &bufferHandler{base: h.base.WithAttrs(attrs), out: h.out}
+}
+
+func (h *bufferHandler) WithGroup(name string) slog.Handler {
+	return &bufferHandler{base: h.base.WithGroup(name), out: h.out}
+}
+
+// runOrdered calls work for items 0..n-1 on up to workers goroutines. Each
+// call logs through its own logger and emits events through its own context;
+// both are held back and written in item order, so the output reads as if the
+// items had been processed one after another. A work func must only write
+// state belonging to its own item; the results are safe to read once
+// runOrdered returns.
+func runOrdered(ctx context.Context, n, workers int, work func(ctx context.Context, log *slog.Logger, i int)) {
+	base := slog.Default().Handler()
+	sink, _ := ctx.Value(eventSinkKey{}).(EventSink)
+
+	outputs := make([]*itemOutput, n)
+	for i := range outputs {
+		outputs[i] = &itemOutput{done: make(chan struct{})}
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range n {
+			jobs <- i
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Go(func() {
+			for i := range jobs {
+				out := outputs[i]
+				itemCtx := WithEventSink(ctx, func(e Event) { out.events = append(out.events, e) })
+				work(itemCtx, slog.New(&bufferHandler{base: base, out: out}), i)
+				close(out.done)
+			}
+		})
+	}
+
+	for _, out := range outputs {
+		<-out.done
+		for _, br := range out.records {
+			if br.handler.Enabled(ctx, br.record.Level) {
+				_ = br.handler.Handle(ctx, br.record)
+			}
+		}
+		if sink != nil {
+			for _, e := range out.events {
+				sink(e)
+			}
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,75 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrency(t *testing.T) {
+	assert.Equal(t, DefaultConcurrency, concurrency(0))
+	assert.Equal(t, DefaultConcurrency, concurrency(-2))
+	assert.Equal(t, 8, concurrency(8))
+}
+
+func TestRunOrdered(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	var events []int
+	ctx := WithEventSink(t.Context(), func(e Event) { events = append(events, e.PR) })
+
+	const n = 6
+	results := make([]int, n)
+	var running, peak atomic.Int32
+	runOrdered(ctx, n, 3, func(ctx context.Context, log *slog.Logger, i int) {
+		cur := running.Add(1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		// Later items finish first
+		time.Sleep(time.Duration(n-i) * 5 * time.Millisecond)
+		log.Info("checked", "item", i)
+		Emit(ctx, Event{Type: EventPRSynced, PR: i})
+		results[i] = i * 10
+		running.Add(-1)
+	})
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, events, "events are written in item order")
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50}, results)
+	assert.LessOrEqual(t, peak.Load(), int32(3), "at most workers items run at once")
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	assert.Equal(t, []string{
+		"level=INFO msg=checked item=0",
+		"level=INFO msg=checked item=1",
+		"level=INFO msg=checked item=2",
+		"level=INFO msg=checked item=3",
+		"level=INFO msg=checked item=4",
+		"level=INFO msg=checked item=5",
+	}, lines, "logs are written in item order")
+}
+
+func TestRunOrdered_NoItems(t *testing.T) {
+	called := false
+	runOrdered(t.Context(), 0, DefaultConcurrency, func(context.Context, *slog.Logger, int) { called = true })
+	assert.False(t, called)
+}
@@ -3,26 +3,40 @@ package fetch
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
 )
 
-// updateReleasedStatus checks all releases and marks cherry-pick PRs as released
-func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *github.Client) bool {
+// releaseScanAttempts bounds how many times a single tag comparison is tried
+// before its range is recorded as unscanned.
+const releaseScanAttempts = 3
+
+// releaseScanRetryDelay is the base delay between attempts; it grows linearly.
+var releaseScanRetryDelay = 2 * time.Second
+
+// commitsBetweenTagsFunc matches github.Client.GetCommitsBetweenTags.
+type commitsBetweenTagsFunc func(ctx context.Context, oldTag, newTag string) ([]github.Commit, error)
+
+// updateReleasedStatus checks all releases and marks cherry-pick PRs as released.
+// It also returns how many release ranges could not be scanned; those are
+// recorded in config.UnscannedReleases so the next run retries them.
+func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *github.Client) (bool, int) {
 	updated := false
 
 	// Get all releases
 	allReleases, err := client.ListReleases(ctx)
 	if err != nil {
 		slog.Warn("Failed to fetch releases", "error", err)
-		return false
+		return false, 0
 	}
 
 	if len(allReleases) == 0 {
 		slog.Info("No releases found")
-		return false
+		return false, 0
 	}
 
 	slog.Info("Fetched all releases", "total_count", len(allReleases))
@@ -33,11 +47,12 @@ func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *githu
 	}
 
 	// First, collect all unique branches with merged PRs
-	// and compute unchecked releases once per branch
+	// and compute the ranges to scan once per branch
 	type branchReleases struct {
 		relevantReleases  []github.Release
 		uncheckedReleases []github.Release
 		lastChecked       string
+		ranges            []cmd.ReleaseRange
 	}
 	branchReleasesMap := make(map[string]*branchReleases)
 
@@ -56,14 +71,19 @@ func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *githu
 			// Only compute releases for this branch once
 			if _, exists := branchReleasesMap[branchName]; !exists {
 				// Filter releases to only those relevant for this branch
-				relevantReleases := filterReleasesForBranch(allReleases, branchName)
+				relevantReleases := filterReleasesForBranch(allReleases, branchName, client.LabelScheme())
 				lastChecked := config.LastCheckedRelease[branchName]
 				uncheckedReleases := filterUncheckedReleases(relevantReleases, lastChecked)
 
+				// Ranges a previous run failed on go first, then the new releases
+				ranges := append([]cmd.ReleaseRange(nil), config.UnscannedReleases[branchName]...)
+				ranges = append(ranges, releaseRanges(uncheckedReleases, lastChecked)...)
+
 				branchReleasesMap[branchName] = &branchReleases{
 					relevantReleases:  relevantReleases,
 					uncheckedReleases: uncheckedReleases,
 					lastChecked:       lastChecked,
+					ranges:            ranges,
 				}
 			}
 		}
@@ -78,10 +98,14 @@ func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *githu
 		} else {
 			slog.Debug("Checking new releases", "branch", branchName, "new_count", len(br.uncheckedReleases), "total_relevant", len(br.relevantReleases))
 		}
+		if pending := len(config.UnscannedReleases[branchName]); pending > 0 {
+			slog.Info("Rescanning release ranges from a previous failed scan", "branch", branchName, "count", pending)
+		}
 	}
 
 	// Track which branches we've checked (to update last checked release)
 	branchesChecked := make(map[string]string) // branch -> latest release checked
+	scanner := newReleaseScanner(client.GetCommitsBetweenTags)
 
 	// Now check each PR against the releases for its branches
 	for i := range config.TrackedPRs {
@@ -98,7 +122,7 @@ func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *githu
 
 			// Get the pre-computed releases for this branch
 			br, exists := branchReleasesMap[branchName]
-			if !exists || len(br.uncheckedReleases) == 0 {
+			if !exists || len(br.ranges) == 0 {
 				continue
 			}
 
@@ -108,12 +132,14 @@ func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *githu
 				branchesChecked[branchName] = br.uncheckedReleases[0].TagName
 			}
 
-			// Check if this cherry-pick PR's commit is in any unchecked release
-			if isInRelease(ctx, client, br.uncheckedReleases, br.lastChecked, trackedPR.Number) {
+			// Check if this cherry-pick PR's commit is in any release range
+			if scanner.isInRelease(ctx, br.ranges, trackedPR.Number) {
 				slog.Info("Cherry-pick found in release", "pr", trackedPR.Number, "branch", branchName, "cherry_pick_pr", branchStatus.PR.Number)
 				branchStatus.Status = cmd.BranchStatusReleased
+				branchStatus.LastUpdated = time.Now()
 				trackedPR.Branches[branchName] = branchStatus
 				updated = true
+				Emit(ctx, Event{Type: EventReleased, PR: trackedPR.Number, Branch: branchName, PickPR: branchStatus.PR.Number})
 			}
 		}
 	}
@@ -125,7 +151,46 @@ func updateReleasedStatus(ctx context.Context, config *cmd.Config, client *githu
 		slog.Debug("Updated last checked release", "branch", branch, "release", latestRelease)
 	}
 
-	return updated
+	// Remember ranges that could not be scanned so the next run targets them
+	for branch, br := range branchReleasesMap {
+		if recordUnscannedReleases(config, branch, scanner.failedRanges(br.ranges)) {
+			updated = true
+		}
+	}
+
+	return updated, len(scanner.failed)
+}
+
+// releaseRanges turns unchecked releases (newest first) into the tag ranges
+// that cover them. The oldest release is compared against lastChecked; when
+// nothing was checked before there is no base to compare from, so it is skipped.
+func releaseRanges(releases []github.Release, lastChecked string) []cmd.ReleaseRange {
+	var ranges []cmd.ReleaseRange
+	for i := 0; i < len(releases)-1; i++ {
+		ranges = append(ranges, cmd.ReleaseRange{From: releases[i+1].TagName, To: releases[i].TagName})
+	}
+	if len(releases) > 0 && lastChecked != "" {
+		ranges = append(ranges, cmd.ReleaseRange{From: lastChecked, To: releases[len(releases)-1].TagName})
+	}
+	return ranges
+}
+
+// recordUnscannedReleases replaces the branch's unscanned ranges with failed,
+// reporting whether anything changed.
+func recordUnscannedReleases(config *cmd.Config, branch string, failed []cmd.ReleaseRange) bool {
+	if slices.Equal(config.UnscannedReleases[branch], failed) {
+		return false
+	}
+	if len(failed) == 0 {
+		delete(config.UnscannedReleases, branch)
+		slog.Info("Release ranges rescanned successfully", "branch", branch)
+		return true
+	}
+	if config.UnscannedReleases == nil {
+		config.UnscannedReleases = make(map[string][]cmd.ReleaseRange)
+	}
+	config.UnscannedReleases[branch] = failed
+	return true
 }
 
 // filterUncheckedReleases returns only releases newer than the last checked release
@@ -151,10 +216,9 @@ func filterUncheckedReleases(releases []github.Release, lastChecked string) []gi
 
 // filterReleasesForBranch filters releases to only those relevant for the target branch
 // e.g., "release-3.6" -> only releases starting with "v3.6"
-func filterReleasesForBranch(releases []github.Release, branchName string) []github.Release {
-	// Extract version from branch name
-	// Expected formats: "release-3.6", "release-3.7", etc.
-	version, ok := strings.CutPrefix(branchName, "release-")
+func filterReleasesForBranch(releases []github.Release, branchName string, scheme github.LabelScheme) []github.Release {
+	// Extract version from branch name using the configured branch template
+	version, ok := scheme.VersionForBranch(branchName)
 	if !ok {
 		// If branch doesn't match expected format, return all releases
 		return releases
@@ -172,49 +236,91 @@ func filterReleasesForBranch(releases []github.Release, branchName string) []git
 	return filtered
 }
 
-// isInRelease checks if a cherry-pick PR is included in any release
-func isInRelease(ctx context.Context, client *github.Client, releases []github.Release, lastChecked string, originalPRNumber int) bool {
-	// For each release, check if it's on the target branch
-	for i := 0; i < len(releases)-1; i++ {
-		currentRelease := releases[i]
-		previousRelease := releases[i+1]
+// releaseScanner fetches the commits of release ranges with bounded retries,
+// caching results so several PRs on a branch share one set of API calls.
+type releaseScanner struct {
+	getCommits commitsBetweenTagsFunc
+	scanned    map[cmd.ReleaseRange][]github.Commit
+	failed     map[cmd.ReleaseRange]bool
+}
+
+func newReleaseScanner(getCommits commitsBetweenTagsFunc) *releaseScanner {
+	return &releaseScanner{
+		getCommits: getCommits,
+		scanned:    make(map[cmd.ReleaseRange][]github.Commit),
+		failed:     make(map[cmd.ReleaseRange]bool),
+	}
+}
 
-		// Get commits between these two releases
-		commits, err := client.GetCommitsBetweenTags(ctx, previousRelease.TagName, currentRelease.TagName)
-		if err != nil {
-			slog.Warn("Failed to get commits between tags", "from", previousRelease.TagName, "to", currentRelease.TagName, "error", err)
+// commits returns the commits in r, or false if every attempt failed
+func (s *releaseScanner) commits(ctx context.Context, r cmd.ReleaseRange) ([]github.Commit, bool) {
+	if commits, ok := s.scanned[r]; ok {
+		return commits, true
+	}
+	if s.failed[r] {
+		return nil, false
+	}
+
+	for attempt := 1; attempt <= releaseScanAttempts; attempt++ {
+		commits, err := s.getCommits(ctx, r.From, r.To)
+		if err == nil {
+			s.scanned[r] = commits
+			return commits, true
+		}
+		slog.Warn("Failed to get commits between tags", "from", r.From, "to", r.To,
+			"attempt", attempt, "max_attempts", releaseScanAttempts, "error", err)
+		if attempt == releaseScanAttempts || !sleepCtx(ctx, releaseScanRetryDelay*time.Duration(attempt)) {
+			break
+		}
+	}
+
+	s.failed[r] = true
+	return nil, false
+}
+
+// failedRanges returns the members of ranges that could not be scanned
+func (s *releaseScanner) failedRanges(ranges []cmd.ReleaseRange) []cmd.ReleaseRange {
+	var failed []cmd.ReleaseRange
+	for _, r := range ranges {
+		if s.failed[r] {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// isInRelease checks if a cherry-pick PR is included in any of the release ranges
+func (s *releaseScanner) isInRelease(ctx context.Context, ranges []cmd.ReleaseRange, originalPRNumber int) bool {
+	for _, r := range ranges {
+		commits, ok := s.commits(ctx, r)
+		if !ok {
 			continue
 		}
 
 		// Check if any commit in this release is the cherry-pick we're looking for
 		for _, commit := range commits {
 			if isCherryPickCommit(commit, originalPRNumber) {
-				slog.Debug("Found cherry-pick in release", "release", currentRelease.TagName, "commit", commit.SHA[:8], "original_pr", originalPRNumber)
+				slog.Debug("Found cherry-pick in release", "release", r.To, "commit", commit.SHA[:8], "original_pr", originalPRNumber, "from", r.From)
 				return true
 			}
 		}
 	}
 
-	// Check the oldest release (or single release case)
-	// When there's only one unchecked release, we need to compare from lastChecked to it
-	if len(releases) > 0 {
-		oldestRelease := releases[len(releases)-1]
-		// Use lastChecked as the starting point if available, otherwise start from beginning
-		fromTag := lastChecked
-		commits, err := client.GetCommitsBetweenTags(ctx, fromTag, oldestRelease.TagName)
-		if err == nil {
-			for _, commit := range commits {
-				if isCherryPickCommit(commit, originalPRNumber) {
-					slog.Debug("Found cherry-pick in release", "release", oldestRelease.TagName, "commit", commit.SHA[:8], "original_pr", originalPRNumber, "from", fromTag)
-					return true
-				}
-			}
-		}
-	}
-
 	return false
 }
 
+// sleepCtx waits for d, returning false if ctx is cancelled first
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 // isCherryPickCommit checks if a commit is a cherry-pick of the specified original PR
 func isCherryPickCommit(commit github.Commit, originalPRNumber int) bool {
 	if github.ContainsCherryPickForPR(commit.Message, originalPRNumber) {
@@ -0,0 +1,192 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+)
+
+// releaseSource is the part of github.Client release detection reads
+type releaseSource interface {
+	ListReleases(ctx context.Context) ([]github.Release, error)
+	GetCommitsBetweenTags(ctx context.Context, oldTag, newTag string) ([]github.Commit, error)
+	LabelScheme() github.LabelScheme
+}
+
+// ReconcileReleases runs only the release detection step of fetch: merged
+// cherry-picks found in a release are marked released. It returns whether the
+// config changed and how many release ranges could not be scanned.
+func ReconcileReleases(ctx context.Context, client *github.Client, config *cmd.Config) (bool, int) {
+	return updateReleasedStatus(ctx, config, client)
+}
+
+// ExplainRelease traces release detection for one tracked PR's branch and
+// writes what it considered to w: the branch's releases, the tag ranges a
+// fetch would compare, and every commit of every release range with whether
+// it counts as the cherry-pick. Nothing in config is changed.
+func ExplainRelease(ctx context.Context, w io.Writer, client releaseSource, config *cmd.Config, prNumber int, branch string) error {
+	var trackedPR *cmd.TrackedPR
+	for i := range config.TrackedPRs {
+		if config.TrackedPRs[i].Number == prNumber {
+			trackedPR = &config.TrackedPRs[i]
+			break
+		}
+	}
+	if trackedPR == nil {
+		return fmt.Errorf("PR #%d not found in configuration", prNumber)
+	}
+	status, tracked := trackedPR.Branches[branch]
+	if !tracked {
+		return fmt.Errorf("PR #%d has no status for branch '%s'", prNumber, branch)
+	}
+
+	fmt.Fprintf(w, "Release detection for PR #%d on %s\n\n", prNumber, branch)
+	fmt.Fprintf(w, "Status: %s\n", describeReleaseCandidate(status))
+
+	allReleases, err := client.ListReleases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list releases: %w", err)
+	}
+	scheme := client.LabelScheme()
+	relevant := filterReleasesForBranch(allReleases, branch, scheme)
+	if version, ok := scheme.VersionForBranch(branch); ok {
+		fmt.Fprintf(w, "Releases: %d in the repository, %d for %s (tags starting with v%s)\n", len(allReleases), len(relevant), branch, version)
+	} else {
+		fmt.Fprintf(w, "Releases: %d in the repository; %s doesn't match the branch template, so all of them are considered\n", len(allReleases), branch)
+	}
+	if len(relevant) == 0 {
+		fmt.Fprintf(w, "\nVerdict: no release for %s yet, so there is nothing the cherry-pick could be in\n", branch)
+		return nil
+	}
+	fmt.Fprintf(w, "  %s\n", strings.Join(releaseTags(relevant), ", "))
+
+	lastChecked := config.LastCheckedRelease[branch]
+	unchecked := filterUncheckedReleases(relevant, lastChecked)
+	fetchRanges := append([]cmd.ReleaseRange(nil), config.UnscannedReleases[branch]...)
+	fetchRanges = append(fetchRanges, releaseRanges(unchecked, lastChecked)...)
+	fmt.Fprintf(w, "Last checked release: %s\n", orNone(lastChecked))
+	fmt.Fprintf(w, "Unchecked releases: %s\n", orNone(strings.Join(releaseTags(unchecked), ", ")))
+	fmt.Fprintf(w, "Ranges left unscanned by a failed scan: %s\n", orNone(formatRanges(config.UnscannedReleases[branch])))
+	fmt.Fprintf(w, "Ranges the next fetch compares: %s\n", orNone(formatRanges(fetchRanges)))
+
+	// Every range of the branch's history, so a match fetch can no longer see still shows up
+	allRanges := releaseRanges(relevant, "")
+	fmt.Fprintf(w, "\nComparing every release range of %s:\n", branch)
+	fmt.Fprintf(w, "  %s is the oldest release and has no earlier tag to compare against; its commits are never scanned\n",
+		relevant[len(relevant)-1].TagName)
+
+	scanner := newReleaseScanner(client.GetCommitsBetweenTags)
+	var foundIn []cmd.ReleaseRange
+	for _, r := range allRanges {
+		commits, ok := scanner.commits(ctx, r)
+		if !ok {
+			fmt.Fprintf(w, "  %s: comparison failed, see the warnings above\n", formatRange(r))
+			continue
+		}
+		fmt.Fprintf(w, "  %s: %d commit(s)\n", formatRange(r), len(commits))
+		for _, commit := range commits {
+			match, reason := explainCommit(commit, prNumber)
+			mark := "✗"
+			if match {
+				mark = "✓"
+				foundIn = append(foundIn, r)
+			}
+			fmt.Fprintf(w, "    %s %s %s: %s\n", mark, shortCommitSHA(commit.SHA), truncateMessage(firstLine(commit.Message), 72), reason)
+		}
+	}
+
+	fmt.Fprintf(w, "\nVerdict: %s\n", releaseVerdict(status, foundIn, fetchRanges, lastChecked))
+	return nil
+}
+
+// describeReleaseCandidate explains whether fetch looks for a branch in releases at all
+func describeReleaseCandidate(status cmd.BranchStatus) string {
+	switch {
+	case status.Status == cmd.BranchStatusReleased:
+		return "released (already marked; fetch no longer checks it)"
+	case status.Status != cmd.BranchStatusMerged:
+		return fmt.Sprintf("%s (fetch only looks for merged cherry-picks in releases)", status.Status)
+	case status.PR == nil:
+		return "merged, but no cherry-pick PR is recorded (fetch skips it)"
+	default:
+		return fmt.Sprintf("merged (cherry-pick PR #%d)", status.PR.Number)
+	}
+}
+
+// releaseVerdict sums up why fetch did or didn't mark the branch released
+func releaseVerdict(status cmd.BranchStatus, foundIn, fetchRanges []cmd.ReleaseRange, lastChecked string) string {
+	if len(foundIn) == 0 {
+		return "no commit in any release range is a cherry-pick of this PR; it isn't released yet, or its commit message doesn't reference the PR"
+	}
+	found := foundIn[0]
+	candidate := status.Status == cmd.BranchStatusMerged && status.PR != nil
+	switch {
+	case status.Status == cmd.BranchStatusReleased:
+		return fmt.Sprintf("found in %s (%s); already marked released", found.To, formatRange(found))
+	case slices.Contains(fetchRanges, found) && candidate:
+		return fmt.Sprintf("found in %s (%s); the next fetch marks it released", found.To, formatRange(found))
+	case slices.Contains(fetchRanges, found):
+		return fmt.Sprintf("found in %s (%s), but fetch only marks merged cherry-picks with a recorded PR as released", found.To, formatRange(found))
+	default:
+		return fmt.Sprintf("found in %s (%s), which is at or before the last checked release %s, so fetch won't compare it again; run fetch --recheck-releases",
+			found.To, formatRange(found), lastChecked)
+	}
+}
+
+// explainCommit reports whether commit is a cherry-pick of prNumber and why
+func explainCommit(commit github.Commit, prNumber int) (bool, string) {
+	if isCherryPickCommit(commit, prNumber) {
+		return true, fmt.Sprintf("references #%d as a cherry-pick", prNumber)
+	}
+	mention := regexp.MustCompile(`\b` + strconv.Itoa(prNumber) + `\b`)
+	if mention.MatchString(commit.Message) {
+		return false, fmt.Sprintf("mentions %d, but not as a cherry-pick", prNumber)
+	}
+	return false, fmt.Sprintf("doesn't mention #%d", prNumber)
+}
+
+func releaseTags(releases []github.Release) []string {
+	tags := make([]string, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+	return tags
+}
+
+func formatRange(r cmd.ReleaseRange) string {
+	return r.From + ".." + r.To
+}
+
+func formatRanges(ranges []cmd.ReleaseRange) string {
+	formatted := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		formatted = append(formatted, formatRange(r))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return line
+}
+
+func shortCommitSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -0,0 +1,108 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReleaseSource serves releases and tag comparisons from memory
+type fakeReleaseSource struct {
+	releases []github.Release
+	commits  map[string][]github.Commit // "from..to" -> commits
+}
+
+func (f *fakeReleaseSource) ListReleases(context.Context) ([]github.Release, error) {
+	return f.releases, nil
+}
+
+func (f *fakeReleaseSource) GetCommitsBetweenTags(_ context.Context, oldTag, newTag string) ([]github.Commit, error) {
+	return f.commits[oldTag+".."+newTag], nil
+}
+
+func (*fakeReleaseSource) LabelScheme() github.LabelScheme {
+	return github.LabelScheme{}
+}
+
+func newFakeReleaseSource() *fakeReleaseSource {
+	return &fakeReleaseSource{
+		releases: []github.Release{{TagName: "v3.6.2"}, {TagName: "v3.6.1"}, {TagName: "v3.6.0"}, {TagName: "v3.7.0"}},
+		commits: map[string][]github.Commit{
+			"v3.6.1..v3.6.2": {
+				{SHA: "aaaaaaaa11111111", Message: "Other fix (cherry-pick #15000 for 3.6)"},
+			},
+			"v3.6.0..v3.6.1": {
+				{SHA: "bbbbbbbb22222222", Message: "Fix bug (cherry-pick #14944 for 3.6)\n\nSigned-off-by: Dev"},
+				{SHA: "cccccccc33333333", Message: "Revert 14944 test tweak"},
+			},
+		},
+	}
+}
+
+func explainConfig(status cmd.BranchStatus, lastChecked string) *cmd.Config {
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{{Number: 14944, Branches: map[string]cmd.BranchStatus{"release-3.6": status}}},
+	}
+	if lastChecked != "" {
+		config.LastCheckedRelease = map[string]string{"release-3.6": lastChecked}
+	}
+	return config
+}
+
+func TestExplainRelease(t *testing.T) {
+	merged := cmd.BranchStatus{Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 15001}}
+
+	var out bytes.Buffer
+	require.NoError(t, ExplainRelease(t.Context(), &out, newFakeReleaseSource(), explainConfig(merged, "v3.6.0"), 14944, "release-3.6"))
+
+	text := out.String()
+	assert.Contains(t, text, "Status: merged (cherry-pick PR #15001)")
+	assert.Contains(t, text, "Releases: 4 in the repository, 3 for release-3.6 (tags starting with v3.6)")
+	assert.Contains(t, text, "Last checked release: v3.6.0")
+	assert.Contains(t, text, "Ranges the next fetch compares: v3.6.1..v3.6.2, v3.6.0..v3.6.1")
+	assert.Contains(t, text, "✓ bbbbbbbb Fix bug (cherry-pick #14944 for 3.6): references #14944 as a cherry-pick")
+	assert.Contains(t, text, "✗ cccccccc Revert 14944 test tweak: mentions 14944, but not as a cherry-pick")
+	assert.Contains(t, text, "✗ aaaaaaaa Other fix (cherry-pick #15000 for 3.6): doesn't mention #14944")
+	assert.Contains(t, text, "Verdict: found in v3.6.1 (v3.6.0..v3.6.1); the next fetch marks it released")
+}
+
+func TestExplainRelease_RangeAlreadyChecked(t *testing.T) {
+	merged := cmd.BranchStatus{Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 15001}}
+
+	var out bytes.Buffer
+	require.NoError(t, ExplainRelease(t.Context(), &out, newFakeReleaseSource(), explainConfig(merged, "v3.6.2"), 14944, "release-3.6"))
+
+	assert.Contains(t, out.String(), "Ranges the next fetch compares: none")
+	assert.Contains(t, out.String(), "at or before the last checked release v3.6.2")
+	assert.Contains(t, out.String(), "--recheck-releases")
+}
+
+func TestExplainRelease_NotFound(t *testing.T) {
+	picked := cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 15001}}
+
+	config := explainConfig(picked, "")
+	config.TrackedPRs[0].Number = 15002
+
+	var out bytes.Buffer
+	require.NoError(t, ExplainRelease(t.Context(), &out, newFakeReleaseSource(), config, 15002, "release-3.6"))
+	assert.Contains(t, out.String(), "no commit in any release range is a cherry-pick of this PR")
+}
+
+func TestExplainRelease_Errors(t *testing.T) {
+	config := explainConfig(cmd.BranchStatus{Status: cmd.BranchStatusMerged}, "")
+	var out bytes.Buffer
+
+	require.ErrorContains(t, ExplainRelease(t.Context(), &out, newFakeReleaseSource(), config, 999, "release-3.6"), "not found")
+	require.ErrorContains(t, ExplainRelease(t.Context(), &out, newFakeReleaseSource(), config, 14944, "release-9.9"), "no status for branch")
+}
+
+func TestDescribeReleaseCandidate(t *testing.T) {
+	assert.Contains(t, describeReleaseCandidate(cmd.BranchStatus{Status: cmd.BranchStatusPicked}), "only looks for merged")
+	assert.Contains(t, describeReleaseCandidate(cmd.BranchStatus{Status: cmd.BranchStatusMerged}), "no cherry-pick PR is recorded")
+	assert.Contains(t, describeReleaseCandidate(cmd.BranchStatus{Status: cmd.BranchStatusReleased}), "already marked")
+}
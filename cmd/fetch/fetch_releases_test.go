@@ -0,0 +1,79 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseRanges(t *testing.T) {
+	releases := []github.Release{{TagName: "v3.6.3"}, {TagName: "v3.6.2"}, {TagName: "v3.6.1"}}
+
+	assert.Equal(t, []cmd.ReleaseRange{
+		{From: "v3.6.2", To: "v3.6.3"},
+		{From: "v3.6.1", To: "v3.6.2"},
+		{From: "v3.6.0", To: "v3.6.1"},
+	}, releaseRanges(releases, "v3.6.0"))
+
+	// Without a last checked tag the oldest release has no base to compare from
+	assert.Equal(t, []cmd.ReleaseRange{
+		{From: "v3.6.2", To: "v3.6.3"},
+		{From: "v3.6.1", To: "v3.6.2"},
+	}, releaseRanges(releases, ""))
+
+	assert.Empty(t, releaseRanges(nil, "v3.6.0"))
+}
+
+func TestReleaseScannerRetries(t *testing.T) {
+	orig := releaseScanRetryDelay
+	releaseScanRetryDelay = 0
+	t.Cleanup(func() { releaseScanRetryDelay = orig })
+
+	calls := map[string]int{}
+	scanner := newReleaseScanner(func(_ context.Context, oldTag, newTag string) ([]github.Commit, error) {
+		key := oldTag + ".." + newTag
+		calls[key]++
+		switch {
+		case key == "v3.6.1..v3.6.2" && calls[key] < 2:
+			return nil, errors.New("transient")
+		case key == "v3.6.2..v3.6.3":
+			return nil, errors.New("persistent")
+		}
+		return []github.Commit{{SHA: "0123456789", Message: "Fix (cherry-pick #14944 for 3.6)"}}, nil
+	})
+
+	flaky := cmd.ReleaseRange{From: "v3.6.1", To: "v3.6.2"}
+	broken := cmd.ReleaseRange{From: "v3.6.2", To: "v3.6.3"}
+	ranges := []cmd.ReleaseRange{broken, flaky}
+
+	assert.True(t, scanner.isInRelease(context.Background(), ranges, 14944))
+	assert.Equal(t, 2, calls["v3.6.1..v3.6.2"], "transient failure should be retried")
+	assert.Equal(t, releaseScanAttempts, calls["v3.6.2..v3.6.3"], "retries should be bounded")
+
+	// A second PR reuses cached results instead of hitting the API again
+	assert.False(t, scanner.isInRelease(context.Background(), ranges, 15000))
+	assert.Equal(t, 2, calls["v3.6.1..v3.6.2"])
+	assert.Equal(t, releaseScanAttempts, calls["v3.6.2..v3.6.3"])
+
+	assert.Equal(t, []cmd.ReleaseRange{broken}, scanner.failedRanges(ranges))
+}
+
+func TestRecordUnscannedReleases(t *testing.T) {
+	config := &cmd.Config{}
+	failed := []cmd.ReleaseRange{{From: "v3.6.1", To: "v3.6.2"}}
+
+	require.True(t, recordUnscannedReleases(config, "release-3.6", failed))
+	assert.Equal(t, failed, config.UnscannedReleases["release-3.6"])
+
+	assert.False(t, recordUnscannedReleases(config, "release-3.6", failed), "unchanged ranges are not an update")
+
+	require.True(t, recordUnscannedReleases(config, "release-3.6", nil))
+	assert.NotContains(t, config.UnscannedReleases, "release-3.6")
+
+	assert.False(t, recordUnscannedReleases(config, "release-3.7", nil))
+}
@@ -2,6 +2,7 @@ package fetch
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
@@ -19,33 +20,59 @@ func syncBranchesWithGitHub(config *cmd.Config, pr github.PR) bool {
 
 		trackedPR := &config.TrackedPRs[i]
 
+		// PRs tracked before merge times and authors were recorded pick them up here
+		if trackedPR.MergedAt == nil && !pr.MergedAt.IsZero() {
+			mergedAt := pr.MergedAt
+			trackedPR.MergedAt = &mergedAt
+			updated = true
+		}
+		if trackedPR.Author == "" && pr.Author != "" {
+			trackedPR.Author = pr.Author
+			updated = true
+		}
+
 		// Build set of branches from GitHub labels
 		githubBranches := make(map[string]bool)
 		for _, branch := range pr.CherryPickFor {
 			githubBranches[branch] = true
 		}
 
-		// Add new branches from GitHub labels
+		// Add new branches from GitHub labels, unless the user declined to backport there
 		for branch := range githubBranches {
+			if trackedPR.IsBranchIgnored(branch) {
+				slog.Debug("Skipping ignored branch", "pr", pr.Number, "branch", branch)
+				continue
+			}
 			if _, exists := trackedPR.Branches[branch]; !exists {
 				slog.Info("Adding new branch from label", "pr", pr.Number, "branch", branch)
 				if trackedPR.Branches == nil {
 					trackedPR.Branches = make(map[string]cmd.BranchStatus)
 				}
-				trackedPR.Branches[branch] = cmd.BranchStatus{Status: cmd.BranchStatusPending}
+				trackedPR.Branches[branch] = cmd.BranchStatus{Status: cmd.BranchStatusPending, LastUpdated: time.Now()}
 				updated = true
 			}
 		}
 
-		// Remove branches that no longer have labels on GitHub (unless already picked/merged)
+		// Handle branches that no longer have labels on GitHub (picked/merged are always kept for history)
+		policy, _ := cmd.ParseLabelRemovedPolicy(string(config.OnLabelRemoved))
 		for branch, status := range trackedPR.Branches {
-			if !githubBranches[branch] {
-				// Only remove if still pending or failed - keep picked/merged for history
-				if status.Status == cmd.BranchStatusPending || status.Status == cmd.BranchStatusFailed {
-					slog.Info("Removing branch - label removed from GitHub", "pr", pr.Number, "branch", branch)
-					delete(trackedPR.Branches, branch)
-					updated = true
-				}
+			if githubBranches[branch] {
+				continue
+			}
+			if status.Status != cmd.BranchStatusPending && status.Status != cmd.BranchStatusFailed {
+				continue
+			}
+			switch policy {
+			case cmd.LabelRemovedKeep:
+				slog.Info("Keeping branch - label removed from GitHub", "pr", pr.Number, "branch", branch, "status", status.Status, "policy", policy)
+			case cmd.LabelRemovedWarn:
+				slog.Warn("Removing branch - label removed from GitHub", "pr", pr.Number, "branch", branch, "status", status.Status, "policy", policy)
+				delete(trackedPR.Branches, branch)
+				updated = true
+			default:
+				slog.Info("Removing branch - label removed from GitHub", "pr", pr.Number, "branch", branch, "status", status.Status, "policy", policy)
+				delete(trackedPR.Branches, branch)
+				updated = true
 			}
 		}
 
@@ -55,14 +82,15 @@ func syncBranchesWithGitHub(config *cmd.Config, pr github.PR) bool {
 	return updated
 }
 
-// removeEmptyPRs removes PRs that have no branches left
+// removeEmptyPRs removes PRs that have no branches left. PRs with ignored branches
+// are kept so the decision survives and fetch doesn't track them again as new
 // Returns the number of PRs removed
 func removeEmptyPRs(config *cmd.Config) int {
 	var remaining []cmd.TrackedPR
 	removed := 0
 
 	for _, pr := range config.TrackedPRs {
-		if len(pr.Branches) == 0 {
+		if len(pr.Branches) == 0 && len(pr.IgnoredBranches) == 0 {
 			slog.Info("Removing PR with no branches", "pr", pr.Number)
 			removed++
 		} else {
@@ -76,14 +104,21 @@ func removeEmptyPRs(config *cmd.Config) int {
 
 // addNewPR adds a new PR to the config without checking cherry-pick status
 func addNewPR(config *cmd.Config, pr github.PR) {
+	now := time.Now()
 	branches := make(map[string]cmd.BranchStatus)
 	for _, branch := range pr.CherryPickFor {
-		branches[branch] = cmd.BranchStatus{Status: cmd.BranchStatusPending}
+		branches[branch] = cmd.BranchStatus{Status: cmd.BranchStatusPending, LastUpdated: now}
 	}
 
-	config.TrackedPRs = append(config.TrackedPRs, cmd.TrackedPR{
+	trackedPR := cmd.TrackedPR{
 		Number:   pr.Number,
 		Title:    pr.Title,
+		Author:   pr.Author,
 		Branches: branches,
-	})
+	}
+	if !pr.MergedAt.IsZero() {
+		mergedAt := pr.MergedAt
+		trackedPR.MergedAt = &mergedAt
+	}
+	config.TrackedPRs = append(config.TrackedPRs, trackedPR)
 }
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestTrackedPRStruct(t *testing.T) {
@@ -171,6 +173,14 @@ func TestRemoveEmptyPRs(t *testing.T) {
 			wantRemoved:   2,
 			wantRemaining: 2,
 		},
+		{
+			name: "PR with only ignored branches is kept",
+			trackedPRs: []cmd.TrackedPR{
+				{Number: 1, Branches: map[string]cmd.BranchStatus{}, IgnoredBranches: []string{"release-1.0"}},
+			},
+			wantRemoved:   0,
+			wantRemaining: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -217,3 +227,106 @@ func TestIsPRTracked(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncBranchesWithGitHubLabelRemovedPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     cmd.LabelRemovedPolicy
+		wantKept   bool
+		wantUpdate bool
+	}{
+		{name: "default removes", policy: "", wantKept: false, wantUpdate: true},
+		{name: "remove", policy: cmd.LabelRemovedRemove, wantKept: false, wantUpdate: true},
+		{name: "warn removes", policy: cmd.LabelRemovedWarn, wantKept: false, wantUpdate: true},
+		{name: "keep", policy: cmd.LabelRemovedKeep, wantKept: true, wantUpdate: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &cmd.Config{
+				OnLabelRemoved: tt.policy,
+				TrackedPRs: []cmd.TrackedPR{{
+					Number: 1,
+					Branches: map[string]cmd.BranchStatus{
+						"release-3.6": {Status: cmd.BranchStatusPending},
+						"release-3.5": {Status: cmd.BranchStatusFailed},
+						"release-3.4": {Status: cmd.BranchStatusPicked},
+					},
+				}},
+			}
+
+			updated := syncBranchesWithGitHub(config, github.PR{Number: 1, CherryPickFor: []string{"release-3.5"}})
+
+			assert.Equal(t, tt.wantUpdate, updated)
+			branches := config.TrackedPRs[0].Branches
+			assert.Contains(t, branches, "release-3.5", "labelled branch must be kept")
+			assert.Contains(t, branches, "release-3.4", "picked branch must always be kept")
+			if tt.wantKept {
+				assert.Contains(t, branches, "release-3.6")
+			} else {
+				assert.NotContains(t, branches, "release-3.6")
+			}
+		})
+	}
+}
+
+func TestSyncBranchesWithGitHubSkipsIgnored(t *testing.T) {
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{{
+			Number:          1,
+			Branches:        map[string]cmd.BranchStatus{"release-3.5": {Status: cmd.BranchStatusPending}},
+			IgnoredBranches: []string{"release-3.4"},
+		}},
+	}
+
+	updated := syncBranchesWithGitHub(config, github.PR{Number: 1, CherryPickFor: []string{"release-3.5", "release-3.4"}})
+
+	assert.False(t, updated)
+	assert.NotContains(t, config.TrackedPRs[0].Branches, "release-3.4", "ignored branch must not be re-added from its label")
+}
+
+func TestBranchesWithoutCherryPick(t *testing.T) {
+	cherryPicks := []github.CherryPickPR{
+		{Number: 15001, Branch: "release-3.6"},
+		{Branch: "release-3.7", Failed: true},
+	}
+
+	missing := branchesWithoutCherryPick([]string{"release-3.5", "release-3.6", "release-3.7"}, cherryPicks)
+	assert.Equal(t, []string{"release-3.5", "release-3.7"}, missing, "failed attempts do not count as found")
+	assert.Empty(t, branchesWithoutCherryPick([]string{"release-3.6"}, cherryPicks))
+}
+
+func TestSyncBranchesWithGitHubBackfillsMergedAt(t *testing.T) {
+	mergedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{{
+			Number:   1,
+			Branches: map[string]cmd.BranchStatus{"release-3.5": {Status: cmd.BranchStatusPending}},
+		}},
+	}
+
+	updated := syncBranchesWithGitHub(config, github.PR{Number: 1, MergedAt: mergedAt, CherryPickFor: []string{"release-3.5"}})
+
+	assert.True(t, updated)
+	if assert.NotNil(t, config.TrackedPRs[0].MergedAt) {
+		assert.Equal(t, mergedAt, *config.TrackedPRs[0].MergedAt)
+	}
+
+	assert.False(t, syncBranchesWithGitHub(config, github.PR{Number: 1, MergedAt: mergedAt, CherryPickFor: []string{"release-3.5"}}),
+		"a recorded merge time is not rewritten")
+}
+
+func TestSyncBranchesWithGitHubBackfillsAuthor(t *testing.T) {
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{{
+			Number:   1,
+			Branches: map[string]cmd.BranchStatus{"release-3.5": {Status: cmd.BranchStatusPending}},
+		}},
+	}
+
+	assert.True(t, syncBranchesWithGitHub(config, github.PR{Number: 1, Author: "octocat", CherryPickFor: []string{"release-3.5"}}))
+	assert.Equal(t, "octocat", config.TrackedPRs[0].Author)
+
+	assert.False(t, syncBranchesWithGitHub(config, github.PR{Number: 1, Author: "octocat", CherryPickFor: []string{"release-3.5"}}),
+		"a recorded author is not rewritten")
+}
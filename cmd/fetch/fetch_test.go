@@ -2,6 +2,7 @@ package fetch
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -45,8 +46,9 @@ func TestFetchCmd_RunE_InvalidSinceDate(t *testing.T) {
 
 	loadConfig := func(_ string) (*cmd.Config, error) {
 		return &cmd.Config{
-			Org:  "test-org",
-			Repo: "test-repo",
+			Org:          "test-org",
+			Repo:         "test-repo",
+			SourceBranch: "main",
 		}, nil
 	}
 	saveConfig := func(_ string, _ *cmd.Config) error {
@@ -242,6 +244,53 @@ func TestDetermineSinceDate(t *testing.T) {
 	}
 }
 
+// fakeTagDater serves tag dates from memory
+type fakeTagDater map[string]time.Time
+
+func (f fakeTagDater) GetTagDate(_ context.Context, tag string) (time.Time, error) {
+	date, ok := f[tag]
+	if !ok {
+		return time.Time{}, fmt.Errorf("failed to get commit of tag %s: not found", tag)
+	}
+	return date, nil
+}
+
+func TestResolveSince(t *testing.T) {
+	tagDate := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lastFetch := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tags := fakeTagDater{"v3.7.0": tagDate}
+
+	since, err := ResolveSince(t.Context(), tags, "", "v3.7.0", &lastFetch)
+	require.NoError(t, err)
+	assert.Equal(t, tagDate, since, "the tag's date overrides the last fetch date")
+
+	since, err = ResolveSince(t.Context(), tags, "", "", &lastFetch)
+	require.NoError(t, err)
+	assert.Equal(t, lastFetch, since)
+
+	_, err = ResolveSince(t.Context(), tags, "2024-01-01", "v3.7.0", nil)
+	require.ErrorContains(t, err, "can't be used together")
+
+	_, err = ResolveSince(t.Context(), tags, "", "v9.9.9", nil)
+	require.ErrorContains(t, err, "v9.9.9")
+}
+
+func TestSearchQualifiers(t *testing.T) {
+	qualifiers, err := SearchQualifiers("", "")
+	require.NoError(t, err)
+	assert.Empty(t, qualifiers)
+
+	qualifiers, err = SearchQualifiers("@octocat", "  label:area/ui -label:wip ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"author:octocat", "label:area/ui -label:wip"}, qualifiers)
+
+	_, err = SearchQualifiers("octo cat", "")
+	require.ErrorContains(t, err, "invalid --author")
+
+	_, err = SearchQualifiers("", "label:a\nrepo:other/repo")
+	require.ErrorContains(t, err, "can't contain newlines")
+}
+
 // TestCommandOutput tests command output formatting
 func TestCommandOutput(t *testing.T) {
 	configFile := "test-config.yaml"
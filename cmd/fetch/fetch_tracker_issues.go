@@ -2,9 +2,7 @@ package fetch
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
-	"strings"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
@@ -56,7 +54,7 @@ func getAllBranches(config *cmd.Config) []string {
 func detectTrackerIssueForBranch(ctx context.Context, client *github.Client, config *cmd.Config, branch string) bool {
 	// Extract version from branch name
 	// Expected format: "release-3.6" -> search for "Release v3.6 patch"
-	version, ok := strings.CutPrefix(branch, "release-")
+	version, ok := client.LabelScheme().VersionForBranch(branch)
 	if !ok {
 		slog.Debug("Branch doesn't match expected format", "branch", branch)
 		return false
@@ -81,7 +79,6 @@ func detectTrackerIssueForBranch(ctx context.Context, client *github.Client, con
 	config.TrackerIssues[branch] = trackerIssue.Number
 
 	slog.Info("Found tracker issue", "branch", branch, "issue", trackerIssue.Number, "title", trackerIssue.Title)
-	fmt.Printf("  Found tracker issue for %s: #%d\n", branch, trackerIssue.Number)
 
 	return true
 }
@@ -2,8 +2,11 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/alan/cherry-picker/cmd"
@@ -30,6 +33,7 @@ func RefreshCherry(ctx context.Context, client *github.Client, config *cmd.Confi
 
 	configUpdated := false
 	newPRsAdded := 0
+	unscannedRanges := 0
 
 	// Add new PRs from search results
 	for _, pr := range allPRs {
@@ -37,6 +41,7 @@ func RefreshCherry(ctx context.Context, client *github.Client, config *cmd.Confi
 			slog.Info("Found new PR", "number", pr.Number, "title", pr.Title, "url", pr.URL, "cherry_pick_labels", pr.CherryPickFor)
 			addNewPR(config, pr)
 			newPRsAdded++
+			Emit(ctx, Event{Type: EventNewPR, PR: pr.Number})
 		}
 	}
 
@@ -79,7 +84,9 @@ func RefreshCherry(ctx context.Context, client *github.Client, config *cmd.Confi
 
 		// Check releases and mark cherry-picks as released
 		slog.Info("Checking releases for merged cherry-picks")
-		if updateReleasedStatus(ctx, config, client) {
+		var releasesUpdated bool
+		releasesUpdated, unscannedRanges = updateReleasedStatus(ctx, config, client)
+		if releasesUpdated {
 			configUpdated = true
 		}
 
@@ -95,6 +102,9 @@ func RefreshCherry(ctx context.Context, client *github.Client, config *cmd.Confi
 	} else {
 		slog.Info("No changes detected")
 	}
+	if unscannedRanges > 0 {
+		slog.Warn("Some release ranges could not be scanned; they will be rescanned on the next fetch", "count", unscannedRanges)
+	}
 
 	return nil
 }
@@ -111,112 +121,232 @@ func fetchPRsFromGitHub(ctx context.Context, client *github.Client, config *cmd.
 	return prs, nil
 }
 
-// updateAllTrackedPRs updates all existing tracked PRs by checking their cherry-pick status
+// updateAllTrackedPRs updates all existing tracked PRs by checking their
+// cherry-pick status. PRs are checked concurrently (fetch_concurrency, default
+// DefaultConcurrency); each worker only touches its own tracked PR and the
+// results are combined here, with logs and events written in tracked-PR order.
+// Tracked PRs GitHub no longer has are pruned once every worker is done.
 func updateAllTrackedPRs(ctx context.Context, config *cmd.Config, client *github.Client) bool {
-	updated := false
+	updated := make([]bool, len(config.TrackedPRs))
+	deleted := make([]bool, len(config.TrackedPRs))
+	runOrdered(ctx, len(config.TrackedPRs), concurrency(config.FetchConcurrency), func(ctx context.Context, log *slog.Logger, i int) {
+		updated[i], deleted[i] = updateTrackedPR(ctx, log, config, client, &config.TrackedPRs[i])
+	})
+
+	remaining := config.TrackedPRs[:0]
+	for i, trackedPR := range config.TrackedPRs {
+		if !deleted[i] {
+			remaining = append(remaining, trackedPR)
+		}
+	}
+	config.TrackedPRs = remaining
+	return slices.Contains(updated, true) || slices.Contains(deleted, true)
+}
 
-	for i := range config.TrackedPRs {
-		trackedPR := &config.TrackedPRs[i]
+// prunableDeletedPR decides what to do with a tracked PR whose comments GitHub
+// reported as not found. It is pruned only when the PR is confirmed deleted
+// (see github.Client.PRDeleted) and the state merge would drop it: the
+// on_label_removed policy isn't "keep" and no branch is picked or beyond.
+func prunableDeletedPR(ctx context.Context, log *slog.Logger, config *cmd.Config, client *github.Client, trackedPR *cmd.TrackedPR) bool {
+	deleted, err := client.PRDeleted(ctx, trackedPR.Number)
+	if err != nil {
+		log.Warn("Tracked PR not found on GitHub but could not confirm it was deleted, keeping it", "pr", trackedPR.Number, "error", err)
+		return false
+	}
+	if !deleted {
+		log.Warn("Tracked PR comments not found on GitHub but the PR exists, keeping it", "pr", trackedPR.Number)
+		return false
+	}
+	if config.OnLabelRemoved == cmd.LabelRemovedKeep || hasAdvancedBranch(trackedPR) {
+		log.Warn("Tracked PR no longer exists on GitHub, keeping its recorded cherry-picks", "pr", trackedPR.Number)
+		return false
+	}
+	log.Warn("Tracked PR no longer exists on GitHub, removing it", "pr", trackedPR.Number)
+	return true
+}
 
-		// Skip PR if all branches are already finalized (merged or released)
-		allFinalized := true
-		for _, status := range trackedPR.Branches {
-			if status.Status != cmd.BranchStatusMerged && status.Status != cmd.BranchStatusReleased {
-				allFinalized = false
-				break
-			}
-		}
-		if allFinalized {
-			slog.Debug("Skipping fully finalized tracked PR", "pr", trackedPR.Number)
-			continue
+// hasAdvancedBranch reports whether any branch of the PR is picked or beyond
+func hasAdvancedBranch(trackedPR *cmd.TrackedPR) bool {
+	for _, status := range trackedPR.Branches {
+		if status.Status != cmd.BranchStatusPending && status.Status != cmd.BranchStatusFailed {
+			return true
 		}
+	}
+	return false
+}
 
-		slog.Info("Checking tracked PR", "pr", trackedPR.Number)
+// updateTrackedPR checks one tracked PR's cherry-picks on GitHub and updates its
+// branches, reporting whether anything changed and whether the PR was deleted
+// on GitHub and should be pruned (see prunableDeletedPR). It logs through log so
+// its output can be held back while other PRs are checked.
+func updateTrackedPR(ctx context.Context, log *slog.Logger, config *cmd.Config, client *github.Client, trackedPR *cmd.TrackedPR) (bool, bool) {
+	updated := false
 
-		cherryPickPRs, err := client.GetCherryPickPRsFromComments(ctx, trackedPR.Number)
-		if err != nil {
-			slog.Warn("Failed to fetch cherry-pick PRs from comments", "pr", trackedPR.Number, "error", err)
-			cherryPickPRs = []github.CherryPickPR{}
+	// Skip PR if all branches are already finalized (merged or released)
+	allFinalized := true
+	for _, status := range trackedPR.Branches {
+		if status.Status != cmd.BranchStatusMerged && status.Status != cmd.BranchStatusReleased {
+			allFinalized = false
+			break
 		}
+	}
+	if allFinalized {
+		log.Debug("Skipping fully finalized tracked PR", "pr", trackedPR.Number)
+		return false, false
+	}
+
+	log.Info("Checking tracked PR", "pr", trackedPR.Number)
+
+	cherryPickPRs, err := client.GetCherryPickPRsFromComments(ctx, trackedPR.Number)
+	if errors.Is(err, github.ErrNotFound) {
+		return false, prunableDeletedPR(ctx, log, config, client, trackedPR)
+	}
+	if err != nil {
+		log.Warn("Failed to fetch cherry-pick PRs from comments", "pr", trackedPR.Number, "error", err)
+		cherryPickPRs = []github.CherryPickPR{}
+	}
 
-		// Get list of branches we're tracking for this PR
-		var branches []string
-		for branch := range trackedPR.Branches {
-			branches = append(branches, branch)
+	// Get list of branches we're tracking for this PR
+	var branches []string
+	for branch := range trackedPR.Branches {
+		branches = append(branches, branch)
+	}
+
+	// Search for manual cherry-pick PRs by title
+	manualCherryPicks, err := client.SearchManualCherryPickPRs(ctx, trackedPR.Number, branches, config.ManualSearchMaxCandidates)
+	if err != nil {
+		log.Warn("Failed to search for manual cherry-pick PRs", "pr", trackedPR.Number, "error", err)
+	} else {
+		// Merge manual cherry-picks with bot cherry-picks
+		cherryPickPRs = append(cherryPickPRs, manualCherryPicks...)
+	}
+
+	// Some bots link the original only in the cherry-pick PR's body
+	if missing := branchesWithoutCherryPick(branches, cherryPickPRs); len(missing) > 0 {
+		bodyCherryPicks, err := client.SearchCherryPickPRsByBody(ctx, trackedPR.Number, missing)
+		if err != nil {
+			log.Warn("Failed to search PR bodies for cherry-pick PRs", "pr", trackedPR.Number, "error", err)
+		} else {
+			cherryPickPRs = append(cherryPickPRs, bodyCherryPicks...)
 		}
+	}
 
-		// Search for manual cherry-pick PRs by title
-		manualCherryPicks, err := client.SearchManualCherryPickPRs(ctx, trackedPR.Number, branches)
+	// Others give the PR a generic title but name its head branch after the original
+	if missing := branchesWithoutCherryPick(branches, cherryPickPRs); len(missing) > 0 && config.HeadBranchPattern != "" {
+		headCherryPicks, err := client.SearchCherryPickPRsByHeadBranch(ctx, trackedPR.Number, missing, config.HeadBranchPattern)
 		if err != nil {
-			slog.Warn("Failed to search for manual cherry-pick PRs", "pr", trackedPR.Number, "error", err)
+			log.Warn("Failed to search head branches for cherry-pick PRs", "pr", trackedPR.Number, "error", err)
 		} else {
-			// Merge manual cherry-picks with bot cherry-picks
-			cherryPickPRs = append(cherryPickPRs, manualCherryPicks...)
+			cherryPickPRs = append(cherryPickPRs, headCherryPicks...)
 		}
+	}
 
-		existingByBranch := make(map[string]github.CherryPickPR)
-		for _, cp := range cherryPickPRs {
-			existing, exists := existingByBranch[cp.Branch]
-			if !exists {
-				existingByBranch[cp.Branch] = cp
-			} else if existing.Failed && !cp.Failed {
-				// Non-failure always wins over failure
-				existingByBranch[cp.Branch] = cp
-				slog.Debug("Preferring successful cherry-pick over failure",
-					"branch", cp.Branch, "pr", cp.Number)
-			} else if !existing.Failed && !cp.Failed && cp.Number > existing.Number {
-				// Both successes: prefer higher PR number (newer)
-				existingByBranch[cp.Branch] = cp
-			}
-			// If existing is success and new is failure, keep existing
+	existingByBranch := make(map[string]github.CherryPickPR)
+	for _, cp := range cherryPickPRs {
+		existing, exists := existingByBranch[cp.Branch]
+		if !exists {
+			existingByBranch[cp.Branch] = cp
+		} else if existing.Failed && !cp.Failed {
+			// Non-failure always wins over failure
+			existingByBranch[cp.Branch] = cp
+			log.Debug("Preferring successful cherry-pick over failure",
+				"branch", cp.Branch, "pr", cp.Number)
+		} else if !existing.Failed && !cp.Failed && cp.Number > existing.Number {
+			// Both successes: prefer higher PR number (newer)
+			existingByBranch[cp.Branch] = cp
+		}
+		// If existing is success and new is failure, keep existing
+	}
+
+	for _, branch := range slices.Sorted(maps.Keys(trackedPR.Branches)) {
+		currentStatus := trackedPR.Branches[branch]
+		if currentStatus.Status == cmd.BranchStatusMerged || currentStatus.Status == cmd.BranchStatusReleased {
+			log.Debug("Skipping finalized tracked PR", "pr", trackedPR.Number, "branch", branch, "status", currentStatus.Status)
+			continue
 		}
+		log.Info("Checking tracked PR", "pr", trackedPR.Number, "branch", branch)
 
-		for branch, currentStatus := range trackedPR.Branches {
-			if currentStatus.Status == cmd.BranchStatusMerged || currentStatus.Status == cmd.BranchStatusReleased {
-				slog.Debug("Skipping finalized tracked PR", "pr", trackedPR.Number, "branch", branch, "status", currentStatus.Status)
-				continue
+		if cherryPick, cpExists := existingByBranch[branch]; cpExists {
+			newStatus := determineBranchStatus(ctx, log, cherryPick, config, client, trackedPR)
+			// Keep how the pick command produced this PR; GitHub does not know it
+			if newStatus.PR != nil && currentStatus.PR != nil && currentStatus.PR.Number == newStatus.PR.Number {
+				newStatus.PR.ResolutionMethod = currentStatus.PR.ResolutionMethod
+			}
+			// A queued PR stays open until the merge queue lands it, unless the
+			// queue dropped it (failed checks, removed by hand): then it is picked
+			// again with the CI just read, so merge and retry act on it
+			if currentStatus.Status == cmd.BranchStatusQueued && newStatus.Status == cmd.BranchStatusPicked &&
+				!dequeued(ctx, log, client, newStatus.PR.Number) {
+				newStatus.Status = cmd.BranchStatusQueued
 			}
-			slog.Info("Checking tracked PR", "pr", trackedPR.Number, "branch", branch)
-
-			if cherryPick, cpExists := existingByBranch[branch]; cpExists {
-				newStatus := determineBranchStatus(ctx, cherryPick, config, client, trackedPR)
-				if currentStatus.Status != newStatus.Status ||
-					(newStatus.PR != nil && (currentStatus.PR == nil || currentStatus.PR.Number != newStatus.PR.Number)) {
-					trackedPR.Branches[branch] = newStatus
-					updated = true
-					slog.Info("Updated branch status", "pr", trackedPR.Number, "branch", branch,
-						"old_status", currentStatus.Status, "new_status", newStatus.Status)
-				} else if currentStatus.Status == cmd.BranchStatusPicked && currentStatus.PR != nil {
-					prDetails, err := client.GetPRWithDetails(ctx, currentStatus.PR.Number)
-					if err == nil {
-						changed := false
-						if currentStatus.PR.CIStatus != cmd.ParseCIStatus(prDetails.CIStatus) {
-							currentStatus.PR.CIStatus = cmd.ParseCIStatus(prDetails.CIStatus)
-							changed = true
-						}
-						if currentStatus.PR.RunAttempt != prDetails.RunAttempt {
-							currentStatus.PR.RunAttempt = prDetails.RunAttempt
-							changed = true
-						}
-						// Update failing checks (only relevant when CI is failing)
-						if !slicesEqual(currentStatus.PR.FailingChecks, prDetails.FailingChecks) {
-							currentStatus.PR.FailingChecks = prDetails.FailingChecks
-							changed = true
-						}
-						if changed {
-							trackedPR.Branches[branch] = currentStatus
-							updated = true
-							slog.Info("Cherry-pick PR CI status updated", "pr", trackedPR.Number, "branch", branch, "ci_status", currentStatus.PR.CIStatus)
-						}
+			if currentStatus.Status != newStatus.Status ||
+				(newStatus.PR != nil && (currentStatus.PR == nil || currentStatus.PR.Number != newStatus.PR.Number)) {
+				newStatus.LastUpdated = currentStatus.LastUpdated
+				if currentStatus.Status != newStatus.Status {
+					newStatus.LastUpdated = time.Now()
+				}
+				trackedPR.Branches[branch] = newStatus
+				updated = true
+				log.Info("Updated branch status", "pr", trackedPR.Number, "branch", branch,
+					"old_status", currentStatus.Status, "new_status", newStatus.Status)
+				if currentStatus.Status != newStatus.Status {
+					Emit(ctx, Event{Type: EventStatusChanged, PR: trackedPR.Number, Branch: branch, From: currentStatus.Status, To: newStatus.Status})
+				}
+			} else if (currentStatus.Status == cmd.BranchStatusPicked || currentStatus.Status == cmd.BranchStatusQueued) && currentStatus.PR != nil {
+				prDetails, err := client.GetPRWithDetails(ctx, currentStatus.PR.Number)
+				if err == nil {
+					changed, newCommits := RefreshPickPRCI(currentStatus.PR, prDetails)
+					if newCommits {
+						log.Info("New commits pushed to cherry-pick PR", "pr", trackedPR.Number, "branch", branch,
+							"cherry_pick_pr", currentStatus.PR.Number, "head_sha", currentStatus.PR.HeadSHA)
+						Emit(ctx, Event{Type: EventNewCommits, PR: trackedPR.Number, Branch: branch, PickPR: currentStatus.PR.Number})
+					}
+					if changed {
+						trackedPR.Branches[branch] = currentStatus
+						updated = true
+						log.Info("Cherry-pick PR CI status updated", "pr", trackedPR.Number, "branch", branch, "ci_status", currentStatus.PR.CIStatus)
 					}
 				}
-			} else {
-				slog.Info("No existing Cherry-pick for tracked PR", "pr", trackedPR.Number, "branch", branch)
 			}
+		} else {
+			log.Info("No existing Cherry-pick for tracked PR", "pr", trackedPR.Number, "branch", branch)
 		}
 	}
 
-	return updated
+	Emit(ctx, Event{Type: EventPRSynced, PR: trackedPR.Number})
+	return updated, false
+}
+
+// dequeued reports whether a queued cherry-pick PR has left the merge queue
+// without merging. When that can't be read the PR is assumed still queued.
+func dequeued(ctx context.Context, log *slog.Logger, client *github.Client, prNumber int) bool {
+	inQueue, err := client.IsInMergeQueue(ctx, prNumber)
+	if err != nil {
+		log.Warn("Failed to check merge queue, keeping the branch queued", "cherry_pick_pr", prNumber, "error", err)
+		return false
+	}
+	if !inQueue {
+		log.Info("Cherry-pick PR left the merge queue without merging", "cherry_pick_pr", prNumber)
+	}
+	return !inQueue
+}
+
+// branchesWithoutCherryPick returns the branches that have no successful cherry-pick PR among cherryPickPRs
+func branchesWithoutCherryPick(branches []string, cherryPickPRs []github.CherryPickPR) []string {
+	found := make(map[string]bool)
+	for _, cp := range cherryPickPRs {
+		if !cp.Failed {
+			found[cp.Branch] = true
+		}
+	}
+
+	var missing []string
+	for _, branch := range branches {
+		if !found[branch] {
+			missing = append(missing, branch)
+		}
+	}
+	return missing
 }
 
 // isPRTracked checks if a PR is already being tracked
@@ -230,19 +360,19 @@ func isPRTracked(config *cmd.Config, prNumber int) bool {
 }
 
 // determineBranchStatus determines the status for a branch based on cherry-pick PR info
-func determineBranchStatus(ctx context.Context, cherryPick github.CherryPickPR, _ *cmd.Config, client *github.Client, trackedPR *cmd.TrackedPR) cmd.BranchStatus {
+func determineBranchStatus(ctx context.Context, log *slog.Logger, cherryPick github.CherryPickPR, config *cmd.Config, client *github.Client, trackedPR *cmd.TrackedPR) cmd.BranchStatus {
 	if cherryPick.Failed {
 		return cmd.BranchStatus{Status: cmd.BranchStatusFailed}
 	}
 
 	prDetails, err := client.GetPRWithDetails(ctx, cherryPick.Number)
 	if err != nil {
-		slog.Warn("Failed to fetch PR details", "pr", cherryPick.Number, "error", err)
+		log.Warn("Failed to fetch PR details", "pr", cherryPick.Number, "error", err)
 		return cmd.BranchStatus{
 			Status: cmd.BranchStatusPicked,
 			PR: &cmd.PickPR{
 				Number:     cherryPick.Number,
-				Title:      fmt.Sprintf("%s (cherry-pick %s)", trackedPR.Title, cherryPick.Branch),
+				Title:      derivedCherryPickTitle(log, config, client, trackedPR, cherryPick.Branch),
 				CIStatus:   "unknown",
 				RunAttempt: 0,
 			},
@@ -254,16 +384,81 @@ func determineBranchStatus(ctx context.Context, cherryPick github.CherryPickPR,
 		status = cmd.BranchStatusMerged
 	}
 
-	return cmd.BranchStatus{
-		Status: status,
-		PR: &cmd.PickPR{
-			Number:        prDetails.Number,
-			Title:         prDetails.Title,
-			CIStatus:      cmd.ParseCIStatus(prDetails.CIStatus),
-			RunAttempt:    prDetails.RunAttempt,
-			FailingChecks: prDetails.FailingChecks,
-		},
+	pickPR := &cmd.PickPR{
+		Number:        prDetails.Number,
+		Title:         prDetails.Title,
+		URL:           prDetails.URL,
+		CIStatus:      cmd.ParseCIStatus(prDetails.CIStatus),
+		RunAttempt:    prDetails.RunAttempt,
+		FailingChecks: prDetails.FailingChecks,
+		HeadSHA:       prDetails.HeadSHA,
+		Mergeable:     prDetails.Mergeable,
+	}
+	if prDetails.Merged && !prDetails.MergedAt.IsZero() {
+		mergedAt := prDetails.MergedAt
+		pickPR.MergedAt = &mergedAt
+	}
+	return cmd.BranchStatus{Status: status, PR: pickPR}
+}
+
+// RefreshPickPRCI updates a tracked cherry-pick PR with freshly fetched details
+// and reports whether anything changed. details' CI was read for its head, so
+// it replaces the cached CI even when the head moved since the last fetch
+// (someone pushed to it); newCommits reports that move. The PR's mergeable
+// state is taken as GitHub reports it either way, and a PR tracked before its
+// URL was recorded picks it up.
+func RefreshPickPRCI(pickPR *cmd.PickPR, details *github.PR) (changed, newCommits bool) {
+	if pickPR.URL == "" && details.URL != "" {
+		pickPR.URL = details.URL
+		changed = true
+	}
+	if !equalMergeable(pickPR.Mergeable, details.Mergeable) {
+		pickPR.Mergeable = details.Mergeable
+		changed = true
+	}
+	if details.HeadSHA != "" && pickPR.HeadSHA != details.HeadSHA {
+		newCommits = pickPR.HeadSHA != ""
+		pickPR.HeadSHA = details.HeadSHA
+		changed = true
+	}
+
+	if pickPR.CIStatus != cmd.ParseCIStatus(details.CIStatus) {
+		pickPR.CIStatus = cmd.ParseCIStatus(details.CIStatus)
+		changed = true
+	}
+	if pickPR.RunAttempt != details.RunAttempt {
+		pickPR.RunAttempt = details.RunAttempt
+		changed = true
+	}
+	// Update failing checks (only relevant when CI is failing)
+	if !slicesEqual(pickPR.FailingChecks, details.FailingChecks) {
+		pickPR.FailingChecks = details.FailingChecks
+		changed = true
+	}
+	return changed, newCommits
+}
+
+// equalMergeable compares two mergeable tri-states
+func equalMergeable(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// derivedCherryPickTitle stands in for a cherry-pick PR's title when its details
+// can't be fetched, rendered with the configured title format
+func derivedCherryPickTitle(log *slog.Logger, config *cmd.Config, client *github.Client, trackedPR *cmd.TrackedPR, branch string) string {
+	version, ok := client.LabelScheme().VersionForBranch(branch)
+	if !ok {
+		version = branch
+	}
+	title, err := config.CherryPickTitle(trackedPR.Title, trackedPR.Number, version, branch)
+	if err != nil {
+		log.Warn("Failed to render cherry-pick title", "pr", trackedPR.Number, "branch", branch, "error", err)
+		return fmt.Sprintf("%s (cherry-pick %s)", trackedPR.Title, branch)
 	}
+	return title
 }
 
 // slicesEqual compares two string slices for equality
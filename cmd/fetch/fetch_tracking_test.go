@@ -0,0 +1,235 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshPickPRCI(t *testing.T) {
+	t.Run("same head takes the fresh CI", func(t *testing.T) {
+		pickPR := &cmd.PickPR{Number: 5678, CIStatus: cmd.CIStatusPending, HeadSHA: "aaa"}
+
+		changed, newCommits := RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "failing", FailingChecks: []string{"Lint"}})
+
+		assert.True(t, changed)
+		assert.False(t, newCommits)
+		assert.Equal(t, cmd.CIStatusFailing, pickPR.CIStatus)
+		assert.Equal(t, []string{"Lint"}, pickPR.FailingChecks)
+	})
+
+	t.Run("new head takes the new head's CI", func(t *testing.T) {
+		pickPR := &cmd.PickPR{Number: 5678, CIStatus: cmd.CIStatusFailing, FailingChecks: []string{"Lint"}, HeadSHA: "aaa"}
+
+		changed, newCommits := RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "bbb", CIStatus: "passing"})
+
+		assert.True(t, changed)
+		assert.True(t, newCommits)
+		assert.Equal(t, cmd.CIStatusPassing, pickPR.CIStatus)
+		assert.Empty(t, pickPR.FailingChecks)
+		assert.Equal(t, "bbb", pickPR.HeadSHA)
+	})
+
+	t.Run("first recorded head is not a push", func(t *testing.T) {
+		pickPR := &cmd.PickPR{Number: 5678, CIStatus: cmd.CIStatusPending}
+
+		changed, newCommits := RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "passing"})
+
+		assert.True(t, changed)
+		assert.False(t, newCommits)
+		assert.Equal(t, cmd.CIStatusPassing, pickPR.CIStatus)
+		assert.Equal(t, "aaa", pickPR.HeadSHA)
+	})
+
+	t.Run("takes the mergeable state", func(t *testing.T) {
+		pickPR := &cmd.PickPR{Number: 5678, CIStatus: cmd.CIStatusPassing, HeadSHA: "aaa"}
+		mergeable := false
+
+		changed, _ := RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "passing", Mergeable: &mergeable})
+		assert.True(t, changed)
+		assert.True(t, pickPR.HasConflicts())
+
+		changed, _ = RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "passing", Mergeable: &mergeable})
+		assert.False(t, changed)
+
+		changed, _ = RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "passing"})
+		assert.True(t, changed, "GitHub recomputing it makes it unknown again")
+		assert.Nil(t, pickPR.Mergeable)
+	})
+
+	t.Run("records a missing URL", func(t *testing.T) {
+		pickPR := &cmd.PickPR{Number: 5678, CIStatus: cmd.CIStatusPassing, HeadSHA: "aaa"}
+		url := "https://ghe.internal/acme/widget/pull/5678"
+
+		changed, _ := RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "passing", URL: url})
+		assert.True(t, changed)
+		assert.Equal(t, url, pickPR.URL)
+
+		changed, _ = RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "passing", URL: url})
+		assert.False(t, changed)
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		pickPR := &cmd.PickPR{Number: 5678, CIStatus: cmd.CIStatusPassing, HeadSHA: "aaa"}
+
+		changed, newCommits := RefreshPickPRCI(pickPR, &github.PR{HeadSHA: "aaa", CIStatus: "passing"})
+
+		assert.False(t, changed)
+		assert.False(t, newCommits)
+	})
+}
+
+// newDeletedPRTestClient serves a repository where PR 1 and PR 4 were deleted and
+// PR 3's comments can't be read; repoReadable decides whether the repository
+// itself answers
+func newDeletedPRTestClient(t *testing.T, repoReadable bool) *github.Client {
+	t.Helper()
+	notFound := func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/issues/1/comments", notFound)
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/pulls/1", notFound)
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/issues/4/comments", notFound)
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/pulls/4", notFound)
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/issues/3/comments", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+	})
+	mux.HandleFunc("GET /api/v3/repos/acme/widget", func(w http.ResponseWriter, r *http.Request) {
+		if !repoReadable {
+			notFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"default_branch": "main"}`))
+	})
+	mux.HandleFunc("GET /api/v3/search/issues", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"total_count": 0, "items": []}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := github.NewEnterpriseClient(t.Context(), "test-token", server.URL+"/api/v3", github.HTTPOptions{})
+	require.NoError(t, err)
+	return client.WithRepository("acme", "widget")
+}
+
+// newDeletedPRTestConfig tracks the PRs newDeletedPRTestClient serves; PR 4 has
+// a picked branch
+func newDeletedPRTestConfig() *cmd.Config {
+	pending := map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPending}}
+	return &cmd.Config{
+		Org:  "acme",
+		Repo: "widget",
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 1, Branches: pending},
+			{Number: 2, Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusMerged}}},
+			{Number: 3, Branches: pending},
+			{Number: 4, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPending},
+				"release-1.1": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 40}},
+			}},
+		},
+	}
+}
+
+func trackedNumbers(config *cmd.Config) []int {
+	var numbers []int
+	for _, pr := range config.TrackedPRs {
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers
+}
+
+func TestUpdateAllTrackedPRs_PrunesDeletedPRs(t *testing.T) {
+	config := newDeletedPRTestConfig()
+
+	assert.True(t, updateAllTrackedPRs(t.Context(), config, newDeletedPRTestClient(t, true)))
+	assert.Equal(t, []int{2, 3, 4}, trackedNumbers(config),
+		"only the deleted PR without picked branches is pruned")
+}
+
+func TestUpdateAllTrackedPRs_KeepsPRsOfUnreadableRepository(t *testing.T) {
+	config := newDeletedPRTestConfig()
+
+	updateAllTrackedPRs(t.Context(), config, newDeletedPRTestClient(t, false))
+	assert.Equal(t, []int{1, 2, 3, 4}, trackedNumbers(config),
+		"a 404 for the repository too means the token can't see it, not that PRs were deleted")
+}
+
+func TestUpdateAllTrackedPRs_KeepPolicyKeepsDeletedPRs(t *testing.T) {
+	config := newDeletedPRTestConfig()
+	config.OnLabelRemoved = cmd.LabelRemovedKeep
+
+	updateAllTrackedPRs(t.Context(), config, newDeletedPRTestClient(t, true))
+	assert.Equal(t, []int{1, 2, 3, 4}, trackedNumbers(config))
+}
+
+// newQueuedPRTestClient serves tracked PR 7, whose release-3.7 cherry-pick PR #70
+// is open with failing CI; inQueue decides what the merge queue reports for it
+func newQueuedPRTestClient(t *testing.T, inQueue bool) *github.Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/issues/7/comments", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"body": "Cherry-pick PR created for 3.7: #70"}]`))
+	})
+	mux.HandleFunc("GET /api/v3/search/issues", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"total_count": 0, "items": []}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/pulls/70", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 70, "title": "Fix (cherry-pick #7 for 3.7)", "head": {"sha": "abc"}, "base": {"ref": "release-3.7"}}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/commits/abc/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses": []}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/commits/abc/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"check_runs": [{"name": "test", "status": "completed", "conclusion": "failure"}]}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/actions/runs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"workflow_runs": []}`))
+	})
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, _ *http.Request) {
+		if inQueue {
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"isInMergeQueue": true}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"isInMergeQueue": false}}}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := github.NewEnterpriseClient(t.Context(), "test-token", server.URL+"/api/v3", github.HTTPOptions{})
+	require.NoError(t, err)
+	return client.WithRepository("acme", "widget")
+}
+
+func TestUpdateTrackedPR_QueuedBranch(t *testing.T) {
+	newConfig := func() *cmd.Config {
+		return &cmd.Config{Org: "acme", Repo: "widget", TrackedPRs: []cmd.TrackedPR{{
+			Number: 7,
+			Branches: map[string]cmd.BranchStatus{"release-3.7": {
+				Status: cmd.BranchStatusQueued,
+				PR:     &cmd.PickPR{Number: 70, CIStatus: cmd.CIStatusPassing, HeadSHA: "abc"},
+			}},
+		}}}
+	}
+
+	t.Run("stays queued while in the merge queue", func(t *testing.T) {
+		config := newConfig()
+		updateAllTrackedPRs(t.Context(), config, newQueuedPRTestClient(t, true))
+		assert.Equal(t, cmd.BranchStatusQueued, config.TrackedPRs[0].Branches["release-3.7"].Status)
+	})
+
+	t.Run("dequeued PR is picked again with fresh CI", func(t *testing.T) {
+		config := newConfig()
+		assert.True(t, updateAllTrackedPRs(t.Context(), config, newQueuedPRTestClient(t, false)))
+		branch := config.TrackedPRs[0].Branches["release-3.7"]
+		assert.Equal(t, cmd.BranchStatusPicked, branch.Status)
+		assert.Equal(t, cmd.CIStatusFailing, branch.PR.CIStatus)
+		assert.False(t, branch.LastUpdated.IsZero())
+	})
+}
@@ -0,0 +1,144 @@
+// Package ignore implements the ignore and unignore commands for recording that a PR won't be backported to a branch.
+package ignore
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the ignore and unignore commands with common functionality
+type command struct {
+	commands.BaseCommand
+	PRNumber int
+	Branch   string
+}
+
+// NewIgnoreCmd creates the ignore command
+func NewIgnoreCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	ignoreCmd := &command{}
+
+	return &cobra.Command{
+		Use:   "ignore <pr-number> <branch>",
+		Short: "Decline to backport a PR to a branch",
+		Long: `Record that a PR won't be backported to a branch.
+
+The branch is dropped from tracking and fetch won't add it back, even while the
+PR still carries the branch's cherry-pick label. Only pending or failed branches
+(or branches not tracked yet) can be ignored. Use unignore to reverse this.
+
+Examples:
+  cherry-picker ignore 123 release-1.0`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := ignoreCmd.init(args, globalConfigFile, loadConfig, saveConfig); err != nil {
+				return err
+			}
+			return ignoreCmd.runIgnore()
+		},
+	}
+}
+
+// NewUnignoreCmd creates the unignore command
+func NewUnignoreCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	unignoreCmd := &command{}
+
+	return &cobra.Command{
+		Use:   "unignore <pr-number> <branch>",
+		Short: "Reverse an earlier ignore of a PR's branch",
+		Long: `Remove a branch from a PR's ignored branches.
+
+The next fetch tracks the branch again if the PR still has its cherry-pick label.
+
+Examples:
+  cherry-picker unignore 123 release-1.0`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := unignoreCmd.init(args, globalConfigFile, loadConfig, saveConfig); err != nil {
+				return err
+			}
+			return unignoreCmd.runUnignore()
+		},
+	}
+}
+
+// init parses the arguments and loads the config; neither command needs GitHub
+func (ic *command) init(args []string, globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) error {
+	prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+	if err != nil {
+		return err
+	}
+	ic.PRNumber = prNumber
+	ic.Branch = commands.GetTargetBranchFromArgs(args)
+
+	ic.ConfigFile = globalConfigFile
+	ic.LoadConfig = loadConfig
+	ic.SaveConfig = saveConfig
+	config, err := loadConfig(*globalConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	ic.Config = config
+	return nil
+}
+
+// runIgnore drops the branch from tracking and records it as ignored
+func (ic *command) runIgnore() error {
+	pr, err := commands.FindAndValidatePR(ic.Config, ic.PRNumber)
+	if err != nil {
+		return err
+	}
+
+	if pr.IsBranchIgnored(ic.Branch) {
+		fmt.Fprintf(os.Stderr, "PR #%d is already ignored for %s\n", ic.PRNumber, ic.Branch)
+		return nil
+	}
+
+	if status, tracked := pr.Branches[ic.Branch]; tracked &&
+		status.Status != cmd.BranchStatusPending && status.Status != cmd.BranchStatusFailed {
+		return fmt.Errorf("PR #%d is already %s on %s; only pending or failed branches can be ignored", ic.PRNumber, status.Status, ic.Branch)
+	}
+
+	delete(pr.Branches, ic.Branch)
+	pr.IgnoredBranches = append(pr.IgnoredBranches, ic.Branch)
+	slices.Sort(pr.IgnoredBranches)
+	pr.IgnoredBranchesUpdated = time.Now()
+
+	if err := ic.SaveConfig(*ic.ConfigFile, ic.Config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "🚫 Ignoring %s for PR #%d; fetch won't track it again (undo with unignore)\n", ic.Branch, ic.PRNumber)
+	return nil
+}
+
+// runUnignore removes the branch from the PR's ignored branches
+func (ic *command) runUnignore() error {
+	pr, err := commands.FindAndValidatePR(ic.Config, ic.PRNumber)
+	if err != nil {
+		return err
+	}
+
+	if !pr.IsBranchIgnored(ic.Branch) {
+		return fmt.Errorf("PR #%d is not ignored for %s", ic.PRNumber, ic.Branch)
+	}
+
+	pr.IgnoredBranches = slices.DeleteFunc(pr.IgnoredBranches, func(b string) bool {
+		return b == ic.Branch
+	})
+	pr.IgnoredBranchesUpdated = time.Now()
+
+	if err := ic.SaveConfig(*ic.ConfigFile, ic.Config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ No longer ignoring %s for PR #%d; the next fetch tracks it again if the label is present\n", ic.Branch, ic.PRNumber)
+	return nil
+}
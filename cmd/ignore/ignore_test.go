@@ -0,0 +1,124 @@
+package ignore
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCommand(branches map[string]cmd.BranchStatus, ignored []string, saves *int) *command {
+	configFile := "cherry-picks.yaml"
+	ic := &command{PRNumber: 123, Branch: "release-1.0"}
+	ic.ConfigFile = &configFile
+	ic.Config = &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{{Number: 123, Branches: branches, IgnoredBranches: ignored}},
+	}
+	ic.SaveConfig = func(_ string, _ *cmd.Config) error {
+		*saves++
+		return nil
+	}
+	return ic
+}
+
+// TestNewIgnoreCmd tests command creation and argument validation
+func TestNewIgnoreCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+	saveConfig := func(_ string, _ *cmd.Config) error {
+		return nil
+	}
+
+	ignoreCmd := NewIgnoreCmd(&configFile, loadConfig, saveConfig)
+	assert.Equal(t, "ignore", ignoreCmd.Name())
+	require.Error(t, ignoreCmd.Args(ignoreCmd, []string{"123"}))
+	require.NoError(t, ignoreCmd.Args(ignoreCmd, []string{"123", "release-1.0"}))
+	require.Error(t, ignoreCmd.RunE(ignoreCmd, []string{"invalid", "release-1.0"}))
+
+	unignoreCmd := NewUnignoreCmd(&configFile, loadConfig, saveConfig)
+	assert.Equal(t, "unignore", unignoreCmd.Name())
+	require.Error(t, unignoreCmd.Args(unignoreCmd, []string{"123"}))
+}
+
+// TestRunIgnore tests which branch states can be ignored
+func TestRunIgnore(t *testing.T) {
+	tests := []struct {
+		name     string
+		branches map[string]cmd.BranchStatus
+		ignored  []string
+		wantErr  bool
+		wantSave int
+	}{
+		{
+			name:     "pending branch",
+			branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPending}},
+			wantSave: 1,
+		},
+		{
+			name:     "failed branch",
+			branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusFailed}},
+			wantSave: 1,
+		},
+		{
+			name:     "untracked branch",
+			branches: map[string]cmd.BranchStatus{"release-2.0": {Status: cmd.BranchStatusPending}},
+			wantSave: 1,
+		},
+		{
+			name:     "picked branch",
+			branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPicked}},
+			wantErr:  true,
+		},
+		{
+			name:    "already ignored",
+			ignored: []string{"release-1.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saves := 0
+			ic := testCommand(tt.branches, tt.ignored, &saves)
+
+			err := ic.runIgnore()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, ic.Config.TrackedPRs[0].Branches, "release-1.0")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSave, saves)
+			pr := ic.Config.TrackedPRs[0]
+			assert.NotContains(t, pr.Branches, "release-1.0")
+			assert.Equal(t, []string{"release-1.0"}, pr.IgnoredBranches)
+			assert.Equal(t, tt.wantSave == 1, !pr.IgnoredBranchesUpdated.IsZero(), "a change is stamped for the state merge")
+		})
+	}
+}
+
+// TestRunIgnore_PRNotTracked tests ignoring a PR that isn't tracked
+func TestRunIgnore_PRNotTracked(t *testing.T) {
+	saves := 0
+	ic := testCommand(nil, nil, &saves)
+	ic.PRNumber = 999
+
+	require.Error(t, ic.runIgnore())
+	assert.Zero(t, saves)
+}
+
+// TestRunUnignore tests removing a branch from the ignore list
+func TestRunUnignore(t *testing.T) {
+	saves := 0
+	ic := testCommand(nil, []string{"release-0.9", "release-1.0"}, &saves)
+
+	require.NoError(t, ic.runUnignore())
+	assert.Equal(t, 1, saves)
+	assert.Equal(t, []string{"release-0.9"}, ic.Config.TrackedPRs[0].IgnoredBranches)
+	assert.False(t, ic.Config.TrackedPRs[0].IgnoredBranchesUpdated.IsZero())
+
+	require.Error(t, ic.runUnignore(), "unignoring a branch that isn't ignored should fail")
+	assert.Equal(t, 1, saves)
+}
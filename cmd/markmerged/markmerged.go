@@ -0,0 +1,98 @@
+// Package markmerged implements the mark-merged command for recording a cherry-pick PR merged outside the tool.
+package markmerged
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the mark-merged command with common functionality
+type command struct {
+	commands.BaseCommand
+	PRNumber     int
+	TargetBranch string
+}
+
+// NewMarkMergedCmd creates the mark-merged command
+func NewMarkMergedCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	markCmd := &command{}
+
+	return &cobra.Command{
+		Use:   "mark-merged <pr-number> <target-branch>",
+		Short: "Record a cherry-pick PR that was merged outside the tool",
+		Long: `Mark a branch merged in the config after its cherry-pick PR was merged
+elsewhere, e.g. in the GitHub UI, without waiting for the next fetch.
+
+The branch must be picked (or queued) with a cherry-pick PR recorded. Nothing
+is merged and GitHub isn't contacted; the next fetch still checks the PR and
+finds it in a release as usual.
+
+Examples:
+  cherry-picker mark-merged 123 release-1.0`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+			if err != nil {
+				return err
+			}
+			markCmd.PRNumber = prNumber
+			markCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+
+			// mark-merged only records what already happened, so it doesn't need GitHub
+			markCmd.ConfigFile = globalConfigFile
+			markCmd.LoadConfig = loadConfig
+			markCmd.SaveConfig = saveConfig
+			config, err := loadConfig(*globalConfigFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			markCmd.Config = config
+
+			return markCmd.Run()
+		},
+	}
+}
+
+// Run sets the branch to merged and saves the config
+func (mc *command) Run() error {
+	trackedPR, err := commands.FindAndValidatePR(mc.Config, mc.PRNumber)
+	if err != nil {
+		return err
+	}
+
+	status, err := markMerged(trackedPR, mc.TargetBranch)
+	if err != nil {
+		return err
+	}
+
+	if err := mc.SaveConfig(*mc.ConfigFile, mc.Config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ PR #%d is marked merged on %s (cherry-pick PR #%d)\n", mc.PRNumber, mc.TargetBranch, status.PR.Number)
+	return nil
+}
+
+// markMerged sets branch to merged, returning its new status. Only a picked or
+// queued branch with a recorded cherry-pick PR can be marked merged.
+func markMerged(trackedPR *cmd.TrackedPR, branch string) (cmd.BranchStatus, error) {
+	status, exists := trackedPR.Branches[branch]
+	if !exists {
+		return cmd.BranchStatus{}, fmt.Errorf("branch %s is not tracked for PR #%d", branch, trackedPR.Number)
+	}
+	if status.Status != cmd.BranchStatusPicked && status.Status != cmd.BranchStatusQueued {
+		return cmd.BranchStatus{}, fmt.Errorf("PR #%d is %s on %s; only a picked branch can be marked merged", trackedPR.Number, status.Status, branch)
+	}
+	if status.PR == nil {
+		return cmd.BranchStatus{}, fmt.Errorf("no cherry-pick PR is recorded for PR #%d on %s, so there is nothing that was merged", trackedPR.Number, branch)
+	}
+
+	status.Status = cmd.BranchStatusMerged
+	trackedPR.Branches[branch] = status
+	return status, nil
+}
@@ -0,0 +1,85 @@
+package markmerged
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewMarkMergedCmd tests command creation and argument validation
+func TestNewMarkMergedCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+	saveConfig := func(_ string, _ *cmd.Config) error {
+		return nil
+	}
+
+	cobraCmd := NewMarkMergedCmd(&configFile, loadConfig, saveConfig)
+
+	assert.Equal(t, "mark-merged", cobraCmd.Name())
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"123", "release-1.0"}))
+	require.Error(t, cobraCmd.RunE(cobraCmd, []string{"invalid", "release-1.0"}))
+}
+
+// TestMarkMerged tests which tracked branches can be marked merged
+func TestMarkMerged(t *testing.T) {
+	pickPR := &cmd.PickPR{Number: 456, Title: "Fix bug (cherry-pick release-1.0)", CIStatus: cmd.CIStatusPassing}
+	trackedPR := &cmd.TrackedPR{
+		Number: 123,
+		Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPicked, PR: pickPR},
+			"release-1.1": {Status: cmd.BranchStatusQueued, PR: &cmd.PickPR{Number: 457}},
+			"release-1.2": {Status: cmd.BranchStatusPending},
+			"release-1.3": {Status: cmd.BranchStatusPicked},
+		},
+	}
+
+	status, err := markMerged(trackedPR, "release-1.0")
+	require.NoError(t, err)
+	assert.Equal(t, cmd.BranchStatus{Status: cmd.BranchStatusMerged, PR: pickPR}, status)
+	assert.Equal(t, status, trackedPR.Branches["release-1.0"], "the PR details are kept")
+
+	_, err = markMerged(trackedPR, "release-1.1")
+	require.NoError(t, err, "a queued PR merged by hand can be marked too")
+
+	_, err = markMerged(trackedPR, "release-1.0")
+	require.ErrorContains(t, err, "is merged on release-1.0")
+
+	_, err = markMerged(trackedPR, "release-1.2")
+	require.ErrorContains(t, err, "only a picked branch")
+
+	_, err = markMerged(trackedPR, "release-1.3")
+	require.ErrorContains(t, err, "no cherry-pick PR")
+
+	_, err = markMerged(trackedPR, "release-9.9")
+	require.ErrorContains(t, err, "not tracked")
+}
+
+// TestCommand_Run tests that marking a branch saves the config
+func TestCommand_Run(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	saves := 0
+	mc := &command{PRNumber: 123, TargetBranch: "release-1.0"}
+	mc.ConfigFile = &configFile
+	mc.SaveConfig = func(string, *cmd.Config) error {
+		saves++
+		return nil
+	}
+	mc.Config = &cmd.Config{TrackedPRs: []cmd.TrackedPR{{
+		Number:   123,
+		Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 456}}},
+	}}}
+
+	require.NoError(t, mc.Run())
+	assert.Equal(t, cmd.BranchStatusMerged, mc.Config.TrackedPRs[0].Branches["release-1.0"].Status)
+	assert.Equal(t, 1, saves)
+
+	mc.PRNumber = 999
+	require.Error(t, mc.Run())
+	assert.Equal(t, 1, saves, "nothing is saved on error")
+}
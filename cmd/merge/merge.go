@@ -3,8 +3,13 @@ package merge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/commands"
@@ -15,13 +20,21 @@ import (
 // command encapsulates the merge command with common functionality
 type command struct {
 	commands.BaseCommand
-	PRNumber     int
-	TargetBranch string
+	PRNumber       int
+	TargetBranch   string
+	UseMergeQueue  bool
+	MergeMethod    string // overrides the config's merge_method when set
+	DeleteBranch   bool   // delete each PR's head branch once it merges (or delete_merged_branches)
+	AllowUnknownCI bool
+	AllowCI        []cmd.CIStatus // CI statuses a picked branch may merge at; empty means passing only
+	Only           cmd.CIStatus   // when set, only branches at this CI status are merged
+	Check          bool
 }
 
 // NewMergeCmd creates the merge command
 func NewMergeCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
 	mergeCmd := &command{}
+	var only, allowCI string
 
 	cobraCmd := &cobra.Command{
 		Use:   "merge [pr-number] [target-branch]",
@@ -32,10 +45,44 @@ This command will perform squash and merge operations on picked PRs,
 equivalent to clicking the "Squash and merge" button in the GitHub UI.
 Only works for PRs that are picked and have passing CI.
 
+With --merge-queue (or use_merge_queue: true in the config), PRs are added to
+GitHub's merge queue instead and marked 'queued' until fetch sees them merged.
+
+PRs are squash-merged unless merge_method in the config or --merge-method
+says merge (merge commit) or rebase.
+
+With --delete-branch (or delete_merged_branches: true in the config), each
+cherry-pick PR's head branch is deleted once the PR merges. A failed delete is
+only a warning; PRs added to the merge queue keep their branch.
+
+With --allow-unknown-ci, PRs whose CI status is 'unknown' or 'no_checks' are
+merged too. Use it only where branch protection, not this tool's CI read, is
+the real merge gate.
+
+With --allow-ci <states>, PRs are merged at any of the listed CI states (passing,
+unknown, no_checks, pending), e.g. --allow-ci passing,no_checks for docs-only
+cherry-picks whose repos run no workflows. --allow-unknown-ci adds unknown to
+the list; unknown means the CI status couldn't be read, no_checks that the
+commit has no checks at all. Allowing unknown allows no_checks too, as such
+commits used to be reported as unknown.
+Failing PRs are never merged.
+
+With --only <status>, only eligible branches whose CI status is exactly
+'passing', 'unknown' or 'no_checks' are merged. --only unknown needs unknown
+allowed, for merging PRs whose CI was verified by hand while leaving the green
+ones alone.
+
+With --check, nothing is merged: a readiness report is printed and the command
+exits non-zero unless at least one branch is eligible and no picked branch has
+failing CI. Use it as a release pipeline gate.
+
 Examples:
   cherry-picker merge                     # Merge all eligible PRs and branches
   cherry-picker merge 123                # Merge PR #123's cherry-picks on all eligible branches
-  cherry-picker merge 123 release-1.0    # Merge PR #123's cherry-pick on release-1.0`,
+  cherry-picker merge 123 release-1.0    # Merge PR #123's cherry-pick on release-1.0
+  cherry-picker merge --only passing     # Merge only PRs with green CI
+  cherry-picker merge --allow-ci passing,pending  # Also merge PRs whose CI is still running
+  cherry-picker merge --check            # Report readiness without merging`,
 		Args:         cobra.RangeArgs(0, 2),
 		SilenceUsage: true,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
@@ -46,6 +93,24 @@ Examples:
 			}
 			mergeCmd.PRNumber = prNumber
 			mergeCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+			if mergeCmd.Only, err = ParseOnlyFilter(only); err != nil {
+				return err
+			}
+			if mergeCmd.AllowCI, err = ParseAllowCI(allowCI, false); err != nil {
+				return err
+			}
+
+			// The check reads only the config, so it needs no GitHub client
+			if mergeCmd.Check {
+				if mergeCmd.Only != "" {
+					return errors.New("--only doesn't apply to --check")
+				}
+				config, err := loadConfig(*globalConfigFile)
+				if err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+				return Check(config, mergeCmd.PRNumber, mergeCmd.TargetBranch, mergeCmd.allowedCI())
+			}
 
 			// Initialize base command
 			mergeCmd.ConfigFile = globalConfigFile
@@ -59,20 +124,98 @@ Examples:
 		},
 	}
 
+	cobraCmd.Flags().BoolVar(&mergeCmd.UseMergeQueue, "merge-queue", false, "Add PRs to GitHub's merge queue instead of merging directly")
+	cobraCmd.Flags().StringVar(&mergeCmd.MergeMethod, "merge-method", "", "Merge method: squash, merge or rebase (overrides merge_method in the config; default squash)")
+	cobraCmd.Flags().BoolVar(&mergeCmd.DeleteBranch, "delete-branch", false, "Delete each cherry-pick PR's head branch after it merges")
+	cobraCmd.Flags().BoolVar(&mergeCmd.AllowUnknownCI, "allow-unknown-ci", false, "Also merge PRs whose CI status is unknown or no_checks")
+	cobraCmd.Flags().StringVar(&allowCI, "allow-ci", "", "Comma-separated CI states PRs may be merged at: passing, unknown, no_checks, pending (default passing)")
+	cobraCmd.Flags().StringVar(&only, "only", "", "Merge only eligible PRs whose CI status is this (passing, unknown or no_checks)")
+	cobraCmd.Flags().BoolVar(&mergeCmd.Check, "check", false, "Report merge readiness without merging; exit non-zero if nothing is ready or any picked PR has failing CI")
+
 	return cobraCmd
 }
 
 // Execute runs the cherry-pick merge operation. base must already be
 // initialized (Config and GitHubClient populated). prNumber == 0 merges all
-// eligible PRs/branches; targetBranch may be "". Exposed for the unified merge
-// command's cherry/dep dispatch.
-func Execute(ctx context.Context, base commands.BaseCommand, prNumber int, targetBranch string) error {
-	mc := &command{BaseCommand: base, PRNumber: prNumber, TargetBranch: targetBranch}
+// eligible PRs/branches; targetBranch may be "". allowCI lists the CI
+// statuses eligible branches may have (see ParseAllowCI), and a non-empty only
+// narrows them to that CI status. Exposed for the unified merge command's
+// cherry/dep dispatch.
+func Execute(ctx context.Context, base commands.BaseCommand, prNumber int, targetBranch string, allowCI []cmd.CIStatus, only cmd.CIStatus) error {
+	mc := &command{BaseCommand: base, PRNumber: prNumber, TargetBranch: targetBranch, AllowCI: allowCI, Only: only}
 	return mc.Run(ctx)
 }
 
+// ParseAllowCI parses the --allow-ci flag, a comma-separated list of the CI
+// statuses a picked branch may be merged at; "" means passing only.
+// allowUnknownCI (--allow-unknown-ci) adds unknown, and unknown brings
+// no_checks along (see withUnknownCI). Failing PRs are never merged.
+func ParseAllowCI(s string, allowUnknownCI bool) ([]cmd.CIStatus, error) {
+	var allowed []cmd.CIStatus
+	for field := range strings.SplitSeq(s, ",") {
+		status := cmd.CIStatus(strings.TrimSpace(field))
+		switch status {
+		case "":
+			continue
+		case cmd.CIStatusPassing, cmd.CIStatusUnknown, cmd.CIStatusNoChecks, cmd.CIStatusPending:
+			if !slices.Contains(allowed, status) {
+				allowed = append(allowed, status)
+			}
+		default:
+			return nil, fmt.Errorf("invalid --allow-ci state %q: must be passing, unknown, no_checks or pending (failing PRs are never merged)", status)
+		}
+	}
+	if len(allowed) == 0 {
+		allowed = []cmd.CIStatus{cmd.CIStatusPassing}
+	}
+	if allowUnknownCI || slices.Contains(allowed, cmd.CIStatusUnknown) {
+		allowed = withUnknownCI(allowed)
+	}
+	return allowed, nil
+}
+
+// withUnknownCI adds unknown and no_checks to allowed. Commits without any
+// checks were reported as unknown before no_checks existed, so allowing
+// unknown keeps merging them.
+func withUnknownCI(allowed []cmd.CIStatus) []cmd.CIStatus {
+	for _, status := range []cmd.CIStatus{cmd.CIStatusUnknown, cmd.CIStatusNoChecks} {
+		if !slices.Contains(allowed, status) {
+			allowed = append(slices.Clone(allowed), status)
+		}
+	}
+	return allowed
+}
+
+// ParseOnlyFilter parses the --only flag. Failing and pending PRs are never
+// merged, so only "passing", "unknown" and "no_checks" narrow anything; ""
+// means no filter.
+func ParseOnlyFilter(s string) (cmd.CIStatus, error) {
+	switch s {
+	case "":
+		return "", nil
+	case string(cmd.CIStatusPassing):
+		return cmd.CIStatusPassing, nil
+	case string(cmd.CIStatusUnknown):
+		return cmd.CIStatusUnknown, nil
+	case string(cmd.CIStatusNoChecks):
+		return cmd.CIStatusNoChecks, nil
+	default:
+		return "", fmt.Errorf("invalid --only value %q: must be passing, unknown or no_checks (failing and pending PRs are never merged)", s)
+	}
+}
+
 // Run executes the merge command
 func (mc *command) Run(ctx context.Context) error {
+	if mc.Only != "" && !slices.Contains(mc.allowedCI(), mc.Only) {
+		return fmt.Errorf("--only %s needs --allow-ci to include %s (or --allow-unknown-ci)", mc.Only, mc.Only)
+	}
+	if _, err := mc.mergeMethod(); err != nil {
+		return err
+	}
+	if mc.allowsUnverifiedCI() {
+		fmt.Fprintf(os.Stderr, "⚠️  PRs with CI status %s will be merged without passing CI\n", joinCIStatuses(mc.allowedCI()))
+	}
+
 	// If no PR number, merge all eligible PRs and branches
 	if mc.PRNumber == 0 {
 		return mc.mergeAllEligiblePRs(ctx)
@@ -86,11 +229,11 @@ func (mc *command) Run(ctx context.Context) error {
 
 	// Validate before requiring GitHub token
 	if mc.TargetBranch != "" {
-		if err := commands.ValidateBranchForOperation(trackedPR, mc.TargetBranch, "merge", commands.IsEligibleForMerge); err != nil {
+		if err := commands.ValidateBranchForOperation(trackedPR, mc.TargetBranch, "merge", mc.eligibleForMerge); err != nil {
 			return err
 		}
 	} else {
-		if err := commands.ValidateAnyBranchForOperation(trackedPR, "merge", commands.IsEligibleForMerge); err != nil {
+		if err := commands.ValidateAnyBranchForOperation(trackedPR, "merge", mc.eligibleForMerge); err != nil {
 			return err
 		}
 	}
@@ -105,7 +248,7 @@ func (mc *command) Run(ctx context.Context) error {
 		ctx,
 		trackedPR,
 		"merge",
-		commands.IsEligibleForMerge,
+		mc.eligibleForMerge,
 		mc.mergeBranchOperation,
 		mc.Config,
 		*mc.ConfigFile,
@@ -114,6 +257,63 @@ func (mc *command) Run(ctx context.Context) error {
 	)
 }
 
+// eligibleForMerge is the merge predicate built from the allowed CI statuses,
+// narrowed to the --only CI status when set
+func (mc *command) eligibleForMerge(branchStatus cmd.BranchStatus) bool {
+	if !commands.MergeEligibility(mc.allowedCI()...)(branchStatus) {
+		return false
+	}
+	return mc.Only == "" || branchStatus.PR.CIStatus == mc.Only
+}
+
+// allowedCI returns the CI statuses a branch may be merged at: --allow-ci
+// (passing when unset), plus unknown and no_checks with --allow-unknown-ci
+func (mc *command) allowedCI() []cmd.CIStatus {
+	allowed := mc.AllowCI
+	if len(allowed) == 0 {
+		allowed = []cmd.CIStatus{cmd.CIStatusPassing}
+	}
+	if mc.AllowUnknownCI || slices.Contains(allowed, cmd.CIStatusUnknown) {
+		allowed = withUnknownCI(allowed)
+	}
+	return allowed
+}
+
+// allowsUnverifiedCI reports whether any CI status other than passing is allowed
+func (mc *command) allowsUnverifiedCI() bool {
+	return slices.ContainsFunc(mc.allowedCI(), func(status cmd.CIStatus) bool { return status != cmd.CIStatusPassing })
+}
+
+// joinCIStatuses lists CI statuses for messages, e.g. "passing/unknown"
+func joinCIStatuses(statuses []cmd.CIStatus) string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, "/")
+}
+
+// mergeMethod resolves the merge method: --merge-method, then the config's
+// merge_method, then squash
+func (mc *command) mergeMethod() (cmd.MergeMethod, error) {
+	if mc.MergeMethod != "" {
+		method, ok := cmd.ParseMergeMethod(mc.MergeMethod)
+		if !ok {
+			return "", fmt.Errorf("invalid --merge-method %q: must be squash, merge or rebase", mc.MergeMethod)
+		}
+		return method, nil
+	}
+	var configured string
+	if mc.Config != nil {
+		configured = string(mc.Config.MergeMethod)
+	}
+	method, ok := cmd.ParseMergeMethod(configured)
+	if !ok {
+		return "", fmt.Errorf("invalid merge_method %q in config: must be squash, merge or rebase", configured)
+	}
+	return method, nil
+}
+
 // mergeBranchPR merges a specific branch's PR
 func (mc *command) mergeBranchPR(ctx context.Context, trackedPR *cmd.TrackedPR, targetBranch string) error {
 	err := mc.mergeBranchOperation(ctx, mc.GitHubClient, mc.Config, trackedPR, targetBranch, trackedPR.Branches[targetBranch])
@@ -126,21 +326,76 @@ func (mc *command) mergeBranchPR(ctx context.Context, trackedPR *cmd.TrackedPR,
 }
 
 // mergeBranchOperation is the core operation for merging a single branch
-func (*command) mergeBranchOperation(ctx context.Context, client *github.Client, _ *cmd.Config, trackedPR *cmd.TrackedPR, branchName string, branchStatus cmd.BranchStatus) error {
-	slog.Info("Merging PR", "original_pr", trackedPR.Number, "cherry_pick_pr", branchStatus.PR.Number, "branch", branchName)
+func (mc *command) mergeBranchOperation(ctx context.Context, client *github.Client, config *cmd.Config, trackedPR *cmd.TrackedPR, branchName string, branchStatus cmd.BranchStatus) error {
+	if ci := branchStatus.PR.CIStatus; ci != cmd.CIStatusPassing {
+		fmt.Fprintf(os.Stderr, "⚠️  Merging cherry-pick PR #%d for %s with %s CI status\n", branchStatus.PR.Number, branchName, ci)
+		slog.Warn("Merging PR without passing CI", "original_pr", trackedPR.Number, "cherry_pick_pr", branchStatus.PR.Number, "branch", branchName, "ci_status", ci)
+	}
+
+	if mc.UseMergeQueue || config.UseMergeQueue {
+		return enqueueBranchOperation(ctx, client, trackedPR, branchName, branchStatus)
+	}
+
+	// Validated by Run before any GitHub call
+	method, err := mc.mergeMethod()
+	if err != nil {
+		return err
+	}
 
-	err := client.MergePR(ctx, branchStatus.PR.Number, "squash")
+	slog.Info("Merging PR", "original_pr", trackedPR.Number, "cherry_pick_pr", branchStatus.PR.Number, "branch", branchName, "method", method)
+
+	err = client.MergePR(ctx, branchStatus.PR.Number, string(method), mc.allowsUnverifiedCI())
 	if err != nil {
 		return fmt.Errorf("failed to merge PR #%d branch %s (cherry-pick PR #%d): %w",
 			trackedPR.Number, branchName, branchStatus.PR.Number, err)
 	}
 
 	// Update the branch status to merged
+	mergedAt := time.Now()
 	branchStatus.Status = cmd.BranchStatusMerged
+	branchStatus.PR.MergedAt = &mergedAt
 	trackedPR.Branches[branchName] = branchStatus
 
 	slog.Info("Successfully merged PR", "original_pr", trackedPR.Number, "branch", branchName, "cherry_pick_pr", branchStatus.PR.Number)
 
+	if mc.DeleteBranch || config.DeleteMergedBranches {
+		deleteHeadBranch(ctx, client, branchStatus.PR.Number)
+	}
+
+	return nil
+}
+
+// deleteHeadBranch deletes a merged cherry-pick PR's head branch. The merge
+// already succeeded, so a failure is only logged.
+func deleteHeadBranch(ctx context.Context, client *github.Client, prNumber int) {
+	ref, err := client.GetPRHeadBranch(ctx, prNumber)
+	if err == nil {
+		err = client.DeleteBranch(ctx, ref)
+	}
+	if err != nil {
+		slog.Warn("Failed to delete merged cherry-pick branch", "cherry_pick_pr", prNumber, "error", err)
+		return
+	}
+	slog.Info("Deleted merged cherry-pick branch", "cherry_pick_pr", prNumber, "branch", ref)
+}
+
+// enqueueBranchOperation adds a single branch's cherry-pick PR to the merge queue
+func enqueueBranchOperation(ctx context.Context, client *github.Client, trackedPR *cmd.TrackedPR, branchName string, branchStatus cmd.BranchStatus) error {
+	slog.Info("Adding PR to merge queue", "original_pr", trackedPR.Number, "cherry_pick_pr", branchStatus.PR.Number, "branch", branchName)
+
+	position, err := client.EnqueuePR(ctx, branchStatus.PR.Number)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue PR #%d branch %s (cherry-pick PR #%d): %w",
+			trackedPR.Number, branchName, branchStatus.PR.Number, err)
+	}
+
+	// Fetch moves the branch to merged once the queue lands it
+	branchStatus.Status = cmd.BranchStatusQueued
+	branchStatus.LastUpdated = time.Now()
+	trackedPR.Branches[branchName] = branchStatus
+
+	slog.Info("Successfully queued PR", "original_pr", trackedPR.Number, "branch", branchName, "cherry_pick_pr", branchStatus.PR.Number, "position", position)
+
 	return nil
 }
 
@@ -150,7 +405,7 @@ func (mc *command) mergeAllEligiblePRs(ctx context.Context) error {
 		ctx,
 		mc.Config,
 		"merge",
-		commands.IsEligibleForMerge,
+		mc.eligibleForMerge,
 		mc.mergeBranchOperation,
 		*mc.ConfigFile,
 		mc.SaveConfig,
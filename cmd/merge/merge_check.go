@@ -0,0 +1,116 @@
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+)
+
+// readinessEntry describes one picked branch in the merge readiness report
+type readinessEntry struct {
+	PRNumber     int
+	Branch       string
+	PickPRNumber int
+	CIStatus     cmd.CIStatus
+}
+
+// readinessReport groups picked branches by whether they would block a merge
+type readinessReport struct {
+	Ready    []readinessEntry // eligible for merge now
+	Blocking []readinessEntry // CI failing
+	Waiting  []readinessEntry // CI pending or unknown, and not allowed by --allow-ci
+}
+
+// Check reports whether the cherry-pick PRs are ready to merge without
+// merging anything. It returns an error unless at least one branch is
+// eligible and none has failing CI. allowCI lists the CI statuses that count as
+// ready (see ParseAllowCI). Exposed for the unified merge command.
+func Check(config *cmd.Config, prNumber int, targetBranch string, allowCI []cmd.CIStatus) error {
+	mc := &command{PRNumber: prNumber, TargetBranch: targetBranch, AllowCI: allowCI}
+	mc.Config = config
+	return mc.runCheck(os.Stdout)
+}
+
+// runCheck prints the readiness report and turns it into a pass/fail result
+func (mc *command) runCheck(w io.Writer) error {
+	if mc.PRNumber != 0 {
+		trackedPR, err := commands.FindAndValidatePR(mc.Config, mc.PRNumber)
+		if err != nil {
+			return err
+		}
+		if mc.TargetBranch != "" {
+			if err := commands.ValidateTargetBranch(trackedPR, mc.TargetBranch); err != nil {
+				return err
+			}
+		}
+	}
+
+	report := mc.buildReadinessReport()
+	printReadinessReport(w, report)
+
+	switch {
+	case len(report.Blocking) > 0:
+		return fmt.Errorf("merge check failed: %d picked branch(es) have failing CI", len(report.Blocking))
+	case len(report.Ready) == 0:
+		return errors.New("merge check failed: no branches are eligible for merge")
+	default:
+		return nil
+	}
+}
+
+// buildReadinessReport classifies every picked branch in scope
+func (mc *command) buildReadinessReport() readinessReport {
+	var report readinessReport
+	for _, pr := range mc.Config.TrackedPRs {
+		if mc.PRNumber != 0 && pr.Number != mc.PRNumber {
+			continue
+		}
+
+		branches := make([]string, 0, len(pr.Branches))
+		for branch := range pr.Branches {
+			branches = append(branches, branch)
+		}
+		sort.Strings(branches)
+
+		for _, branch := range branches {
+			if mc.TargetBranch != "" && branch != mc.TargetBranch {
+				continue
+			}
+			status := pr.Branches[branch]
+			if status.Status != cmd.BranchStatusPicked || status.PR == nil {
+				continue
+			}
+
+			entry := readinessEntry{PRNumber: pr.Number, Branch: branch, PickPRNumber: status.PR.Number, CIStatus: status.PR.CIStatus}
+			switch {
+			case mc.eligibleForMerge(status):
+				report.Ready = append(report.Ready, entry)
+			case status.PR.CIStatus == cmd.CIStatusFailing:
+				report.Blocking = append(report.Blocking, entry)
+			default:
+				report.Waiting = append(report.Waiting, entry)
+			}
+		}
+	}
+	return report
+}
+
+// printReadinessReport writes a concise summary of the report to w
+func printReadinessReport(w io.Writer, report readinessReport) {
+	fmt.Fprintln(w, "Merge readiness:")
+	for _, e := range report.Ready {
+		fmt.Fprintf(w, "  ✅ PR #%d → %s: cherry-pick PR #%d ready (CI %s)\n", e.PRNumber, e.Branch, e.PickPRNumber, e.CIStatus)
+	}
+	for _, e := range report.Blocking {
+		fmt.Fprintf(w, "  ❌ PR #%d → %s: cherry-pick PR #%d blocked (CI %s)\n", e.PRNumber, e.Branch, e.PickPRNumber, e.CIStatus)
+	}
+	for _, e := range report.Waiting {
+		fmt.Fprintf(w, "  ⏳ PR #%d → %s: cherry-pick PR #%d waiting (CI %s)\n", e.PRNumber, e.Branch, e.PickPRNumber, e.CIStatus)
+	}
+	fmt.Fprintf(w, "Ready: %d, blocking: %d, waiting: %d\n", len(report.Ready), len(report.Blocking), len(report.Waiting))
+}
@@ -0,0 +1,117 @@
+package merge
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func picked(prNumber int, ci cmd.CIStatus) cmd.BranchStatus {
+	return cmd.BranchStatus{
+		Status: cmd.BranchStatusPicked,
+		PR:     &cmd.PickPR{Number: prNumber, CIStatus: ci},
+	}
+}
+
+func checkConfig(branches map[string]cmd.BranchStatus) *cmd.Config {
+	return &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 100, Branches: branches},
+			{Number: 200, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 201, CIStatus: cmd.CIStatusPassing}},
+				"release-2.0": {Status: cmd.BranchStatusFailed},
+			}},
+		},
+	}
+}
+
+// TestRunCheck tests the merge readiness gate
+func TestRunCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		branches       map[string]cmd.BranchStatus
+		allowUnknownCI bool
+		wantErr        string
+		wantOutput     []string
+	}{
+		{
+			name: "ready",
+			branches: map[string]cmd.BranchStatus{
+				"release-1.0": picked(101, cmd.CIStatusPassing),
+				"release-2.0": picked(102, cmd.CIStatusPending),
+			},
+			wantOutput: []string{"✅ PR #100 → release-1.0", "⏳ PR #100 → release-2.0", "Ready: 1, blocking: 0, waiting: 1"},
+		},
+		{
+			name: "failing CI blocks even with eligible branches",
+			branches: map[string]cmd.BranchStatus{
+				"release-1.0": picked(101, cmd.CIStatusPassing),
+				"release-2.0": picked(102, cmd.CIStatusFailing),
+			},
+			wantErr:    "failing CI",
+			wantOutput: []string{"❌ PR #100 → release-2.0: cherry-pick PR #102 blocked", "Ready: 1, blocking: 1, waiting: 0"},
+		},
+		{
+			name: "nothing eligible",
+			branches: map[string]cmd.BranchStatus{
+				"release-1.0": picked(101, cmd.CIStatusUnknown),
+			},
+			wantErr:    "no branches are eligible",
+			wantOutput: []string{"Ready: 0, blocking: 0, waiting: 1"},
+		},
+		{
+			name: "unknown CI allowed",
+			branches: map[string]cmd.BranchStatus{
+				"release-1.0": picked(101, cmd.CIStatusUnknown),
+			},
+			allowUnknownCI: true,
+			wantOutput:     []string{"Ready: 1, blocking: 0, waiting: 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &command{AllowUnknownCI: tt.allowUnknownCI}
+			mc.Config = checkConfig(tt.branches)
+			before := checkConfig(tt.branches)
+
+			var buf bytes.Buffer
+			err := mc.runCheck(&buf)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, buf.String(), want)
+			}
+			assert.Equal(t, before, mc.Config, "check must not change the config")
+		})
+	}
+}
+
+// TestRunCheck_Scope tests that a PR number and target branch narrow the check
+func TestRunCheck_Scope(t *testing.T) {
+	branches := map[string]cmd.BranchStatus{
+		"release-1.0": picked(101, cmd.CIStatusPassing),
+		"release-2.0": picked(102, cmd.CIStatusFailing),
+	}
+
+	mc := &command{PRNumber: 100, TargetBranch: "release-1.0"}
+	mc.Config = checkConfig(branches)
+	var buf bytes.Buffer
+	require.NoError(t, mc.runCheck(&buf))
+	assert.NotContains(t, buf.String(), "release-2.0")
+
+	mc = &command{PRNumber: 999}
+	mc.Config = checkConfig(branches)
+	require.Error(t, mc.runCheck(&buf))
+
+	mc = &command{PRNumber: 100, TargetBranch: "release-9.9"}
+	mc.Config = checkConfig(branches)
+	require.Error(t, mc.runCheck(&buf))
+}
@@ -3,9 +3,12 @@ package merge
 import (
 	"bytes"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -135,6 +138,227 @@ func TestMergeCommand_Run_BranchNotEligible(t *testing.T) {
 	require.Error(t, err)
 }
 
+// TestMergeCommand_EligibleForMerge_UnknownCI tests that unknown CI only passes with --allow-unknown-ci
+func TestMergeCommand_EligibleForMerge_UnknownCI(t *testing.T) {
+	status := cmd.BranchStatus{
+		Status: cmd.BranchStatusPicked,
+		PR:     &cmd.PickPR{Number: 456, CIStatus: cmd.CIStatusUnknown},
+	}
+
+	mc := &command{}
+	assert.False(t, mc.eligibleForMerge(status), "unknown CI blocks merge by default")
+
+	mc.AllowUnknownCI = true
+	assert.True(t, mc.eligibleForMerge(status))
+
+	status.PR.CIStatus = cmd.CIStatusFailing
+	assert.False(t, mc.eligibleForMerge(status), "failing CI still blocks merge")
+}
+
+// TestMergeCommand_EligibleForMerge_Only tests that --only narrows the eligible branches by CI status
+func TestMergeCommand_EligibleForMerge_Only(t *testing.T) {
+	passing := cmd.BranchStatus{
+		Status: cmd.BranchStatusPicked,
+		PR:     &cmd.PickPR{Number: 456, CIStatus: cmd.CIStatusPassing},
+	}
+	unknown := cmd.BranchStatus{
+		Status: cmd.BranchStatusPicked,
+		PR:     &cmd.PickPR{Number: 457, CIStatus: cmd.CIStatusUnknown},
+	}
+
+	mc := &command{AllowUnknownCI: true, Only: cmd.CIStatusPassing}
+	assert.True(t, mc.eligibleForMerge(passing))
+	assert.False(t, mc.eligibleForMerge(unknown))
+
+	mc.Only = cmd.CIStatusUnknown
+	assert.False(t, mc.eligibleForMerge(passing))
+	assert.True(t, mc.eligibleForMerge(unknown))
+
+	mc.AllowUnknownCI = false
+	assert.False(t, mc.eligibleForMerge(unknown), "--only can't widen the default eligibility")
+}
+
+func TestParseOnlyFilter(t *testing.T) {
+	for _, value := range []string{"", "passing", "unknown", "no_checks"} {
+		status, err := ParseOnlyFilter(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, cmd.CIStatus(value), status)
+	}
+
+	for _, value := range []string{"failing", "pending", "green"} {
+		_, err := ParseOnlyFilter(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseAllowCI(t *testing.T) {
+	tests := []struct {
+		value          string
+		allowUnknownCI bool
+		want           []cmd.CIStatus
+		wantErr        string
+	}{
+		{value: "", want: []cmd.CIStatus{cmd.CIStatusPassing}},
+		{value: "passing,unknown", want: []cmd.CIStatus{cmd.CIStatusPassing, cmd.CIStatusUnknown, cmd.CIStatusNoChecks}},
+		{value: " pending , passing,pending", want: []cmd.CIStatus{cmd.CIStatusPending, cmd.CIStatusPassing}},
+		{value: "unknown", want: []cmd.CIStatus{cmd.CIStatusUnknown, cmd.CIStatusNoChecks}},
+		{value: "passing,no_checks", want: []cmd.CIStatus{cmd.CIStatusPassing, cmd.CIStatusNoChecks}},
+		{value: "no_checks", allowUnknownCI: true, want: []cmd.CIStatus{cmd.CIStatusNoChecks, cmd.CIStatusUnknown}},
+		{value: "", allowUnknownCI: true, want: []cmd.CIStatus{cmd.CIStatusPassing, cmd.CIStatusUnknown, cmd.CIStatusNoChecks}},
+		{value: "passing,failing", wantErr: "failing PRs are never merged"},
+		{value: "green", wantErr: "invalid --allow-ci state"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAllowCI(tt.value, tt.allowUnknownCI)
+		if tt.wantErr != "" {
+			require.ErrorContains(t, err, tt.wantErr, tt.value)
+			continue
+		}
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+}
+
+// TestMergeCommand_EligibleForMerge_AllowCI tests that --allow-ci decides which CI states are eligible
+func TestMergeCommand_EligibleForMerge_AllowCI(t *testing.T) {
+	status := func(ci cmd.CIStatus) cmd.BranchStatus {
+		return cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 456, CIStatus: ci}}
+	}
+
+	mc := &command{AllowCI: []cmd.CIStatus{cmd.CIStatusPassing, cmd.CIStatusPending}}
+	assert.True(t, mc.eligibleForMerge(status(cmd.CIStatusPassing)))
+	assert.True(t, mc.eligibleForMerge(status(cmd.CIStatusPending)))
+	assert.False(t, mc.eligibleForMerge(status(cmd.CIStatusUnknown)))
+	assert.False(t, mc.eligibleForMerge(status(cmd.CIStatusFailing)))
+	assert.True(t, mc.allowsUnverifiedCI())
+
+	mc.AllowUnknownCI = true
+	assert.True(t, mc.eligibleForMerge(status(cmd.CIStatusUnknown)), "--allow-unknown-ci adds unknown")
+	assert.True(t, mc.eligibleForMerge(status(cmd.CIStatusNoChecks)), "--allow-unknown-ci adds no_checks, once reported as unknown")
+
+	mc = &command{AllowCI: []cmd.CIStatus{cmd.CIStatusUnknown}}
+	assert.False(t, mc.eligibleForMerge(status(cmd.CIStatusPassing)), "only the listed states are accepted")
+	assert.True(t, mc.eligibleForMerge(status(cmd.CIStatusNoChecks)), "allowing unknown allows no_checks")
+	assert.False(t, (&command{}).allowsUnverifiedCI())
+}
+
+// TestMergeCommand_Run_OnlyUnknownNeedsAllowUnknownCI tests that --only unknown alone is rejected
+func TestMergeCommand_Run_OnlyUnknownNeedsAllowUnknownCI(t *testing.T) {
+	mc := &command{Only: cmd.CIStatusUnknown}
+
+	err := mc.Run(t.Context())
+	require.ErrorContains(t, err, "--allow-unknown-ci")
+
+	mc.AllowCI = []cmd.CIStatus{cmd.CIStatusPending}
+	err = mc.Run(t.Context())
+	require.ErrorContains(t, err, "--only unknown needs --allow-ci to include unknown")
+}
+
+// TestMergeCommand_MergeMethod tests that --merge-method overrides the config and unknown methods are rejected
+func TestMergeCommand_MergeMethod(t *testing.T) {
+	tests := []struct {
+		name       string
+		flag       string
+		configured cmd.MergeMethod
+		want       cmd.MergeMethod
+		wantErr    string
+	}{
+		{name: "defaults to squash", want: cmd.MergeMethodSquash},
+		{name: "config", configured: cmd.MergeMethodRebase, want: cmd.MergeMethodRebase},
+		{name: "flag overrides config", flag: "merge", configured: cmd.MergeMethodRebase, want: cmd.MergeMethodMerge},
+		{name: "unknown flag value", flag: "fast-forward", wantErr: "invalid --merge-method"},
+		{name: "unknown config value", configured: "fast-forward", wantErr: "invalid merge_method"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &command{MergeMethod: tt.flag}
+			mc.Config = &cmd.Config{MergeMethod: tt.configured}
+
+			method, err := mc.mergeMethod()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, method)
+		})
+	}
+}
+
+// TestMergeCommand_MergeBranchOperation_DeleteBranch tests that the head branch
+// is deleted only once the merge succeeded, and that a failed delete doesn't
+// fail the merge
+func TestMergeCommand_MergeBranchOperation_DeleteBranch(t *testing.T) {
+	var deleted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"number": ` + r.PathValue("number") + `, "title": "fix", "mergeable": true, "mergeable_state": "clean", "head": {"ref": "cherry-pick-123-release-1.0"}}`))
+	})
+	mux.HandleFunc("PUT /api/v3/repos/acme/widget/pulls/{number}/merge", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("number") == "457" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_, _ = w.Write([]byte(`{"message": "Pull Request is not mergeable"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"merged": true}`))
+	})
+	mux.HandleFunc("DELETE /api/v3/repos/acme/widget/git/refs/heads/{ref}", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.PathValue("ref"))
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Reference does not exist"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := github.NewEnterpriseClient(t.Context(), "test-token", server.URL+"/api/v3", github.HTTPOptions{})
+	require.NoError(t, err)
+	client = client.WithRepository("acme", "widget")
+
+	trackedPR := &cmd.TrackedPR{
+		Number: 123,
+		Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 456, CIStatus: cmd.CIStatusPassing}},
+			"release-1.1": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 457, CIStatus: cmd.CIStatusPassing}},
+		},
+	}
+	mc := &command{DeleteBranch: true}
+	config := &cmd.Config{}
+
+	require.NoError(t, mc.mergeBranchOperation(t.Context(), client, config, trackedPR, "release-1.0", trackedPR.Branches["release-1.0"]),
+		"a failed delete only warns")
+	assert.Equal(t, cmd.BranchStatusMerged, trackedPR.Branches["release-1.0"].Status)
+	assert.Equal(t, []string{"cherry-pick-123-release-1.0"}, deleted)
+
+	require.Error(t, mc.mergeBranchOperation(t.Context(), client, config, trackedPR, "release-1.1", trackedPR.Branches["release-1.1"]))
+	assert.Len(t, deleted, 1, "no delete after a failed merge")
+
+	mc.DeleteBranch = false
+	trackedPR.Branches["release-1.0"] = cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 456, CIStatus: cmd.CIStatusPassing}}
+	require.NoError(t, mc.mergeBranchOperation(t.Context(), client, config, trackedPR, "release-1.0", trackedPR.Branches["release-1.0"]))
+	assert.Len(t, deleted, 1, "branches are kept by default")
+}
+
+// TestMergeCommand_Run_InvalidMergeMethod tests that an unknown method fails before any GitHub call
+func TestMergeCommand_Run_InvalidMergeMethod(t *testing.T) {
+	mc := &command{MergeMethod: "fast-forward"}
+	mc.Config = &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{
+			{
+				Number: 123,
+				Branches: map[string]cmd.BranchStatus{
+					"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 456, CIStatus: cmd.CIStatusPassing}},
+				},
+			},
+		},
+	}
+
+	// No GitHub client is set, so reaching the API would panic
+	err := mc.Run(t.Context())
+	require.ErrorContains(t, err, "must be squash, merge or rebase")
+}
+
 // TestMergeCommand_Run_BranchNotTracked tests when specified branch is not tracked
 func TestMergeCommand_Run_BranchNotTracked(t *testing.T) {
 	mc := &command{
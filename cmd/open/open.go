@@ -0,0 +1,127 @@
+// Package open implements the open command for opening tracked PRs in the browser.
+package open
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/cmd/status"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the open command
+type command struct {
+	Config       *cmd.Config
+	PRNumber     int
+	TargetBranch string
+	PrintOnly    bool
+	openBrowser  func(url string) error // launches the default browser; replaced in tests
+}
+
+// NewOpenCmd creates the open command
+func NewOpenCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error)) *cobra.Command {
+	openCmd := &command{openBrowser: launchBrowser}
+
+	cobraCmd := &cobra.Command{
+		Use:   "open <pr-number> [target-branch]",
+		Short: "Open a tracked PR or its cherry-pick PR in the browser",
+		Long: `Open a tracked PR in the default browser.
+
+With no branch the original PR is opened. With a branch that has a cherry-pick
+PR (picked, queued, merged or released), that PR is opened instead; for a
+pending or failed branch the original PR is opened, as that's where the bot
+reports failures.
+
+With --print-only, the URL is written to stdout instead, for headless
+environments. Nothing is read from GitHub.
+
+Examples:
+  cherry-picker open 123
+  cherry-picker open 123 release-1.0
+  cherry-picker open 123 release-1.0 --print-only`,
+		Args:         cobra.RangeArgs(1, 2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+			if err != nil {
+				return err
+			}
+			openCmd.PRNumber = prNumber
+			openCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+
+			config, err := loadConfig(*globalConfigFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			openCmd.Config = config
+			return openCmd.run(os.Stdout)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&openCmd.PrintOnly, "print-only", false, "Print the URL instead of opening a browser")
+
+	return cobraCmd
+}
+
+// run opens (or, with --print-only, prints to w) the URL of the requested PR
+func (oc *command) run(w io.Writer) error {
+	url, err := oc.url()
+	if err != nil {
+		return err
+	}
+	if oc.PrintOnly {
+		fmt.Fprintln(w, url)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Opening %s\n", url)
+	return oc.openBrowser(url)
+}
+
+// url returns the link to open: the branch's cherry-pick PR when there is one,
+// else the original PR
+func (oc *command) url() (string, error) {
+	trackedPR, err := commands.FindAndValidatePR(oc.Config, oc.PRNumber)
+	if err != nil {
+		return "", err
+	}
+	if oc.TargetBranch == "" {
+		return status.PullRequestURL(oc.Config, trackedPR.Number), nil
+	}
+	if err := commands.ValidateTargetBranch(trackedPR, oc.TargetBranch); err != nil {
+		return "", err
+	}
+
+	branchStatus := trackedPR.Branches[oc.TargetBranch]
+	if branchStatus.PR == nil || branchStatus.PR.Number == 0 {
+		fmt.Fprintf(os.Stderr, "PR #%d has no cherry-pick PR on %s yet (status %s), using the original PR\n", trackedPR.Number, oc.TargetBranch, branchStatus.Status)
+		return status.PullRequestURL(oc.Config, trackedPR.Number), nil
+	}
+	return status.PickPRURL(oc.Config, branchStatus.PR), nil
+}
+
+// launchBrowser opens url with the platform's opener
+func launchBrowser(url string) error {
+	name, args := browserCommand(runtime.GOOS, url)
+	if err := exec.Command(name, args...).Start(); err != nil { //nolint:gosec // opener is fixed per platform, url comes from the config
+		return fmt.Errorf("failed to open browser with %s (use --print-only to get the URL): %w", name, err)
+	}
+	return nil
+}
+
+// browserCommand returns the command that opens url in the default browser on goos
+func browserCommand(goos, url string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		// start is a cmd builtin; its first quoted argument is the window title
+		return "cmd", []string{"/c", "start", "", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
@@ -0,0 +1,110 @@
+package open
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *cmd.Config {
+	return &cmd.Config{
+		Org:  "acme",
+		Repo: "widget",
+		TrackedPRs: []cmd.TrackedPR{{
+			Number: 100,
+			Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 101}},
+				"release-1.1": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 102, URL: "https://github.example.com/acme/widget/pull/102"}},
+				"release-1.2": {Status: cmd.BranchStatusFailed},
+			},
+		}},
+	}
+}
+
+// TestNewOpenCmd tests command creation and argument validation
+func TestNewOpenCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return testConfig(), nil
+	}
+
+	cobraCmd := NewOpenCmd(&configFile, loadConfig)
+
+	assert.Equal(t, "open", cobraCmd.Name())
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"100"}))
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"100", "release-1.0"}))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{}))
+	assert.NotNil(t, cobraCmd.Flags().Lookup("print-only"))
+}
+
+// TestCommand_URL tests which PR each form of the command links to
+func TestCommand_URL(t *testing.T) {
+	tests := []struct {
+		name    string
+		pr      int
+		branch  string
+		want    string
+		wantErr string
+	}{
+		{name: "original PR", pr: 100, want: "https://github.com/acme/widget/pull/100"},
+		{name: "cherry-pick PR", pr: 100, branch: "release-1.0", want: "https://github.com/acme/widget/pull/101"},
+		{name: "recorded cherry-pick URL", pr: 100, branch: "release-1.1", want: "https://github.example.com/acme/widget/pull/102"},
+		{name: "no cherry-pick PR yet", pr: 100, branch: "release-1.2", want: "https://github.com/acme/widget/pull/100"},
+		{name: "untracked branch", pr: 100, branch: "release-9.9", wantErr: "release-9.9"},
+		{name: "untracked PR", pr: 999, wantErr: "PR #999 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &command{Config: testConfig(), PRNumber: tt.pr, TargetBranch: tt.branch}
+			url, err := oc.url()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, url)
+		})
+	}
+}
+
+// TestCommand_Run tests printing versus launching the browser
+func TestCommand_Run(t *testing.T) {
+	var opened []string
+	oc := &command{
+		Config:       testConfig(),
+		PRNumber:     100,
+		TargetBranch: "release-1.0",
+		openBrowser:  func(url string) error { opened = append(opened, url); return nil },
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, oc.run(&out))
+	assert.Equal(t, []string{"https://github.com/acme/widget/pull/101"}, opened)
+	assert.Empty(t, out.String())
+
+	oc.PrintOnly = true
+	require.NoError(t, oc.run(&out))
+	assert.Equal(t, "https://github.com/acme/widget/pull/101\n", out.String())
+	assert.Len(t, opened, 1, "--print-only doesn't launch a browser")
+}
+
+// TestBrowserCommand tests the opener chosen for each platform
+func TestBrowserCommand(t *testing.T) {
+	url := "https://github.com/acme/widget/pull/1"
+
+	name, args := browserCommand("linux", url)
+	assert.Equal(t, "xdg-open", name)
+	assert.Equal(t, []string{url}, args)
+
+	name, args = browserCommand("darwin", url)
+	assert.Equal(t, "open", name)
+	assert.Equal(t, []string{url}, args)
+
+	name, args = browserCommand("windows", url)
+	assert.Equal(t, "cmd", name)
+	assert.Equal(t, []string{"/c", "start", "", url}, args)
+}
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/commands"
@@ -17,9 +18,20 @@ import (
 // command encapsulates the pick command with common functionality
 type command struct {
 	commands.BaseCommand
-	PRNumber     int
-	TargetBranch string
-	Force        bool
+	PRNumber      int
+	TargetBranch  string
+	Force         bool
+	TrackNew      bool
+	ForceReset    bool
+	NoReset       bool
+	NoClobber     bool
+	Yes           bool
+	NoSignoff     bool
+	Autosquash    bool
+	SkipConflicts bool
+	Draft         bool   // create cherry-pick PRs as drafts
+	SHA           string // commit to pick instead of the PR's merge commit
+	dir           string // working tree git commands run in; "" for the current directory
 }
 
 // NewPickCmd creates and returns the pick command
@@ -37,6 +49,33 @@ The PR must be currently tracked and have 'failed' status for the target branch(
 
 Use --force to amend an existing bot-created cherry-pick PR that has 'picked' status.
 This fetches the existing PR branch, allows AI-assisted modifications, and force pushes.
+Add --no-clobber to abort instead of overwriting the PR branch if someone else
+pushed to it while the session was running.
+
+Use --track-new with a target branch the PR isn't tracked for yet to start tracking it
+(as 'failed') and pick to it, provided the branch exists on the remote.
+
+Each target branch is reset to its upstream before picking. If the local branch
+has commits that aren't on the remote, pick stops rather than lose them; pass
+--force-reset to discard them anyway, or --no-reset to pick onto the local branch
+as it is (e.g. to validate a backport against a branch that isn't pushed yet).
+The PR still targets the remote branch, so any local-only commits show up in it.
+
+For a PR merged with a merge commit, --autosquash picks each of its commits
+instead of the merge commit, then squashes its fixup!/squash! commits into
+their targets (git rebase --autosquash) so the backport has clean history.
+
+With --skip-conflicts, each branch is first tried in a scratch worktree. Branches
+the PR doesn't apply to cleanly are skipped, before any branch is created or
+pushed, and stay as they are for the AI-assisted pick or a later manual one.
+
+With --sha <commit>, that commit is picked instead of the PR's merge commit,
+e.g. when the bot picked the wrong one or the squash commit isn't the change
+to backport. It must exist locally once origin has been fetched.
+
+With --draft, new cherry-pick PRs are created as drafts, so CI runs but
+reviewers aren't asked for a review until the PR is marked ready. PRs amended
+with --force keep their state.
 
 Conflicts are automatically resolved using configured AI assistant.`,
 		Args:         cobra.RangeArgs(1, 2),
@@ -63,6 +102,16 @@ Conflicts are automatically resolved using configured AI assistant.`,
 	}
 
 	cobraCmd.Flags().BoolVar(&pickCmd.Force, "force", false, "Amend existing cherry-pick PR instead of creating new one")
+	cobraCmd.Flags().BoolVar(&pickCmd.NoClobber, "no-clobber", false, "With --force, abort rather than overwrite the PR branch if it changed on the remote since it was fetched")
+	cobraCmd.Flags().BoolVar(&pickCmd.ForceReset, "force-reset", false, "Discard local commits on the target branch that are not on the remote")
+	cobraCmd.Flags().BoolVar(&pickCmd.NoReset, "no-reset", false, "Pick onto the local target branch as it is instead of resetting it to the remote")
+	cobraCmd.Flags().BoolVar(&pickCmd.NoSignoff, "no-signoff", false, "Don't add a Signed-off-by trailer to the cherry-pick commit (for repos without DCO)")
+	cobraCmd.Flags().BoolVar(&pickCmd.Autosquash, "autosquash", false, "Pick each commit of a merge-committed PR and squash its fixup!/squash! commits")
+	cobraCmd.Flags().BoolVar(&pickCmd.SkipConflicts, "skip-conflicts", false, "Skip branches the PR doesn't cherry-pick onto cleanly, checked before creating any branch")
+	cobraCmd.Flags().StringVar(&pickCmd.SHA, "sha", "", "Cherry-pick this commit instead of the PR's merge commit")
+	cobraCmd.Flags().BoolVar(&pickCmd.Draft, "draft", false, "Create the cherry-pick PRs as drafts")
+	cobraCmd.Flags().BoolVarP(&pickCmd.Yes, "yes", "y", false, "Launch the AI assistant without waiting for Enter")
+	cobraCmd.Flags().BoolVar(&pickCmd.TrackNew, "track-new", false, "Start tracking the target branch if the PR isn't tracked for it yet")
 
 	return cobraCmd
 }
@@ -74,6 +123,30 @@ func (pc *command) Run(ctx context.Context) error {
 
 // runPick executes the full cherry-pick workflow
 func (pc *command) runPick(ctx context.Context) error {
+	if pc.NoClobber && !pc.Force {
+		return fmt.Errorf("--no-clobber only applies to --force")
+	}
+	if pc.NoReset && pc.Force {
+		return fmt.Errorf("--no-reset doesn't apply to --force, which works on the PR branch")
+	}
+	if pc.NoReset && pc.ForceReset {
+		return fmt.Errorf("--no-reset and --force-reset can't be used together")
+	}
+	if pc.Autosquash && pc.Force {
+		return fmt.Errorf("--autosquash doesn't apply to --force, which amends the existing PR branch")
+	}
+	if pc.SkipConflicts && pc.Force {
+		return fmt.Errorf("--skip-conflicts doesn't apply to --force, which amends the existing PR branch")
+	}
+	if pc.SHA != "" && pc.Force {
+		return fmt.Errorf("--sha doesn't apply to --force, which amends the existing PR branch")
+	}
+	if pc.Config != nil {
+		if err := pc.Config.ValidatePick(); err != nil {
+			return err
+		}
+	}
+
 	// Find and validate PR (4 lines vs ~15 lines)
 	pr, err := commands.FindAndValidatePR(pc.Config, pc.PRNumber)
 	if err != nil {
@@ -83,6 +156,13 @@ func (pc *command) runPick(ctx context.Context) error {
 	// Determine branches to update (3 lines vs ~10 lines)
 	branches := commands.DetermineBranchesToUpdate(pr, pc.TargetBranch)
 
+	// Start tracking an ad-hoc target branch before validating it
+	if pc.TrackNew {
+		if err := pc.trackNewBranches(pr, branches); err != nil {
+			return err
+		}
+	}
+
 	// Validate branch status
 	if err := pc.validatePickableStatus(pr, branches); err != nil {
 		return err
@@ -93,9 +173,15 @@ func (pc *command) runPick(ctx context.Context) error {
 		return err
 	}
 
-	// Get commit SHA only in normal mode (not needed for force amend)
+	// Fail clearly on a label that names a branch origin doesn't have
+	if err := pc.validateBranchesOnRemote(branches); err != nil {
+		return err
+	}
+
+	// Get commit SHA only in normal mode (not needed for force amend); --sha
+	// names it directly
 	var sha string
-	if !pc.Force {
+	if !pc.Force && pc.SHA == "" {
 		var err error
 		sha, err = pc.getCommitSHA(ctx, pc.PRNumber)
 		if err != nil {
@@ -107,11 +193,32 @@ func (pc *command) runPick(ctx context.Context) error {
 		return fmt.Errorf("failed to fetch from remote: %w", err)
 	}
 
+	if pc.SHA != "" {
+		var err error
+		sha, err = resolveCommit(pc.dir, pc.SHA)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Picking commit %s instead of PR #%d's merge commit (--sha)\n", sha[:8], pc.PRNumber)
+	}
+
 	// Perform cherry-pick (or force amend) for each branch with immediate saving
+	var picked []string
 	for _, branch := range branches {
 		var result *CherryPickResult
 		var err error
 
+		if pc.SkipConflicts {
+			clean, err := pc.appliesCleanly(sha, pc.pickBase(branch))
+			if err != nil {
+				return fmt.Errorf("pre-check for branch %s failed: %w", branch, err)
+			}
+			if !clean {
+				fmt.Fprintf(os.Stderr, "⏭️  Skipping %s: PR #%d doesn't cherry-pick onto it cleanly (--skip-conflicts)\n", branch, pc.PRNumber)
+				continue
+			}
+		}
+
 		if pc.Force {
 			// Force mode: amend existing cherry-pick PR
 			result, err = pc.performForceAmendForBranch(ctx, branch, pr)
@@ -126,6 +233,7 @@ func (pc *command) runPick(ctx context.Context) error {
 
 		// Update and save immediately after each successful cherry-pick
 		pc.updateSingleBranchStatus(pr, branch, result)
+		picked = append(picked, branch)
 		if err := pc.SaveConfig(*pc.ConfigFile, pc.Config); err != nil {
 			slog.Warn("Failed to save config after successful cherry-pick", "branch", branch, "error", err)
 		} else {
@@ -138,7 +246,11 @@ func (pc *command) runPick(ctx context.Context) error {
 		return err
 	}
 
-	commands.DisplaySuccessMessage("picked", pc.PRNumber, pc.TargetBranch, branches)
+	if len(picked) == 0 {
+		fmt.Fprintf(os.Stderr, "No branches picked for PR #%d: it conflicts with all of them\n", pc.PRNumber)
+		return nil
+	}
+	commands.DisplaySuccessMessage("picked", pc.PRNumber, pc.TargetBranch, picked)
 	return nil
 }
 
@@ -179,7 +291,7 @@ func (pc *command) validatePickableStatus(pr *cmd.TrackedPR, branches []string)
 	for _, branch := range branches {
 		status, exists := pr.Branches[branch]
 		if !exists {
-			return fmt.Errorf("PR #%d has no status for branch '%s'", pc.PRNumber, branch)
+			return fmt.Errorf("PR #%d has no status for branch '%s' (use --track-new to start tracking it)", pc.PRNumber, branch)
 		}
 
 		// Handle --force mode: require 'picked' status with existing PR
@@ -195,12 +307,12 @@ func (pc *command) validatePickableStatus(pr *cmd.TrackedPR, branches []string)
 
 		// Normal mode validation
 		switch status.Status {
-		case cmd.BranchStatusPicked, cmd.BranchStatusMerged, cmd.BranchStatusReleased:
+		case cmd.BranchStatusPicked, cmd.BranchStatusQueued, cmd.BranchStatusMerged, cmd.BranchStatusReleased:
 			return fmt.Errorf("PR #%d for branch '%s' can only be picked if bot cherry-pick failed or pending (current status: %s)", pc.PRNumber, branch, status.Status)
 		case cmd.BranchStatusPending:
 			// Bot hasn't attempted yet - ask user to confirm
-			fmt.Printf("⚠️  PR #%d for branch '%s' is still pending (bot hasn't attempted cherry-pick yet).\n", pc.PRNumber, branch)
-			fmt.Printf("Are you sure you want to pick manually? (y/N): ")
+			fmt.Fprintf(os.Stderr, "⚠️  PR #%d for branch '%s' is still pending (bot hasn't attempted cherry-pick yet).\n", pc.PRNumber, branch)
+			fmt.Fprintf(os.Stderr, "Are you sure you want to pick manually? (y/N): ")
 
 			reader := bufio.NewReader(os.Stdin)
 			response, err := reader.ReadString('\n')
@@ -219,6 +331,73 @@ func (pc *command) validatePickableStatus(pr *cmd.TrackedPR, branches []string)
 	return nil
 }
 
+// validateBranchesOnRemote checks that every target branch exists on origin before any checkout
+func (pc *command) validateBranchesOnRemote(branches []string) error {
+	var missing []string
+	for _, branch := range branches {
+		onRemote, err := pc.remoteBranchExists(branch)
+		if err != nil {
+			return err
+		}
+		if !onRemote {
+			missing = append(missing, branch)
+		}
+	}
+
+	switch len(missing) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%s doesn't exist on origin — check the cherry-pick label", missing[0])
+	default:
+		return fmt.Errorf("%s don't exist on origin — check the cherry-pick labels", strings.Join(missing, ", "))
+	}
+}
+
+// trackNewBranches adds branches the PR isn't tracked for yet with 'failed' status so
+// they can be picked, provided they exist on the remote. New entries are saved immediately.
+func (pc *command) trackNewBranches(pr *cmd.TrackedPR, branches []string) error {
+	if pc.Force {
+		return fmt.Errorf("--track-new cannot be combined with --force")
+	}
+	if pc.TargetBranch == "" {
+		return fmt.Errorf("--track-new requires a target branch")
+	}
+	if pr.Branches == nil {
+		pr.Branches = make(map[string]cmd.BranchStatus)
+	}
+
+	added := false
+	for _, branch := range branches {
+		if _, exists := pr.Branches[branch]; exists {
+			continue
+		}
+		if pr.IsBranchIgnored(branch) {
+			return fmt.Errorf("PR #%d is ignored for %s; run 'unignore %d %s' first", pr.Number, branch, pr.Number, branch)
+		}
+
+		onRemote, err := pc.remoteBranchExists(branch)
+		if err != nil {
+			return err
+		}
+		if !onRemote {
+			return fmt.Errorf("branch '%s' does not exist on the remote", branch)
+		}
+
+		pr.Branches[branch] = cmd.BranchStatus{Status: cmd.BranchStatusFailed, LastUpdated: time.Now()}
+		slog.Info("Started tracking branch", "pr", pr.Number, "branch", branch)
+		added = true
+	}
+
+	if !added {
+		return nil
+	}
+	if err := pc.SaveConfig(*pc.ConfigFile, pc.Config); err != nil {
+		return fmt.Errorf("failed to save newly tracked branch: %w", err)
+	}
+	return nil
+}
+
 // updatePRStatus updates the PR status to picked for specified branches
 func (*command) updatePRStatus(pr *cmd.TrackedPR, branches []string) {
 	for _, branch := range branches {
@@ -232,18 +411,30 @@ func (*command) updatePRStatus(pr *cmd.TrackedPR, branches []string) {
 // updateSingleBranchStatus updates PR status for a single branch with cherry-pick result
 func (*command) updateSingleBranchStatus(pr *cmd.TrackedPR, branch string, result *CherryPickResult) {
 	pr.Branches[branch] = cmd.BranchStatus{
-		Status: cmd.BranchStatusPicked,
+		Status:      cmd.BranchStatusPicked,
+		LastUpdated: time.Now(),
 		PR: &cmd.PickPR{
-			Number:   result.PRNumber,
-			Title:    result.Title,
-			CIStatus: cmd.ParseCIStatus(result.CIStatus),
+			Number:           result.PRNumber,
+			Title:            result.Title,
+			URL:              result.URL,
+			CIStatus:         cmd.ParseCIStatus(result.CIStatus),
+			ResolutionMethod: result.ResolutionMethod,
 		},
 	}
 }
 
 // performCherryPickForBranch performs cherry-pick for a specific branch
 func (pc *command) performCherryPickForBranch(ctx context.Context, sha, branch string, prNumber int, originalTitle string) (*CherryPickResult, error) {
-	cherryPickBranch := fmt.Sprintf("cherry-pick-%d-%s", prNumber, branch)
+	cherryPickBranch := cmd.PickBranchName(prNumber, branch)
+
+	trailers, err := pc.commitTrailers(prNumber, branch)
+	if err != nil {
+		return nil, err
+	}
+	prTitle, err := pc.Config.CherryPickTitle(originalTitle, prNumber, pc.branchVersion(branch), branch)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := pc.checkoutBranch(branch); err != nil {
 		return nil, err
@@ -253,34 +444,36 @@ func (pc *command) performCherryPickForBranch(ctx context.Context, sha, branch s
 		return nil, fmt.Errorf("failed to create branch %s: %w", cherryPickBranch, err)
 	}
 
-	if err := pc.performCherryPick(sha); err != nil {
+	resolution, err := pc.pickCommits(sha)
+	if err != nil {
 		return nil, fmt.Errorf("git cherry-pick failed for commit %s: %w", sha[:8], err)
 	}
 
-	if err := pc.moveSignedOffByLinesToEnd(); err != nil {
-		return nil, fmt.Errorf("failed to reorder Signed-off-by lines: %w", err)
+	// Without a signoff there is nothing to reorder, only trailers to append
+	if pc.signoff() || len(trailers) > 0 {
+		if err := pc.moveSignedOffByLinesToEnd(trailers); err != nil {
+			return nil, fmt.Errorf("failed to reorder Signed-off-by lines: %w", err)
+		}
 	}
 
 	if err := pc.pushBranch(cherryPickBranch); err != nil {
 		return nil, fmt.Errorf("git push failed for branch %s: %w", cherryPickBranch, err)
 	}
 
-	cherryPickPRNumber, err := pc.createCherryPickPR(ctx, cherryPickBranch, branch, prNumber, originalTitle)
+	cherryPickPR, err := pc.createCherryPickPR(ctx, cherryPickBranch, branch, prNumber, originalTitle, prTitle)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("✅ Successfully cherry-picked to branch: %s\n", branch)
-	fmt.Printf("✅ Created PR #%d: %s → %s\n", cherryPickPRNumber, cherryPickBranch, branch)
-
-	// Extract version from branch name for title (e.g., "release-3.7" -> "3.7")
-	version := strings.TrimPrefix(branch, "release-")
-	prTitle := fmt.Sprintf("%s (cherry-pick #%d for %s)", originalTitle, prNumber, version)
+	fmt.Fprintf(os.Stderr, "✅ Successfully cherry-picked to branch: %s\n", branch)
+	fmt.Fprintf(os.Stderr, "✅ Created PR #%d: %s → %s\n", cherryPickPR.Number, cherryPickBranch, branch)
 
 	return &CherryPickResult{
-		PRNumber: cherryPickPRNumber,
-		Title:    prTitle,
-		CIStatus: "pending",
+		PRNumber:         cherryPickPR.Number,
+		Title:            prTitle,
+		URL:              cherryPickPR.URL,
+		CIStatus:         "pending",
+		ResolutionMethod: resolution,
 	}, nil
 }
 
@@ -289,7 +482,7 @@ func (pc *command) performForceAmendForBranch(ctx context.Context, branch string
 	branchStatus := trackedPR.Branches[branch]
 	existingPRNumber := branchStatus.PR.Number
 
-	fmt.Printf("Fetching existing cherry-pick PR #%d for branch %s...\n", existingPRNumber, branch)
+	fmt.Fprintf(os.Stderr, "Fetching existing cherry-pick PR #%d for branch %s...\n", existingPRNumber, branch)
 
 	// Fetch the existing PR's branch
 	if err := pc.fetchPRBranch(existingPRNumber); err != nil {
@@ -302,23 +495,40 @@ func (pc *command) performForceAmendForBranch(ctx context.Context, branch string
 		return nil, fmt.Errorf("failed to get PR #%d head branch: %w", existingPRNumber, err)
 	}
 
+	// Remember what was fetched so --no-clobber can tell if someone else pushed meanwhile
+	localBranch := fmt.Sprintf("pr-%d", existingPRNumber)
+	var fetchedSHA string
+	if pc.NoClobber {
+		fetchedSHA, err = pc.branchSHA(localBranch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Launch AI assistant with amend-specific prompt
 	if err := pc.launchAmendAIAssistant(existingPRNumber, branch, trackedPR.Title); err != nil {
 		return nil, fmt.Errorf("AI assistant session failed: %w", err)
 	}
 
+	if pc.NoClobber {
+		if err := pc.checkRemoteUnchanged(existingPRNumber, remoteBranch, fetchedSHA); err != nil {
+			return nil, err
+		}
+	}
+
 	// Force push to update the existing PR
-	localBranch := fmt.Sprintf("pr-%d", existingPRNumber)
-	if err := pc.forcePushBranch(localBranch, remoteBranch); err != nil {
+	if err := pc.forcePushBranch(localBranch, remoteBranch, fetchedSHA); err != nil {
 		return nil, fmt.Errorf("failed to force push: %w", err)
 	}
 
-	fmt.Printf("Updated existing PR #%d for branch: %s\n", existingPRNumber, branch)
+	fmt.Fprintf(os.Stderr, "Updated existing PR #%d for branch: %s\n", existingPRNumber, branch)
 
 	// Return result preserving existing PR info (CI will re-run after push)
 	return &CherryPickResult{
-		PRNumber: existingPRNumber,
-		Title:    branchStatus.PR.Title,
-		CIStatus: "pending",
+		PRNumber:         existingPRNumber,
+		Title:            branchStatus.PR.Title,
+		URL:              branchStatus.PR.URL,
+		CIStatus:         "pending",
+		ResolutionMethod: cmd.ResolutionForceAmend,
 	}, nil
 }
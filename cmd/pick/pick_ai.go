@@ -1,6 +1,8 @@
 package pick
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -8,6 +10,21 @@ import (
 	"strings"
 )
 
+// errCherryPickAborted is returned when the user aborts the cherry-pick after the AI assistant failed
+var errCherryPickAborted = errors.New("cherry-pick aborted")
+
+// aiRecoveryChoice is what to do when the AI assistant exits with an error while conflicts remain
+type aiRecoveryChoice int
+
+const (
+	// aiRecoveryRelaunch starts the AI assistant again
+	aiRecoveryRelaunch aiRecoveryChoice = iota
+	// aiRecoveryManual waits for the user to resolve the conflicts by hand
+	aiRecoveryManual
+	// aiRecoveryAbort runs git cherry-pick --abort
+	aiRecoveryAbort
+)
+
 // launchInteractiveAIAssistant launches configured AI assistant with initial context, then hands control to user
 func (pc *command) launchInteractiveAIAssistant(sha string) error {
 	if pc.Config.AIAssistantCommand == "" {
@@ -28,22 +45,98 @@ func (pc *command) launchInteractiveAIAssistant(sha string) error {
 
 	initialPrompt := pc.createInitialConflictPrompt(conflictedFiles, sha)
 
-	fmt.Printf("💡 Starting AI session with conflict context, then handing control to you.\n")
-	fmt.Printf("   - The AI will receive details about the cherry-pick conflicts\n")
-	fmt.Printf("   - You can then guide the resolution process\n")
-	fmt.Printf("   - Exit the agent when you're satisfied with the resolution\n\n")
+	fmt.Fprintf(os.Stderr, "💡 Starting AI session with conflict context, then handing control to you.\n")
+	fmt.Fprintf(os.Stderr, "   - The AI will receive details about the cherry-pick conflicts\n")
+	fmt.Fprintf(os.Stderr, "   - You can then guide the resolution process\n")
+	fmt.Fprintf(os.Stderr, "   - Exit the agent when you're satisfied with the resolution\n\n")
 
 	slog.Info("Sending initial context to AI")
 	separator := strings.Repeat("=", 80)
-	fmt.Printf("\n%s\n", separator)
-	fmt.Printf("%s\n", initialPrompt)
-	fmt.Printf("%s\n\n", separator)
+	fmt.Fprintf(os.Stderr, "\n%s\n", separator)
+	fmt.Fprintf(os.Stderr, "%s\n", initialPrompt)
+	fmt.Fprintf(os.Stderr, "%s\n\n", separator)
+
+	fmt.Fprintf(os.Stderr, "🤖 Starting %s session...\n", pc.Config.AIAssistantCommand)
+	fmt.Fprintf(os.Stderr, "💡 Copy the context above and paste it to start the conversation with the AI.\n")
+	pc.waitForLaunch(fmt.Sprintf("   Press Enter to launch %s...\n", pc.Config.AIAssistantCommand))
 
-	fmt.Printf("🤖 Starting %s session...\n", pc.Config.AIAssistantCommand)
-	fmt.Printf("💡 Copy the context above and paste it to start the conversation with the AI.\n")
-	fmt.Printf("   Press Enter to launch %s...\n", pc.Config.AIAssistantCommand)
+	return pc.runAIAssistantUntilResolved()
+}
+
+// waitForLaunch prints prompt and waits for Enter before the AI assistant starts,
+// unless --yes or ai_assistant_auto_launch asked to launch it right away
+func (pc *command) waitForLaunch(prompt string) {
+	if pc.autoLaunch() {
+		return
+	}
+	fmt.Fprint(os.Stderr, prompt)
 	_, _ = fmt.Scanln() // Ignore error, just waiting for Enter key
+}
 
+// autoLaunch reports whether the AI assistant starts without the Enter pause
+func (pc *command) autoLaunch() bool {
+	return pc.Yes || pc.Config.AIAssistantAutoLaunch
+}
+
+// runAIAssistantUntilResolved runs the AI assistant. If it exits non-zero (e.g. the
+// agent crashed) the conflicts may already be partly resolved, so instead of failing
+// the pick it re-checks the conflicted files and, while any remain, lets the user
+// relaunch the assistant, resolve the rest by hand, or abort the cherry-pick.
+func (pc *command) runAIAssistantUntilResolved() error {
+	relaunch := true
+	var aiErr error
+	// One reader for every prompt: a new one per prompt would drop whatever
+	// the previous one had already buffered past its line
+	stdin := bufio.NewReader(os.Stdin)
+	for {
+		if relaunch {
+			aiErr = pc.runAIAssistant()
+			var exitErr *exec.ExitError
+			if !errors.As(aiErr, &exitErr) {
+				// nil, or the assistant couldn't be started at all
+				return aiErr
+			}
+			slog.Warn("AI assistant exited with an error", "command", pc.Config.AIAssistantCommand, "error", aiErr)
+		}
+
+		remainingConflicts, err := pc.getConflictedFiles()
+		if err != nil {
+			return fmt.Errorf("failed to check for remaining conflicts: %w", err)
+		}
+		if len(remainingConflicts) == 0 {
+			slog.Info("No conflicts remain despite the AI assistant's error exit")
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "\n⚠️  %d file(s) still have conflicts: %s\n", len(remainingConflicts), strings.Join(remainingConflicts, ", "))
+		fmt.Fprintf(os.Stderr, "[r]elaunch %s, resolve [m]anually, or [a]bort the cherry-pick? ", pc.Config.AIAssistantCommand)
+		answer, err := stdin.ReadString('\n')
+		if err != nil {
+			// No one to ask (e.g. stdin closed): leave the conflicts for manual resolution
+			return fmt.Errorf("%w; conflicts remain in %s", aiErr, strings.Join(remainingConflicts, ", "))
+		}
+
+		switch parseAIRecoveryChoice(answer) {
+		case aiRecoveryRelaunch:
+			relaunch = true
+		case aiRecoveryManual:
+			fmt.Fprintln(os.Stderr, "Resolve and stage the conflicts in another terminal, then press Enter to continue...")
+			_, _ = stdin.ReadString('\n')
+			relaunch = false
+		case aiRecoveryAbort:
+			abortCmd := exec.Command("git", "cherry-pick", "--abort")
+			abortCmd.Stdout = os.Stderr
+			abortCmd.Stderr = os.Stderr
+			if err := abortCmd.Run(); err != nil {
+				return fmt.Errorf("failed to abort cherry-pick: %w", err)
+			}
+			return errCherryPickAborted
+		}
+	}
+}
+
+// runAIAssistant runs the configured AI assistant attached to the terminal
+func (pc *command) runAIAssistant() error {
 	cmd := exec.Command(pc.Config.AIAssistantCommand) //nolint:gosec // AI assistant command is user-configured
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
@@ -56,6 +149,19 @@ func (pc *command) launchInteractiveAIAssistant(sha string) error {
 	return nil
 }
 
+// parseAIRecoveryChoice maps the user's answer to a recovery choice. Anything
+// unrecognised (including an empty answer) relaunches the assistant.
+func parseAIRecoveryChoice(answer string) aiRecoveryChoice {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "m", "manual", "manually":
+		return aiRecoveryManual
+	case "a", "abort":
+		return aiRecoveryAbort
+	default:
+		return aiRecoveryRelaunch
+	}
+}
+
 // createInitialConflictPrompt creates a detailed initial prompt for the AI about the cherry-pick conflicts
 func (pc *command) createInitialConflictPrompt(conflictedFiles []string, sha string) string {
 	commitInfo, err := pc.getCommitInfo(sha)
@@ -94,17 +200,17 @@ func (pc *command) launchAmendAIAssistant(prNumber int, targetBranch, originalTi
 
 	prompt := pc.createAmendPrompt(prNumber, targetBranch, originalTitle)
 
-	fmt.Printf("Amending existing cherry-pick PR #%d\n", prNumber)
-	fmt.Printf("You are now on the PR's branch and can make changes.\n\n")
+	fmt.Fprintf(os.Stderr, "Amending existing cherry-pick PR #%d\n", prNumber)
+	fmt.Fprintf(os.Stderr, "You are now on the PR's branch and can make changes.\n\n")
 
 	separator := strings.Repeat("=", 80)
-	fmt.Printf("\n%s\n", separator)
-	fmt.Printf("%s\n", prompt)
-	fmt.Printf("%s\n\n", separator)
+	fmt.Fprintf(os.Stderr, "\n%s\n", separator)
+	fmt.Fprintf(os.Stderr, "%s\n", prompt)
+	fmt.Fprintf(os.Stderr, "%s\n\n", separator)
 
-	fmt.Printf("Starting %s session...\n", pc.Config.AIAssistantCommand)
-	fmt.Printf("Copy the context above to start. Press Enter to launch...\n")
-	_, _ = fmt.Scanln()
+	fmt.Fprintf(os.Stderr, "Starting %s session...\n", pc.Config.AIAssistantCommand)
+	fmt.Fprintf(os.Stderr, "Copy the context above to start.\n")
+	pc.waitForLaunch("Press Enter to launch...\n")
 
 	cmd := exec.Command(pc.Config.AIAssistantCommand) //nolint:gosec // AI assistant command is user-configured
 	cmd.Stdin = os.Stdin
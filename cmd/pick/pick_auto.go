@@ -0,0 +1,163 @@
+package pick
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"slices"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/github"
+)
+
+// AutoPicked is a branch fetch --auto-pick-clean cherry-picked and opened a PR for
+type AutoPicked struct {
+	PR           int
+	Branch       string
+	CherryPickPR int
+}
+
+// AutoPickClean tries to cherry-pick every pending branch of the given tracked
+// PRs in a scratch worktree of the local checkout. A pick that applies without
+// conflicts is pushed, gets a PR and is recorded as picked; any other branch is
+// left pending for the bot or a manual pick. The current checkout isn't
+// touched. It returns the branches it picked; errors pushing or opening a PR
+// are joined, while picks that don't apply cleanly aren't errors.
+func AutoPickClean(ctx context.Context, client *github.Client, config *cmd.Config, prNumbers []int) ([]AutoPicked, error) {
+	if !commands.IsGitRepository() {
+		return nil, fmt.Errorf("--auto-pick-clean needs a local checkout of %s/%s: run fetch from inside it", config.Org, config.Repo)
+	}
+	if len(prNumbers) == 0 {
+		return nil, nil
+	}
+
+	pc := &command{BaseCommand: commands.BaseCommand{GitHubClient: client, Config: config}}
+	if err := runGit("", "fetch", "origin"); err != nil {
+		return nil, fmt.Errorf("failed to fetch from remote: %w", err)
+	}
+
+	var picked []AutoPicked
+	var errs []error
+	for _, prNumber := range slices.Sorted(slices.Values(prNumbers)) {
+		trackedPR, err := commands.FindAndValidatePR(config, prNumber)
+		if err != nil {
+			continue
+		}
+		branches := pendingBranches(trackedPR)
+		if len(branches) == 0 {
+			continue
+		}
+
+		sha, err := pc.getCommitSHA(ctx, prNumber)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, branch := range branches {
+			result, err := pc.autoPickBranch(ctx, sha, branch, trackedPR)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("PR #%d branch %s: %w", prNumber, branch, err))
+				continue
+			}
+			if result == nil {
+				continue
+			}
+			pc.updateSingleBranchStatus(trackedPR, branch, result)
+			picked = append(picked, AutoPicked{PR: prNumber, Branch: branch, CherryPickPR: result.PRNumber})
+		}
+	}
+	return picked, errors.Join(errs...)
+}
+
+// pendingBranches returns the sorted branches of pr the bot hasn't handled yet
+func pendingBranches(pr *cmd.TrackedPR) []string {
+	var branches []string
+	for branch, status := range pr.Branches {
+		if status.Status == cmd.BranchStatusPending {
+			branches = append(branches, branch)
+		}
+	}
+	slices.Sort(branches)
+	return branches
+}
+
+// autoPickBranch cherry-picks sha onto origin/<branch> in a scratch worktree and,
+// if it applies cleanly, pushes it and opens the cherry-pick PR. It returns nil
+// without an error when the pick doesn't apply cleanly.
+func (pc *command) autoPickBranch(ctx context.Context, sha, branch string, trackedPR *cmd.TrackedPR) (*CherryPickResult, error) {
+	cherryPickBranch := cmd.PickBranchName(trackedPR.Number, branch)
+
+	trailers, err := pc.commitTrailers(trackedPR.Number, branch)
+	if err != nil {
+		return nil, err
+	}
+	prTitle, err := pc.Config.CherryPickTitle(trackedPR.Title, trackedPR.Number, pc.branchVersion(branch), branch)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "cherry-picker-auto-pick-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch worktree directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := runGit("", "worktree", "add", "--detach", dir, "origin/"+branch); err != nil {
+		return nil, fmt.Errorf("failed to create scratch worktree on origin/%s: %w", branch, err)
+	}
+	defer func() {
+		if err := runGit("", "worktree", "remove", "--force", dir); err != nil {
+			slog.Warn("Failed to remove scratch worktree", "dir", dir, "error", err)
+		}
+	}()
+
+	slog.Info("Trying clean cherry-pick", "pr", trackedPR.Number, "branch", branch, "sha", sha)
+	if err := runGit(dir, cherryPickArgs(sha, pc.gitSignoff())...); err != nil {
+		// Conflicts, an empty pick or a merge commit: leave it to the bot or pick
+		slog.Info("Cherry-pick doesn't apply cleanly, leaving branch pending", "pr", trackedPR.Number, "branch", branch, "error", err)
+		_ = runGit(dir, "cherry-pick", "--abort")
+		return nil, nil
+	}
+
+	pc.dir = dir
+	defer func() { pc.dir = "" }()
+	if pc.signoff() || len(trailers) > 0 {
+		if err := pc.moveSignedOffByLinesToEnd(trailers); err != nil {
+			return nil, fmt.Errorf("failed to reorder Signed-off-by lines: %w", err)
+		}
+	}
+
+	if !skipPushForDryRun("push branch", "branch", cherryPickBranch) {
+		if err := runGit(dir, "push", "origin", "HEAD:refs/heads/"+cherryPickBranch); err != nil {
+			return nil, fmt.Errorf("git push failed for branch %s: %w", cherryPickBranch, err)
+		}
+	}
+
+	cherryPickPR, err := pc.createCherryPickPR(ctx, cherryPickBranch, branch, trackedPR.Number, trackedPR.Title, prTitle)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "✅ Auto-picked PR #%d to %s: PR #%d\n", trackedPR.Number, branch, cherryPickPR.Number)
+
+	return &CherryPickResult{
+		PRNumber:         cherryPickPR.Number,
+		Title:            prTitle,
+		URL:              cherryPickPR.URL,
+		CIStatus:         "pending",
+		ResolutionMethod: cmd.ResolutionAutoPicked,
+	}, nil
+}
+
+// runGit runs git with args in dir ("" for the current directory). Its output
+// is progress, so it goes to stderr and stdout stays free for fetch --jsonl.
+func runGit(dir string, args ...string) error {
+	gitCmd := exec.Command("git", args...) //nolint:gosec // Arguments are branch names and SHAs from tracked config
+	gitCmd.Dir = dir
+	gitCmd.Stdout = os.Stderr
+	gitCmd.Stderr = os.Stderr
+	return gitCmd.Run()
+}
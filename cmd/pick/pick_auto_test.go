@@ -0,0 +1,114 @@
+package pick
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoPickClean_Integration(t *testing.T) {
+	originDir := setupTestGitRepo(t)
+	createCommit(t, originDir, "file1.txt", "line 1\n", "Initial commit")
+	gitCmd := exec.Command("git", "branch", "release-1.0")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+	cleanSHA := createCommit(t, originDir, "file2.txt", "new file\n", "Add file2")
+	conflictSHA := createCommit(t, originDir, "file1.txt", "line 1 on main\n", "Change file1")
+	gitCmd = exec.Command("git", "checkout", "release-1.0")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+	createCommit(t, originDir, "file1.txt", "line 1 on release\n", "Change file1 on release")
+
+	repoDir := setupTestGitRepo(t)
+	for _, args := range [][]string{
+		{"remote", "add", "origin", originDir},
+		{"fetch", "origin"},
+		{"checkout", "-b", "work", "origin/release-1.0"},
+	} {
+		gitCmd = exec.Command("git", args...)
+		gitCmd.Dir = repoDir
+		require.NoError(t, gitCmd.Run(), "git %v", args)
+	}
+
+	var created []map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/pulls/100", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 100, "merge_commit_sha": "` + cleanSHA + `"}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/pulls/200", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 200, "merge_commit_sha": "` + conflictSHA + `"}`))
+	})
+	mux.HandleFunc("POST /api/v3/repos/acme/widget/pulls", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		created = append(created, body)
+		_, _ = w.Write([]byte(`{"number": 901}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := github.NewEnterpriseClient(t.Context(), "test-token", server.URL+"/api/v3", github.HTTPOptions{})
+	require.NoError(t, err)
+	client = client.WithRepository("acme", "widget")
+
+	config := &cmd.Config{
+		Org:  "acme",
+		Repo: "widget",
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 100, Title: "Add file2", Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPending}}},
+			{Number: 200, Title: "Change file1", Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPending}}},
+		},
+	}
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+
+	picked, err := AutoPickClean(t.Context(), client, config, []int{200, 100})
+	require.NoError(t, err)
+
+	assert.Equal(t, []AutoPicked{{PR: 100, Branch: "release-1.0", CherryPickPR: 901}}, picked)
+	assert.Equal(t, cmd.BranchStatusPicked, config.TrackedPRs[0].Branches["release-1.0"].Status)
+	assert.Equal(t, cmd.ResolutionAutoPicked, config.TrackedPRs[0].Branches["release-1.0"].PR.ResolutionMethod)
+	assert.Equal(t, cmd.BranchStatusPending, config.TrackedPRs[1].Branches["release-1.0"].Status, "a conflicting pick stays pending")
+
+	require.Len(t, created, 1)
+	assert.Equal(t, "cherry-pick-100-release-1.0", created[0]["head"])
+	assert.Equal(t, "release-1.0", created[0]["base"])
+
+	// The pick was pushed with the -x reference and a sign-off
+	logCmd := exec.Command("git", "log", "-1", "--pretty=format:%B", "cherry-pick-100-release-1.0")
+	logCmd.Dir = originDir
+	message, err := logCmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(message), "(cherry picked from commit "+cleanSHA+")")
+	assert.Contains(t, string(message), "Signed-off-by: Test User <test@example.com>")
+
+	// The checkout itself is untouched and the scratch worktrees are gone
+	head, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "work", strings.TrimSpace(string(head)))
+	worktrees, err := exec.Command("git", "worktree", "list", "--porcelain").Output()
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(worktrees), "worktree "))
+}
+
+func TestAutoPickClean_NoCheckout(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+
+	_, err := AutoPickClean(t.Context(), nil, &cmd.Config{Org: "acme", Repo: "widget"}, []int{100})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs a local checkout of acme/widget")
+}
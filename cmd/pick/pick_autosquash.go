@@ -0,0 +1,132 @@
+package pick
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/alan/cherry-picker/cmd"
+)
+
+// pickCommits cherry-picks the PR onto the current branch: its merge commit
+// sha or, with --autosquash, each commit it merged followed by an autosquash
+// rebase. It reports how conflicts, if any, were resolved.
+func (pc *command) pickCommits(sha string) (cmd.ResolutionMethod, error) {
+	if !pc.Autosquash {
+		return pc.performCherryPick(sha)
+	}
+	return pc.performAutosquashPick(sha)
+}
+
+// performAutosquashPick cherry-picks the commits the merge commit sha brought
+// in one by one, then collapses their fixup!/squash! commits into their targets
+func (pc *command) performAutosquashPick(sha string) (cmd.ResolutionMethod, error) {
+	commits, err := mergedCommits(pc.dir, sha)
+	if err != nil {
+		return "", err
+	}
+	base, err := gitOutput(pc.dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	slog.Info("Cherry-picking the PR's commits for autosquash", "merge_commit", sha, "commits", len(commits))
+	resolution := cmd.ResolutionClean
+	for _, commit := range commits {
+		commitResolution, err := pc.performCherryPick(commit)
+		if err != nil {
+			return "", fmt.Errorf("cherry-pick of %s failed: %w", shortSHA(commit), err)
+		}
+		if commitResolution != cmd.ResolutionClean {
+			resolution = commitResolution
+		}
+	}
+
+	rebaseResolution, err := pc.autosquash(base, sha)
+	if err != nil {
+		return "", err
+	}
+	if rebaseResolution != cmd.ResolutionClean {
+		resolution = rebaseResolution
+	}
+	return resolution, nil
+}
+
+// mergedCommits returns, oldest first, the non-merge commits the merge commit
+// sha brought into its first parent. A squash or rebase merge isn't a merge
+// commit, so it has no such range and nothing to autosquash.
+func mergedCommits(dir, sha string) ([]string, error) {
+	parents, err := gitOutput(dir, "rev-list", "--parents", "-n", "1", sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the parents of %s: %w", shortSHA(sha), err)
+	}
+	if len(strings.Fields(parents)) < 3 {
+		return nil, fmt.Errorf("--autosquash needs a PR merged with a merge commit, but %s has a single parent (squash or rebase merge)", shortSHA(sha))
+	}
+
+	output, err := gitOutput(dir, "rev-list", "--reverse", "--no-merges", sha+"^1.."+sha+"^2")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the commits merged by %s: %w", shortSHA(sha), err)
+	}
+	commits := strings.Fields(output)
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("merge commit %s brought in no commits", shortSHA(sha))
+	}
+	return commits, nil
+}
+
+// autosquash rebases the commits picked since base with --autosquash, without
+// opening an editor. Conflicts the reordering causes go through the
+// conflict_strategy steps like those of the pick itself; sha is the PR's merge
+// commit, for the AI assistant's prompt.
+func (pc *command) autosquash(base, sha string) (cmd.ResolutionMethod, error) {
+	slog.Info("Squashing fixup commits", "onto", shortSHA(base))
+	resolution := cmd.ResolutionClean
+	err := pc.runRebase("-i", "--autosquash", base)
+	for err != nil {
+		conflicted, conflictErr := pc.getConflictedFiles()
+		if conflictErr != nil || len(conflicted) == 0 {
+			printRebaseHints()
+			return "", fmt.Errorf("autosquash rebase failed: %w", err)
+		}
+
+		slog.Warn("Autosquash rebase conflicts detected, attempting resolution", "conflicted_files", conflicted)
+		stepResolution, resolveErr := pc.resolveConflicts(sha)
+		if resolveErr != nil {
+			printRebaseHints()
+			return "", fmt.Errorf("autosquash rebase: %w", resolveErr)
+		}
+		resolution = stepResolution
+		err = pc.runRebase("--continue")
+	}
+	return resolution, nil
+}
+
+// runRebase runs git rebase with args. The todo list is taken as generated and
+// squash messages as combined, so no editor opens.
+func (pc *command) runRebase(args ...string) error {
+	rebaseCmd := exec.Command("git", append([]string{"rebase"}, args...)...) //nolint:gosec // Arguments are SHAs from git itself
+	rebaseCmd.Dir = pc.dir
+	rebaseCmd.Env = append(os.Environ(), "GIT_SEQUENCE_EDITOR=:", "GIT_EDITOR=true")
+	rebaseCmd.Stdout = os.Stderr
+	rebaseCmd.Stderr = os.Stderr
+	return rebaseCmd.Run()
+}
+
+// printRebaseHints tells the user how to finish an autosquash rebase pick gave up on
+func printRebaseHints() {
+	fmt.Fprintf(os.Stderr, "   - Run 'git rebase --abort' to keep the unsquashed commits, or resolve and 'git rebase --continue'\n")
+}
+
+// gitOutput runs git in dir and returns its trimmed stdout
+func gitOutput(dir string, args ...string) (string, error) {
+	gitCmd := exec.Command("git", args...) //nolint:gosec // Arguments are SHAs from tracked config or git itself
+	gitCmd.Dir = dir
+	output, err := gitCmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,99 @@
+package pick
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupMergedFixupPR commits a feature and its fixup on a branch merged with a
+// merge commit, and checks out a release branch cut before the merge. It
+// returns the merge commit.
+func setupMergedFixupPR(t *testing.T, repoDir string) string {
+	t.Helper()
+	base := createCommit(t, repoDir, "base.txt", "base\n", "Initial commit")
+	runGitIn(t, repoDir, "checkout", "-b", "feature")
+	createCommit(t, repoDir, "feature.txt", "feature with a typo\n", "Add feature")
+	createCommit(t, repoDir, "feature.txt", "feature\n", "fixup! Add feature")
+	runGitIn(t, repoDir, "checkout", "-")
+	createCommit(t, repoDir, "other.txt", "other\n", "Unrelated change")
+	runGitIn(t, repoDir, "merge", "--no-ff", "-m", "Merge feature", "feature")
+	mergeSHA, err := gitOutput(repoDir, "rev-parse", "HEAD")
+	require.NoError(t, err)
+	runGitIn(t, repoDir, "checkout", "-b", "release", base)
+	return mergeSHA
+}
+
+func TestMergedCommits_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	mergeSHA := setupMergedFixupPR(t, repoDir)
+
+	commits, err := mergedCommits(repoDir, mergeSHA)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	subject, err := gitOutput(repoDir, "log", "-1", "--format=%s", commits[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Add feature", subject, "oldest commit first")
+
+	_, err = mergedCommits(repoDir, commits[1])
+	require.ErrorContains(t, err, "needs a PR merged with a merge commit")
+}
+
+func TestPerformAutosquashPick_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+	mergeSHA := setupMergedFixupPR(t, repoDir)
+
+	pc := &command{Autosquash: true, NoSignoff: true}
+	resolution, err := pc.pickCommits(mergeSHA)
+	require.NoError(t, err)
+	assert.EqualValues(t, "clean", resolution)
+
+	log, err := gitOutput(repoDir, "log", "--format=%s", "release")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Add feature", "Initial commit"}, strings.Split(log, "\n"), "the fixup is squashed into its target")
+
+	content, err := os.ReadFile(filepath.Join(repoDir, "feature.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "feature\n", string(content))
+	_, statErr := os.Stat(filepath.Join(repoDir, "other.txt"))
+	assert.True(t, os.IsNotExist(statErr), "only the PR's commits are picked")
+}
+
+func TestPerformAutosquashPick_Conflict_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+	t.Setenv("GIT_EDITOR", "true")
+	mergeSHA := setupMergedFixupPR(t, repoDir)
+	createCommit(t, repoDir, "feature.txt", "release\n", "Release change")
+
+	pc := &command{Autosquash: true, NoSignoff: true}
+	pc.Config = &cmd.Config{
+		ConflictStrategy:    []cmd.ConflictStep{cmd.ConflictStepAutoResolve},
+		ConflictAutoResolve: map[string]cmd.ConflictSide{"feature.txt": cmd.ConflictSideTheirs},
+	}
+	resolution, err := pc.pickCommits(mergeSHA)
+	require.NoError(t, err)
+	assert.Equal(t, cmd.ResolutionAutoResolved, resolution)
+
+	log, err := gitOutput(repoDir, "log", "--format=%s", "release")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Add feature", "Release change", "Initial commit"}, strings.Split(log, "\n"))
+	content, err := os.ReadFile(filepath.Join(repoDir, "feature.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "feature\n", string(content))
+
+	status, err := exec.Command("git", "status", "--porcelain").Output()
+	require.NoError(t, err)
+	assert.Empty(t, string(status))
+}
@@ -0,0 +1,248 @@
+package pick
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/alan/cherry-picker/cmd"
+)
+
+// conflictSteps returns the conflict_strategy steps pick tries, in order
+func (pc *command) conflictSteps() ([]cmd.ConflictStep, error) {
+	if pc.Config == nil {
+		return []cmd.ConflictStep{cmd.ConflictStepAI}, nil
+	}
+	return pc.Config.ConflictSteps()
+}
+
+// resolveConflicts runs the conflict_strategy steps on the conflicted
+// cherry-pick of sha until no conflicts remain, then completes the pick. A step
+// that fails or leaves conflicts hands over to the next one; when the last one
+// does, the conflicts are left in the working tree for the user. It returns the
+// resolution method of the step that cleared the last conflicts.
+func (pc *command) resolveConflicts(sha string) (cmd.ResolutionMethod, error) {
+	steps, err := pc.conflictSteps()
+	if err != nil {
+		return "", err
+	}
+
+	for i, step := range steps {
+		slog.Info("Trying conflict resolution step", "step", step)
+		if err := pc.runConflictStep(step, sha); err != nil {
+			if errors.Is(err, errCherryPickAborted) {
+				return "", err
+			}
+			if i == len(steps)-1 {
+				printManualResolutionHints()
+				return "", fmt.Errorf("conflict resolution step %s failed: %w", step, err)
+			}
+			slog.Warn("Conflict resolution step failed, trying the next one", "step", step, "error", err)
+			continue
+		}
+
+		remainingConflicts, err := pc.getConflictedFiles()
+		if err != nil {
+			return "", fmt.Errorf("failed to check for remaining conflicts: %w", err)
+		}
+		if len(remainingConflicts) > 0 {
+			slog.Warn("Files still have conflicts", "step", step, "conflicted_files", remainingConflicts)
+			continue
+		}
+
+		if err := pc.completeCherryPick(slices.Contains(steps, cmd.ConflictStepRerere)); err != nil {
+			return "", err
+		}
+		slog.Info("Cherry-pick completed after resolving conflicts", "step", step)
+		return resolutionForStep(step), nil
+	}
+
+	printManualResolutionHints()
+	return "", fmt.Errorf("conflicts still remain after %s", joinSteps(steps))
+}
+
+// runConflictStep runs one conflict resolution step. Steps don't check what
+// conflicts remain afterwards; resolveConflicts does.
+func (pc *command) runConflictStep(step cmd.ConflictStep, sha string) error {
+	switch step {
+	case cmd.ConflictStepRerere:
+		return pc.replayRerere()
+	case cmd.ConflictStepAutoResolve:
+		return pc.autoResolvePaths()
+	case cmd.ConflictStepAI:
+		if err := pc.launchInteractiveAIAssistant(sha); err != nil {
+			return err
+		}
+		slog.Info("AI assistant session completed")
+		fmt.Fprintln(os.Stderr, "   - Assuming conflicts have been resolved during the AI session")
+		fmt.Fprintln(os.Stderr, "   - Checking if cherry-pick is complete...")
+		return nil
+	case cmd.ConflictStepManual:
+		return pc.waitForManualResolution()
+	default:
+		return fmt.Errorf("unknown conflict resolution step %q", step)
+	}
+}
+
+// resolutionForStep is the resolution method recorded for a pick whose
+// conflicts step cleared
+func resolutionForStep(step cmd.ConflictStep) cmd.ResolutionMethod {
+	switch step {
+	case cmd.ConflictStepRerere:
+		return cmd.ResolutionRerere
+	case cmd.ConflictStepAutoResolve:
+		return cmd.ResolutionAutoResolved
+	case cmd.ConflictStepManual:
+		return cmd.ResolutionManual
+	default:
+		return cmd.ResolutionAIAssisted
+	}
+}
+
+// joinSteps renders steps as "rerere, auto-resolve and ai"
+func joinSteps(steps []cmd.ConflictStep) string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = string(step)
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// printManualResolutionHints tells the user how to finish a pick whose
+// conflicts the strategy couldn't resolve
+func printManualResolutionHints() {
+	fmt.Fprintf(os.Stderr, "   - You can resolve conflicts manually using standard Git tools\n")
+	fmt.Fprintf(os.Stderr, "   - Run 'git cherry-pick --abort' to cancel, or resolve and 'git cherry-pick --continue'\n")
+}
+
+// completeCherryPick commits the resolved cherry-pick, unless a step already
+// did. With rerere the resolution is recorded for the next pick that hits the
+// same conflicts.
+func (*command) completeCherryPick(rerere bool) error {
+	if _, err := os.Stat(".git/CHERRY_PICK_HEAD"); os.IsNotExist(err) {
+		slog.Info("Cherry-pick appears to be already complete")
+		return nil
+	}
+
+	slog.Info("No conflicts remaining, completing cherry-pick commit")
+	var args []string
+	if rerere {
+		args = append(args, "-c", "rerere.enabled=true")
+	}
+	args = append(args, "cherry-pick", "--continue")
+	continueCmd := exec.Command("git", args...)
+	continueCmd.Stdout = os.Stdout
+	continueCmd.Stderr = os.Stderr
+	if err := continueCmd.Run(); err != nil {
+		return fmt.Errorf("failed to complete cherry-pick: %w", err)
+	}
+	return nil
+}
+
+// replayRerere applies the resolutions git rerere recorded for these conflicts
+// (even where rerere.enabled isn't set) and stages the files it fully resolved
+func (pc *command) replayRerere() error {
+	if err := runGit("", "-c", "rerere.enabled=true", "rerere"); err != nil {
+		return fmt.Errorf("git rerere failed: %w", err)
+	}
+
+	output, err := exec.Command("git", "-c", "rerere.enabled=true", "rerere", "remaining").Output()
+	if err != nil {
+		return fmt.Errorf("git rerere remaining failed: %w", err)
+	}
+	remaining := strings.Split(strings.TrimSpace(string(output)), "\n")
+
+	conflicted, err := pc.getConflictedFiles()
+	if err != nil {
+		return fmt.Errorf("failed to get conflicted files: %w", err)
+	}
+	var resolved []string
+	for _, file := range conflicted {
+		if !slices.Contains(remaining, file) {
+			resolved = append(resolved, file)
+		}
+	}
+	if len(resolved) == 0 {
+		slog.Info("git rerere has no recorded resolution for these conflicts")
+		return nil
+	}
+
+	slog.Info("Replayed recorded resolutions", "files", resolved)
+	return runGit("", append([]string{"add", "--"}, resolved...)...)
+}
+
+// autoResolvePaths checks out the configured side of every conflicted file
+// matching a conflict_auto_resolve pattern and stages it
+func (pc *command) autoResolvePaths() error {
+	if len(pc.Config.ConflictAutoResolve) == 0 {
+		return fmt.Errorf("no conflict_auto_resolve paths configured")
+	}
+
+	conflicted, err := pc.getConflictedFiles()
+	if err != nil {
+		return fmt.Errorf("failed to get conflicted files: %w", err)
+	}
+	for _, file := range conflicted {
+		side, ok := autoResolveSide(pc.Config.ConflictAutoResolve, file)
+		if !ok {
+			continue
+		}
+		slog.Info("Auto-resolving conflicted file", "file", file, "side", side)
+		if err := runGit("", "checkout", "--"+string(side), "--", file); err != nil {
+			// e.g. the file was deleted on that side
+			slog.Warn("Failed to auto-resolve file, leaving it conflicted", "file", file, "side", side, "error", err)
+			continue
+		}
+		if err := runGit("", "add", "--", file); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// autoResolveSide returns the side rules keep for file. A pattern matches the
+// whole path with path.Match or, when it has no slash, the file's base name.
+// Patterns are tried in sorted order and the first match wins.
+func autoResolveSide(rules map[string]cmd.ConflictSide, file string) (cmd.ConflictSide, bool) {
+	patterns := make([]string, 0, len(rules))
+	for pattern := range rules {
+		patterns = append(patterns, pattern)
+	}
+	slices.Sort(patterns)
+
+	for _, pattern := range patterns {
+		name := file
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(file)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return rules[pattern], true
+		}
+	}
+	return "", false
+}
+
+// waitForManualResolution lists the conflicted files and waits for the user to
+// resolve and stage them
+func (pc *command) waitForManualResolution() error {
+	conflicted, err := pc.getConflictedFiles()
+	if err != nil {
+		return fmt.Errorf("failed to get conflicted files: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "\n⚠️  %d file(s) have conflicts: %s\n", len(conflicted), strings.Join(conflicted, ", "))
+	fmt.Fprintln(os.Stderr, "Resolve and stage the conflicts in another terminal, then press Enter to continue...")
+	if _, err := bufio.NewReader(os.Stdin).ReadString('\n'); err != nil {
+		// No one to wait for (e.g. stdin closed)
+		return fmt.Errorf("can't wait for manual resolution: %w", err)
+	}
+	return nil
+}
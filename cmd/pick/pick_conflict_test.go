@@ -0,0 +1,131 @@
+package pick
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoResolveSide(t *testing.T) {
+	rules := map[string]cmd.ConflictSide{
+		"go.sum":         cmd.ConflictSideOurs,
+		"docs/*.md":      cmd.ConflictSideTheirs,
+		"*.generated.go": cmd.ConflictSideTheirs,
+	}
+
+	tests := []struct {
+		file string
+		side cmd.ConflictSide
+		ok   bool
+	}{
+		{file: "go.sum", side: cmd.ConflictSideOurs, ok: true},
+		{file: "tools/go.sum", side: cmd.ConflictSideOurs, ok: true},
+		{file: "docs/README.md", side: cmd.ConflictSideTheirs, ok: true},
+		{file: "docs/api/index.md", ok: false},
+		{file: "pkg/api/types.generated.go", side: cmd.ConflictSideTheirs, ok: true},
+		{file: "main.go", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			side, ok := autoResolveSide(rules, tt.file)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.side, side)
+		})
+	}
+}
+
+func TestJoinSteps(t *testing.T) {
+	assert.Equal(t, "ai", joinSteps([]cmd.ConflictStep{cmd.ConflictStepAI}))
+	assert.Equal(t, "rerere, auto-resolve and ai", joinSteps([]cmd.ConflictStep{cmd.ConflictStepRerere, cmd.ConflictStepAutoResolve, cmd.ConflictStepAI}))
+}
+
+// runGitIn runs git in dir and fails the test on error
+func runGitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Dir = dir
+	out, err := gitCmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestResolveConflicts_AutoResolve_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+	t.Setenv("GIT_EDITOR", "true")
+	setupCherryPickConflict(t, repoDir)
+
+	pc := &command{}
+	pc.Config = &cmd.Config{
+		ConflictStrategy:    []cmd.ConflictStep{cmd.ConflictStepAutoResolve},
+		ConflictAutoResolve: map[string]cmd.ConflictSide{"conflict.txt": cmd.ConflictSideTheirs},
+	}
+
+	resolution, err := pc.resolveConflicts("")
+	require.NoError(t, err)
+	assert.Equal(t, cmd.ResolutionAutoResolved, resolution)
+
+	content, err := os.ReadFile(filepath.Join(repoDir, "conflict.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "feature\n", string(content))
+	_, statErr := os.Stat(filepath.Join(repoDir, ".git", "CHERRY_PICK_HEAD"))
+	assert.True(t, os.IsNotExist(statErr), "cherry-pick should be committed")
+}
+
+func TestResolveConflicts_Unresolved_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+	setupCherryPickConflict(t, repoDir)
+
+	// No recorded resolution and no matching path: the conflicts are left for the user
+	pc := &command{}
+	pc.Config = &cmd.Config{
+		ConflictStrategy:    []cmd.ConflictStep{cmd.ConflictStepRerere, cmd.ConflictStepAutoResolve},
+		ConflictAutoResolve: map[string]cmd.ConflictSide{"*.lock": cmd.ConflictSideOurs},
+	}
+
+	_, err := pc.resolveConflicts("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts still remain after rerere and auto-resolve")
+
+	_, statErr := os.Stat(filepath.Join(repoDir, ".git", "CHERRY_PICK_HEAD"))
+	assert.NoError(t, statErr, "cherry-pick should still be in progress")
+}
+
+func TestResolveConflicts_Rerere_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+	t.Setenv("GIT_EDITOR", "true")
+	setupCherryPickConflict(t, repoDir)
+
+	// Record a resolution for the conflict, then start the same pick over
+	runGitIn(t, repoDir, "-c", "rerere.enabled=true", "rerere")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "conflict.txt"), []byte("merged\n"), 0644))
+	runGitIn(t, repoDir, "add", "conflict.txt")
+	runGitIn(t, repoDir, "-c", "rerere.enabled=true", "rerere")
+	runGitIn(t, repoDir, "cherry-pick", "--abort")
+	gitCmd := exec.Command("git", "cherry-pick", "feature")
+	gitCmd.Dir = repoDir
+	require.Error(t, gitCmd.Run(), "cherry-pick should conflict again")
+
+	pc := &command{}
+	pc.Config = &cmd.Config{ConflictStrategy: []cmd.ConflictStep{cmd.ConflictStepRerere, cmd.ConflictStepAI}}
+
+	resolution, err := pc.resolveConflicts("")
+	require.NoError(t, err)
+	assert.Equal(t, cmd.ResolutionRerere, resolution)
+
+	content, err := os.ReadFile(filepath.Join(repoDir, "conflict.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "merged\n", string(content))
+}
@@ -1,11 +1,19 @@
 package pick
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
 )
 
 // performGitFetch fetches the latest changes from remote
@@ -17,8 +25,40 @@ func (*command) performGitFetch() error {
 	return cmd.Run()
 }
 
-// checkoutBranch switches to the target branch and force updates it to match upstream
-func (*command) checkoutBranch(branch string) error {
+// resolveCommit returns the full SHA of a commit given by --sha, which must
+// exist in the local repository
+func resolveCommit(dir, sha string) (string, error) {
+	if strings.HasPrefix(sha, "-") {
+		return "", fmt.Errorf("invalid --sha %q", sha)
+	}
+	full, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", sha+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("commit %s not found locally after fetching origin; fetch the remote that has it first", sha)
+	}
+	return full, nil
+}
+
+// checkoutBranch switches to the target branch and force updates it to match upstream.
+// It refuses to discard local commits that aren't on the remote unless ForceReset is set,
+// and leaves the local branch as it is when NoReset is set
+func (pc *command) checkoutBranch(branch string) error {
+	if pc.NoReset {
+		return pc.checkoutLocalBranch(branch)
+	}
+
+	localOnly, err := pc.localOnlyCommits(branch)
+	if err != nil {
+		return err
+	}
+	if localOnly > 0 {
+		if !pc.ForceReset {
+			return fmt.Errorf("local branch %s has %d commit(s) not on origin/%s; push or move them elsewhere, or rerun with --force-reset to discard them",
+				branch, localOnly, branch)
+		}
+		fmt.Fprintf(os.Stderr, "⚠️  Discarding %d local commit(s) on %s that are not on origin/%s (--force-reset)\n", localOnly, branch, branch)
+		slog.Warn("Discarding local commits", "branch", branch, "commits", localOnly)
+	}
+
 	slog.Info("Checking out branch", "branch", branch)
 
 	checkoutCmd := exec.Command("git", "checkout", branch) //nolint:gosec // Branch name is from tracked config
@@ -39,6 +79,66 @@ func (*command) checkoutBranch(branch string) error {
 	return nil
 }
 
+// checkoutLocalBranch switches to the target branch without resetting it to upstream
+func (pc *command) checkoutLocalBranch(branch string) error {
+	localOnly, err := pc.localOnlyCommits(branch)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  --no-reset: picking onto local %s as it is; the PR's base is still origin/%s", branch, branch)
+	if localOnly > 0 {
+		fmt.Fprintf(os.Stderr, ", so its %d local-only commit(s) will show up in the PR", localOnly)
+	}
+	fmt.Fprintln(os.Stderr)
+	slog.Warn("Picking onto local branch without resetting to upstream", "branch", branch, "local_only_commits", localOnly)
+
+	checkoutCmd := exec.Command("git", "checkout", branch) //nolint:gosec // Branch name is from tracked config
+	checkoutCmd.Stdout = os.Stdout
+	checkoutCmd.Stderr = os.Stderr
+	if err := checkoutCmd.Run(); err != nil {
+		return fmt.Errorf("failed to checkout branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// localOnlyCommits counts the commits on the local branch that origin/<branch> doesn't have.
+// A branch with no local checkout has none
+func (*command) localOnlyCommits(branch string) (int, error) {
+	verifyCmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch) //nolint:gosec // Branch name is from tracked config
+	if verifyCmd.Run() != nil {
+		// --verify fails when there is no local branch yet
+		return 0, nil
+	}
+
+	countCmd := exec.Command("git", "rev-list", "--count", fmt.Sprintf("origin/%s..refs/heads/%s", branch, branch)) //nolint:gosec // Branch name is from tracked config
+	output, err := countCmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to compare local branch %s with origin: %w", branch, err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected rev-list output for %s: %w", branch, err)
+	}
+	return count, nil
+}
+
+// remoteBranchExists reports whether the branch exists on origin
+func (*command) remoteBranchExists(branch string) (bool, error) {
+	lsCmd := exec.Command("git", "ls-remote", "--exit-code", "--heads", "origin", "refs/heads/"+branch) //nolint:gosec // Branch name is from command arguments
+	err := lsCmd.Run()
+	if err == nil {
+		return true, nil
+	}
+
+	// --exit-code makes ls-remote exit 2 when no ref matched
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check remote for branch %s: %w", branch, err)
+}
+
 // createAndCheckoutBranch creates a new branch and checks it out, recreating if it already exists
 func (*command) createAndCheckoutBranch(branchName string) error {
 	slog.Info("Creating and checking out branch", "branch", branchName)
@@ -48,8 +148,10 @@ func (*command) createAndCheckoutBranch(branchName string) error {
 	_ = deleteLocalCmd.Run()
 
 	// Delete remote branch if it exists (ignore error if branch doesn't exist)
-	deleteRemoteCmd := exec.Command("git", "push", "origin", "--delete", branchName) //nolint:gosec // Branch name is from tracked config
-	_ = deleteRemoteCmd.Run()
+	if !skipPushForDryRun("delete remote branch", "branch", branchName) {
+		deleteRemoteCmd := exec.Command("git", "push", "origin", "--delete", branchName) //nolint:gosec // Branch name is from tracked config
+		_ = deleteRemoteCmd.Run()
+	}
 
 	// Create and checkout the new branch
 	cmd := exec.Command("git", "checkout", "-b", branchName) //nolint:gosec // Branch name is from tracked config
@@ -58,66 +160,77 @@ func (*command) createAndCheckoutBranch(branchName string) error {
 	return cmd.Run()
 }
 
-// performCherryPick executes the git cherry-pick command with AI integration for conflicts
-func (pc *command) performCherryPick(sha string) error {
-	slog.Info("Cherry-picking commit", "sha", sha)
-	cmd := exec.Command("git", "cherry-pick", "-x", "--signoff", sha) //nolint:gosec // Commit SHA is from tracked config
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	err := cmd.Run()
-	if err != nil {
-		if pc.isConflictError(err) {
-			slog.Warn("Cherry-pick conflicts detected, attempting AI-assisted resolution")
-
-			if resolveErr := pc.launchInteractiveAIAssistant(sha); resolveErr != nil {
-				slog.Error("Failed to launch AI assistant", "error", resolveErr)
-				fmt.Printf("   - You can resolve conflicts manually using standard Git tools\n")
-				fmt.Printf("   - Run 'git cherry-pick --abort' to cancel, or resolve and 'git cherry-pick --continue'\n")
-				return fmt.Errorf("cherry-pick failed and AI assistant launch failed: %w (original: %v)", resolveErr, err)
-			}
-
-			slog.Info("AI assistant session completed")
-			fmt.Println("   - Assuming conflicts have been resolved during the AI session")
-			fmt.Println("   - Checking if cherry-pick is complete...")
-
-			remainingConflicts, err := pc.getConflictedFiles()
-			if err != nil {
-				return fmt.Errorf("failed to check for remaining conflicts: %w", err)
-			}
-
-			if len(remainingConflicts) > 0 {
-				slog.Warn("Files still have conflicts after AI session", "conflicted_files", remainingConflicts)
-				fmt.Println("   - Please resolve these manually and run 'git cherry-pick --continue'")
-				fmt.Println("   - Or run 'git cherry-pick --abort' to cancel")
-				return fmt.Errorf("conflicts still remain after AI session")
-			}
-
-			if _, err := os.Stat(".git/CHERRY_PICK_HEAD"); os.IsNotExist(err) {
-				slog.Info("Cherry-pick appears to be already complete")
-				return nil
-			}
-
-			slog.Info("No conflicts remaining, completing cherry-pick commit")
-			continueCmd := exec.Command("git", "cherry-pick", "--continue")
-			continueCmd.Stdout = os.Stdout
-			continueCmd.Stderr = os.Stderr
-			if continueErr := continueCmd.Run(); continueErr != nil {
-				return fmt.Errorf("failed to complete cherry-pick: %w", continueErr)
-			}
-
-			slog.Info("Cherry-pick completed with AI-assisted conflict resolution")
-			return nil
+// performCherryPick executes the git cherry-pick command, resolving any conflicts
+// with the conflict_strategy steps. It reports how the pick was resolved: clean,
+// or the step that cleared the conflicts.
+func (pc *command) performCherryPick(sha string) (cmd.ResolutionMethod, error) {
+	slog.Info("Cherry-picking commit", "sha", sha, "signoff", pc.signoff())
+	gitCmd := exec.Command("git", cherryPickArgs(sha, pc.gitSignoff())...) //nolint:gosec // Commit SHA is from tracked config
+	gitCmd.Stdout = os.Stdout
+	gitCmd.Stderr = os.Stderr
+
+	if err := gitCmd.Run(); err != nil {
+		if !pc.isConflictError(err) {
+			return "", err
 		}
+		slog.Warn("Cherry-pick conflicts detected, attempting resolution")
+		return pc.resolveConflicts(sha)
+	}
 
-		return err
+	return cmd.ResolutionClean, nil
+}
+
+// cherryPickArgs returns the git arguments that cherry-pick sha, recording the
+// original commit (-x) and, with signoff, adding a Signed-off-by trailer
+func cherryPickArgs(sha string, signoff bool) []string {
+	args := []string{"cherry-pick", "-x"}
+	if signoff {
+		args = append(args, "--signoff")
 	}
+	return append(args, sha)
+}
 
-	return nil
+// signoff reports whether pick commits get a Signed-off-by trailer: not with
+// --no-signoff or signoff: false in the config
+func (pc *command) signoff() bool {
+	if pc.NoSignoff {
+		return false
+	}
+	return pc.Config == nil || pc.Config.SignoffEnabled()
+}
+
+// gitSignoff reports whether git cherry-pick --signoff adds the sign-off, in
+// git's committer identity. With signoff_identity the trailer is added by
+// commitTrailers instead.
+func (pc *command) gitSignoff() bool {
+	return pc.signoff() && (pc.Config == nil || pc.Config.SignoffIdentity == "")
+}
+
+// signoffIdentityPattern matches a "Name <email>" sign-off identity
+var signoffIdentityPattern = regexp.MustCompile(`^[^<>\n]+ <[^<>\s]+@[^<>\s]+>$`)
+
+// commitTrailers returns the trailers of a backport of originalPR onto branch:
+// the configured commit_trailers, then the Signed-off-by line of
+// signoff_identity when signing off as someone other than the committer
+func (pc *command) commitTrailers(originalPR int, branch string) ([]string, error) {
+	trailers, err := renderCommitTrailers(pc.Config.CommitTrailers, originalPR, branch)
+	if err != nil {
+		return nil, err
+	}
+	if identity := pc.Config.SignoffIdentity; identity != "" && pc.signoff() {
+		if !signoffIdentityPattern.MatchString(identity) {
+			return nil, fmt.Errorf("invalid signoff_identity %q (want \"Name <email>\")", identity)
+		}
+		trailers = append(trailers, "Signed-off-by: "+identity)
+	}
+	return trailers, nil
 }
 
 // pushBranch pushes a branch to origin
 func (*command) pushBranch(branchName string) error {
+	if skipPushForDryRun("push branch", "branch", branchName) {
+		return nil
+	}
 	slog.Info("Pushing branch", "branch", branchName)
 	cmd := exec.Command("git", "push", "origin", branchName) //nolint:gosec // Branch name is from tracked config
 	cmd.Stdout = os.Stdout
@@ -125,9 +238,22 @@ func (*command) pushBranch(branchName string) error {
 	return cmd.Run()
 }
 
-// moveSignedOffByLinesToEnd ensures Signed-off-by lines are at the end of the commit message
-func (*command) moveSignedOffByLinesToEnd() error {
+// skipPushForDryRun logs a change to origin that --dry-run leaves out and
+// reports whether to skip it. The local branch is still built, so the pick can
+// be inspected.
+func skipPushForDryRun(action string, args ...any) bool {
+	if !commands.DryRun() {
+		return false
+	}
+	slog.Info("Dry run: would "+action, args...)
+	return true
+}
+
+// moveSignedOffByLinesToEnd ensures Signed-off-by lines are at the end of the commit message,
+// followed by any extra trailers, so they all form the final trailer block
+func (pc *command) moveSignedOffByLinesToEnd(trailers []string) error {
 	getMessageCmd := exec.Command("git", "log", "-1", "--pretty=format:%B")
+	getMessageCmd.Dir = pc.dir
 	messageBytes, err := getMessageCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to get commit message: %w", err)
@@ -138,21 +264,58 @@ func (*command) moveSignedOffByLinesToEnd() error {
 		return nil
 	}
 
-	lines := strings.Split(originalMessage, "\n")
+	finalMessage, signedOffByLines := buildCommitMessage(originalMessage, trailers)
+
+	if len(signedOffByLines) > 0 {
+		slog.Info("Found Signed-off-by lines", "count", len(signedOffByLines))
+		for _, line := range signedOffByLines {
+			fmt.Fprintf(os.Stderr, "   %s\n", strings.TrimSpace(line))
+		}
+	}
+
+	if finalMessage != originalMessage {
+		slog.Info("Moving Signed-off-by lines and trailers to end of commit message", "trailers", len(trailers))
+
+		amendCmd := exec.Command("git", "commit", "--amend", "-m", finalMessage) //nolint:gosec // Commit message is from current git commit
+		amendCmd.Dir = pc.dir
+		amendCmd.Stdout = os.Stderr
+		amendCmd.Stderr = os.Stderr
+
+		if err := amendCmd.Run(); err != nil {
+			return fmt.Errorf("failed to amend commit message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildCommitMessage moves Signed-off-by lines to the end of message and appends
+// trailers after them. Lines already matching a trailer are moved rather than
+// repeated. It also returns the Signed-off-by lines it found.
+func buildCommitMessage(message string, trailers []string) (string, []string) {
+	isTrailer := make(map[string]bool, len(trailers))
+	for _, trailer := range trailers {
+		isTrailer[trailer] = true
+	}
+
+	lines := strings.Split(message, "\n")
 	var bodyLines []string
 	var signedOffByLines []string
 
 	for _, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmedLine, "Signed-off-by:") {
+		switch {
+		case isTrailer[trimmedLine]:
+			// Re-added below, after the Signed-off-by lines
+		case strings.HasPrefix(trimmedLine, "Signed-off-by:"):
 			signedOffByLines = append(signedOffByLines, line)
-		} else {
+		default:
 			bodyLines = append(bodyLines, line)
 		}
 	}
 
-	if len(signedOffByLines) == 0 {
-		return nil
+	if len(signedOffByLines) == 0 && len(trailers) == 0 {
+		return message, nil
 	}
 
 	// Remove trailing empty lines from body
@@ -160,48 +323,46 @@ func (*command) moveSignedOffByLinesToEnd() error {
 		bodyLines = bodyLines[:len(bodyLines)-1]
 	}
 
-	var newMessage strings.Builder
-
-	for i, line := range bodyLines {
-		newMessage.WriteString(line)
-		if i < len(bodyLines)-1 {
-			newMessage.WriteString("\n")
+	var footer []string
+	footer = append(footer, signedOffByLines...)
+	for _, trailer := range trailers {
+		if !slices.Contains(footer, trailer) {
+			footer = append(footer, trailer)
 		}
 	}
 
-	if len(bodyLines) > 0 && len(signedOffByLines) > 0 {
+	var newMessage strings.Builder
+	newMessage.WriteString(strings.Join(bodyLines, "\n"))
+	if len(bodyLines) > 0 {
 		newMessage.WriteString("\n\n")
 	}
+	newMessage.WriteString(strings.Join(footer, "\n"))
 
-	for i, line := range signedOffByLines {
-		newMessage.WriteString(line)
-		if i < len(signedOffByLines)-1 {
-			newMessage.WriteString("\n")
-		}
-	}
-
-	finalMessage := newMessage.String()
+	return newMessage.String(), signedOffByLines
+}
 
-	if len(signedOffByLines) > 0 {
-		slog.Info("Found Signed-off-by lines", "count", len(signedOffByLines))
-		for _, line := range signedOffByLines {
-			fmt.Printf("   %s\n", strings.TrimSpace(line))
+// renderCommitTrailers expands the configured trailer templates for a backport of originalPR onto branch
+func renderCommitTrailers(templates []string, originalPR int, branch string) ([]string, error) {
+	data := struct {
+		OriginalPR int
+		Branch     string
+	}{OriginalPR: originalPR, Branch: branch}
+
+	trailers := make([]string, 0, len(templates))
+	for _, text := range templates {
+		tmpl, err := template.New("trailer").Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit trailer %q: %w", text, err)
 		}
-	}
-
-	if finalMessage != originalMessage {
-		slog.Info("Moving Signed-off-by lines to end of commit message")
-
-		amendCmd := exec.Command("git", "commit", "--amend", "-m", finalMessage) //nolint:gosec // Commit message is from current git commit
-		amendCmd.Stdout = os.Stdout
-		amendCmd.Stderr = os.Stderr
-
-		if err := amendCmd.Run(); err != nil {
-			return fmt.Errorf("failed to amend commit message: %w", err)
+		var rendered strings.Builder
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			return nil, fmt.Errorf("failed to render commit trailer %q: %w", text, err)
+		}
+		if trailer := strings.TrimSpace(rendered.String()); trailer != "" {
+			trailers = append(trailers, trailer)
 		}
 	}
-
-	return nil
+	return trailers, nil
 }
 
 // getCommitInfo gets a human-readable description of a commit
@@ -276,12 +437,66 @@ func (*command) fetchPRBranch(prNumber int) error {
 	return nil
 }
 
-// forcePushBranch force pushes a local branch to a remote branch
-func (*command) forcePushBranch(localBranch, remoteBranch string) error {
-	slog.Info("Force pushing branch", "local", localBranch, "remote", remoteBranch)
+// forcePushBranch force pushes a local branch to a remote branch. With a non-empty
+// expectedSHA the push uses --force-with-lease, so git refuses it if the remote
+// branch no longer points at that commit.
+func (*command) forcePushBranch(localBranch, remoteBranch, expectedSHA string) error {
+	if skipPushForDryRun("force push branch", "local", localBranch, "remote", remoteBranch) {
+		return nil
+	}
+	slog.Info("Force pushing branch", "local", localBranch, "remote", remoteBranch, "lease", expectedSHA)
 	refSpec := fmt.Sprintf("%s:%s", localBranch, remoteBranch)
-	cmd := exec.Command("git", "push", "--force", "origin", refSpec) //nolint:gosec // Branch names are from tracked config
+	force := "--force"
+	if expectedSHA != "" {
+		force = fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", remoteBranch, expectedSHA)
+	}
+	cmd := exec.Command("git", "push", force, "origin", refSpec) //nolint:gosec // Branch names are from tracked config
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+// branchSHA returns the commit a local branch points at
+func (*command) branchSHA(branch string) (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--verify", "refs/heads/"+branch).Output() //nolint:gosec // Branch name is from tracked config
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve branch %s: %w", branch, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// remoteBranchSHA returns the commit a branch points at on origin, or "" if origin doesn't have it
+func (*command) remoteBranchSHA(branch string) (string, error) {
+	output, err := exec.Command("git", "ls-remote", "--heads", "origin", "refs/heads/"+branch).Output() //nolint:gosec // Branch name is from tracked config
+	if err != nil {
+		return "", fmt.Errorf("failed to read origin/%s: %w", branch, err)
+	}
+	sha, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\t")
+	return sha, nil
+}
+
+// checkRemoteUnchanged returns an error if the PR's remote branch moved away from
+// fetchedSHA, i.e. someone pushed to it while the amend was in progress
+func (pc *command) checkRemoteUnchanged(prNumber int, remoteBranch, fetchedSHA string) error {
+	current, err := pc.remoteBranchSHA(remoteBranch)
+	if err != nil {
+		return err
+	}
+	if current == fetchedSHA {
+		return nil
+	}
+	return fmt.Errorf("PR #%d's branch %s moved on origin since it was fetched (%s -> %s); not overwriting someone else's push. "+
+		"Your amendments are still on the local pr-%d branch; re-run pick --force to start from the new commits",
+		prNumber, remoteBranch, shortSHA(fetchedSHA), shortSHA(current), prNumber)
+}
+
+// shortSHA abbreviates a commit SHA for messages
+func shortSHA(sha string) string {
+	if sha == "" {
+		return "deleted"
+	}
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
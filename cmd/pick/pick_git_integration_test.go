@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alan/cherry-picker/cmd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -130,7 +131,7 @@ With some description.`,
 			pc := &command{}
 
 			// Move Signed-off-by lines
-			err := pc.moveSignedOffByLinesToEnd()
+			err := pc.moveSignedOffByLinesToEnd(nil)
 			require.NoError(t, err)
 
 			// Get the amended commit message
@@ -298,9 +299,10 @@ func TestCherryPickCleanApply_Integration(t *testing.T) {
 
 	// Cherry-pick the commit from feature branch
 	pc := &command{}
-	err := pc.performCherryPick(sha)
+	resolution, err := pc.performCherryPick(sha)
 
 	require.NoError(t, err)
+	assert.EqualValues(t, "clean", resolution, "clean cherry-pick should not report conflicts")
 
 	// Verify the file exists
 	content, err := os.ReadFile(filepath.Join(repoDir, "file2.txt"))
@@ -318,6 +320,53 @@ func TestCherryPickCleanApply_Integration(t *testing.T) {
 	assert.Contains(t, commitMsg, "Signed-off-by:")
 }
 
+// TestCherryPickSignoffSettings_Integration tests the pick commit's sign-off
+// when it is disabled or made in a configured identity
+func TestCherryPickSignoffSettings_Integration(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name    string
+		config  *cmd.Config
+		want    string
+		notWant string
+	}{
+		{name: "disabled", config: &cmd.Config{Signoff: &disabled, SignoffIdentity: "Release Bot <bot@example.com>"}, notWant: "Signed-off-by:"},
+		{name: "identity", config: &cmd.Config{SignoffIdentity: "Release Bot <bot@example.com>"}, want: "Signed-off-by: Release Bot <bot@example.com>", notWant: "Test User"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := setupTestGitRepo(t)
+			createCommit(t, repoDir, "file1.txt", "initial content\n", "Initial commit")
+			runGitIn(t, repoDir, "checkout", "-b", "feature")
+			sha := createCommit(t, repoDir, "file2.txt", "feature content\n", "Add feature file")
+			runGitIn(t, repoDir, "checkout", "-")
+
+			originalDir, _ := os.Getwd()
+			defer func() { _ = os.Chdir(originalDir) }()
+			require.NoError(t, os.Chdir(repoDir))
+
+			pc := &command{}
+			pc.Config = tt.config
+			_, err := pc.performCherryPick(sha)
+			require.NoError(t, err)
+			trailers, err := pc.commitTrailers(100, "release-1.0")
+			require.NoError(t, err)
+			if pc.signoff() || len(trailers) > 0 {
+				require.NoError(t, pc.moveSignedOffByLinesToEnd(trailers))
+			}
+
+			message, err := gitOutput(repoDir, "log", "-1", "--pretty=format:%B")
+			require.NoError(t, err)
+			assert.Contains(t, message, "(cherry picked from commit "+sha+")")
+			if tt.want != "" {
+				assert.True(t, strings.HasSuffix(message, tt.want), "sign-off goes last: %q", message)
+			}
+			assert.NotContains(t, message, tt.notWant)
+		})
+	}
+}
+
 // TestCommitMessageWithMultipleSignoffs_Integration tests complex commit messages
 func TestCommitMessageWithMultipleSignoffs_Integration(t *testing.T) {
 	repoDir := setupTestGitRepo(t)
@@ -342,7 +391,7 @@ More notes here.`
 	createCommit(t, repoDir, "complex.txt", "content", complexMessage)
 
 	pc := &command{}
-	err := pc.moveSignedOffByLinesToEnd()
+	err := pc.moveSignedOffByLinesToEnd(nil)
 	require.NoError(t, err)
 
 	// Get amended message
@@ -373,3 +422,317 @@ More notes here.`
 	assert.Equal(t, len(lines)-2, signoffIndices[0], "First Signed-off-by should be second-to-last line")
 	assert.Equal(t, len(lines)-1, signoffIndices[1], "Second Signed-off-by should be last line")
 }
+
+// TestTrackNewBranches_Integration tests that --track-new only adds branches that exist on origin
+func TestTrackNewBranches_Integration(t *testing.T) {
+	originDir := setupTestGitRepo(t)
+	createCommit(t, originDir, "file1.txt", "initial content\n", "Initial commit")
+	gitCmd := exec.Command("git", "branch", "release-1.0")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+
+	repoDir := setupTestGitRepo(t)
+	gitCmd = exec.Command("git", "remote", "add", "origin", originDir)
+	gitCmd.Dir = repoDir
+	require.NoError(t, gitCmd.Run())
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+
+	saves := 0
+	configFile := "test-config.yaml"
+	pc := &command{PRNumber: 123, TargetBranch: "release-1.0", TrackNew: true}
+	pc.ConfigFile = &configFile
+	pc.SaveConfig = func(_ string, _ *cmd.Config) error {
+		saves++
+		return nil
+	}
+
+	pr := &cmd.TrackedPR{Number: 123}
+	require.NoError(t, pc.trackNewBranches(pr, []string{"release-1.0"}))
+	assert.Equal(t, cmd.BranchStatusFailed, pr.Branches["release-1.0"].Status)
+	assert.Equal(t, 1, saves, "new entry should be persisted")
+
+	// Already tracked: nothing to add or save
+	require.NoError(t, pc.trackNewBranches(pr, []string{"release-1.0"}))
+	assert.Equal(t, 1, saves)
+
+	pc.TargetBranch = "release-9.9"
+	err := pc.trackNewBranches(pr, []string{"release-9.9"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist on the remote")
+	assert.NotContains(t, pr.Branches, "release-9.9")
+}
+
+func TestCheckoutBranch_LocalCommits_Integration(t *testing.T) {
+	originDir := setupTestGitRepo(t)
+	createCommit(t, originDir, "file1.txt", "initial content\n", "Initial commit")
+	gitCmd := exec.Command("git", "branch", "release-1.0")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+
+	repoDir := setupTestGitRepo(t)
+	for _, args := range [][]string{
+		{"remote", "add", "origin", originDir},
+		{"fetch", "origin"},
+		{"checkout", "-b", "release-1.0", "origin/release-1.0"},
+	} {
+		gitCmd = exec.Command("git", args...)
+		gitCmd.Dir = repoDir
+		require.NoError(t, gitCmd.Run(), "git %v", args)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+
+	pc := &command{}
+
+	// In sync with the remote: nothing to protect
+	require.NoError(t, pc.checkoutBranch("release-1.0"))
+
+	localSHA := createCommit(t, repoDir, "local.txt", "local work\n", "Local work")
+
+	err := pc.checkoutBranch("release-1.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 commit(s) not on origin/release-1.0")
+	assert.Contains(t, err.Error(), "--force-reset")
+
+	// The local commit must survive the refused reset
+	headCmd := exec.Command("git", "rev-parse", "HEAD")
+	head, err := headCmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, localSHA, strings.TrimSpace(string(head)))
+
+	pc.ForceReset = true
+	require.NoError(t, pc.checkoutBranch("release-1.0"))
+	count, err := pc.localOnlyCommits("release-1.0")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// No local branch at all
+	count, err = pc.localOnlyCommits("release-2.0")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestValidateBranchesOnRemote_Integration(t *testing.T) {
+	originDir := setupTestGitRepo(t)
+	createCommit(t, originDir, "file1.txt", "initial content\n", "Initial commit")
+	gitCmd := exec.Command("git", "branch", "release-1.0")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+
+	repoDir := setupTestGitRepo(t)
+	gitCmd = exec.Command("git", "remote", "add", "origin", originDir)
+	gitCmd.Dir = repoDir
+	require.NoError(t, gitCmd.Run())
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+
+	pc := &command{}
+	require.NoError(t, pc.validateBranchesOnRemote([]string{"release-1.0"}))
+
+	err := pc.validateBranchesOnRemote([]string{"release-1.0", "release-3.9"})
+	require.Error(t, err)
+	assert.Equal(t, "release-3.9 doesn't exist on origin — check the cherry-pick label", err.Error())
+
+	err = pc.validateBranchesOnRemote([]string{"release-3.8", "release-3.9"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "release-3.8, release-3.9 don't exist on origin")
+}
+
+func TestForcePushNoClobber_Integration(t *testing.T) {
+	originDir := setupTestGitRepo(t)
+	createCommit(t, originDir, "file1.txt", "initial content\n", "Initial commit")
+	gitCmd := exec.Command("git", "checkout", "-b", "cherry-pick-5")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+	createCommit(t, originDir, "file1.txt", "backport\n", "Backport")
+	// Leave origin on another branch so it accepts pushes to cherry-pick-5
+	gitCmd = exec.Command("git", "checkout", "-")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+
+	repoDir := setupTestGitRepo(t)
+	for _, args := range [][]string{
+		{"remote", "add", "origin", originDir},
+		{"fetch", "origin"},
+		{"checkout", "-b", "pr-5", "origin/cherry-pick-5"},
+	} {
+		gitCmd = exec.Command("git", args...)
+		gitCmd.Dir = repoDir
+		require.NoError(t, gitCmd.Run(), "git %v", args)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+
+	pc := &command{NoClobber: true}
+	fetchedSHA, err := pc.branchSHA("pr-5")
+	require.NoError(t, err)
+	require.NoError(t, pc.checkRemoteUnchanged(5, "cherry-pick-5", fetchedSHA))
+
+	// Someone else pushes to the PR branch while we amend locally
+	gitCmd = exec.Command("git", "checkout", "cherry-pick-5")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+	createCommit(t, originDir, "file2.txt", "reviewer fix\n", "Reviewer fix")
+	gitCmd = exec.Command("git", "checkout", "-")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+	createCommit(t, repoDir, "file1.txt", "amended\n", "Amended backport")
+
+	err = pc.checkRemoteUnchanged(5, "cherry-pick-5", fetchedSHA)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "moved on origin")
+	assert.Contains(t, err.Error(), "pr-5")
+
+	// The lease also stops the push itself
+	require.Error(t, pc.forcePushBranch("pr-5", "cherry-pick-5", fetchedSHA))
+
+	// Without --no-clobber the push overwrites, as before
+	require.NoError(t, pc.forcePushBranch("pr-5", "cherry-pick-5", ""))
+	localSHA, err := pc.branchSHA("pr-5")
+	require.NoError(t, err)
+	remoteSHA, err := pc.remoteBranchSHA("cherry-pick-5")
+	require.NoError(t, err)
+	assert.Equal(t, localSHA, remoteSHA)
+}
+
+// setupCherryPickConflict leaves repoDir mid cherry-pick with conflict.txt conflicted
+func setupCherryPickConflict(t *testing.T, repoDir string) {
+	t.Helper()
+	createCommit(t, repoDir, "conflict.txt", "base\n", "Initial commit")
+
+	gitCmd := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	gitCmd("checkout", "-b", "feature")
+	sha := createCommit(t, repoDir, "conflict.txt", "feature\n", "Feature change")
+	gitCmd("checkout", "-")
+	createCommit(t, repoDir, "conflict.txt", "release\n", "Release change")
+
+	cmd := exec.Command("git", "cherry-pick", sha)
+	cmd.Dir = repoDir
+	require.Error(t, cmd.Run(), "cherry-pick should conflict")
+}
+
+// writeAIScript writes an executable stand-in for the AI assistant and returns its path
+func writeAIScript(t *testing.T, body string) string {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "fake-ai")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\n"+body), 0755)) //nolint:gosec // test script must be executable
+	return script
+}
+
+func TestRunAIAssistantUntilResolved_CrashAfterResolving_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+	setupCherryPickConflict(t, repoDir)
+
+	// The assistant resolves the conflict, then crashes
+	script := writeAIScript(t, "echo resolved > conflict.txt && git add conflict.txt\nexit 1\n")
+	pc := &command{}
+	pc.Config = &cmd.Config{AIAssistantCommand: script}
+
+	require.NoError(t, pc.runAIAssistantUntilResolved())
+
+	conflicts, err := pc.getConflictedFiles()
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestRunAIAssistantUntilResolved_CrashWithConflicts_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+	setupCherryPickConflict(t, repoDir)
+
+	// With no answer on stdin, the conflicts are left for manual resolution
+	stdin, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer func() { _ = stdin.Close() }()
+	origStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = origStdin }()
+
+	script := writeAIScript(t, "exit 1\n")
+	pc := &command{}
+	pc.Config = &cmd.Config{AIAssistantCommand: script}
+
+	err = pc.runAIAssistantUntilResolved()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts remain in conflict.txt")
+
+	_, statErr := os.Stat(filepath.Join(repoDir, ".git", "CHERRY_PICK_HEAD"))
+	assert.NoError(t, statErr, "cherry-pick should still be in progress")
+}
+
+func TestCheckoutBranch_NoReset_Integration(t *testing.T) {
+	originDir := setupTestGitRepo(t)
+	createCommit(t, originDir, "file1.txt", "initial content\n", "Initial commit")
+	gitCmd := exec.Command("git", "branch", "release-1.0")
+	gitCmd.Dir = originDir
+	require.NoError(t, gitCmd.Run())
+
+	repoDir := setupTestGitRepo(t)
+	for _, args := range [][]string{
+		{"remote", "add", "origin", originDir},
+		{"fetch", "origin"},
+		{"checkout", "-b", "release-1.0", "origin/release-1.0"},
+	} {
+		gitCmd = exec.Command("git", args...)
+		gitCmd.Dir = repoDir
+		require.NoError(t, gitCmd.Run(), "git %v", args)
+	}
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(repoDir))
+
+	localSHA := createCommit(t, repoDir, "local.txt", "local work\n", "Local work")
+
+	// Leave the release branch so checkoutBranch has to switch back to it
+	gitCmd = exec.Command("git", "checkout", "-b", "scratch")
+	require.NoError(t, gitCmd.Run())
+
+	pc := &command{NoReset: true}
+	require.NoError(t, pc.checkoutBranch("release-1.0"))
+
+	// The local commit is kept and checked out
+	headCmd := exec.Command("git", "rev-parse", "HEAD")
+	head, err := headCmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, localSHA, strings.TrimSpace(string(head)))
+
+	branchCmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	branch, err := branchCmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "release-1.0", strings.TrimSpace(string(branch)))
+}
+
+func TestResolveCommit_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	sha := createCommit(t, repoDir, "file1.txt", "line 1\n", "Initial commit")
+
+	full, err := resolveCommit(repoDir, sha[:7])
+	require.NoError(t, err)
+	assert.Equal(t, sha, full, "a short SHA resolves to the full one")
+
+	_, err = resolveCommit(repoDir, "0123456789abcdef0123456789abcdef01234567")
+	require.ErrorContains(t, err, "not found locally after fetching origin")
+
+	_, err = resolveCommit(repoDir, "--all")
+	require.ErrorContains(t, err, "invalid --sha")
+}
@@ -3,14 +3,19 @@ package pick
 import (
 	"context"
 	"fmt"
-	"strings"
+	"os"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
 )
 
 // CherryPickResult holds the result of a cherry-pick operation
 type CherryPickResult struct {
-	PRNumber int
-	Title    string
-	CIStatus string
+	PRNumber         int
+	Title            string
+	URL              string // empty when the PR wasn't just created
+	CIStatus         string
+	ResolutionMethod cmd.ResolutionMethod
 }
 
 // getCommitSHA retrieves the merge commit SHA for a PR
@@ -27,22 +32,33 @@ func (pc *command) getCommitSHA(ctx context.Context, prNumber int) (string, erro
 	return pr.SHA, nil
 }
 
-// createCherryPickPR creates a PR for the cherry-pick using bot-style formatting
-func (pc *command) createCherryPickPR(ctx context.Context, headBranch, baseBranch string, originalPRNumber int, originalTitle string) (int, error) {
-	// Extract version from branch name (e.g., "release-3.7" -> "3.7")
-	version := strings.TrimPrefix(baseBranch, "release-")
+// branchVersion extracts the version from a release branch name for cherry-pick
+// titles (e.g., "release-3.7" -> "3.7"), falling back to the branch name itself
+func (pc *command) branchVersion(branch string) string {
+	if version, ok := pc.GitHubClient.LabelScheme().VersionForBranch(branch); ok {
+		return version
+	}
+	return branch
+}
 
-	// Title format matches bot: "<original-title> (cherry-pick #<pr> for <version>)"
-	prTitle := fmt.Sprintf("%s (cherry-pick #%d for %s)", originalTitle, originalPRNumber, version)
+// createCherryPickPR creates a PR for the cherry-pick titled prTitle, with a
+// bot-style body linking the original, as a draft with --draft
+func (pc *command) createCherryPickPR(ctx context.Context, headBranch, baseBranch string, originalPRNumber int, originalTitle, prTitle string) (*github.PR, error) {
 
-	// Body format matches bot: "Cherry-picked <original-title> (#<pr>)"
-	prDescription := fmt.Sprintf("Cherry-picked %s (#%d)", originalTitle, originalPRNumber)
+	// Body format matches bot: "Cherry-picked <original-title> (#<pr>)". The
+	// "Backport of #<pr>" line links the original even when title_format drops
+	// the cherry-pick reference from the title; fetch and verify-links read it.
+	prDescription := fmt.Sprintf("Cherry-picked %s (#%d)\n\nBackport of #%d", originalTitle, originalPRNumber, originalPRNumber)
 
-	prNumber, err := pc.GitHubClient.CreatePR(ctx, prTitle, prDescription, headBranch, baseBranch)
+	pr, err := pc.GitHubClient.CreatePR(ctx, prTitle, prDescription, headBranch, baseBranch, pc.Draft)
 	if err != nil {
-		return 0, fmt.Errorf("GitHub API error creating PR from %s to %s: %w", headBranch, baseBranch, err)
+		return nil, fmt.Errorf("GitHub API error creating PR from %s to %s: %w", headBranch, baseBranch, err)
 	}
 
-	fmt.Printf("📝 Created PR #%d: %s\n", prNumber, prTitle)
-	return prNumber, nil
+	if pc.Draft {
+		fmt.Fprintf(os.Stderr, "📝 Created draft PR #%d: %s\n", pr.Number, prTitle)
+	} else {
+		fmt.Fprintf(os.Stderr, "📝 Created PR #%d: %s\n", pr.Number, prTitle)
+	}
+	return pr, nil
 }
@@ -0,0 +1,63 @@
+package pick
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// appliesCleanly reports whether the PR's commits (sha, or with --autosquash
+// the commits it merged) cherry-pick onto base without conflicts. They are
+// tried with --no-commit in a scratch worktree, so neither the checkout nor the
+// remote is touched. Failures other than conflicts are returned as errors.
+func (pc *command) appliesCleanly(sha, base string) (bool, error) {
+	commits := []string{sha}
+	if pc.Autosquash {
+		var err error
+		if commits, err = mergedCommits(pc.dir, sha); err != nil {
+			return false, err
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "cherry-picker-precheck-")
+	if err != nil {
+		return false, fmt.Errorf("failed to create scratch worktree directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := runGit(pc.dir, "worktree", "add", "--detach", dir, base); err != nil {
+		return false, fmt.Errorf("failed to create scratch worktree on %s: %w", base, err)
+	}
+	defer func() {
+		if err := runGit(pc.dir, "worktree", "remove", "--force", dir); err != nil {
+			slog.Warn("Failed to remove scratch worktree", "dir", dir, "error", err)
+		}
+	}()
+
+	pickCmd := exec.Command("git", append([]string{"cherry-pick", "--no-commit"}, commits...)...) //nolint:gosec // Arguments are SHAs from GitHub or git itself
+	pickCmd.Dir = dir
+	output, pickErr := pickCmd.CombinedOutput()
+	if pickErr == nil {
+		return true, nil
+	}
+
+	conflicted, err := gitOutput(dir, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return false, fmt.Errorf("failed to list conflicted files: %w", err)
+	}
+	if conflicted == "" {
+		return false, fmt.Errorf("trial cherry-pick onto %s failed: %w: %s", base, pickErr, strings.TrimSpace(string(output)))
+	}
+	slog.Info("Cherry-pick would conflict", "base", base, "conflicted_files", strings.Fields(conflicted))
+	return false, nil
+}
+
+// pickBase is the ref a pick to branch starts from: origin/<branch>, which the
+// branch is reset to, or with --no-reset the local branch as it is
+func (pc *command) pickBase(branch string) string {
+	if pc.NoReset {
+		return branch
+	}
+	return "origin/" + branch
+}
@@ -0,0 +1,54 @@
+package pick
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppliesCleanly_Integration(t *testing.T) {
+	repoDir := setupTestGitRepo(t)
+	createCommit(t, repoDir, "file1.txt", "line 1\n", "Initial commit")
+	runGitIn(t, repoDir, "branch", "release-1.0")
+	cleanSHA := createCommit(t, repoDir, "file2.txt", "new file\n", "Add file2")
+	conflictSHA := createCommit(t, repoDir, "file1.txt", "line 1 on main\n", "Change file1")
+	runGitIn(t, repoDir, "checkout", "release-1.0")
+	releaseHead := createCommit(t, repoDir, "file1.txt", "line 1 on release\n", "Change file1 on release")
+
+	pc := &command{dir: repoDir}
+
+	clean, err := pc.appliesCleanly(cleanSHA, "release-1.0")
+	require.NoError(t, err)
+	assert.True(t, clean)
+
+	clean, err = pc.appliesCleanly(conflictSHA, "release-1.0")
+	require.NoError(t, err)
+	assert.False(t, clean)
+
+	_, err = pc.appliesCleanly("0000000000000000000000000000000000000000", "release-1.0")
+	require.Error(t, err, "a commit that can't be picked at all isn't a conflict")
+
+	// The checkout is untouched and the scratch worktrees are gone
+	head, err := gitOutput(repoDir, "rev-parse", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, releaseHead, head)
+	status, err := gitOutput(repoDir, "status", "--porcelain")
+	require.NoError(t, err)
+	assert.Empty(t, status)
+	listCmd := exec.Command("git", "worktree", "list", "--porcelain")
+	listCmd.Dir = repoDir
+	worktrees, err := listCmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(worktrees), "worktree "))
+}
+
+func TestPickBase(t *testing.T) {
+	pc := &command{}
+	assert.Equal(t, "origin/release-1.0", pc.pickBase("release-1.0"))
+
+	pc.NoReset = true
+	assert.Equal(t, "release-1.0", pc.pickBase("release-1.0"))
+}
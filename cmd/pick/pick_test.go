@@ -2,10 +2,15 @@ package pick
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -387,6 +392,22 @@ func TestValidatePickableStatus(t *testing.T) {
 	}
 }
 
+// TestTrackNewBranches_InvalidUsage tests --track-new argument checks that run before any git call
+func TestTrackNewBranches_InvalidUsage(t *testing.T) {
+	pr := &cmd.TrackedPR{Number: 123}
+
+	pc := &command{PRNumber: 123, TrackNew: true}
+	err := pc.trackNewBranches(pr, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a target branch")
+
+	pc = &command{PRNumber: 123, TargetBranch: "release-1.0", TrackNew: true, Force: true}
+	err = pc.trackNewBranches(pr, []string{"release-1.0"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with --force")
+	assert.Empty(t, pr.Branches)
+}
+
 // TestUpdatePRStatus tests the status update logic
 func TestUpdatePRStatus(t *testing.T) {
 	pc := &command{}
@@ -417,9 +438,10 @@ func TestUpdateSingleBranchStatus(t *testing.T) {
 	}
 
 	result := &CherryPickResult{
-		PRNumber: 456,
-		Title:    "Cherry-pick PR",
-		CIStatus: "pending",
+		PRNumber:         456,
+		Title:            "Cherry-pick PR",
+		CIStatus:         "pending",
+		ResolutionMethod: cmd.ResolutionAIAssisted,
 	}
 
 	pc.updateSingleBranchStatus(pr, "release-1.0", result)
@@ -429,6 +451,7 @@ func TestUpdateSingleBranchStatus(t *testing.T) {
 	assert.Equal(t, 456, pr.Branches["release-1.0"].PR.Number)
 	assert.Equal(t, "Cherry-pick PR", pr.Branches["release-1.0"].PR.Title)
 	assert.Equal(t, cmd.CIStatusPending, pr.Branches["release-1.0"].PR.CIStatus)
+	assert.Equal(t, cmd.ResolutionAIAssisted, pr.Branches["release-1.0"].PR.ResolutionMethod)
 }
 
 // TestCommandOutput tests command output formatting
@@ -445,3 +468,237 @@ func TestCommandOutput(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, buf.String(), "should generate help text")
 }
+
+// TestBuildCommitMessage tests that trailers are grouped after Signed-off-by lines at the end of the message
+func TestBuildCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		trailers []string
+		expected string
+	}{
+		{
+			name:     "trailer follows signoff",
+			message:  "Fix bug\n\nSigned-off-by: John Doe <john@example.com>",
+			trailers: []string{"Backport-of: #14944"},
+			expected: "Fix bug\n\nSigned-off-by: John Doe <john@example.com>\nBackport-of: #14944",
+		},
+		{
+			name:     "signoff in the middle is moved before trailers",
+			message:  "Fix bug\n\nSigned-off-by: John Doe <john@example.com>\nMore details",
+			trailers: []string{"Backport-of: #14944", "Branch: release-3.6"},
+			expected: "Fix bug\n\nMore details\n\nSigned-off-by: John Doe <john@example.com>\nBackport-of: #14944\nBranch: release-3.6",
+		},
+		{
+			name:     "no signoff",
+			message:  "Fix bug",
+			trailers: []string{"Backport-of: #14944"},
+			expected: "Fix bug\n\nBackport-of: #14944",
+		},
+		{
+			name:     "trailer already present is not repeated",
+			message:  "Fix bug\n\nSigned-off-by: John Doe <john@example.com>\nBackport-of: #14944",
+			trailers: []string{"Backport-of: #14944"},
+			expected: "Fix bug\n\nSigned-off-by: John Doe <john@example.com>\nBackport-of: #14944",
+		},
+		{
+			name:     "signoff trailer already present is not repeated",
+			message:  "Fix bug\n\nSigned-off-by: Release Bot <bot@example.com>\nSigned-off-by: John Doe <john@example.com>",
+			trailers: []string{"Signed-off-by: Release Bot <bot@example.com>"},
+			expected: "Fix bug\n\nSigned-off-by: John Doe <john@example.com>\nSigned-off-by: Release Bot <bot@example.com>",
+		},
+		{
+			name:     "nothing to do",
+			message:  "Fix bug\n\nDetails",
+			expected: "Fix bug\n\nDetails",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := buildCommitMessage(tt.message, tt.trailers)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+// TestRenderCommitTrailers tests template expansion of configured trailers
+func TestRenderCommitTrailers(t *testing.T) {
+	trailers, err := renderCommitTrailers([]string{"Backport-of: #{{.OriginalPR}}", "Target: {{.Branch}}"}, 14944, "release-3.6")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Backport-of: #14944", "Target: release-3.6"}, trailers)
+
+	trailers, err = renderCommitTrailers(nil, 14944, "release-3.6")
+	require.NoError(t, err)
+	assert.Empty(t, trailers)
+
+	_, err = renderCommitTrailers([]string{"Backport-of: #{{.OriginalPR"}, 14944, "release-3.6")
+	require.Error(t, err)
+
+	_, err = renderCommitTrailers([]string{"Backport-of: {{.Unknown}}"}, 14944, "release-3.6")
+	require.Error(t, err)
+}
+
+func TestParseAIRecoveryChoice(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   aiRecoveryChoice
+	}{
+		{answer: "r\n", want: aiRecoveryRelaunch},
+		{answer: "\n", want: aiRecoveryRelaunch},
+		{answer: "m\n", want: aiRecoveryManual},
+		{answer: " Manual \n", want: aiRecoveryManual},
+		{answer: "a\n", want: aiRecoveryAbort},
+		{answer: "ABORT\n", want: aiRecoveryAbort},
+		{answer: "what?\n", want: aiRecoveryRelaunch},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.answer), func(t *testing.T) {
+			assert.Equal(t, tt.want, parseAIRecoveryChoice(tt.answer))
+		})
+	}
+}
+
+func TestAutoLaunch(t *testing.T) {
+	pc := &command{}
+	pc.Config = &cmd.Config{}
+	assert.False(t, pc.autoLaunch(), "prompt stays the default")
+
+	pc.Yes = true
+	assert.True(t, pc.autoLaunch())
+
+	pc.Yes = false
+	pc.Config.AIAssistantAutoLaunch = true
+	assert.True(t, pc.autoLaunch())
+}
+
+func TestCherryPickArgs(t *testing.T) {
+	assert.Equal(t, []string{"cherry-pick", "-x", "--signoff", "abc123"}, cherryPickArgs("abc123", true))
+	assert.Equal(t, []string{"cherry-pick", "-x", "abc123"}, cherryPickArgs("abc123", false))
+}
+
+func TestSignoff(t *testing.T) {
+	disabled := false
+	enabled := true
+	tests := []struct {
+		name      string
+		config    *bool
+		noSignoff bool
+		want      bool
+	}{
+		{name: "default", want: true},
+		{name: "enabled in config", config: &enabled, want: true},
+		{name: "disabled in config", config: &disabled, want: false},
+		{name: "--no-signoff", noSignoff: true, want: false},
+		{name: "--no-signoff overrides config", config: &enabled, noSignoff: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &command{NoSignoff: tt.noSignoff}
+			pc.Config = &cmd.Config{Signoff: tt.config}
+			assert.Equal(t, tt.want, pc.signoff())
+		})
+	}
+}
+
+func TestCommitTrailers_SignoffIdentity(t *testing.T) {
+	disabled := false
+	identity := "Release Bot <bot@example.com>"
+
+	t.Run("signs off as the identity instead of git", func(t *testing.T) {
+		pc := &command{}
+		pc.Config = &cmd.Config{CommitTrailers: []string{"Backport-of: #{{.OriginalPR}}"}, SignoffIdentity: identity}
+
+		trailers, err := pc.commitTrailers(14944, "release-3.6")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Backport-of: #14944", "Signed-off-by: " + identity}, trailers)
+		assert.False(t, pc.gitSignoff())
+	})
+
+	t.Run("disabled sign-off adds nothing", func(t *testing.T) {
+		for _, pc := range []*command{{NoSignoff: true}, {}} {
+			pc.Config = &cmd.Config{SignoffIdentity: identity}
+			if !pc.NoSignoff {
+				pc.Config.Signoff = &disabled
+			}
+
+			trailers, err := pc.commitTrailers(14944, "release-3.6")
+			require.NoError(t, err)
+			assert.Empty(t, trailers)
+			assert.False(t, pc.gitSignoff())
+		}
+	})
+
+	t.Run("without an identity git signs off", func(t *testing.T) {
+		pc := &command{}
+		pc.Config = &cmd.Config{}
+
+		trailers, err := pc.commitTrailers(14944, "release-3.6")
+		require.NoError(t, err)
+		assert.Empty(t, trailers)
+		assert.True(t, pc.gitSignoff())
+	})
+
+	t.Run("rejects a malformed identity", func(t *testing.T) {
+		pc := &command{}
+		pc.Config = &cmd.Config{SignoffIdentity: "bot@example.com"}
+
+		_, err := pc.commitTrailers(14944, "release-3.6")
+		require.ErrorContains(t, err, "invalid signoff_identity")
+	})
+}
+
+func TestRunPick_NoResetFlagConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		pc      *command
+		wantErr string
+	}{
+		{name: "with --force", pc: &command{NoReset: true, Force: true}, wantErr: "--no-reset doesn't apply to --force"},
+		{name: "with --force-reset", pc: &command{NoReset: true, ForceReset: true}, wantErr: "--no-reset and --force-reset can't be used together"},
+		{name: "--sha with --force", pc: &command{SHA: "abc1234", Force: true}, wantErr: "--sha doesn't apply to --force"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pc.runPick(t.Context())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestCreateCherryPickPR_CustomTitleFormat tests that a pick whose title_format
+// drops the cherry-pick reference still links the original in its body
+func TestCreateCherryPickPR_CustomTitleFormat(t *testing.T) {
+	var created map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v3/repos/acme/widget/pulls", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&created)
+		_, _ = w.Write([]byte(`{"number": 901}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := github.NewEnterpriseClient(t.Context(), "test-token", server.URL+"/api/v3", github.HTTPOptions{})
+	require.NoError(t, err)
+
+	pc := &command{}
+	pc.GitHubClient = client.WithRepository("acme", "widget")
+	pc.Config = &cmd.Config{TitleFormat: "[{version}] {title}"}
+
+	prTitle, err := pc.Config.CherryPickTitle("Fix crash", 14894, "3.7", "release-3.7")
+	require.NoError(t, err)
+	require.False(t, github.ContainsCherryPickForPR(prTitle, 14894), "the title alone doesn't link the original")
+
+	pr, err := pc.createCherryPickPR(t.Context(), "cherry-pick-14894-release-3.7", "release-3.7", 14894, "Fix crash", prTitle)
+	require.NoError(t, err)
+	assert.Equal(t, 901, pr.Number)
+
+	assert.Equal(t, "[3.7] Fix crash", created["title"])
+	original, ok := github.ParseOriginalPRFromBody(created["body"].(string))
+	assert.True(t, ok)
+	assert.Equal(t, 14894, original)
+}
@@ -0,0 +1,140 @@
+// Package propagate implements the propagate command for carrying a release branch's backport set over to a new release branch.
+package propagate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the propagate command with common functionality
+type command struct {
+	commands.BaseCommand
+	FromBranch string
+	ToBranch   string
+}
+
+// NewPropagateCmd creates the propagate command
+func NewPropagateCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	propagateCmd := &command{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "propagate <from-branch> <to-branch>",
+		Short: "Carry the backport set of one release branch over to another",
+		Long: `Propagate tracked PRs from one release branch to a new one.
+
+For every PR tracked on the from-branch (in any status) that isn't tracked on
+the to-branch yet, this adds the cherry-pick label for the to-branch on GitHub
+(e.g. cherry-pick/4.1 for release-4.1) and seeds a 'pending' entry in the config,
+so the bot and fetch pick it up like any other labelled PR.
+
+Examples:
+  cherry-picker propagate release-4.0 release-4.1            # Label and track PRs for release-4.1
+  cherry-picker propagate release-4.0 release-4.1 --dry-run  # Show what would be labelled`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			propagateCmd.FromBranch = args[0]
+			propagateCmd.ToBranch = args[1]
+
+			// Initialize base command
+			propagateCmd.ConfigFile = globalConfigFile
+			propagateCmd.LoadConfig = loadConfig
+			propagateCmd.SaveConfig = saveConfig
+			if err := propagateCmd.Init(cobraCmd.Context()); err != nil {
+				return err
+			}
+
+			return propagateCmd.Run(cobraCmd.Context())
+		},
+	}
+
+	return cobraCmd
+}
+
+// Run executes the propagate command
+func (pc *command) Run(ctx context.Context) error {
+	if pc.FromBranch == pc.ToBranch {
+		return fmt.Errorf("from-branch and to-branch must differ (both are %s)", pc.FromBranch)
+	}
+	// Built from the config rather than the client, which a dry run doesn't need
+	scheme, err := github.ParseLabelScheme(pc.Config.LabelPrefix, pc.Config.BranchTemplate)
+	if err != nil {
+		return err
+	}
+	label, ok := scheme.LabelForBranch(pc.ToBranch)
+	if !ok {
+		return fmt.Errorf("to-branch %s is not a release branch (expected %s)", pc.ToBranch, scheme)
+	}
+
+	prs := pc.candidatePRs()
+	if len(prs) == 0 {
+		fmt.Fprintf(os.Stderr, "No PRs tracked on %s need propagating to %s\n", pc.FromBranch, pc.ToBranch)
+		return nil
+	}
+
+	if commands.DryRun() {
+		for _, pr := range prs {
+			fmt.Printf("Would add label %s to PR #%d: %s\n", label, pr.Number, pr.Title)
+		}
+		fmt.Printf("Dry run: %d PR(s) would be propagated from %s to %s\n", len(prs), pc.FromBranch, pc.ToBranch)
+		return nil
+	}
+
+	var errs []error
+	propagated := 0
+	for _, pr := range prs {
+		slog.Info("Adding cherry-pick label", "pr", pr.Number, "label", label)
+		if err := pc.GitHubClient.AddLabelsToPR(ctx, pr.Number, label); err != nil {
+			slog.Warn("Failed to propagate PR", "pr", pr.Number, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		// Seed the entry fetch would create from the new label
+		pr.Branches[pc.ToBranch] = cmd.BranchStatus{Status: cmd.BranchStatusPending}
+		propagated++
+	}
+
+	if propagated > 0 {
+		if err := pc.SaveConfig(*pc.ConfigFile, pc.Config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	commands.DisplayBulkOperationSuccess("propagate", propagated, errs, "")
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to propagate %d of %d PR(s)", len(errs), len(prs))
+	}
+	return nil
+}
+
+// candidatePRs returns the tracked PRs on FromBranch that aren't tracked (or ignored) on ToBranch yet, by PR number
+func (pc *command) candidatePRs() []*cmd.TrackedPR {
+	var prs []*cmd.TrackedPR
+	for i := range pc.Config.TrackedPRs {
+		pr := &pc.Config.TrackedPRs[i]
+		if _, onFrom := pr.Branches[pc.FromBranch]; !onFrom {
+			continue
+		}
+		if _, onTo := pr.Branches[pc.ToBranch]; onTo {
+			continue
+		}
+		if pr.IsBranchIgnored(pc.ToBranch) {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+	return prs
+}
@@ -0,0 +1,130 @@
+package propagate
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *cmd.Config {
+	return &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{
+			{
+				Number: 300,
+				Title:  "Already on both",
+				Branches: map[string]cmd.BranchStatus{
+					"release-4.0": {Status: cmd.BranchStatusMerged},
+					"release-4.1": {Status: cmd.BranchStatusPending},
+				},
+			},
+			{
+				Number: 200,
+				Title:  "Released on 4.0",
+				Branches: map[string]cmd.BranchStatus{
+					"release-4.0": {Status: cmd.BranchStatusReleased},
+				},
+			},
+			{
+				Number: 100,
+				Title:  "Failed on 4.0",
+				Branches: map[string]cmd.BranchStatus{
+					"release-3.9": {Status: cmd.BranchStatusMerged},
+					"release-4.0": {Status: cmd.BranchStatusFailed},
+				},
+			},
+			{
+				Number: 400,
+				Title:  "Only on 3.9",
+				Branches: map[string]cmd.BranchStatus{
+					"release-3.9": {Status: cmd.BranchStatusPicked},
+				},
+			},
+		},
+	}
+}
+
+// TestNewPropagateCmd tests command creation and argument validation
+func TestNewPropagateCmd(t *testing.T) {
+	configFile := "test-config.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+	saveConfig := func(_ string, _ *cmd.Config) error {
+		return nil
+	}
+
+	cobraCmd := NewPropagateCmd(&configFile, loadConfig, saveConfig)
+
+	assert.NotNil(t, cobraCmd)
+	// --dry-run is the global persistent flag, not a local one that would shadow it
+	assert.Nil(t, cobraCmd.Flags().Lookup("dry-run"))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"release-4.0"}))
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"release-4.0", "release-4.1"}))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"a", "b", "c"}))
+}
+
+// TestCandidatePRs tests that only PRs tracked on the from-branch and not yet on the to-branch are selected
+func TestCandidatePRs(t *testing.T) {
+	pc := &command{FromBranch: "release-4.0", ToBranch: "release-4.1"}
+	pc.Config = testConfig()
+
+	var numbers []int
+	for _, pr := range pc.candidatePRs() {
+		numbers = append(numbers, pr.Number)
+	}
+	assert.Equal(t, []int{100, 200}, numbers)
+}
+
+// TestCandidatePRs_SkipsIgnored tests that a PR ignored on the to-branch is not propagated
+func TestCandidatePRs_SkipsIgnored(t *testing.T) {
+	pc := &command{FromBranch: "release-4.0", ToBranch: "release-4.1"}
+	pc.Config = testConfig()
+	for i := range pc.Config.TrackedPRs {
+		if pc.Config.TrackedPRs[i].Number == 200 {
+			pc.Config.TrackedPRs[i].IgnoredBranches = []string{"release-4.1"}
+		}
+	}
+
+	var numbers []int
+	for _, pr := range pc.candidatePRs() {
+		numbers = append(numbers, pr.Number)
+	}
+	assert.Equal(t, []int{100}, numbers)
+}
+
+// TestRun_DryRun tests that a dry run changes neither the config nor GitHub
+func TestRun_DryRun(t *testing.T) {
+	saved := false
+	configFile := "test-config.yaml"
+	commands.SetDryRun(true)
+	t.Cleanup(func() { commands.SetDryRun(false) })
+
+	pc := &command{FromBranch: "release-4.0", ToBranch: "release-4.1"}
+	pc.ConfigFile = &configFile
+	pc.Config = testConfig()
+	pc.SaveConfig = func(_ string, _ *cmd.Config) error {
+		saved = true
+		return nil
+	}
+
+	// GitHubClient is nil, so any API call would panic
+	require.NoError(t, pc.Run(t.Context()))
+	assert.False(t, saved)
+	assert.Equal(t, testConfig(), pc.Config)
+}
+
+// TestRun_InvalidBranches tests argument validation
+func TestRun_InvalidBranches(t *testing.T) {
+	pc := &command{FromBranch: "release-4.0", ToBranch: "release-4.0"}
+	pc.Config = testConfig()
+	require.Error(t, pc.Run(t.Context()))
+
+	pc = &command{FromBranch: "release-4.0", ToBranch: "main"}
+	pc.Config = testConfig()
+	err := pc.Run(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a release branch")
+}
@@ -0,0 +1,91 @@
+// Package reconcile implements the reconcile-releases command for checking merged cherry-picks against releases.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/cmd/fetch"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the reconcile-releases command with common functionality
+type command struct {
+	commands.BaseCommand
+	Explain      bool
+	PRNumber     int
+	TargetBranch string
+}
+
+// NewReconcileReleasesCmd creates the reconcile-releases command
+func NewReconcileReleasesCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	reconcileCmd := &command{}
+
+	command := &cobra.Command{
+		Use:   "reconcile-releases [--explain <pr-number> <target-branch>]",
+		Short: "Mark merged cherry-picks found in a release as released",
+		Long: `Run the release detection step of fetch on its own: merged cherry-picks
+whose commit is in a release of their branch are marked released.
+
+With --explain, nothing is saved. Instead the detection is traced for one PR's
+branch and written to stdout: which releases were considered, which tag
+ranges a fetch compares, every commit in the branch's release ranges with
+whether it counts as the cherry-pick, and why it was or wasn't found.
+
+Examples:
+  cherry-picker reconcile-releases
+  cherry-picker reconcile-releases --explain 123 release-1.0`,
+		SilenceUsage: true,
+		Args: func(cobraCmd *cobra.Command, args []string) error {
+			if reconcileCmd.Explain {
+				return cobra.ExactArgs(2)(cobraCmd, args)
+			}
+			return cobra.NoArgs(cobraCmd, args)
+		},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if reconcileCmd.Explain {
+				prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+				if err != nil {
+					return err
+				}
+				reconcileCmd.PRNumber = prNumber
+				reconcileCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+			}
+
+			reconcileCmd.ConfigFile = globalConfigFile
+			reconcileCmd.LoadConfig = loadConfig
+			reconcileCmd.SaveConfig = saveConfig
+			if err := reconcileCmd.Init(cobraCmd.Context()); err != nil {
+				return err
+			}
+
+			return reconcileCmd.Run(cobraCmd.Context())
+		},
+	}
+
+	command.Flags().BoolVar(&reconcileCmd.Explain, "explain", false, "Trace release detection for one PR's branch instead of updating the config")
+
+	return command
+}
+
+// Run marks released cherry-picks, or with --explain traces the detection for one branch
+func (rc *command) Run(ctx context.Context) error {
+	if rc.Explain {
+		return fetch.ExplainRelease(ctx, os.Stdout, rc.GitHubClient, rc.Config, rc.PRNumber, rc.TargetBranch)
+	}
+
+	updated, unscanned := fetch.ReconcileReleases(ctx, rc.GitHubClient, rc.Config)
+	if updated {
+		if err := rc.SaveConfig(*rc.ConfigFile, rc.Config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+	if unscanned > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  %d release range(s) could not be scanned; they are retried on the next run\n", unscanned)
+	}
+	fmt.Fprintln(os.Stderr, "✅ Release status reconciled")
+	return nil
+}
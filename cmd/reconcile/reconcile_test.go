@@ -0,0 +1,31 @@
+package reconcile
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewReconcileReleasesCmd tests command creation and argument validation
+func TestNewReconcileReleasesCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+	saveConfig := func(_ string, _ *cmd.Config) error {
+		return nil
+	}
+
+	cobraCmd := NewReconcileReleasesCmd(&configFile, loadConfig, saveConfig)
+
+	assert.Equal(t, "reconcile-releases", cobraCmd.Name())
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{}))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123", "release-1.0"}), "a PR and branch need --explain")
+
+	require.NoError(t, cobraCmd.Flags().Set("explain", "true"))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"123", "release-1.0"}))
+	require.Error(t, cobraCmd.RunE(cobraCmd, []string{"invalid", "release-1.0"}))
+}
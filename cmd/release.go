@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/alan/cherry-picker/internal/github"
+)
+
+// ExpectedRelease returns the release tag a backport merged into branch should
+// ship in: the patch release after the branch's last checked release (v3.7.4 ->
+// v3.7.5) or, before fetch has seen any release of the branch, the first
+// release of its line (release-3.7 -> v3.7.0). Branch names follow
+// branch_template. It returns "" when neither can be worked out, e.g. for a
+// branch outside the template or one not named after an X.Y version.
+func (c *Config) ExpectedRelease(branch string) string {
+	if last := c.LastCheckedRelease[branch]; last != "" {
+		return NextReleaseTag(last)
+	}
+	scheme, err := github.ParseLabelScheme(c.LabelPrefix, c.BranchTemplate)
+	if err != nil {
+		return ""
+	}
+	line, ok := scheme.VersionForBranch(branch)
+	if !ok {
+		return ""
+	}
+	v, err := semver.NewVersion(line + ".0")
+	if err != nil || v.Patch() != 0 || strings.Count(line, ".") != 1 {
+		return ""
+	}
+	return fmt.Sprintf("v%d.%d.0", v.Major(), v.Minor())
+}
+
+// NextReleaseTag returns the patch release after tag, keeping its "v" prefix
+// if it has one, or "" when tag isn't a semver version
+func NextReleaseTag(tag string) string {
+	v, err := semver.NewVersion(tag)
+	if err != nil {
+		return ""
+	}
+	next := v.IncPatch()
+	if strings.HasPrefix(tag, "v") {
+		return "v" + next.String()
+	}
+	return next.String()
+}
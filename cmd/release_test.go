@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigExpectedRelease(t *testing.T) {
+	config := &Config{LastCheckedRelease: map[string]string{
+		"release-3.7": "v3.7.4",
+		"release-3.6": "3.6.9",
+		"release-3.5": "nightly",
+	}}
+
+	assert.Equal(t, "v3.7.5", config.ExpectedRelease("release-3.7"))
+	assert.Equal(t, "3.6.10", config.ExpectedRelease("release-3.6"))
+	assert.Empty(t, config.ExpectedRelease("release-3.5"), "last release isn't a version")
+	assert.Equal(t, "v3.8.0", config.ExpectedRelease("release-3.8"), "no release yet: first of the line")
+	assert.Empty(t, config.ExpectedRelease("release-3.8.1"))
+	assert.Empty(t, config.ExpectedRelease("stable"))
+
+	config = &Config{BranchTemplate: "stable/{version}"}
+	assert.Equal(t, "v3.8.0", config.ExpectedRelease("stable/3.8"), "branch_template names the branch")
+	assert.Empty(t, config.ExpectedRelease("release-3.8"))
+}
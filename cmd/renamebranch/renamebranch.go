@@ -0,0 +1,166 @@
+// Package renamebranch implements the rename-branch command for moving tracking from a renamed release branch to its new name.
+package renamebranch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the rename-branch command
+type command struct {
+	commands.BaseCommand
+	OldBranch string
+	NewBranch string
+	Repo      string
+}
+
+// NewRenameBranchCmd creates the rename-branch command. saveConfig must write
+// the config as is: the old branch's keys have to disappear from the file.
+func NewRenameBranchCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	renameCmd := &command{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "rename-branch <old> <new>",
+		Short: "Move tracking from a renamed release branch to its new name",
+		Long: `Rewrite every reference to a release branch renamed on the remote.
+
+Each tracked PR's branch entry for <old> moves to <new> with its status and
+cherry-pick PR untouched, as do ignored branches, the last checked release,
+unscanned release ranges, the tracker issue and required_checks. A PR already
+tracking <new> is an error and nothing is changed.
+
+With --repo org/repo, the tracking state of that further repository of
+cherry_picks.repositories is renamed instead of the top-level one.
+
+Examples:
+  cherry-picker rename-branch release-1.0 release-1.0.x`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			renameCmd.OldBranch = args[0]
+			renameCmd.NewBranch = args[1]
+			renameCmd.ConfigFile = globalConfigFile
+			renameCmd.LoadConfig = loadConfig
+			renameCmd.SaveConfig = saveConfig
+			config, err := loadConfig(*globalConfigFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			renameCmd.Config = config
+			return renameCmd.run()
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&renameCmd.Repo, "repo", "", "Rename the branch in this repository (org/repo) of cherry_picks.repositories")
+	return cobraCmd
+}
+
+// run renames the branch in the selected repository's view and saves the config
+func (rc *command) run() error {
+	i, ok := rc.Config.FindRepositoryView(rc.Repo)
+	if !ok {
+		return fmt.Errorf("repository %s is not tracked in %s", rc.Repo, *rc.ConfigFile)
+	}
+	view := rc.Config.RepositoryViews()[i]
+
+	renamed, err := Rename(view, rc.OldBranch, rc.NewBranch)
+	if err != nil {
+		return err
+	}
+	rc.Config.ApplyRepositoryView(i, view)
+
+	if err := rc.SaveConfig(*rc.ConfigFile, rc.Config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "🔀 Renamed %s to %s in %s/%s (%d tracked PR(s))\n", rc.OldBranch, rc.NewBranch, view.Org, view.Repo, renamed)
+	return nil
+}
+
+// Rename moves every reference to branch oldName in config's tracking state to
+// newName and returns how many tracked PRs it renamed the branch of. Nothing
+// is changed when it returns an error: oldName isn't referenced anywhere, or
+// newName already is somewhere oldName would move to.
+func Rename(config *cmd.Config, oldName, newName string) (int, error) {
+	if oldName == "" || newName == "" {
+		return 0, errors.New("branch names can't be empty")
+	}
+	if oldName == newName {
+		return 0, fmt.Errorf("%s is already the branch's name", oldName)
+	}
+
+	found := false
+	for _, pr := range config.TrackedPRs {
+		_, tracked := pr.Branches[oldName]
+		if tracked || pr.IsBranchIgnored(oldName) {
+			found = true
+		}
+		if _, clash := pr.Branches[newName]; clash && tracked {
+			return 0, fmt.Errorf("PR #%d already tracks %s", pr.Number, newName)
+		}
+	}
+	if err := errors.Join(
+		keyClash("last_checked_release", config.LastCheckedRelease, oldName, newName, &found),
+		keyClash("unscanned_releases", config.UnscannedReleases, oldName, newName, &found),
+		keyClash("tracker_issues", config.TrackerIssues, oldName, newName, &found),
+		keyClash("required_checks", config.RequiredChecks, oldName, newName, &found),
+	); err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("branch %s is not tracked", oldName)
+	}
+
+	renamed := 0
+	for i := range config.TrackedPRs {
+		pr := &config.TrackedPRs[i]
+		if status, ok := pr.Branches[oldName]; ok {
+			delete(pr.Branches, oldName)
+			pr.Branches[newName] = status
+			renamed++
+		}
+		if pr.IsBranchIgnored(oldName) {
+			pr.IgnoredBranches = slices.DeleteFunc(pr.IgnoredBranches, func(b string) bool {
+				return b == oldName
+			})
+			if !pr.IsBranchIgnored(newName) {
+				pr.IgnoredBranches = append(pr.IgnoredBranches, newName)
+				slices.Sort(pr.IgnoredBranches)
+			}
+			pr.IgnoredBranchesUpdated = time.Now()
+		}
+	}
+	renameKey(config.LastCheckedRelease, oldName, newName)
+	renameKey(config.UnscannedReleases, oldName, newName)
+	renameKey(config.TrackerIssues, oldName, newName)
+	renameKey(config.RequiredChecks, oldName, newName)
+	return renamed, nil
+}
+
+// keyClash reports an error when m, the config's key map, has both oldName and
+// newName, and sets *found when it has oldName
+func keyClash[V any](key string, m map[string]V, oldName, newName string, found *bool) error {
+	if _, ok := m[oldName]; !ok {
+		return nil
+	}
+	*found = true
+	if _, ok := m[newName]; ok {
+		return fmt.Errorf("%s already has an entry for %s", key, newName)
+	}
+	return nil
+}
+
+// renameKey moves m's oldName entry, if any, to newName
+func renameKey[V any](m map[string]V, oldName, newName string) {
+	if v, ok := m[oldName]; ok {
+		delete(m, oldName)
+		m[newName] = v
+	}
+}
@@ -0,0 +1,108 @@
+package renamebranch
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *cmd.Config {
+	return &cmd.Config{
+		Org:  "acme",
+		Repo: "widget",
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 1, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 10, CIStatus: cmd.CIStatusPassing}},
+				"release-2.0": {Status: cmd.BranchStatusPending},
+			}},
+			{Number: 2, Branches: map[string]cmd.BranchStatus{
+				"release-2.0": {Status: cmd.BranchStatusPending},
+			}, IgnoredBranches: []string{"release-1.0"}},
+		},
+		LastCheckedRelease: map[string]string{"release-1.0": "v1.0.3", "release-2.0": "v2.0.0"},
+		TrackerIssues:      map[string]int{"release-1.0": 42},
+		RequiredChecks:     map[string][]string{"release-1.0": {"test"}},
+	}
+}
+
+// TestRename tests that every reference to the old branch moves to the new one
+func TestRename(t *testing.T) {
+	config := testConfig()
+
+	renamed, err := Rename(config, "release-1.0", "release-1.0.x")
+	require.NoError(t, err)
+	assert.Equal(t, 1, renamed)
+
+	assert.Equal(t, map[string]cmd.BranchStatus{
+		"release-1.0.x": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 10, CIStatus: cmd.CIStatusPassing}},
+		"release-2.0":   {Status: cmd.BranchStatusPending},
+	}, config.TrackedPRs[0].Branches)
+	assert.Equal(t, []string{"release-1.0.x"}, config.TrackedPRs[1].IgnoredBranches)
+	assert.Equal(t, map[string]string{"release-1.0.x": "v1.0.3", "release-2.0": "v2.0.0"}, config.LastCheckedRelease)
+	assert.Equal(t, map[string]int{"release-1.0.x": 42}, config.TrackerIssues)
+	assert.Equal(t, map[string][]string{"release-1.0.x": {"test"}}, config.RequiredChecks)
+}
+
+// TestRename_Errors tests the renames refused without changing anything
+func TestRename_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		wantErr  string
+	}{
+		{name: "same name", old: "release-1.0", new: "release-1.0", wantErr: "already the branch's name"},
+		{name: "empty name", old: "release-1.0", new: "", wantErr: "can't be empty"},
+		{name: "untracked branch", old: "release-3.0", new: "release-3.0.x", wantErr: "branch release-3.0 is not tracked"},
+		{name: "PR already tracks new", old: "release-1.0", new: "release-2.0", wantErr: "PR #1 already tracks release-2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig()
+			_, err := Rename(config, tt.old, tt.new)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, testConfig(), config)
+		})
+	}
+
+	t.Run("key map clash", func(t *testing.T) {
+		config := testConfig()
+		config.TrackerIssues["release-1.0.x"] = 7
+		_, err := Rename(config, "release-1.0", "release-1.0.x")
+		require.ErrorContains(t, err, "tracker_issues already has an entry for release-1.0.x")
+		assert.Contains(t, config.TrackedPRs[0].Branches, "release-1.0")
+	})
+}
+
+// TestRun tests that the command renames the selected repository and saves
+func TestRun(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	config := testConfig()
+	config.Repositories = []cmd.RepoConfig{{
+		Org:  "acme",
+		Repo: "gadget",
+		TrackedPRs: []cmd.TrackedPR{{Number: 5, Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPending},
+		}}},
+	}}
+
+	saves := 0
+	rc := &command{OldBranch: "release-1.0", NewBranch: "release-1.0.x", Repo: "acme/gadget"}
+	rc.ConfigFile = &configFile
+	rc.Config = config
+	rc.SaveConfig = func(_ string, _ *cmd.Config) error {
+		saves++
+		return nil
+	}
+
+	require.NoError(t, rc.run())
+	assert.Equal(t, 1, saves)
+	assert.Contains(t, config.Repositories[0].TrackedPRs[0].Branches, "release-1.0.x")
+	assert.Contains(t, config.TrackedPRs[0].Branches, "release-1.0", "the top-level repository is left alone")
+
+	rc.Repo = "acme/unknown"
+	require.ErrorContains(t, rc.run(), "repository acme/unknown is not tracked")
+}
@@ -0,0 +1,125 @@
+// Package reopen implements the reopen command for reviving a cherry-pick PR that was closed without merging.
+package reopen
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the reopen command with common functionality
+type command struct {
+	commands.BaseCommand
+	PRNumber     int
+	TargetBranch string
+}
+
+// NewReopenCmd creates the reopen command
+func NewReopenCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	reopenCmd := &command{}
+
+	return &cobra.Command{
+		Use:   "reopen <pr-number> <target-branch>",
+		Short: "Reopen a cherry-pick PR that was closed without merging",
+		Long: `Reopen the cherry-pick PR tracked for a branch after it was closed by accident.
+
+Unlike picking again, this keeps the PR's review history. The branch goes back
+to picked and its CI status is read again. A merged PR can't be reopened.
+
+Examples:
+  cherry-picker reopen 123 release-1.0`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+			if err != nil {
+				return err
+			}
+			reopenCmd.PRNumber = prNumber
+			reopenCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+
+			reopenCmd.ConfigFile = globalConfigFile
+			reopenCmd.LoadConfig = loadConfig
+			reopenCmd.SaveConfig = saveConfig
+			if err := reopenCmd.Init(cobraCmd.Context()); err != nil {
+				return err
+			}
+
+			return reopenCmd.Run(cobraCmd.Context())
+		},
+	}
+}
+
+// Run reopens the branch's cherry-pick PR and marks the branch picked again
+func (rc *command) Run(ctx context.Context) error {
+	trackedPR, err := commands.FindAndValidatePR(rc.Config, rc.PRNumber)
+	if err != nil {
+		return err
+	}
+
+	pickPR, err := reopenTarget(trackedPR, rc.TargetBranch)
+	if err != nil {
+		return err
+	}
+
+	open, merged, err := rc.GitHubClient.GetPRState(ctx, pickPR.Number)
+	if err != nil {
+		return err
+	}
+	switch {
+	case merged:
+		return fmt.Errorf("cherry-pick PR #%d for %s was merged and can't be reopened", pickPR.Number, rc.TargetBranch)
+	case open:
+		fmt.Fprintf(os.Stderr, "Cherry-pick PR #%d for %s is already open\n", pickPR.Number, rc.TargetBranch)
+	default:
+		if err := rc.GitHubClient.ReopenPR(ctx, pickPR.Number); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "🔄 Reopened cherry-pick PR #%d for %s\n", pickPR.Number, rc.TargetBranch)
+	}
+
+	reopened := &cmd.PickPR{
+		Number:           pickPR.Number,
+		Title:            pickPR.Title,
+		CIStatus:         cmd.CIStatusUnknown,
+		ResolutionMethod: pickPR.ResolutionMethod,
+	}
+	details, err := rc.GitHubClient.GetPRWithDetails(ctx, pickPR.Number)
+	if err != nil {
+		slog.Warn("Failed to read CI status of reopened PR", "pr", pickPR.Number, "error", err)
+	} else {
+		reopened.Title = details.Title
+		reopened.CIStatus = cmd.ParseCIStatus(details.CIStatus)
+		reopened.RunAttempt = details.RunAttempt
+		reopened.FailingChecks = details.FailingChecks
+	}
+	trackedPR.Branches[rc.TargetBranch] = cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: reopened}
+
+	if err := rc.SaveConfig(*rc.ConfigFile, rc.Config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ PR #%d is picked again for %s (CI %s)\n", rc.PRNumber, rc.TargetBranch, reopened.CIStatus)
+	return nil
+}
+
+// reopenTarget returns the cherry-pick PR recorded for branch, or an error when
+// there is none or the branch is already merged
+func reopenTarget(trackedPR *cmd.TrackedPR, branch string) (*cmd.PickPR, error) {
+	status, exists := trackedPR.Branches[branch]
+	if !exists {
+		return nil, fmt.Errorf("branch %s is not tracked for PR #%d", branch, trackedPR.Number)
+	}
+	if status.Status == cmd.BranchStatusMerged || status.Status == cmd.BranchStatusReleased {
+		return nil, fmt.Errorf("PR #%d is already %s on %s; a merged PR can't be reopened", trackedPR.Number, status.Status, branch)
+	}
+	if status.PR == nil {
+		return nil, fmt.Errorf("no cherry-pick PR is recorded for PR #%d on %s; use pick to create one", trackedPR.Number, branch)
+	}
+	return status.PR, nil
+}
@@ -0,0 +1,61 @@
+package reopen
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewReopenCmd tests command creation and argument validation
+func TestNewReopenCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+	saveConfig := func(_ string, _ *cmd.Config) error {
+		return nil
+	}
+
+	cobraCmd := NewReopenCmd(&configFile, loadConfig, saveConfig)
+
+	assert.Equal(t, "reopen", cobraCmd.Name())
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"123", "release-1.0"}))
+	require.Error(t, cobraCmd.RunE(cobraCmd, []string{"invalid", "release-1.0"}))
+}
+
+// TestReopenTarget tests which tracked branches have a cherry-pick PR that can be reopened
+func TestReopenTarget(t *testing.T) {
+	pickPR := &cmd.PickPR{Number: 456, Title: "Fix bug (cherry-pick release-1.0)"}
+	trackedPR := &cmd.TrackedPR{
+		Number: 123,
+		Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPicked, PR: pickPR},
+			"release-1.1": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 457}},
+			"release-1.2": {Status: cmd.BranchStatusFailed},
+		},
+	}
+
+	got, err := reopenTarget(trackedPR, "release-1.0")
+	require.NoError(t, err)
+	assert.Equal(t, pickPR, got)
+
+	_, err = reopenTarget(trackedPR, "release-1.1")
+	require.ErrorContains(t, err, "can't be reopened")
+
+	_, err = reopenTarget(trackedPR, "release-1.2")
+	require.ErrorContains(t, err, "no cherry-pick PR")
+
+	_, err = reopenTarget(trackedPR, "release-2.0")
+	require.ErrorContains(t, err, "not tracked")
+}
+
+// TestCommand_Run_PRNotFound tests when the PR is not tracked
+func TestCommand_Run_PRNotFound(t *testing.T) {
+	rc := &command{PRNumber: 999, TargetBranch: "release-1.0"}
+	rc.Config = &cmd.Config{}
+
+	require.Error(t, rc.Run(t.Context()))
+}
@@ -3,12 +3,15 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/commands"
 	"github.com/alan/cherry-picker/internal/github"
+	"github.com/alan/cherry-picker/internal/poll"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +20,8 @@ type command struct {
 	commands.BaseCommand
 	PRNumber     int
 	TargetBranch string
+	Wait         WaitOptions
+	retried      []*retriedPR
 }
 
 // NewRetryCmd creates the retry command
@@ -31,10 +36,15 @@ func NewRetryCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config,
 This command will trigger a re-run of all failed CI jobs for picked PRs.
 Only works for PRs with failed CI status.
 
+With --wait, retry then polls the CI status of each retried cherry-pick PR
+until it passes or fails, or --timeout elapses, and prints the final states.
+It exits non-zero when any of them is still failing or didn't finish.
+
 Examples:
   cherry-picker retry                     # Retry failed CI for all eligible PRs and branches
   cherry-picker retry 123                # Retry failed CI for PR #123 on all branches
-  cherry-picker retry 123 release-1.0    # Retry failed CI for PR #123 on release-1.0`,
+  cherry-picker retry 123 release-1.0    # Retry failed CI for PR #123 on release-1.0
+  cherry-picker retry 123 --wait         # Retry and report whether CI now passes`,
 		Args:         cobra.RangeArgs(0, 2),
 		SilenceUsage: true,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
@@ -45,6 +55,9 @@ Examples:
 			}
 			retryCmd.PRNumber = prNumber
 			retryCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+			if err := ValidateWaitOptions(retryCmd.Wait); err != nil {
+				return err
+			}
 
 			// Initialize base command
 			retryCmd.ConfigFile = globalConfigFile
@@ -58,20 +71,46 @@ Examples:
 		},
 	}
 
+	AddWaitFlags(cobraCmd, &retryCmd.Wait)
+
 	return cobraCmd
 }
 
+// AddWaitFlags registers --wait and --timeout on a retry command
+func AddWaitFlags(cobraCmd *cobra.Command, opts *WaitOptions) {
+	cobraCmd.Flags().BoolVar(&opts.Enabled, "wait", false, "Wait for the retried CI to pass or fail and print the result")
+	cobraCmd.Flags().DurationVar(&opts.Timeout, "timeout", DefaultWaitTimeout, "How long --wait polls before giving up")
+}
+
+// ValidateWaitOptions checks the --wait flags
+func ValidateWaitOptions(opts WaitOptions) error {
+	if opts.Enabled && opts.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive, got %s", opts.Timeout)
+	}
+	return nil
+}
+
 // Execute runs the cherry-pick retry operation. base must already be
 // initialized (Config and GitHubClient populated). prNumber == 0 retries all
 // eligible PRs/branches; targetBranch may be "". Exposed for the unified retry
 // command's cherry/dep dispatch.
-func Execute(ctx context.Context, base commands.BaseCommand, prNumber int, targetBranch string) error {
-	rc := &command{BaseCommand: base, PRNumber: prNumber, TargetBranch: targetBranch}
+func Execute(ctx context.Context, base commands.BaseCommand, prNumber int, targetBranch string, wait WaitOptions) error {
+	rc := &command{BaseCommand: base, PRNumber: prNumber, TargetBranch: targetBranch, Wait: wait}
 	return rc.Run(ctx)
 }
 
-// Run executes the retry command
+// Run executes the retry command, then waits for the retried CI with --wait
 func (rc *command) Run(ctx context.Context) error {
+	err := rc.retry(ctx)
+	if !rc.Wait.Enabled {
+		return err
+	}
+	// Retries that did go through are still worth waiting for
+	return errors.Join(err, waitForRetried(ctx, os.Stdout, rc.retried, rc.Wait))
+}
+
+// retry triggers the re-runs for the selected PRs and branches
+func (rc *command) retry(ctx context.Context) error {
 	// If no PR number, retry all eligible PRs and branches
 	if rc.PRNumber == 0 {
 		return rc.retryAllEligiblePRs(ctx)
@@ -118,8 +157,9 @@ func (rc *command) retryBranchCI(ctx context.Context, trackedPR *cmd.TrackedPR,
 	return rc.retryBranchOperation(ctx, rc.GitHubClient, rc.Config, trackedPR, targetBranch, trackedPR.Branches[targetBranch])
 }
 
-// retryBranchOperation is the core operation for retrying CI on a single branch
-func (*command) retryBranchOperation(ctx context.Context, client *github.Client, _ *cmd.Config, trackedPR *cmd.TrackedPR, branchName string, branchStatus cmd.BranchStatus) error {
+// retryBranchOperation is the core operation for retrying CI on a single branch.
+// The retried cherry-pick PR is recorded for --wait.
+func (rc *command) retryBranchOperation(ctx context.Context, client *github.Client, _ *cmd.Config, trackedPR *cmd.TrackedPR, branchName string, branchStatus cmd.BranchStatus) error {
 	slog.Info("Retrying failed CI for PR", "original_pr", trackedPR.Number, "cherry_pick_pr", branchStatus.PR.Number, "branch", branchName)
 
 	err := client.RetryFailedWorkflows(ctx, branchStatus.PR.Number)
@@ -129,6 +169,10 @@ func (*command) retryBranchOperation(ctx context.Context, client *github.Client,
 	}
 
 	slog.Info("Successfully triggered retry for failed CI jobs", "original_pr", trackedPR.Number, "branch", branchName, "cherry_pick_pr", branchStatus.PR.Number)
+	rc.retried = append(rc.retried, &retriedPR{
+		CIPR:   poll.CIPR{OriginalPR: trackedPR.Number, Branch: branchName, CherryPickPR: branchStatus.PR.Number},
+		client: client,
+	})
 
 	return nil
 }
@@ -0,0 +1,81 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/alan/cherry-picker/internal/poll"
+)
+
+// DefaultWaitTimeout is how long --wait polls when --timeout isn't given
+const DefaultWaitTimeout = 30 * time.Minute
+
+// defaultPollInterval is how often --wait reads the CI status of the retried PRs
+const defaultPollInterval = 30 * time.Second
+
+// WaitOptions controls whether retry waits for the re-runs it triggered
+type WaitOptions struct {
+	Enabled      bool
+	Timeout      time.Duration
+	PollInterval time.Duration // defaultPollInterval when zero
+}
+
+// ciPoller is the part of github.Client --wait reads
+type ciPoller interface {
+	GetPR(ctx context.Context, number int) (*github.PR, error)
+	GetCIStatus(ctx context.Context, sha, baseBranch string) (string, error)
+}
+
+// retriedPR is a cherry-pick PR whose failed workflows retry re-ran
+type retriedPR struct {
+	poll.CIPR
+	client  ciPoller
+	headSHA string
+}
+
+// waitForRetried polls the CI status of the retried cherry-pick PRs until each
+// is passing or failing, or opts.Timeout elapses, and writes their final
+// states to w. It fails when any PR is still failing or didn't finish in time.
+func waitForRetried(ctx context.Context, w io.Writer, retried []*retriedPR, opts WaitOptions) error {
+	if len(retried) == 0 {
+		return nil
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	// The re-runs keep the PR's head commit, so its SHA is read once
+	prs := make([]*poll.CIPR, 0, len(retried))
+	byPR := make(map[*poll.CIPR]*retriedPR, len(retried))
+	for _, r := range retried {
+		pr, err := r.client.GetPR(ctx, r.CherryPickPR)
+		if err != nil {
+			return fmt.Errorf("failed to read cherry-pick PR #%d: %w", r.CherryPickPR, err)
+		}
+		r.headSHA = pr.HeadSHA
+		prs = append(prs, &r.CIPR)
+		byPR[&r.CIPR] = r
+	}
+
+	// Right after a retry the old failed runs may still be what GitHub
+	// reports, so the first read waits an interval
+	ciOpts := poll.CIOptions{Backoff: poll.Backoff{Initial: interval}, Timeout: opts.Timeout, Settle: true}
+	slog.Info("Waiting for retried CI to finish", "prs", len(retried), "timeout", opts.Timeout)
+	return poll.UntilCIFinished(ctx, w, prs, ciOpts, func(ctx context.Context, ci *poll.CIPR) (cmd.CIStatus, error) {
+		r := byPR[ci]
+		status, err := r.client.GetCIStatus(ctx, r.headSHA, r.Branch)
+		if err != nil {
+			return "", err
+		}
+		if poll.CIFinished(cmd.CIStatus(status)) {
+			slog.Info("Retried CI finished", "original_pr", r.OriginalPR, "branch", r.Branch, "cherry_pick_pr", r.CherryPickPR, "status", status)
+		}
+		return cmd.CIStatus(status), nil
+	})
+}
@@ -0,0 +1,94 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/alan/cherry-picker/internal/poll"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePoller answers GetCIStatus from a queue of statuses per head SHA
+type fakePoller struct {
+	statuses map[string][]string
+	reads    int
+	branches []string
+}
+
+func (*fakePoller) GetPR(_ context.Context, number int) (*github.PR, error) {
+	return &github.PR{Number: number, HeadSHA: fmt.Sprintf("head%d", number)}, nil
+}
+
+func (f *fakePoller) GetCIStatus(_ context.Context, sha, baseBranch string) (string, error) {
+	f.reads++
+	f.branches = append(f.branches, baseBranch)
+	queue := f.statuses[sha]
+	if len(queue) == 0 {
+		return "pending", nil
+	}
+	status := queue[0]
+	if len(queue) > 1 {
+		f.statuses[sha] = queue[1:]
+	}
+	return status, nil
+}
+
+func TestWaitForRetried(t *testing.T) {
+	opts := WaitOptions{Enabled: true, Timeout: time.Second, PollInterval: time.Millisecond}
+
+	t.Run("reports each PR once it finishes", func(t *testing.T) {
+		poller := &fakePoller{statuses: map[string][]string{
+			"head201": {"pending", "passing"},
+			"head202": {"failing"},
+		}}
+		retried := []*retriedPR{
+			{CIPR: poll.CIPR{OriginalPR: 100, Branch: "release-1.0", CherryPickPR: 201}, client: poller},
+			{CIPR: poll.CIPR{OriginalPR: 100, Branch: "release-1.1", CherryPickPR: 202}, client: poller},
+		}
+
+		var out bytes.Buffer
+		err := waitForRetried(t.Context(), &out, retried, opts)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CI failing on cherry-pick PR #202")
+		assert.Equal(t, "✅ PR #100 on release-1.0: CI passing (cherry-pick PR #201)\n"+
+			"❌ PR #100 on release-1.1: CI failing (cherry-pick PR #202)\n", out.String())
+		assert.Equal(t, 3, poller.reads, "finished PRs aren't read again")
+		assert.ElementsMatch(t, []string{"release-1.0", "release-1.1", "release-1.0"}, poller.branches, "read against each PR's base branch")
+	})
+
+	t.Run("passing PRs succeed", func(t *testing.T) {
+		poller := &fakePoller{statuses: map[string][]string{"head201": {"passing"}}}
+		retried := []*retriedPR{{CIPR: poll.CIPR{OriginalPR: 100, Branch: "release-1.0", CherryPickPR: 201}, client: poller}}
+
+		var out bytes.Buffer
+		require.NoError(t, waitForRetried(t.Context(), &out, retried, opts))
+	})
+
+	t.Run("times out while pending", func(t *testing.T) {
+		poller := &fakePoller{statuses: map[string][]string{}}
+		retried := []*retriedPR{{CIPR: poll.CIPR{OriginalPR: 100, Branch: "release-1.0", CherryPickPR: 201}, client: poller}}
+
+		var out bytes.Buffer
+		err := waitForRetried(t.Context(), &out, retried, WaitOptions{Enabled: true, Timeout: 20 * time.Millisecond, PollInterval: time.Millisecond})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out after 20ms waiting for CI on cherry-pick PR #201")
+		assert.Contains(t, out.String(), "⏳ PR #100 on release-1.0: CI not finished after 20ms")
+	})
+
+	t.Run("nothing retried", func(t *testing.T) {
+		require.NoError(t, waitForRetried(t.Context(), &bytes.Buffer{}, nil, opts))
+	})
+}
+
+func TestValidateWaitOptions(t *testing.T) {
+	require.NoError(t, ValidateWaitOptions(WaitOptions{}))
+	require.NoError(t, ValidateWaitOptions(WaitOptions{Enabled: true, Timeout: time.Minute}))
+	require.Error(t, ValidateWaitOptions(WaitOptions{Enabled: true}))
+}
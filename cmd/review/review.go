@@ -0,0 +1,182 @@
+// Package review implements the review command for comparing a cherry-pick PR's changes with its original PR's.
+package review
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// diffGetter is the part of github.Client review reads
+type diffGetter interface {
+	GetPRDiff(ctx context.Context, number int) (string, error)
+}
+
+// command encapsulates the review command with common functionality
+type command struct {
+	commands.BaseCommand
+	PRNumber     int
+	TargetBranch string
+}
+
+// fileDrift is a file whose changes differ between the original and the cherry-pick
+type fileDrift struct {
+	Path   string
+	Reason string
+}
+
+// NewReviewCmd creates the review command
+func NewReviewCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error)) *cobra.Command {
+	reviewCmd := &command{}
+
+	return &cobra.Command{
+		Use:   "review <pr-number> <branch>",
+		Short: "Check whether a cherry-pick PR makes the same changes as its original",
+		Long: `Compare the diff of a tracked PR's cherry-pick on a branch with the diff of
+the original PR, to find backports whose conflict resolution changed what they
+do.
+
+Both diffs are normalized before comparing: only the added and removed lines
+of each file count, so context lines, line numbers and trailing whitespace
+don't. Files that only one PR touches, or whose changes differ, are written to
+stdout and the command exits non-zero. Nothing is saved.
+
+Examples:
+  cherry-picker review 123 release-1.0`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+			if err != nil {
+				return err
+			}
+			reviewCmd.PRNumber = prNumber
+			reviewCmd.TargetBranch = commands.GetTargetBranchFromArgs(args)
+
+			reviewCmd.ConfigFile = globalConfigFile
+			reviewCmd.LoadConfig = loadConfig
+			if err := reviewCmd.Init(cobraCmd.Context()); err != nil {
+				return err
+			}
+
+			return reviewCmd.Run(cobraCmd.Context(), os.Stdout)
+		},
+	}
+}
+
+// Run compares the cherry-pick PR's diff with the original's and writes the result to w
+func (rc *command) Run(ctx context.Context, w io.Writer) error {
+	return review(ctx, rc.GitHubClient, rc.Config, rc.PRNumber, rc.TargetBranch, w)
+}
+
+// review looks up the branch's cherry-pick PR, compares both diffs and reports
+// the drift, returning an error when there is any
+func review(ctx context.Context, client diffGetter, config *cmd.Config, prNumber int, branch string, w io.Writer) error {
+	trackedPR, err := commands.FindAndValidatePR(config, prNumber)
+	if err != nil {
+		return err
+	}
+	if err := commands.ValidateTargetBranch(trackedPR, branch); err != nil {
+		return err
+	}
+	status := trackedPR.Branches[branch]
+	if status.PR == nil || status.PR.Number == 0 {
+		return fmt.Errorf("PR #%d has no cherry-pick PR on %s yet (status %s)", prNumber, branch, status.Status)
+	}
+	cherryPickPR := status.PR.Number
+
+	original, err := client.GetPRDiff(ctx, prNumber)
+	if err != nil {
+		return err
+	}
+	cherryPick, err := client.GetPRDiff(ctx, cherryPickPR)
+	if err != nil {
+		return err
+	}
+
+	drift := compareDiffs(original, cherryPick)
+	if len(drift) == 0 {
+		fmt.Fprintf(w, "✅ Cherry-pick #%d on %s makes the same changes as PR #%d\n", cherryPickPR, branch, prNumber)
+		return nil
+	}
+
+	fmt.Fprintf(w, "⚠️  Cherry-pick #%d on %s differs from PR #%d in %d file(s):\n", cherryPickPR, branch, prNumber, len(drift))
+	for _, d := range drift {
+		fmt.Fprintf(w, "  %s: %s\n", d.Path, d.Reason)
+	}
+	return fmt.Errorf("cherry-pick #%d differs from PR #%d; review the changes before merging", cherryPickPR, prNumber)
+}
+
+// compareDiffs compares two unified diffs file by file, in path order
+func compareDiffs(original, cherryPick string) []fileDrift {
+	originalFiles := parseDiff(original)
+	cherryPickFiles := parseDiff(cherryPick)
+
+	paths := make([]string, 0, len(originalFiles)+len(cherryPickFiles))
+	for path := range originalFiles {
+		paths = append(paths, path)
+	}
+	for path := range cherryPickFiles {
+		if _, ok := originalFiles[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	var drift []fileDrift
+	for _, path := range paths {
+		originalLines, inOriginal := originalFiles[path]
+		cherryPickLines, inCherryPick := cherryPickFiles[path]
+		switch {
+		case !inCherryPick:
+			drift = append(drift, fileDrift{Path: path, Reason: "only changed by the original PR"})
+		case !inOriginal:
+			drift = append(drift, fileDrift{Path: path, Reason: "only changed by the cherry-pick"})
+		case !slices.Equal(originalLines, cherryPickLines):
+			drift = append(drift, fileDrift{Path: path, Reason: "changes differ"})
+		}
+	}
+	return drift
+}
+
+// parseDiff normalizes a unified diff to each file's added and removed lines,
+// in order and without trailing whitespace, keyed by the file's new path.
+// Headers, hunk positions and context lines are dropped, as they differ
+// between branches without the change itself differing.
+func parseDiff(diff string) map[string][]string {
+	files := make(map[string][]string)
+	var path string
+	inHunk := false
+	for line := range strings.Lines(diff) {
+		line = strings.TrimRight(line, " \t\r\n")
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			path = diffPath(line)
+			files[path] = nil
+			inHunk = false
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk || path == "":
+			// File headers such as index, mode and ---/+++ lines
+		case strings.HasPrefix(line, "+"), strings.HasPrefix(line, "-"):
+			files[path] = append(files[path], line)
+		}
+	}
+	return files
+}
+
+// diffPath returns the new path from a "diff --git a/<old> b/<new>" line
+func diffPath(line string) string {
+	header := strings.TrimPrefix(line, "diff --git ")
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		return header[i+len(" b/"):]
+	}
+	return header
+}
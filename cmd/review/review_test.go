@@ -0,0 +1,154 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDiffGetter answers GetPRDiff from a map keyed by PR number
+type fakeDiffGetter struct {
+	diffs map[int]string
+	err   error
+}
+
+func (f *fakeDiffGetter) GetPRDiff(_ context.Context, number int) (string, error) {
+	return f.diffs[number], f.err
+}
+
+const originalDiff = `diff --git a/pkg/server.go b/pkg/server.go
+index 1111111..2222222 100644
+--- a/pkg/server.go
++++ b/pkg/server.go
+@@ -10,7 +10,7 @@ func serve() {
+ 	ctx := context.Background()
+-	timeout := 5 * time.Second
++	timeout := 30 * time.Second
+ 	run(ctx, timeout)
+ }
+diff --git a/docs/changelog.md b/docs/changelog.md
+index 3333333..4444444 100644
+--- a/docs/changelog.md
++++ b/docs/changelog.md
+@@ -1,2 +1,3 @@
+ # Changelog
++- Longer server timeout
+`
+
+// TestNewReviewCmd tests command creation and argument validation
+func TestNewReviewCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+
+	cobraCmd := NewReviewCmd(&configFile, loadConfig)
+
+	assert.Equal(t, "review", cobraCmd.Name())
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{"123", "release-1.0"}))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+}
+
+// TestCompareDiffs tests that only the changed lines of each file are compared
+func TestCompareDiffs(t *testing.T) {
+	t.Run("same changes at other positions with other context", func(t *testing.T) {
+		cherryPick := `diff --git a/pkg/server.go b/pkg/server.go
+index 5555555..6666666 100644
+--- a/pkg/server.go
++++ b/pkg/server.go
+@@ -42,7 +42,7 @@ func serve() {
+ 	ctx := context.TODO()
+-	timeout := 5 * time.Second
++	timeout := 30 * time.Second
+ 	run(ctx, timeout)
+diff --git a/docs/changelog.md b/docs/changelog.md
+--- a/docs/changelog.md
++++ b/docs/changelog.md
+@@ -8,2 +8,3 @@
+ ## v1.0.1
++- Longer server timeout
+`
+		assert.Empty(t, compareDiffs(originalDiff, cherryPick))
+	})
+
+	t.Run("changed, missing and extra files", func(t *testing.T) {
+		cherryPick := `diff --git a/pkg/server.go b/pkg/server.go
+--- a/pkg/server.go
++++ b/pkg/server.go
+@@ -10,7 +10,7 @@ func serve() {
+-	timeout := 5 * time.Second
++	timeout := 60 * time.Second
+diff --git a/pkg/legacy.go b/pkg/legacy.go
+--- a/pkg/legacy.go
++++ b/pkg/legacy.go
+@@ -1,1 +1,1 @@
+-var mode = "old"
++var mode = "new"
+`
+		assert.Equal(t, []fileDrift{
+			{Path: "docs/changelog.md", Reason: "only changed by the original PR"},
+			{Path: "pkg/legacy.go", Reason: "only changed by the cherry-pick"},
+			{Path: "pkg/server.go", Reason: "changes differ"},
+		}, compareDiffs(originalDiff, cherryPick))
+	})
+}
+
+// TestParseDiff tests the normalized form of a diff, including renames
+func TestParseDiff(t *testing.T) {
+	diff := `diff --git a/old.go b/new.go
+similarity index 90%
+rename from old.go
+rename to new.go
+--- a/old.go
++++ b/new.go
+@@ -1,3 +1,3 @@
+ package main
+-const a = 1
++const a = 2
+\ No newline at end of file
+`
+	assert.Equal(t, map[string][]string{"new.go": {"-const a = 1", "+const a = 2"}}, parseDiff(diff))
+	assert.Empty(t, parseDiff(""))
+}
+
+// TestReview tests the report written for equivalent and drifted cherry-picks
+func TestReview(t *testing.T) {
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{{
+			Number: 100,
+			Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 101}},
+				"release-1.1": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 102}},
+				"release-1.2": {Status: cmd.BranchStatusPending},
+			},
+		}},
+	}
+	client := &fakeDiffGetter{diffs: map[int]string{
+		100: originalDiff,
+		101: originalDiff,
+		102: "diff --git a/pkg/server.go b/pkg/server.go\n@@ -1 +1 @@\n+other\n",
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, review(t.Context(), client, config, 100, "release-1.0", &out))
+	assert.Equal(t, "✅ Cherry-pick #101 on release-1.0 makes the same changes as PR #100\n", out.String())
+
+	out.Reset()
+	err := review(t.Context(), client, config, 100, "release-1.1", &out)
+	require.ErrorContains(t, err, "cherry-pick #102 differs from PR #100")
+	assert.Equal(t, "⚠️  Cherry-pick #102 on release-1.1 differs from PR #100 in 2 file(s):\n"+
+		"  docs/changelog.md: only changed by the original PR\n"+
+		"  pkg/server.go: changes differ\n", out.String())
+
+	require.ErrorContains(t, review(t.Context(), client, config, 100, "release-1.2", &out), "has no cherry-pick PR on release-1.2")
+	require.ErrorContains(t, review(t.Context(), client, config, 100, "release-9.9", &out), "release-9.9")
+	require.ErrorContains(t, review(t.Context(), client, config, 999, "release-1.0", &out), "PR #999 not found")
+
+	client.err = errors.New("boom")
+	require.ErrorContains(t, review(t.Context(), client, config, 100, "release-1.0", &out), "boom")
+}
@@ -0,0 +1,120 @@
+// Package setrelease implements the set-release command for annotating a tracked PR with the release effort it belongs to.
+package setrelease
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// command encapsulates the set-release command
+type command struct {
+	commands.BaseCommand
+	PRNumber int
+	Release  string
+	Clear    bool
+}
+
+// NewSetReleaseCmd creates the set-release command
+func NewSetReleaseCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	setReleaseCmd := &command{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "set-release <pr-number> [release]",
+		Short: "Annotate a tracked PR with the release effort it targets",
+		Long: `Record which release effort a tracked PR belongs to, e.g. v3.7.5.
+
+status --release-label and summary --release-label then show only the PRs
+annotated with that release, so several release efforts can share one
+tracking file. Without a release argument the config's release_label is used.
+--clear removes the annotation.
+
+Examples:
+  cherry-picker set-release 123 v3.7.5
+  cherry-picker set-release 123          # Use release_label from the config
+  cherry-picker set-release 123 --clear`,
+		Args:         cobra.RangeArgs(1, 2),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, true)
+			if err != nil {
+				return err
+			}
+			setReleaseCmd.PRNumber = prNumber
+			if len(args) > 1 {
+				setReleaseCmd.Release = args[1]
+			}
+
+			setReleaseCmd.ConfigFile = globalConfigFile
+			setReleaseCmd.LoadConfig = loadConfig
+			setReleaseCmd.SaveConfig = saveConfig
+			config, err := loadConfig(*globalConfigFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			setReleaseCmd.Config = config
+			return setReleaseCmd.run()
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&setReleaseCmd.Clear, "clear", false, "Remove the PR's release annotation")
+
+	return cobraCmd
+}
+
+// run annotates the PR, or clears its annotation, and saves the config
+func (sc *command) run() error {
+	release, err := sc.release()
+	if err != nil {
+		return err
+	}
+
+	pr, err := commands.FindAndValidatePR(sc.Config, sc.PRNumber)
+	if err != nil {
+		return err
+	}
+	if pr.Release == release {
+		fmt.Fprintf(os.Stderr, "PR #%d is already %s\n", sc.PRNumber, describe(release))
+		return nil
+	}
+	pr.Release = release
+	pr.ReleaseUpdated = time.Now()
+
+	if err := sc.SaveConfig(*sc.ConfigFile, sc.Config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "🏷️  PR #%d is now %s\n", sc.PRNumber, describe(release))
+	return nil
+}
+
+// release resolves the annotation to set: "" with --clear, else the argument or
+// the config's release_label
+func (sc *command) release() (string, error) {
+	if sc.Clear {
+		if sc.Release != "" {
+			return "", errors.New("--clear takes no release argument")
+		}
+		return "", nil
+	}
+	if sc.Release != "" {
+		return sc.Release, nil
+	}
+	if sc.Config.ReleaseLabel != "" {
+		return sc.Config.ReleaseLabel, nil
+	}
+	return "", errors.New("no release given and release_label isn't set in the config")
+}
+
+// describe phrases a PR's release annotation for messages
+func describe(release string) string {
+	if release == "" {
+		return "not part of any release effort"
+	}
+	return "part of the " + release + " release effort"
+}
@@ -0,0 +1,65 @@
+package setrelease
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommand(config *cmd.Config, saved *int) *command {
+	configFile := "test.yaml"
+	sc := &command{}
+	sc.Config = config
+	sc.ConfigFile = &configFile
+	sc.SaveConfig = func(string, *cmd.Config) error {
+		*saved++
+		return nil
+	}
+	return sc
+}
+
+func TestRun(t *testing.T) {
+	config := &cmd.Config{
+		ReleaseLabel: "v3.7.5",
+		TrackedPRs:   []cmd.TrackedPR{{Number: 123}, {Number: 124, Release: "v3.6.9"}},
+	}
+	var saved int
+
+	sc := newTestCommand(config, &saved)
+	sc.PRNumber, sc.Release = 123, "v3.8.0"
+	require.NoError(t, sc.run())
+	assert.Equal(t, "v3.8.0", config.TrackedPRs[0].Release)
+	assert.False(t, config.TrackedPRs[0].ReleaseUpdated.IsZero(), "the change is stamped for the state merge")
+	assert.Equal(t, 1, saved)
+
+	sc = newTestCommand(config, &saved)
+	sc.PRNumber = 124
+	require.NoError(t, sc.run())
+	assert.Equal(t, "v3.7.5", config.TrackedPRs[1].Release, "defaults to release_label")
+
+	require.NoError(t, sc.run())
+	assert.Equal(t, 2, saved, "an unchanged annotation isn't saved")
+
+	sc = newTestCommand(config, &saved)
+	sc.PRNumber, sc.Clear = 123, true
+	require.NoError(t, sc.run())
+	assert.Empty(t, config.TrackedPRs[0].Release)
+}
+
+func TestRun_Errors(t *testing.T) {
+	var saved int
+
+	sc := newTestCommand(&cmd.Config{TrackedPRs: []cmd.TrackedPR{{Number: 123}}}, &saved)
+	sc.PRNumber = 123
+	require.ErrorContains(t, sc.run(), "release_label isn't set")
+
+	sc.Release, sc.Clear = "v3.7.5", true
+	require.ErrorContains(t, sc.run(), "--clear takes no release argument")
+
+	sc.Clear = false
+	sc.PRNumber = 999
+	require.Error(t, sc.run())
+	assert.Zero(t, saved)
+}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/cmd/fetch"
+	"github.com/alan/cherry-picker/internal/github"
 	"github.com/alan/cherry-picker/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -17,25 +19,73 @@ import (
 func NewStatusCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
 	var showReleased bool
 	var doFetch bool
+	var sortBy string
+	var output string
+	var watch bool
+	var interval time.Duration
+	var releaseLabel string
 
 	statusCmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show status of tracked PRs across target branches",
 		Long: `Display the current status of all tracked PRs.
 Shows which PRs are pending, picked, or merged for each target branch.
-By default, hides PRs that are completely released across all branches.`,
+By default, hides PRs that are completely released across all branches.
+
+With --output json, the same PRs are written to stdout as a JSON document with
+a summary of the branch counts, for dashboards and scripts. With --output html,
+they are written as a self-contained HTML page with a table of PRs by branch.
+
+With --watch, the status is redrawn every --interval (re-fetching first when
+--fetch is given) until every tracked PR is released or Ctrl-C is pressed.
+
+With --release-label (or release_label in the config), only the PRs that
+set-release annotated with that release effort are shown; --release-label ""
+shows every PR despite release_label.`,
 		RunE: func(cobraCmd *cobra.Command, _ []string) error {
-			return runStatus(cobraCmd.Context(), *globalConfigFile, loadConfig, saveConfig, showReleased, doFetch)
+			var release *string
+			if cobraCmd.Flags().Changed("release-label") {
+				release = &releaseLabel
+			}
+			order, err := ParseSortOrder(sortBy)
+			if err != nil {
+				return err
+			}
+			format, err := ParseOutputFormat(output)
+			if err != nil {
+				return err
+			}
+			if !watch {
+				return runStatus(cobraCmd.Context(), *globalConfigFile, loadConfig, saveConfig, showReleased, doFetch, order, format, release)
+			}
+			if format != OutputText {
+				return fmt.Errorf("--watch only works with text output")
+			}
+			return Watch(cobraCmd.Context(), os.Stdout, interval, func(ctx context.Context) (bool, error) {
+				if err := runStatus(ctx, *globalConfigFile, loadConfig, saveConfig, showReleased, doFetch, order, format, release); err != nil {
+					return false, err
+				}
+				config, err := loadConfig(*globalConfigFile)
+				if err != nil {
+					return false, fmt.Errorf("failed to load config: %w", err)
+				}
+				return AllReleased(config.ScopedToRelease(config.ReleaseScope(release))), nil
+			})
 		},
 	}
 
 	statusCmd.Flags().BoolVar(&showReleased, "show-released", false, "Show PRs that are completely released")
 	statusCmd.Flags().BoolVar(&doFetch, "fetch", false, "Fetch latest data from GitHub before showing status")
+	statusCmd.Flags().StringVar(&output, "output", string(OutputText), "Output format: text, json or html")
+	statusCmd.Flags().StringVar(&sortBy, "sort", string(SortByNumber), "Order PRs by number, status (most actionable first) or ci (failing CI first)")
+	statusCmd.Flags().BoolVar(&watch, "watch", false, "Redraw the status every --interval until all PRs are released or Ctrl-C")
+	statusCmd.Flags().DurationVar(&interval, "interval", DefaultWatchInterval, "How often --watch redraws (and fetches, with --fetch)")
+	statusCmd.Flags().StringVar(&releaseLabel, "release-label", "", "Only show PRs annotated with this release effort by set-release (default release_label from the config)")
 
 	return statusCmd
 }
 
-func runStatus(ctx context.Context, configFile string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error, showReleased bool, doFetch bool) error {
+func runStatus(ctx context.Context, configFile string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error, showReleased bool, doFetch bool, order SortOrder, format OutputFormat, release *string) error {
 	config, err := loadConfig(configFile)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -52,6 +102,14 @@ func runStatus(ctx context.Context, configFile string, loadConfig func(string) (
 			return fmt.Errorf("failed to reload config after fetch: %w", err)
 		}
 	}
+	config = config.ScopedToRelease(config.ReleaseScope(release))
+
+	switch format {
+	case OutputJSON:
+		return RenderJSON(os.Stdout, config, showReleased, order)
+	case OutputHTML:
+		return RenderHTML(os.Stdout, config, showReleased, order)
+	}
 
 	if len(config.TrackedPRs) == 0 {
 		fmt.Println("No PRs to track.")
@@ -70,20 +128,37 @@ func runStatus(ctx context.Context, configFile string, loadConfig func(string) (
 		return nil
 	}
 
-	sortPRsByNumber(prsToDisplay)
+	sortPRs(prsToDisplay, order)
 	displayRepositoryHeader(config)
 	displayAllPRStatuses(prsToDisplay, config, configFile)
-	displayStatusSummary(prsToDisplay)
+	displayStatusSummary(prsToDisplay, config)
 
 	return nil
 }
 
 // Render writes the cherry-pick status section for config to stdout. Exposed
 // so the unified status command can show cherry-picks and dependencies
-// together. showReleased includes fully-released PRs.
-func Render(config *cmd.Config, configFile string, showReleased bool) {
+// together. showReleased includes fully-released PRs; order picks the PR order.
+func Render(config *cmd.Config, configFile string, showReleased bool, order SortOrder) {
+	views := config.RepositoryViews()
+	for i, view := range views {
+		if i > 0 {
+			fmt.Println()
+		}
+		renderRepository(view, configFile, showReleased, order, len(views) > 1)
+	}
+}
+
+// renderRepository prints the status of one repository's tracked PRs. With
+// several repositories, the empty-state messages name the repository.
+func renderRepository(config *cmd.Config, configFile string, showReleased bool, order SortOrder, named bool) {
+	repoSuffix := ""
+	if named {
+		repoSuffix = fmt.Sprintf(" in %s/%s", config.Org, config.Repo)
+	}
+
 	if len(config.TrackedPRs) == 0 {
-		fmt.Println("No cherry-pick PRs tracked.")
+		fmt.Printf("No cherry-pick PRs tracked%s.\n", repoSuffix)
 		return
 	}
 
@@ -93,14 +168,14 @@ func Render(config *cmd.Config, configFile string, showReleased bool) {
 	}
 
 	if len(prsToDisplay) == 0 {
-		fmt.Println("No active cherry-pick PRs. All are released (use --show-released to see them).")
+		fmt.Printf("No active cherry-pick PRs%s. All are released (use --show-released to see them).\n", repoSuffix)
 		return
 	}
 
-	sortPRsByNumber(prsToDisplay)
+	sortPRs(prsToDisplay, order)
 	displayRepositoryHeader(config)
 	displayAllPRStatuses(prsToDisplay, config, configFile)
-	displayStatusSummary(prsToDisplay)
+	displayStatusSummary(prsToDisplay, config)
 }
 
 // filterNonReleasedPRs filters out PRs that are completely released (all branches have status "released")
@@ -151,18 +226,26 @@ func displayAllPRStatuses(prs []cmd.TrackedPR, config *cmd.Config, configFile st
 func displayPRStatus(pr cmd.TrackedPR, config *cmd.Config, configFile string) {
 	displayPRHeader(pr, config)
 
-	if len(pr.Branches) == 0 {
+	if len(pr.Branches) == 0 && len(pr.IgnoredBranches) == 0 {
 		fmt.Println("  No branch status recorded")
 		return
 	}
 
-	displayTrackedBranches(pr.Branches, config, pr.Number, configFile)
+	displayTrackedBranches(pr, config, configFile)
+	displayIgnoredBranches(pr.IgnoredBranches)
+}
+
+// displayIgnoredBranches lists the branches the PR was explicitly not backported to
+func displayIgnoredBranches(branches []string) {
+	for _, branch := range branches {
+		fmt.Printf("  %-15s: 🚫 ignored (won't be backported)\n", branch)
+	}
 }
 
 // displayPRHeader shows the PR number, title, and URL
 func displayPRHeader(pr cmd.TrackedPR, config *cmd.Config) {
 	// Generate GitHub PR URL
-	url := fmt.Sprintf("https://github.com/%s/%s/pull/%d", config.Org, config.Repo, pr.Number)
+	url := PullRequestURL(config, pr.Number)
 
 	// Display title and URL instead of just PR number
 	if pr.Title != "" {
@@ -175,12 +258,43 @@ func displayPRHeader(pr cmd.TrackedPR, config *cmd.Config) {
 }
 
 // displayTrackedBranches shows status for all tracked branches
-func displayTrackedBranches(branches map[string]cmd.BranchStatus, config *cmd.Config, prNumber int, configFile string) {
-	sortedBranches := getSortedBranchNames(branches)
+func displayTrackedBranches(pr cmd.TrackedPR, config *cmd.Config, configFile string) {
+	now := time.Now()
+	sortedBranches := getSortedBranchNames(pr.Branches)
 	for _, branch := range sortedBranches {
-		status := branches[branch]
-		displayBranchStatus(branch, status, config, prNumber, configFile)
+		status := pr.Branches[branch]
+		if status.Status == cmd.BranchStatusPending && pr.IsPendingStale(config.PendingGracePeriod, now) {
+			fmt.Printf("  %-15s: ⚠️  pending for %s since merge (bot should have acted by now)\n", branch, cmd.FormatAge(now.Sub(*pr.MergedAt)))
+			continue
+		}
+		displayBranchStatus(branch, status, config, pr.Number, configFile, now)
+	}
+}
+
+// statusAge returns " for <age>" for a branch whose status change time is
+// recorded, and "" for one tracked before LastUpdated was
+func statusAge(status cmd.BranchStatus, now time.Time) string {
+	age, ok := status.StatusAge(now)
+	if !ok {
+		return ""
+	}
+	return " for " + cmd.FormatAge(age)
+}
+
+// countStalePending counts the pending branches past the config's pending_grace_period
+func countStalePending(prs []cmd.TrackedPR, config *cmd.Config, now time.Time) int {
+	stale := 0
+	for _, pr := range prs {
+		if !pr.IsPendingStale(config.PendingGracePeriod, now) {
+			continue
+		}
+		for _, status := range pr.Branches {
+			if status.Status == cmd.BranchStatusPending {
+				stale++
+			}
+		}
 	}
+	return stale
 }
 
 // getSortedBranchNames returns branch names sorted alphabetically
@@ -199,6 +313,30 @@ type ciStatusInfo struct {
 	suggestedCommand string
 }
 
+// PullRequestURL links a PR on github.com, or on the Enterprise Server the config's base_url names
+func PullRequestURL(config *cmd.Config, number int) string {
+	return fmt.Sprintf("%s/%s/%s/pull/%d", webHost(config), config.Org, config.Repo, number)
+}
+
+// PickPRURL links a cherry-pick PR: the URL recorded when it was picked or
+// fetched, or for PRs tracked before URLs were recorded, one built from the config
+func PickPRURL(config *cmd.Config, pr *cmd.PickPR) string {
+	if pr.URL != "" {
+		return pr.URL
+	}
+	return PullRequestURL(config, pr.Number)
+}
+
+// webHost is the root PR links point at: github.com, or the base_url's Enterprise Server
+func webHost(config *cmd.Config) string {
+	if config.BaseURL != "" {
+		if enterpriseHost, err := github.EnterpriseHost(config.BaseURL); err == nil {
+			return enterpriseHost
+		}
+	}
+	return "https://github.com"
+}
+
 // getCIStatusInfo returns display information for a given CI status
 func getCIStatusInfo(ciStatus cmd.CIStatus, executablePath, configFlag string, prNumber int, branch string) ciStatusInfo {
 	switch ciStatus {
@@ -222,6 +360,11 @@ func getCIStatusInfo(ciStatus cmd.CIStatus, executablePath, configFlag string, p
 			indicator:        "❓ CI unknown",
 			suggestedCommand: "",
 		}
+	case cmd.CIStatusNoChecks:
+		return ciStatusInfo{
+			indicator:        "⚪ no CI configured",
+			suggestedCommand: fmt.Sprintf("%s%s merge %d %s --allow-ci no_checks", executablePath, configFlag, prNumber, branch),
+		}
 	default:
 		return ciStatusInfo{
 			indicator:        "❓ CI " + string(ciStatus),
@@ -231,21 +374,22 @@ func getCIStatusInfo(ciStatus cmd.CIStatus, executablePath, configFlag string, p
 }
 
 // displayBranchStatus displays the status for a single branch
-func displayBranchStatus(branch string, status cmd.BranchStatus, config *cmd.Config, prNumber int, configFile string) {
+func displayBranchStatus(branch string, status cmd.BranchStatus, config *cmd.Config, prNumber int, configFile string, now time.Time) {
 	executablePath := os.Args[0]
 	configFlag := getConfigFlag(configFile)
+	age := statusAge(status, now)
 
 	switch status.Status {
 	case cmd.BranchStatusPending:
-		fmt.Printf("  %-15s: ⏳ pending (bot hasn't attempted)\n", branch)
+		fmt.Printf("  %-15s: ⏳ pending%s (bot hasn't attempted)\n", branch, age)
 	case cmd.BranchStatusFailed:
-		fmt.Printf("  %-15s: ❌ failed (bot couldn't cherry-pick)\n", branch)
+		fmt.Printf("  %-15s: ❌ failed%s (bot couldn't cherry-pick)\n", branch, age)
 		// Show pick command for AI-assisted resolution
 		fmt.Printf("  %-15s  💡 %s%s pick %d %s\n", "", executablePath, configFlag, prNumber, branch)
 	case cmd.BranchStatusPicked:
 		if status.PR != nil {
-			prURL := fmt.Sprintf("https://github.com/%s/%s/pull/%d", config.Org, config.Repo, status.PR.Number)
-			fmt.Printf("  %-15s: 🔄 picked (%s)\n", branch, prURL)
+			prURL := PickPRURL(config, status.PR)
+			fmt.Printf("  %-15s: 🔄 picked%s (%s)\n", branch, age, prURL)
 
 			// Show stored PR details underneath
 			fmt.Printf("  %-15s  %s", "", status.PR.Title)
@@ -259,6 +403,14 @@ func displayBranchStatus(branch string, status cmd.BranchStatus, config *cmd.Con
 			if status.PR.RunAttempt > 0 {
 				fmt.Printf(" [run attempt %d]", status.PR.RunAttempt)
 			}
+
+			// Show how the pick command produced the PR, if it did
+			if status.PR.ResolutionMethod != "" {
+				fmt.Printf(" [%s]", status.PR.ResolutionMethod)
+			}
+			if status.PR.HasConflicts() {
+				fmt.Print(" [⚠️ has conflicts]")
+			}
 			fmt.Println()
 
 			// Show failing checks if CI is failing
@@ -266,49 +418,68 @@ func displayBranchStatus(branch string, status cmd.BranchStatus, config *cmd.Con
 				fmt.Printf("  %-15s  Failed: %s\n", "", types.FormatFailingChecks(status.PR.FailingChecks))
 			}
 
-			// Show suggested command if available
-			if ciInfo.suggestedCommand != "" {
+			// Show suggested command if available; GitHub rejects merging a PR
+			// with conflicts, however its CI looks
+			if status.PR.HasConflicts() {
+				fmt.Printf("  %-15s  💡 resolve the conflicts with %s on the PR branch before merging\n", "", branch)
+			} else if ciInfo.suggestedCommand != "" {
 				fmt.Printf("  %-15s  💡 %s\n", "", ciInfo.suggestedCommand)
 			}
 		} else {
-			fmt.Printf("  %-15s: ✅ picked\n", branch)
+			fmt.Printf("  %-15s: ✅ picked%s\n", branch, age)
+		}
+	case cmd.BranchStatusQueued:
+		if status.PR != nil {
+			prURL := PickPRURL(config, status.PR)
+			fmt.Printf("  %-15s: 🚦 queued%s (%s)\n", branch, age, prURL)
+
+			// Show stored PR details underneath; no command to suggest while queued
+			ciInfo := getCIStatusInfo(status.PR.CIStatus, executablePath, configFlag, prNumber, branch)
+			note := "waiting in merge queue"
+			if status.PR.HasConflicts() {
+				note = "⚠️ has conflicts"
+			}
+			fmt.Printf("  %-15s  %s [%s] [%s]\n", "", status.PR.Title, ciInfo.indicator, note)
+		} else {
+			fmt.Printf("  %-15s: 🚦 queued%s\n", branch, age)
 		}
 	case cmd.BranchStatusMerged:
-		fmt.Printf("  %-15s: ✅ merged\n", branch)
+		merged := awaitingReleaseNote(config.ExpectedRelease(branch), age)
+		if status.PR != nil && status.PR.ResolutionMethod != "" {
+			fmt.Printf("  %-15s: ✅ %s [%s]\n", branch, merged, status.PR.ResolutionMethod)
+		} else {
+			fmt.Printf("  %-15s: ✅ %s\n", branch, merged)
+		}
 	case cmd.BranchStatusReleased:
-		fmt.Printf("  %-15s: 🎉 released\n", branch)
+		fmt.Printf("  %-15s: 🎉 released%s\n", branch, age)
 	default:
 		fmt.Printf("  %-15s: ❓ unknown status: %s\n", branch, status.Status)
 	}
 }
 
-// displayStatusSummary displays the summary statistics
-func displayStatusSummary(prs []cmd.TrackedPR) {
-	totalPending := 0
-	totalFailed := 0
-	totalPicked := 0
-	totalMerged := 0
-	totalReleased := 0
-	for _, pr := range prs {
-		for _, status := range pr.Branches {
-			switch status.Status {
-			case cmd.BranchStatusPending:
-				totalPending++
-			case cmd.BranchStatusFailed:
-				totalFailed++
-			case cmd.BranchStatusPicked:
-				totalPicked++
-			case cmd.BranchStatusMerged:
-				totalMerged++
-			case cmd.BranchStatusReleased:
-				totalReleased++
-			}
-		}
+// awaitingReleaseNote describes a merged backport that hasn't shipped yet,
+// naming the release it is expected in when that is known; age is statusAge's
+// suffix for how long it has been merged
+func awaitingReleaseNote(expected, age string) string {
+	if expected == "" {
+		return fmt.Sprintf("merged%s, awaiting next release", age)
 	}
+	return fmt.Sprintf("merged%s, awaiting next release (expected %s)", age, expected)
+}
 
-	totalCompleted := totalPicked + totalMerged + totalReleased
-	fmt.Printf("Summary: %d PR(s), %d pending, %d failed, %d completed (%d picked, %d merged, %d released)\n",
-		len(prs), totalPending, totalFailed, totalCompleted, totalPicked, totalMerged, totalReleased)
+// displayStatusSummary displays the summary statistics
+func displayStatusSummary(prs []cmd.TrackedPR, config *cmd.Config) {
+	fmt.Println(summaryLine(countStatuses(prs), countStalePending(prs, config, time.Now())))
+}
+
+// summaryLine renders branch counts as the one-line status summary
+func summaryLine(counts statusCounts, stale int) string {
+	pending := fmt.Sprintf("%d pending", counts.Pending)
+	if stale > 0 {
+		pending = fmt.Sprintf("%d pending (%d stale)", counts.Pending, stale)
+	}
+	return fmt.Sprintf("Summary: %d PR(s), %s, %d failed, %d completed (%d picked, %d queued, %d merged, %d released)",
+		counts.PRs, pending, counts.Failed, counts.Completed, counts.Picked, counts.Queued, counts.Merged, counts.Released)
 }
 
 // getConfigFlag returns the config flag if not using default
@@ -0,0 +1,176 @@
+package status
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+)
+
+// htmlPage is what statusTemplate renders: one table per repository
+type htmlPage struct {
+	LastFetch    string
+	Repositories []htmlRepository
+}
+
+// htmlRepository is one repository's table: a row per PR, a column per branch
+type htmlRepository struct {
+	Name     string
+	URL      string
+	Summary  statusCounts
+	Branches []string
+	PRs      []htmlPR
+}
+
+// htmlPR is a table row
+type htmlPR struct {
+	Number  int
+	Title   string
+	URL     string
+	Cells   []htmlCell // one per htmlRepository.Branches entry
+	Ignored []string
+}
+
+// htmlCell is a PR's status on one branch; Status is empty if the branch isn't tracked for the PR
+type htmlCell struct {
+	Status cmd.BranchStatusType
+	Stale  bool
+	// ExpectedRelease is the release a merged backport should ship in, if known
+	ExpectedRelease string
+	PR              *jsonPickPR
+	URL             string
+	CIFailing       bool
+	Conflicts       bool
+}
+
+// statusTemplate renders a self-contained page (inline CSS, no scripts)
+var statusTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Cherry-pick status</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 2em; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+.meta { color: #656d76; }
+table { border-collapse: collapse; margin-top: 0.5em; }
+th, td { border: 1px solid #d0d7de; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+.status { display: inline-block; padding: 1px 8px; border-radius: 10px; font-size: 0.85em; font-weight: 600; }
+.pending { background: #fff8c5; }
+.failed { background: #ffebe9; color: #cf222e; }
+.picked { background: #ddf4ff; }
+.queued { background: #fbefff; }
+.merged { background: #dafbe1; color: #1a7f37; }
+.released { background: #1a7f37; color: #fff; }
+.ci { font-size: 0.85em; color: #656d76; }
+.ci-failing { color: #cf222e; font-weight: 600; }
+.none { color: #8c959f; }
+</style>
+</head>
+<body>
+<h1>Cherry-pick status</h1>
+{{- if .LastFetch}}
+<p class="meta">Last fetched {{.LastFetch}}</p>
+{{- end}}
+{{- range .Repositories}}
+<h2><a href="{{.URL}}">{{.Name}}</a></h2>
+<p class="meta">{{.Summary.PRs}} PRs: {{.Summary.Pending}} pending, {{.Summary.Failed}} failed, {{.Summary.Picked}} picked, {{.Summary.Queued}} queued, {{.Summary.Merged}} merged, {{.Summary.Released}} released</p>
+{{- if .PRs}}
+<table>
+<thead>
+<tr><th>PR</th><th>Title</th>{{range .Branches}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .PRs}}
+<tr>
+<td><a href="{{.URL}}">#{{.Number}}</a></td>
+<td>{{.Title}}{{if .Ignored}}<div class="ci">ignored: {{range $i, $b := .Ignored}}{{if $i}}, {{end}}{{$b}}{{end}}</div>{{end}}</td>
+{{- range .Cells}}
+<td>{{if .Status}}<span class="status {{.Status}}">{{.Status}}</span>{{if .Stale}} <span class="ci ci-failing">stale</span>{{end}}{{if .ExpectedRelease}} <span class="ci">expected in {{.ExpectedRelease}}</span>{{end}}{{if .PR}}<div><a href="{{.URL}}">#{{.PR.Number}}</a> <span class="ci{{if .CIFailing}} ci-failing{{end}}">CI {{.PR.CIStatus}}{{if .PR.FailingChecks}}: {{range $i, $c := .PR.FailingChecks}}{{if $i}}, {{end}}{{$c}}{{end}}{{end}}</span>{{if .Conflicts}} <span class="ci ci-failing">has conflicts</span>{{end}}</div>{{end}}{{else}}<span class="none">–</span>{{end}}</td>
+{{- end}}
+</tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No active cherry-pick PRs.</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTML writes the cherry-pick status of config to w as a self-contained
+// HTML page, built from the same document as RenderJSON so the two agree.
+// Exposed for the unified status command.
+func RenderHTML(w io.Writer, config *cmd.Config, showReleased bool, order SortOrder) error {
+	doc := buildStatusDocument(config, showReleased, order)
+	host := webHost(config)
+
+	page := htmlPage{}
+	if config.LastFetchDate != nil {
+		page.LastFetch = config.LastFetchDate.UTC().Format(time.RFC1123)
+	}
+	for _, repoDoc := range append([]statusDocument{doc}, doc.Repositories...) {
+		page.Repositories = append(page.Repositories, newHTMLRepository(host, repoDoc))
+	}
+
+	if err := statusTemplate.Execute(w, page); err != nil {
+		return fmt.Errorf("failed to write status HTML: %w", err)
+	}
+	return nil
+}
+
+// newHTMLRepository lays a repository's document out as a PR by branch table
+func newHTMLRepository(host string, doc statusDocument) htmlRepository {
+	repoURL := fmt.Sprintf("%s/%s/%s", host, doc.Org, doc.Repo)
+	repo := htmlRepository{
+		Name:    doc.Org + "/" + doc.Repo,
+		URL:     repoURL,
+		Summary: doc.Summary,
+	}
+
+	for _, pr := range doc.TrackedPRs {
+		for branch := range pr.Branches {
+			if !slices.Contains(repo.Branches, branch) {
+				repo.Branches = append(repo.Branches, branch)
+			}
+		}
+	}
+	slices.Sort(repo.Branches)
+
+	for _, pr := range doc.TrackedPRs {
+		row := htmlPR{
+			Number:  pr.Number,
+			Title:   pr.Title,
+			URL:     fmt.Sprintf("%s/pull/%d", repoURL, pr.Number),
+			Ignored: pr.IgnoredBranches,
+		}
+		for _, branch := range repo.Branches {
+			status, tracked := pr.Branches[branch]
+			if !tracked {
+				row.Cells = append(row.Cells, htmlCell{})
+				continue
+			}
+			cell := htmlCell{Status: status.Status, Stale: status.Stale, ExpectedRelease: status.ExpectedRelease, PR: status.PR}
+			if status.PR != nil {
+				cell.URL = status.PR.URL
+				if cell.URL == "" {
+					cell.URL = fmt.Sprintf("%s/pull/%d", repoURL, status.PR.Number)
+				}
+				cell.CIFailing = status.PR.CIStatus == cmd.CIStatusFailing
+				cell.Conflicts = status.PR.Mergeable != nil && !*status.PR.Mergeable
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		repo.PRs = append(repo.PRs, row)
+	}
+	return repo
+}
@@ -0,0 +1,87 @@
+package status
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderHTML(t *testing.T) {
+	config := &cmd.Config{
+		Org:  "acme",
+		Repo: "widget",
+		TrackedPRs: []cmd.TrackedPR{
+			{
+				Number: 200,
+				Title:  "Fix <script> crash",
+				Branches: map[string]cmd.BranchStatus{
+					"release-3.6": {Status: cmd.BranchStatusPending},
+					"release-3.7": {
+						Status: cmd.BranchStatusPicked,
+						PR: &cmd.PickPR{
+							Number:        5678,
+							CIStatus:      cmd.CIStatusFailing,
+							FailingChecks: []string{"Lint"},
+						},
+					},
+				},
+			},
+			{
+				Number:   100,
+				Title:    "Old fix",
+				Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusReleased}},
+			},
+		},
+	}
+
+	t.Run("table of active PRs", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RenderHTML(&out, config, false, SortByNumber))
+		page := out.String()
+
+		assert.Contains(t, page, "<!DOCTYPE html>")
+		assert.Contains(t, page, "<style>")
+		assert.Contains(t, page, `<a href="https://github.com/acme/widget">acme/widget</a>`)
+		assert.Contains(t, page, "<th>release-3.6</th><th>release-3.7</th>")
+		assert.Contains(t, page, `<a href="https://github.com/acme/widget/pull/200">#200</a>`)
+		assert.Contains(t, page, `<a href="https://github.com/acme/widget/pull/5678">#5678</a>`)
+		assert.Contains(t, page, `<span class="ci ci-failing">CI failing: Lint</span>`)
+		assert.Contains(t, page, "Fix &lt;script&gt; crash")
+		assert.NotContains(t, page, "<script>")
+		assert.NotContains(t, page, "#100")
+	})
+
+	t.Run("released PRs on request", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RenderHTML(&out, config, true, SortByNumber))
+		assert.Contains(t, out.String(), `<a href="https://github.com/acme/widget/pull/100">#100</a>`)
+	})
+
+	t.Run("enterprise links and further repositories", func(t *testing.T) {
+		enterprise := &cmd.Config{
+			Org:     "acme",
+			Repo:    "widget",
+			BaseURL: "https://github.example.com/api/v3/",
+			Repositories: []cmd.RepoConfig{{
+				Org:  "acme",
+				Repo: "gadget",
+				TrackedPRs: []cmd.TrackedPR{{
+					Number:   7,
+					Title:    "Gadget fix",
+					Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusFailed}},
+				}},
+			}},
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, RenderHTML(&out, enterprise, false, SortByNumber))
+		page := out.String()
+
+		assert.Contains(t, page, "No active cherry-pick PRs.")
+		assert.Contains(t, page, `<a href="https://github.example.com/acme/gadget">acme/gadget</a>`)
+		assert.Contains(t, page, `<a href="https://github.example.com/acme/gadget/pull/7">#7</a>`)
+	})
+}
@@ -0,0 +1,201 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+)
+
+// OutputFormat selects how status prints the tracked PRs
+type OutputFormat string
+
+// Status output formats
+const (
+	OutputText OutputFormat = "text" // human-readable tree (default)
+	OutputJSON OutputFormat = "json" // statusDocument, for dashboards and scripts
+	OutputHTML OutputFormat = "html" // self-contained page of statusDocument, for a static site
+)
+
+// ParseOutputFormat converts an --output value to an OutputFormat; empty means OutputText
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(s) {
+	case "", OutputText:
+		return OutputText, nil
+	case OutputJSON:
+		return OutputJSON, nil
+	case OutputHTML:
+		return OutputHTML, nil
+	default:
+		return OutputText, fmt.Errorf("invalid output format %q (want text, json or html)", s)
+	}
+}
+
+// statusCounts is the number of branches in each status across the displayed
+// PRs, as printed by displayStatusSummary
+type statusCounts struct {
+	PRs       int `json:"prs"`
+	Pending   int `json:"pending"`
+	Failed    int `json:"failed"`
+	Completed int `json:"completed"` // picked + queued + merged + released
+	Picked    int `json:"picked"`
+	Queued    int `json:"queued"`
+	Merged    int `json:"merged"`
+	Released  int `json:"released"`
+}
+
+// countStatuses tallies the branch statuses of prs
+func countStatuses(prs []cmd.TrackedPR) statusCounts {
+	counts := statusCounts{PRs: len(prs)}
+	for _, pr := range prs {
+		for _, status := range pr.Branches {
+			switch status.Status {
+			case cmd.BranchStatusPending:
+				counts.Pending++
+			case cmd.BranchStatusFailed:
+				counts.Failed++
+			case cmd.BranchStatusPicked:
+				counts.Picked++
+			case cmd.BranchStatusQueued:
+				counts.Queued++
+			case cmd.BranchStatusMerged:
+				counts.Merged++
+			case cmd.BranchStatusReleased:
+				counts.Released++
+			}
+		}
+	}
+	counts.Completed = counts.Picked + counts.Queued + counts.Merged + counts.Released
+	return counts
+}
+
+// statusDocument is the --output json document. Its field names are part of
+// the command's interface, so they are spelled out here rather than taken from
+// cmd.TrackedPR.
+type statusDocument struct {
+	Org        string          `json:"org"`
+	Repo       string          `json:"repo"`
+	Summary    statusCounts    `json:"summary"`
+	TrackedPRs []jsonTrackedPR `json:"tracked_prs"`
+	// Repositories holds the further repositories of cherry_picks.repositories;
+	// the top-level org/repo's PRs stay at the top of the document
+	Repositories []statusDocument `json:"repositories,omitempty"`
+}
+
+// jsonTrackedPR is a tracked PR in statusDocument
+type jsonTrackedPR struct {
+	Number          int                         `json:"number"`
+	Title           string                      `json:"title"`
+	MergedAt        *time.Time                  `json:"merged_at,omitempty"`
+	Branches        map[string]jsonBranchStatus `json:"branches"`
+	IgnoredBranches []string                    `json:"ignored_branches,omitempty"`
+}
+
+// jsonBranchStatus is a target branch's status in statusDocument
+type jsonBranchStatus struct {
+	Status cmd.BranchStatusType `json:"status"`
+	Stale  bool                 `json:"stale,omitempty"` // pending past pending_grace_period since the original PR merged
+	// LastUpdated is when the status last changed; absent for branches
+	// tracked before that was recorded
+	LastUpdated *time.Time `json:"last_updated,omitempty"`
+	// ExpectedRelease is the release a merged backport should ship in (see
+	// cmd.Config.ExpectedRelease); absent for other statuses or when unknown
+	ExpectedRelease string      `json:"expected_release,omitempty"`
+	PR              *jsonPickPR `json:"pr,omitempty"`
+}
+
+// jsonPickPR is a cherry-pick PR in statusDocument
+type jsonPickPR struct {
+	Number           int                  `json:"number"`
+	Title            string               `json:"title"`
+	URL              string               `json:"url,omitempty"` // as recorded by pick or fetch; absent for PRs tracked before URLs were
+	CIStatus         cmd.CIStatus         `json:"ci_status"`
+	RunAttempt       int                  `json:"run_attempt"`
+	FailingChecks    []string             `json:"failing_checks,omitempty"`
+	HeadSHA          string               `json:"head_sha,omitempty"`
+	ResolutionMethod cmd.ResolutionMethod `json:"resolution_method,omitempty"`
+	Mergeable        *bool                `json:"mergeable,omitempty"` // false when the PR has conflicts with its base; absent while unknown
+}
+
+// newStatusDocument builds the JSON document for the PRs status would display
+func newStatusDocument(config *cmd.Config, prs []cmd.TrackedPR) statusDocument {
+	now := time.Now()
+	doc := statusDocument{
+		Org:        config.Org,
+		Repo:       config.Repo,
+		Summary:    countStatuses(prs),
+		TrackedPRs: make([]jsonTrackedPR, 0, len(prs)),
+	}
+	for _, pr := range prs {
+		jsonPR := jsonTrackedPR{
+			Number:          pr.Number,
+			Title:           pr.Title,
+			MergedAt:        pr.MergedAt,
+			Branches:        make(map[string]jsonBranchStatus, len(pr.Branches)),
+			IgnoredBranches: pr.IgnoredBranches,
+		}
+		for branch, status := range pr.Branches {
+			branchStatus := jsonBranchStatus{
+				Status: status.Status,
+				Stale:  status.Status == cmd.BranchStatusPending && pr.IsPendingStale(config.PendingGracePeriod, now),
+			}
+			if !status.LastUpdated.IsZero() {
+				lastUpdated := status.LastUpdated
+				branchStatus.LastUpdated = &lastUpdated
+			}
+			if status.Status == cmd.BranchStatusMerged {
+				branchStatus.ExpectedRelease = config.ExpectedRelease(branch)
+			}
+			if status.PR != nil {
+				branchStatus.PR = &jsonPickPR{
+					Number:           status.PR.Number,
+					Title:            status.PR.Title,
+					URL:              status.PR.URL,
+					CIStatus:         status.PR.CIStatus,
+					RunAttempt:       status.PR.RunAttempt,
+					FailingChecks:    status.PR.FailingChecks,
+					HeadSHA:          status.PR.HeadSHA,
+					ResolutionMethod: status.PR.ResolutionMethod,
+					Mergeable:        status.PR.Mergeable,
+				}
+			}
+			jsonPR.Branches[branch] = branchStatus
+		}
+		doc.TrackedPRs = append(doc.TrackedPRs, jsonPR)
+	}
+	return doc
+}
+
+// RenderJSON writes the cherry-pick status of config to w as a statusDocument,
+// filtered and ordered the same way as the text output. Exposed for the
+// unified status command.
+func RenderJSON(w io.Writer, config *cmd.Config, showReleased bool, order SortOrder) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(buildStatusDocument(config, showReleased, order)); err != nil {
+		return fmt.Errorf("failed to write status JSON: %w", err)
+	}
+	return nil
+}
+
+// buildStatusDocument assembles the document the JSON and HTML output render:
+// the PRs status would display for each repository, filtered and ordered alike
+func buildStatusDocument(config *cmd.Config, showReleased bool, order SortOrder) statusDocument {
+	var doc statusDocument
+	for i, view := range config.RepositoryViews() {
+		prs := view.TrackedPRs
+		if !showReleased {
+			prs = filterNonReleasedPRs(view.TrackedPRs)
+		}
+		sortPRs(prs, order)
+
+		if i == 0 {
+			doc = newStatusDocument(view, prs)
+		} else {
+			doc.Repositories = append(doc.Repositories, newStatusDocument(view, prs))
+		}
+	}
+	return doc
+}
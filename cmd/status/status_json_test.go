@@ -0,0 +1,181 @@
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	format, err := ParseOutputFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, OutputText, format)
+
+	format, err = ParseOutputFormat("json")
+	require.NoError(t, err)
+	assert.Equal(t, OutputJSON, format)
+
+	_, err = ParseOutputFormat("yaml")
+	assert.ErrorContains(t, err, "want text, json or html")
+}
+
+func TestRenderJSON(t *testing.T) {
+	config := &cmd.Config{
+		Org:  "acme",
+		Repo: "widget",
+		TrackedPRs: []cmd.TrackedPR{
+			{
+				Number: 200,
+				Title:  "Fix crash",
+				Branches: map[string]cmd.BranchStatus{
+					"release-3.6": {Status: cmd.BranchStatusPending},
+					"release-3.7": {
+						Status:      cmd.BranchStatusPicked,
+						LastUpdated: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+						PR: &cmd.PickPR{
+							Number:        5678,
+							Title:         "Fix crash (cherry-pick #200 for 3.7)",
+							CIStatus:      cmd.CIStatusFailing,
+							RunAttempt:    2,
+							FailingChecks: []string{"Lint"},
+							Mergeable:     new(bool),
+						},
+					},
+				},
+			},
+			{
+				Number:   100,
+				Title:    "Old fix",
+				Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusReleased}},
+			},
+		},
+	}
+
+	t.Run("active PRs with summary", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, config, false, SortByNumber))
+
+		var doc statusDocument
+		require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		assert.Equal(t, "acme", doc.Org)
+		assert.Equal(t, statusCounts{PRs: 1, Pending: 1, Completed: 1, Picked: 1}, doc.Summary)
+		require.Len(t, doc.TrackedPRs, 1)
+
+		picked := doc.TrackedPRs[0].Branches["release-3.7"]
+		assert.Equal(t, cmd.BranchStatusPicked, picked.Status)
+		require.NotNil(t, picked.PR)
+		assert.Equal(t, 5678, picked.PR.Number)
+		assert.Equal(t, cmd.CIStatusFailing, picked.PR.CIStatus)
+		assert.Equal(t, 2, picked.PR.RunAttempt)
+		require.NotNil(t, picked.PR.Mergeable)
+		assert.False(t, *picked.PR.Mergeable)
+		assert.Nil(t, doc.TrackedPRs[0].Branches["release-3.6"].PR)
+		require.NotNil(t, picked.LastUpdated)
+		assert.True(t, picked.LastUpdated.Equal(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)))
+		assert.Nil(t, doc.TrackedPRs[0].Branches["release-3.6"].LastUpdated, "unknown age is omitted")
+	})
+
+	t.Run("show released, sorted by number", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, config, true, SortByNumber))
+
+		var doc statusDocument
+		require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		require.Len(t, doc.TrackedPRs, 2)
+		assert.Equal(t, 100, doc.TrackedPRs[0].Number)
+		assert.Equal(t, 1, doc.Summary.Released)
+	})
+
+	t.Run("stable field names", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, config, false, SortByNumber))
+
+		assert.Contains(t, out.String(), `"summary": {`)
+		assert.Contains(t, out.String(), `"tracked_prs": [`)
+		assert.Contains(t, out.String(), `"ci_status": "failing"`)
+		assert.Contains(t, out.String(), `"run_attempt": 2`)
+	})
+
+	t.Run("merged branches name the expected release", func(t *testing.T) {
+		merged := *config
+		merged.LastCheckedRelease = map[string]string{"release-3.7": "v3.7.4"}
+		merged.TrackedPRs = []cmd.TrackedPR{{
+			Number: 300,
+			Branches: map[string]cmd.BranchStatus{
+				"release-3.7": {Status: cmd.BranchStatusMerged},
+				"release-3.6": {Status: cmd.BranchStatusPicked},
+			},
+		}}
+
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, &merged, false, SortByNumber))
+
+		var doc statusDocument
+		require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		assert.Equal(t, "v3.7.5", doc.TrackedPRs[0].Branches["release-3.7"].ExpectedRelease)
+		assert.Empty(t, doc.TrackedPRs[0].Branches["release-3.6"].ExpectedRelease)
+	})
+
+	t.Run("no PRs is an empty list", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, &cmd.Config{}, false, SortByNumber))
+
+		assert.Contains(t, out.String(), `"tracked_prs": []`)
+	})
+	t.Run("further repositories", func(t *testing.T) {
+		multi := *config
+		multi.Repositories = []cmd.RepoConfig{{
+			Org:        "acme",
+			Repo:       "gadget",
+			TrackedPRs: []cmd.TrackedPR{{Number: 7, Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusFailed}}}},
+		}}
+
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, &multi, false, SortByNumber))
+
+		var doc statusDocument
+		require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		assert.Equal(t, "widget", doc.Repo)
+		require.Len(t, doc.Repositories, 1)
+		assert.Equal(t, "gadget", doc.Repositories[0].Repo)
+		assert.Equal(t, 7, doc.Repositories[0].TrackedPRs[0].Number)
+		assert.Equal(t, 1, doc.Repositories[0].Summary.Failed)
+	})
+
+	t.Run("single repository has no repositories field", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, config, false, SortByNumber))
+
+		assert.NotContains(t, out.String(), `"repositories"`)
+	})
+
+	t.Run("pending past the grace period is stale", func(t *testing.T) {
+		longAgo := time.Now().Add(-72 * time.Hour)
+		recently := time.Now().Add(-time.Hour)
+		graced := &cmd.Config{
+			PendingGracePeriod: 48 * time.Hour,
+			TrackedPRs: []cmd.TrackedPR{
+				{Number: 1, MergedAt: &longAgo, Branches: map[string]cmd.BranchStatus{"release-3.6": {Status: cmd.BranchStatusPending}, "release-3.7": {Status: cmd.BranchStatusFailed}}},
+				{Number: 2, MergedAt: &recently, Branches: map[string]cmd.BranchStatus{"release-3.6": {Status: cmd.BranchStatusPending}}},
+			},
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, RenderJSON(&out, graced, false, SortByNumber))
+
+		var doc statusDocument
+		require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		assert.True(t, doc.TrackedPRs[0].Branches["release-3.6"].Stale)
+		assert.False(t, doc.TrackedPRs[0].Branches["release-3.7"].Stale, "only pending branches are stale")
+		assert.False(t, doc.TrackedPRs[1].Branches["release-3.6"].Stale)
+
+		out.Reset()
+		require.NoError(t, RenderJSON(&out, config, false, SortByNumber))
+		assert.NotContains(t, out.String(), `"stale"`, "nothing is stale without a grace period")
+	})
+}
@@ -0,0 +1,82 @@
+package status
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+)
+
+// Snapshot records the branch statuses of every repository in a config, so a
+// --notify message can report what a fetch or merge changed
+type Snapshot struct {
+	statuses map[string]cmd.BranchStatusType // "org/repo#pr branch" -> status
+	counts   statusCounts
+	stale    int
+	failing  []string
+}
+
+// TakeSnapshot records config's branch statuses, the status summary counts and
+// the picked or queued branches whose CI is failing
+func TakeSnapshot(config *cmd.Config) Snapshot {
+	snapshot := Snapshot{statuses: make(map[string]cmd.BranchStatusType)}
+	views := config.RepositoryViews()
+	now := time.Now()
+	for _, view := range views {
+		prs := slices.Clone(view.TrackedPRs)
+		sortPRsByNumber(prs)
+		counts := countStatuses(prs)
+		snapshot.counts.PRs += counts.PRs
+		snapshot.counts.Pending += counts.Pending
+		snapshot.counts.Failed += counts.Failed
+		snapshot.counts.Completed += counts.Completed
+		snapshot.counts.Picked += counts.Picked
+		snapshot.counts.Queued += counts.Queued
+		snapshot.counts.Merged += counts.Merged
+		snapshot.counts.Released += counts.Released
+		snapshot.stale += countStalePending(prs, view, now)
+
+		prefix := ""
+		if len(views) > 1 {
+			prefix = view.Org + "/" + view.Repo
+		}
+		for _, pr := range prs {
+			for _, branch := range getSortedBranchNames(pr.Branches) {
+				status := pr.Branches[branch]
+				snapshot.statuses[fmt.Sprintf("%s/%s#%d %s", view.Org, view.Repo, pr.Number, branch)] = status.Status
+				if isOpenPick(status) && status.PR.CIStatus == cmd.CIStatusFailing {
+					snapshot.failing = append(snapshot.failing, fmt.Sprintf("%s#%d on %s (#%d)", prefix, pr.Number, branch, status.PR.Number))
+				}
+			}
+		}
+	}
+	return snapshot
+}
+
+// isOpenPick reports whether a branch has a cherry-pick PR that isn't merged yet
+func isOpenPick(status cmd.BranchStatus) bool {
+	return status.PR != nil && (status.Status == cmd.BranchStatusPicked || status.Status == cmd.BranchStatusQueued)
+}
+
+// NotifyMessage renders the compact report --notify posts after command ran:
+// how many branches moved to picked, merged and released between before and
+// after, the status summary line, and the cherry-pick PRs with failing CI
+func NotifyMessage(command string, before, after Snapshot) string {
+	moved := make(map[cmd.BranchStatusType]int)
+	for key, status := range after.statuses {
+		if before.statuses[key] != status {
+			moved[status]++
+		}
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "cherry-picker %s: %d moved to picked, %d to merged, %d to released\n",
+		command, moved[cmd.BranchStatusPicked], moved[cmd.BranchStatusMerged], moved[cmd.BranchStatusReleased])
+	msg.WriteString(summaryLine(after.counts, after.stale))
+	if len(after.failing) > 0 {
+		fmt.Fprintf(&msg, "\nFailing CI: %s", strings.Join(after.failing, ", "))
+	}
+	return msg.String()
+}
@@ -0,0 +1,61 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyMessage(t *testing.T) {
+	before := &cmd.Config{
+		Org:  "argoproj",
+		Repo: "argo-workflows",
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 100, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 101, CIStatus: cmd.CIStatusPassing}},
+				"release-1.1": {Status: cmd.BranchStatusPending},
+			}},
+		},
+	}
+	beforeSnapshot := TakeSnapshot(before)
+
+	after := &cmd.Config{
+		Org:  "argoproj",
+		Repo: "argo-workflows",
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 100, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 101, CIStatus: cmd.CIStatusPassing}},
+				"release-1.1": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 102, CIStatus: cmd.CIStatusFailing}},
+			}},
+			{Number: 200, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPending},
+			}},
+		},
+	}
+
+	got := NotifyMessage("fetch", beforeSnapshot, TakeSnapshot(after))
+	assert.Equal(t, "cherry-picker fetch: 1 moved to picked, 1 to merged, 0 to released\n"+
+		"Summary: 2 PR(s), 1 pending, 0 failed, 2 completed (1 picked, 0 queued, 1 merged, 0 released)\n"+
+		"Failing CI: #100 on release-1.1 (#102)", got)
+}
+
+func TestNotifyMessage_NamesRepositoriesWhenSeveral(t *testing.T) {
+	config := &cmd.Config{
+		Org:  "argoproj",
+		Repo: "argo-workflows",
+		Repositories: []cmd.RepoConfig{{
+			Org:  "argoproj",
+			Repo: "argo-events",
+			TrackedPRs: []cmd.TrackedPR{{Number: 7, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusQueued, PR: &cmd.PickPR{Number: 8, CIStatus: cmd.CIStatusFailing}},
+			}}},
+		}},
+	}
+	snapshot := TakeSnapshot(config)
+
+	got := NotifyMessage("merge", snapshot, snapshot)
+	assert.Equal(t, "cherry-picker merge: 0 moved to picked, 0 to merged, 0 to released\n"+
+		"Summary: 1 PR(s), 0 pending, 0 failed, 1 completed (0 picked, 1 queued, 0 merged, 0 released)\n"+
+		"Failing CI: argoproj/argo-events#7 on release-1.0 (#8)", got)
+}
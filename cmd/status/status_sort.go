@@ -0,0 +1,128 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/alan/cherry-picker/cmd"
+)
+
+// SortOrder selects how status orders the displayed PRs
+type SortOrder string
+
+// Status sort orders
+const (
+	SortByNumber SortOrder = "number" // ascending PR number (default)
+	SortByStatus SortOrder = "status" // most actionable branch first
+	SortByCI     SortOrder = "ci"     // worst cherry-pick PR CI first
+)
+
+// ParseSortOrder converts a --sort value to a SortOrder; empty means SortByNumber
+func ParseSortOrder(s string) (SortOrder, error) {
+	switch SortOrder(s) {
+	case "", SortByNumber:
+		return SortByNumber, nil
+	case SortByStatus:
+		return SortByStatus, nil
+	case SortByCI:
+		return SortByCI, nil
+	default:
+		return SortByNumber, fmt.Errorf("invalid sort order %q (want number, status or ci)", s)
+	}
+}
+
+// sortPRs orders PRs for display; ties (and SortByNumber) fall back to PR number
+func sortPRs(prs []cmd.TrackedPR, order SortOrder) {
+	var rank func(cmd.TrackedPR) int
+	switch order {
+	case SortByStatus:
+		rank = actionRank
+	case SortByCI:
+		rank = ciRank
+	case SortByNumber:
+	}
+	if rank == nil {
+		sortPRsByNumber(prs)
+		return
+	}
+
+	sort.SliceStable(prs, func(i, j int) bool {
+		ri, rj := rank(prs[i]), rank(prs[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return prs[i].Number < prs[j].Number
+	})
+}
+
+// actionRank ranks a PR by its most actionable branch: failing CI, then
+// pending-CI picks, then failed, then pending, then everything further along
+func actionRank(pr cmd.TrackedPR) int {
+	best := branchActionRankNone
+	for _, status := range pr.Branches {
+		best = min(best, branchActionRank(status))
+	}
+	return best
+}
+
+// branchActionRankNone ranks PRs with no tracked branches last
+const branchActionRankNone = 8
+
+func branchActionRank(status cmd.BranchStatus) int {
+	switch status.Status {
+	case cmd.BranchStatusPicked:
+		if status.PR != nil {
+			switch status.PR.CIStatus {
+			case cmd.CIStatusFailing:
+				return 0
+			case cmd.CIStatusPending:
+				return 1
+			case cmd.CIStatusPassing, cmd.CIStatusUnknown, cmd.CIStatusNoChecks:
+			}
+		}
+		return 4
+	case cmd.BranchStatusFailed:
+		return 2
+	case cmd.BranchStatusPending:
+		return 3
+	case cmd.BranchStatusQueued:
+		return 5
+	case cmd.BranchStatusMerged:
+		return 6
+	case cmd.BranchStatusReleased:
+		return 7
+	default:
+		return branchActionRankNone
+	}
+}
+
+// ciRank ranks a PR by the worst CI status among its open cherry-pick PRs;
+// PRs with no open cherry-pick PR come last
+func ciRank(pr cmd.TrackedPR) int {
+	best := ciRankNone
+	for _, status := range pr.Branches {
+		if status.PR == nil || (status.Status != cmd.BranchStatusPicked && status.Status != cmd.BranchStatusQueued) {
+			continue
+		}
+		best = min(best, ciStatusRank(status.PR.CIStatus))
+	}
+	return best
+}
+
+// ciRankNone ranks PRs without an open cherry-pick PR last
+const ciRankNone = 4
+
+func ciStatusRank(ci cmd.CIStatus) int {
+	switch ci {
+	case cmd.CIStatusFailing:
+		return 0
+	case cmd.CIStatusPending:
+		return 1
+	case cmd.CIStatusUnknown, cmd.CIStatusNoChecks:
+		return 2
+	case cmd.CIStatusPassing:
+		return 3
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,79 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func picked(ci cmd.CIStatus) cmd.BranchStatus {
+	return cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 1, CIStatus: ci}}
+}
+
+func sortTestPRs() []cmd.TrackedPR {
+	return []cmd.TrackedPR{
+		{Number: 1, Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusMerged}}},
+		{Number: 2, Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusFailed}}},
+		{Number: 3, Branches: map[string]cmd.BranchStatus{"release-1.0": picked(cmd.CIStatusPending)}},
+		{Number: 4, Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusMerged},
+			"release-1.1": picked(cmd.CIStatusFailing),
+		}},
+		{Number: 5, Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPending}}},
+		{Number: 6, Branches: map[string]cmd.BranchStatus{"release-1.0": picked(cmd.CIStatusPassing)}},
+	}
+}
+
+func prNumbers(prs []cmd.TrackedPR) []int {
+	var numbers []int
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers
+}
+
+func TestParseSortOrder(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want SortOrder
+	}{
+		{"", SortByNumber},
+		{"number", SortByNumber},
+		{"status", SortByStatus},
+		{"ci", SortByCI},
+	} {
+		got, err := ParseSortOrder(tt.in)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := ParseSortOrder("age")
+	require.Error(t, err)
+}
+
+func TestSortPRs(t *testing.T) {
+	tests := []struct {
+		order SortOrder
+		want  []int
+	}{
+		{SortByNumber, []int{1, 2, 3, 4, 5, 6}},
+		// failing CI, pending CI, failed, pending, passing pick, merged
+		{SortByStatus, []int{4, 3, 2, 5, 6, 1}},
+		// failing, pending, passing, then PRs without an open pick
+		{SortByCI, []int{4, 3, 6, 1, 2, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			prs := sortTestPRs()
+			// Start from reverse order so the number tie-break is exercised
+			for i, j := 0, len(prs)-1; i < j; i, j = i+1, j-1 {
+				prs[i], prs[j] = prs[j], prs[i]
+			}
+			sortPRs(prs, tt.order)
+			assert.Equal(t, tt.want, prNumbers(prs))
+		})
+	}
+}
@@ -39,6 +39,9 @@ func TestNewStatusCmd(t *testing.T) {
 	if flags.Lookup("fetch") == nil {
 		t.Error("NewStatusCmd() should have --fetch flag")
 	}
+	if flags.Lookup("sort") == nil {
+		t.Error("NewStatusCmd() should have --sort flag")
+	}
 }
 
 func TestRunStatus_NoConfig(t *testing.T) {
@@ -49,7 +52,7 @@ func TestRunStatus_NoConfig(t *testing.T) {
 		return nil
 	}
 
-	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false)
+	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false, SortByNumber, OutputText, nil)
 
 	if err == nil {
 		t.Error("runStatus() expected error for missing config, got nil")
@@ -76,7 +79,7 @@ func TestRunStatus_NoPRs(t *testing.T) {
 
 	// This would normally print to stdout, but we can't easily capture that in tests
 	// The important thing is that it doesn't error
-	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false)
+	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false, SortByNumber, OutputText, nil)
 
 	if err != nil {
 		t.Errorf("runStatus() unexpected error = %v", err)
@@ -119,7 +122,7 @@ func TestRunStatus_WithActivePRs(t *testing.T) {
 	saveConfig := func(_ string, _ *cmd.Config) error {
 		return nil
 	}
-	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false)
+	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false, SortByNumber, OutputText, nil)
 
 	if err != nil {
 		t.Errorf("runStatus() unexpected error = %v", err)
@@ -140,7 +143,7 @@ func TestRunStatus_EmptyConfig(t *testing.T) {
 		return nil
 	}
 
-	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false)
+	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false, SortByNumber, OutputText, nil)
 
 	if err != nil {
 		t.Errorf("runStatus() unexpected error = %v", err)
@@ -167,7 +170,7 @@ func TestRunStatus_PRsWithoutBranches(t *testing.T) {
 		return nil
 	}
 
-	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false)
+	err := runStatus(context.Background(), "test-config.yaml", loadConfig, saveConfig, false, false, SortByNumber, OutputText, nil)
 
 	if err != nil {
 		t.Errorf("runStatus() unexpected error = %v", err)
@@ -288,3 +291,63 @@ func TestFilterNonReleasedPRs(t *testing.T) {
 		t.Error("filterNonReleasedPRs() should include PR 300")
 	}
 }
+
+func TestPullRequestURL(t *testing.T) {
+	config := &cmd.Config{Org: "acme", Repo: "widget"}
+	if got := PullRequestURL(config, 42); got != "https://github.com/acme/widget/pull/42" {
+		t.Errorf("PullRequestURL() = %q, want the github.com link", got)
+	}
+
+	config.BaseURL = "https://ghe.internal/api/v3"
+	if got := PullRequestURL(config, 42); got != "https://ghe.internal/acme/widget/pull/42" {
+		t.Errorf("PullRequestURL() = %q, want the Enterprise Server link", got)
+	}
+}
+
+func TestPickPRURL(t *testing.T) {
+	config := &cmd.Config{Org: "acme", Repo: "widget"}
+	if got := PickPRURL(config, &cmd.PickPR{Number: 42}); got != "https://github.com/acme/widget/pull/42" {
+		t.Errorf("PickPRURL() = %q, want the link built from the config", got)
+	}
+
+	stored := "https://ghe.internal/fork/widget/pull/42"
+	if got := PickPRURL(config, &cmd.PickPR{Number: 42, URL: stored}); got != stored {
+		t.Errorf("PickPRURL() = %q, want the stored URL", got)
+	}
+}
+
+func TestAwaitingReleaseNote(t *testing.T) {
+	if got := awaitingReleaseNote("v3.7.5", ""); got != "merged, awaiting next release (expected v3.7.5)" {
+		t.Errorf("awaitingReleaseNote() = %q, want the expected release named", got)
+	}
+	if got := awaitingReleaseNote("", ""); got != "merged, awaiting next release" {
+		t.Errorf("awaitingReleaseNote() = %q, want no version", got)
+	}
+	if got := awaitingReleaseNote("", " for 2d"); got != "merged for 2d, awaiting next release" {
+		t.Errorf("awaitingReleaseNote() = %q, want the age after merged", got)
+	}
+}
+
+func TestStatusAge(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	failed := cmd.BranchStatus{Status: cmd.BranchStatusFailed, LastUpdated: now.Add(-75 * time.Hour)}
+	if got := statusAge(failed, now); got != " for 3d" {
+		t.Errorf("statusAge() = %q, want \" for 3d\"", got)
+	}
+	if got := statusAge(cmd.BranchStatus{Status: cmd.BranchStatusFailed}, now); got != "" {
+		t.Errorf("statusAge() = %q, want nothing for an unknown age", got)
+	}
+}
+
+func TestGetCIStatusInfo_NoChecks(t *testing.T) {
+	info := getCIStatusInfo(cmd.CIStatusNoChecks, "cherry-picker", "", 42, "release-1.0")
+	if info.indicator != "⚪ no CI configured" {
+		t.Errorf("indicator = %q, want no CI configured", info.indicator)
+	}
+	if info.suggestedCommand != "cherry-picker merge 42 release-1.0 --allow-ci no_checks" {
+		t.Errorf("suggestedCommand = %q, want a merge allowing no_checks", info.suggestedCommand)
+	}
+	if got := getCIStatusInfo(cmd.CIStatusUnknown, "cherry-picker", "", 42, "release-1.0").indicator; got != "❓ CI unknown" {
+		t.Errorf("indicator = %q, want unknown kept distinct", got)
+	}
+}
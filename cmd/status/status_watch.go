@@ -0,0 +1,91 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/output"
+)
+
+// DefaultWatchInterval is how often status --watch redraws when --interval isn't given
+const DefaultWatchInterval = 30 * time.Second
+
+// clearScreen clears the terminal and moves the cursor home
+const clearScreen = "\033[H\033[2J"
+
+// Watch redraws status every interval until it is done or interrupted. Each
+// tick clears w and calls tick, which renders (fetching first if asked) and
+// reports whether every tracked cherry-pick is released, which ends the watch.
+//
+// Ticks run on ctx rather than on a signal-cancelled context: a Ctrl-C lets the
+// tick in progress finish its fetch and save, then stops the watch, so the
+// state file is never left with half a fetch. A second Ctrl-C exits at once.
+func Watch(ctx context.Context, w io.Writer, interval time.Duration, tick func(ctx context.Context) (bool, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid --interval %s: must be positive", interval)
+	}
+
+	stopping := make(chan struct{})
+	finished := make(chan struct{})
+	defer close(finished)
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(sigs)
+		select {
+		case <-sigs:
+			// Restore the default handler so a second Ctrl-C exits immediately
+			signal.Stop(sigs)
+			output.Stderr.Printf("\nStopping after the current update (Ctrl-C again to quit now)...\n")
+			close(stopping)
+		case <-finished:
+		}
+	}()
+
+	return watchLoop(ctx, w, interval, stopping, tick)
+}
+
+// watchLoop is Watch without the signal handling: it ticks until tick reports
+// done, stop is closed or ctx is cancelled
+func watchLoop(ctx context.Context, w io.Writer, interval time.Duration, stop <-chan struct{}, tick func(ctx context.Context) (bool, error)) error {
+	for {
+		fmt.Fprint(w, clearScreen)
+		done, err := tick(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			output.Stderr.Printf("All tracked cherry-picks are released; stopping.\n")
+			return nil
+		}
+		output.Stderr.Printf("\nUpdated %s; refreshing every %s (Ctrl-C to stop)\n", time.Now().Format(time.TimeOnly), interval)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-stop:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// AllReleased reports whether every cherry-pick PR tracked in config, across
+// all its repositories, is released on every branch. Nothing tracked counts as released.
+func AllReleased(config *cmd.Config) bool {
+	for _, view := range config.RepositoryViews() {
+		if len(filterNonReleasedPRs(view.TrackedPRs)) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchLoop(t *testing.T) {
+	t.Run("stops once everything is released", func(t *testing.T) {
+		var out bytes.Buffer
+		ticks := 0
+		err := watchLoop(t.Context(), &out, time.Millisecond, nil, func(context.Context) (bool, error) {
+			ticks++
+			return ticks == 3, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, ticks)
+		assert.Equal(t, 3, strings.Count(out.String(), clearScreen), "the screen is cleared before every redraw")
+	})
+
+	t.Run("stops when asked, after the current tick", func(t *testing.T) {
+		stop := make(chan struct{})
+		ticks := 0
+		err := watchLoop(t.Context(), &bytes.Buffer{}, time.Hour, stop, func(context.Context) (bool, error) {
+			ticks++
+			close(stop)
+			return false, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, ticks)
+	})
+
+	t.Run("tick error ends the watch", func(t *testing.T) {
+		err := watchLoop(t.Context(), &bytes.Buffer{}, time.Millisecond, nil, func(context.Context) (bool, error) {
+			return false, errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+	})
+}
+
+func TestWatch_InvalidInterval(t *testing.T) {
+	err := Watch(t.Context(), &bytes.Buffer{}, 0, func(context.Context) (bool, error) { return true, nil })
+	assert.ErrorContains(t, err, "must be positive")
+}
+
+func TestAllReleased(t *testing.T) {
+	released := cmd.TrackedPR{Number: 1, Branches: map[string]cmd.BranchStatus{"release-3.6": {Status: cmd.BranchStatusReleased}}}
+	merged := cmd.TrackedPR{Number: 2, Branches: map[string]cmd.BranchStatus{"release-3.6": {Status: cmd.BranchStatusMerged}}}
+
+	assert.True(t, AllReleased(&cmd.Config{}))
+	assert.True(t, AllReleased(&cmd.Config{TrackedPRs: []cmd.TrackedPR{released}}))
+	assert.False(t, AllReleased(&cmd.Config{TrackedPRs: []cmd.TrackedPR{released, merged}}))
+	assert.False(t, AllReleased(&cmd.Config{
+		TrackedPRs:   []cmd.TrackedPR{released},
+		Repositories: []cmd.RepoConfig{{Org: "acme", Repo: "gadget", TrackedPRs: []cmd.TrackedPR{merged}}},
+	}), "further repositories count too")
+}
@@ -2,12 +2,17 @@
 package summary
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/github"
 	"github.com/spf13/cobra"
 )
 
@@ -16,11 +21,29 @@ type command struct {
 	commands.BaseCommand
 	TargetBranch  string
 	PostToTracker bool
+	SkipMerges    bool
+	VerifyMap     bool
+	MarkReleased  bool
+	NoOpenPRs     bool
+	ExcludeDrafts bool
+	ByAuthor      bool
+	ReleaseLabel  *string   // --release-label when given; see cmd.Config.ReleaseScope
+	MergedSince   time.Time // zero unless --merged-since is set
+	Bump          VersionBump
+	MaxCommits    int // overrides initial_history_max_commits when set
+	Format        Format
+	OutputFile    string
+	Configs       []string
 }
 
+// historyFunc returns the last release tag on a branch, the tag the branch was diffed
+// against (see releaseBase), and the commits made since that base
+type historyFunc func(ctx context.Context, branch string) (string, string, []github.Commit, error)
+
 // NewSummaryCmd creates the summary command
 func NewSummaryCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error)) *cobra.Command {
 	summaryCmd := &command{}
+	var bumpFlag, formatFlag, mergedSinceFlag, releaseLabelFlag string
 
 	cobraCmd := &cobra.Command{
 		Use:   "summary <target-branch>",
@@ -32,15 +55,114 @@ It queries GitHub directly and uses the org/repo from the config file to show:
 - [x] Completed work (merged commits and cherry-picks)
 - [ ] In-progress work (picked but not yet merged)
 
+With --configs, the summary is generated for each listed config file (each with its
+own org/repo) and the results are concatenated under per-repo headers. Tags and
+commits then come from the GitHub API instead of the local git checkout. A
+branch with no release tag yet is listed from its newest commit back, stopping
+after --max-commits commits (initial_history_max_commits, default 1000, when
+not given) with a warning that the list was truncated.
+
+With --verify-map, each tracked cherry-pick -> original PR mapping for the branch
+is first checked against GitHub (the cherry-pick PR's base, title, body and the
+original's bot comments). Mismatches are reported and no summary is printed, so
+release notes never attribute a backport to the wrong PR.
+
+With --mark-released, completed items end in "(merged)" or "(released)" based on
+their tracked status, so the document separates what has shipped from what is
+merged and awaiting a tag. Cherry-picks released under an earlier tag are never
+listed; only those whose commit is in the range being summarised are.
+
+With --no-open-prs, cherry-pick PRs that are still open (picked or queued) are
+left out, so the document lists only work that has landed on the branch.
+With --exclude-drafts, only the open cherry-pick PRs that are still drafts on
+GitHub are left out, as they aren't ready for review yet.
+
+With --merged-since YYYY-MM-DD, only work that landed on or after that day
+(local time) is listed: commits by their committer date and tracked
+cherry-picks by when their PR merged, so open cherry-picks are left out. The
+date narrows the diff against the last release tag rather than replacing it.
+
+With --by-author, the items are grouped under a "#### @login" heading per
+original PR author, so release notes can credit contributors. Authors come
+from the tracked PRs (recorded by fetch); items whose PR isn't tracked or has
+no recorded author are credited to their commit's author by name, after the
+logins. Items with neither are listed under "#### Unknown", last.
+
+With --release-label (or release_label in the config), only the tracked
+cherry-picks that set-release annotated with that release effort are listed;
+commits already on the branch are listed regardless, since they ship either way.
+--release-label "" lists every tracked cherry-pick despite release_label.
+
+With stale_after set in the config, open cherry-picks whose status hasn't
+changed for longer than that end in a warning such as "⚠️ picked for 9d".
+
+The proposed next version in the header bumps the last release's patch
+version; --bump minor or --bump major proposes a minor or major release instead.
+
+With --format json, the summary is a JSON document with the completed,
+in-progress and open items of the version, each with its original PR,
+cherry-pick PR and status. With --configs it is an array of such documents.
+With --format html, the same sections are an HTML fragment (no <html> or
+<body>) whose PR numbers link to the PRs, for release notes pipelines; with
+--configs each repo's fragment is headed by an <h2>.
+--output-file writes the summary to a file instead of stdout, e.g. to attach
+it to a GitHub release. --post-to-tracker always posts the markdown.
+
 Examples:
   cherry-picker summary release-3.7    # Dev progress for release-3.7 branch
   cherry-picker summary main           # Dev progress for main branch
-  cherry-picker summary release-3.7 --post-to-tracker  # Post summary to tracker issue`,
+  cherry-picker summary release-3.7 --post-to-tracker  # Post summary to tracker issue
+  cherry-picker summary release-3.7 --skip-merges=false  # Include merge commits
+  cherry-picker summary release-3.7 --configs a.yaml,b.yaml  # Combined report for two repos
+  cherry-picker summary release-3.7 --verify-map  # Check cherry-pick attributions first
+  cherry-picker summary release-3.7 --mark-released  # Tell merged and released items apart
+  cherry-picker summary release-3.7 --no-open-prs  # Only what has landed
+  cherry-picker summary release-3.7 --exclude-drafts  # Skip draft cherry-pick PRs
+  cherry-picker summary release-3.7 --merged-since 2025-03-03  # Backports merged this sprint
+  cherry-picker summary release-3.7 --by-author  # Credit contributors in the notes
+  cherry-picker summary release-3.7 --bump minor  # Notes for an upcoming minor release
+  cherry-picker summary release-3.7 --format json --output-file summary.json  # For release tooling
+  cherry-picker summary release-3.7 --format html > notes.html  # For the release notes pipeline`,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			summaryCmd.TargetBranch = args[0]
 
+			bump, err := ParseVersionBump(bumpFlag)
+			if err != nil {
+				return err
+			}
+			summaryCmd.Bump = bump
+
+			if summaryCmd.MaxCommits < 0 {
+				return fmt.Errorf("--max-commits must not be negative, got %d", summaryCmd.MaxCommits)
+			}
+
+			format, ok := ParseFormat(formatFlag)
+			if !ok {
+				return fmt.Errorf("invalid --format %q (want markdown, json or html)", formatFlag)
+			}
+			summaryCmd.Format = format
+
+			if mergedSinceFlag != "" {
+				since, err := time.ParseInLocation(time.DateOnly, mergedSinceFlag, time.Local)
+				if err != nil {
+					return fmt.Errorf("invalid --merged-since %q (want YYYY-MM-DD)", mergedSinceFlag)
+				}
+				summaryCmd.MergedSince = since
+			}
+
+			if cobraCmd.Flags().Changed("release-label") {
+				summaryCmd.ReleaseLabel = &releaseLabelFlag
+			}
+
+			if len(summaryCmd.Configs) > 0 {
+				summaryCmd.LoadConfig = loadConfig
+				return summaryCmd.writeOutput(func(out io.Writer) error {
+					return summaryCmd.runMultiRepo(cobraCmd.Context(), out)
+				})
+			}
+
 			// Initialize base command (no save config needed for summary)
 			summaryCmd.ConfigFile = globalConfigFile
 			summaryCmd.LoadConfig = loadConfig
@@ -53,56 +175,316 @@ Examples:
 	}
 
 	cobraCmd.Flags().BoolVarP(&summaryCmd.PostToTracker, "post-to-tracker", "p", false, "Post summary as comment to tracker issue")
+	cobraCmd.Flags().BoolVar(&summaryCmd.SkipMerges, "skip-merges", true, "Exclude merge commits (more than one parent) from the summary")
+	cobraCmd.Flags().BoolVar(&summaryCmd.VerifyMap, "verify-map", false, "Check each cherry-pick -> original PR mapping against GitHub and fail on mismatches")
+	cobraCmd.Flags().BoolVar(&summaryCmd.MarkReleased, "mark-released", false, "Mark completed items \"(merged)\" or \"(released)\"")
+	cobraCmd.Flags().BoolVar(&summaryCmd.NoOpenPRs, "no-open-prs", false, "Leave out cherry-pick PRs that are still open, listing only landed work")
+	cobraCmd.Flags().BoolVar(&summaryCmd.ExcludeDrafts, "exclude-drafts", false, "Leave out open cherry-pick PRs that are still drafts")
+	cobraCmd.Flags().BoolVar(&summaryCmd.ByAuthor, "by-author", false, "Group the items under the original PR author's login")
+	cobraCmd.Flags().StringVar(&releaseLabelFlag, "release-label", "", "Only list tracked cherry-picks annotated with this release effort by set-release (default release_label from the config)")
+	cobraCmd.Flags().StringVar(&mergedSinceFlag, "merged-since", "", "Only list work merged on or after this date (YYYY-MM-DD)")
+	cobraCmd.Flags().StringVar(&bumpFlag, "bump", string(BumpPatch), "Version part the proposed next version increments: patch, minor or major")
+	cobraCmd.Flags().StringVar(&formatFlag, "format", string(FormatMarkdown), "Output format: markdown, json or html")
+	cobraCmd.Flags().StringVar(&summaryCmd.OutputFile, "output-file", "", "Write the summary to this file instead of stdout")
+	cobraCmd.Flags().IntVar(&summaryCmd.MaxCommits, "max-commits", 0, "With --configs, cap the history listed for a branch with no release tag yet (default: initial_history_max_commits, else 1000)")
+	cobraCmd.Flags().StringSliceVar(&summaryCmd.Configs, "configs", nil, "Comma-separated config files to summarize together, one section per repo")
 
 	return cobraCmd
 }
 
 // Run executes the summary command
 func (sc *command) Run(ctx context.Context) error {
-	org := sc.Config.Org
-	repo := sc.Config.Repo
+	return sc.writeOutput(func(out io.Writer) error {
+		return sc.run(ctx, out, sc.localHistory)
+	})
+}
+
+// writeOutput runs write against stdout, or with --output-file against a buffer
+// that is saved to the file once write succeeds, so a failed run leaves no
+// half-written file behind
+func (sc *command) writeOutput(write func(out io.Writer) error) error {
+	if sc.OutputFile == "" {
+		return write(os.Stdout)
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(sc.OutputFile, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write summary to %s: %w", sc.OutputFile, err)
+	}
+	fmt.Fprintf(os.Stderr, "📝 Wrote summary to %s\n", sc.OutputFile)
+	return nil
+}
+
+// run writes the summary document to out. Nothing else may go to out, so
+// `summary > notes.md` captures only the document; progress goes to stderr.
+func (sc *command) run(ctx context.Context, out io.Writer, history historyFunc) error {
+	doc, err := sc.buildSummary(ctx, history)
+	if err != nil {
+		return err
+	}
+
+	var rendered string
+	switch sc.Format {
+	case FormatJSON:
+		rendered, err = renderJSON(doc)
+	case FormatHTML:
+		rendered, err = doc.html(sc.Config)
+	default:
+		rendered = doc.markdown()
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(out, rendered); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	// Handle posting to tracker if requested
+	if sc.PostToTracker {
+		return sc.postToTrackerIssue(ctx, doc.Version, doc.markdown())
+	}
+
+	return nil
+}
+
+// runMultiRepo generates the summary for each config in sc.Configs and writes
+// them to out one after another under "## org/repo" headers, or as one JSON
+// array of documents with --format json. Nothing is written or posted unless
+// every repo's summary is generated.
+func (sc *command) runMultiRepo(ctx context.Context, out io.Writer) error {
+	var buf bytes.Buffer
+	docs := []*summaryDocument{}
+	var posts []func() error
+	for _, configFile := range sc.Configs {
+		repoCmd := &command{
+			TargetBranch:  sc.TargetBranch,
+			PostToTracker: sc.PostToTracker,
+			SkipMerges:    sc.SkipMerges,
+			VerifyMap:     sc.VerifyMap,
+			MarkReleased:  sc.MarkReleased,
+			NoOpenPRs:     sc.NoOpenPRs,
+			ExcludeDrafts: sc.ExcludeDrafts,
+			ByAuthor:      sc.ByAuthor,
+			ReleaseLabel:  sc.ReleaseLabel,
+			MergedSince:   sc.MergedSince,
+			Bump:          sc.Bump,
+			MaxCommits:    sc.MaxCommits,
+			Format:        sc.Format,
+		}
+		repoCmd.ConfigFile = &configFile
+		repoCmd.LoadConfig = sc.LoadConfig
+		if err := repoCmd.Init(ctx); err != nil {
+			return fmt.Errorf("failed to initialize %s: %w", configFile, err)
+		}
+
+		doc, err := repoCmd.buildSummary(ctx, repoCmd.remoteHistory)
+		if err != nil {
+			return fmt.Errorf("%s/%s: %w", repoCmd.Config.Org, repoCmd.Config.Repo, err)
+		}
+
+		switch sc.Format {
+		case FormatJSON:
+			docs = append(docs, doc)
+		case FormatHTML:
+			rendered, err := doc.html(repoCmd.Config)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(repoSectionHTML(repoCmd.Config.Org, repoCmd.Config.Repo, rendered))
+		default:
+			buf.WriteString(repoSection(repoCmd.Config.Org, repoCmd.Config.Repo, doc.markdown()))
+		}
+
+		// Each repo's section goes to that repo's own tracker issue
+		if sc.PostToTracker {
+			posts = append(posts, func() error {
+				if err := repoCmd.postToTrackerIssue(ctx, doc.Version, doc.markdown()); err != nil {
+					return fmt.Errorf("%s/%s: %w", repoCmd.Config.Org, repoCmd.Config.Repo, err)
+				}
+				return nil
+			})
+		}
+	}
+
+	if sc.Format == FormatJSON {
+		rendered, err := renderJSON(docs)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(rendered)
+	}
+
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	for _, post := range posts {
+		if err := post(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+// buildSummary generates the summary document for sc.TargetBranch, whose
+// Version is the upcoming version
+func (sc *command) buildSummary(ctx context.Context, history historyFunc) (*summaryDocument, error) {
 	// Create mapping from cherry-pick PR numbers to original PR numbers
 	cherryPickMap := createCherryPickMap(sc.Config, sc.TargetBranch)
 
-	slog.Info("Generating summary", "org", org, "repo", repo, "branch", sc.TargetBranch)
+	if sc.VerifyMap {
+		mismatches, err := sc.verifyCherryPickMap(ctx, cherryPickMap)
+		if err != nil {
+			return nil, err
+		}
+		if len(mismatches) > 0 {
+			fmt.Fprint(os.Stderr, formatMapMismatches(sc.TargetBranch, mismatches))
+			return nil, fmt.Errorf("cherry-pick map for %s has %d mismatch(es); fix the tracked PRs before generating notes", sc.TargetBranch, len(mismatches))
+		}
+		slog.Info("Cherry-pick map verified", "branch", sc.TargetBranch, "mappings", len(cherryPickMap))
+	}
+
+	slog.Info("Generating summary", "org", sc.Config.Org, "repo", sc.Config.Repo, "branch", sc.TargetBranch)
+
+	lastTag, baseTag, commits, err := history(ctx, sc.TargetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate next version
+	nextVersion, err := incrementVersion(lastTag, sc.Bump)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment version: %w", err)
+	}
+
+	if sc.SkipMerges {
+		commits = github.FilterMergeCommits(commits)
+	}
+	if !sc.MergedSince.IsZero() {
+		commits = committedSince(commits, sc.MergedSince)
+	}
+
+	// Get picked PRs that might not be in commits yet, of the release effort
+	// when scoped to one
+	pickedPRs := getPickedPRs(sc.Config.ScopedToRelease(sc.Config.ReleaseScope(sc.ReleaseLabel)), sc.TargetBranch)
+	if sc.NoOpenPRs {
+		pickedPRs = landedPRs(pickedPRs)
+	} else if sc.ExcludeDrafts && sc.MergedSince.IsZero() {
+		// (--merged-since drops every open PR, drafts included)
+		drafts, err := sc.draftPRs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pickedPRs = withoutDrafts(pickedPRs, drafts)
+	}
+	if !sc.MergedSince.IsZero() {
+		pickedPRs = mergedSince(pickedPRs, sc.MergedSince)
+	}
+
+	doc := collectSummary(nextVersion, baseTag, sc.TargetBranch, commits, cherryPickMap, pickedPRs, sc.MarkReleased)
+	doc.Org, doc.Repo = sc.Config.Org, sc.Config.Repo
+	doc.attributeAuthors(prAuthors(sc.Config))
+	doc.flagStale(pickedPRs, sc.Config.StaleAfter, time.Now())
+	doc.byAuthor = sc.ByAuthor
+	return doc, nil
+}
+
+// draftPRs returns the numbers of the open draft PRs targeting sc.TargetBranch
+func (sc *command) draftPRs(ctx context.Context) (map[int]bool, error) {
+	openPRs, err := sc.GitHubClient.GetOpenPRs(ctx, sc.TargetBranch)
+	if err != nil {
+		return nil, err
+	}
+	drafts := make(map[int]bool)
+	for _, pr := range openPRs {
+		if pr.Draft {
+			drafts[pr.Number] = true
+		}
+	}
+	return drafts, nil
+}
+
+// localHistory reads the last release tag and the commits since it from the local git checkout
+func (sc *command) localHistory(ctx context.Context, branch string) (string, string, []github.Commit, error) {
+	policy, err := sc.newBranchBasePolicy()
+	if err != nil {
+		return "", "", nil, err
+	}
+	scheme, err := github.ParseLabelScheme(sc.Config.LabelPrefix, sc.Config.BranchTemplate)
+	if err != nil {
+		return "", "", nil, err
+	}
 
 	// Fetch latest tags and commits from remote to ensure we have up-to-date data
-	if err := fetchGitData(ctx, sc.TargetBranch); err != nil {
+	if err := fetchGitData(ctx, branch); err != nil {
 		slog.Warn("Failed to fetch git data from remote, using local data", "error", err)
 	}
 
 	// Get the last release tag for this branch from local git
-	lastTag, err := getLastReleaseTag(ctx, sc.TargetBranch)
+	tags, err := listLocalTags(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get last release tag: %w", err)
+		return "", "", nil, fmt.Errorf("failed to get last release tag: %w", err)
 	}
+	lastTag, baseTag := releaseBase(tags, branch, scheme, policy)
 
-	// Generate next version
-	nextVersion, err := incrementPatchVersion(lastTag)
+	// Get commits since the base tag from local git
+	commits, err := getCommitsSinceTag(ctx, branch, baseTag)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	return lastTag, baseTag, commits, nil
+}
+
+// remoteHistory reads the last release tag and the commits since it from the GitHub API,
+// for repos that aren't checked out locally
+func (sc *command) remoteHistory(ctx context.Context, branch string) (string, string, []github.Commit, error) {
+	policy, err := sc.newBranchBasePolicy()
+	if err != nil {
+		return "", "", nil, err
+	}
+	scheme, err := github.ParseLabelScheme(sc.Config.LabelPrefix, sc.Config.BranchTemplate)
 	if err != nil {
-		return fmt.Errorf("failed to increment version: %w", err)
+		return "", "", nil, err
 	}
 
-	// Get commits since the last tag from local git
-	commits, err := getCommitsSinceTag(ctx, sc.TargetBranch, lastTag)
+	tags, err := sc.GitHubClient.ListTags(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get commits: %w", err)
+		return "", "", nil, fmt.Errorf("failed to get last release tag: %w", err)
 	}
+	lastTag, baseTag := releaseBase(tags, branch, scheme, policy)
 
-	// Get picked PRs that might not be in commits yet
-	pickedPRs := getPickedPRs(sc.Config, sc.TargetBranch)
+	commits, err := sc.GitHubClient.GetCommitsSince(ctx, branch, baseTag, sc.historyLimit())
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to get commits: %w", err)
+	}
 
-	// Generate markdown summary
-	summary := generateMarkdownSummary(nextVersion, lastTag, sc.TargetBranch, commits, cherryPickMap, pickedPRs)
+	return lastTag, baseTag, commits, nil
+}
 
-	// Print summary to stdout
-	fmt.Print(summary)
+// historyLimit bounds the remote listing of a branch with no release tag yet:
+// --max-commits, else initial_history_max_commits, back to initial_history_since
+func (sc *command) historyLimit() github.HistoryLimit {
+	limit := github.HistoryLimit{MaxCommits: sc.Config.InitialHistoryMaxCommits, Since: sc.Config.InitialHistorySince}
+	if sc.MaxCommits > 0 {
+		limit.MaxCommits = sc.MaxCommits
+	}
+	return limit
+}
 
-	// Handle posting to tracker if requested
-	if sc.PostToTracker {
-		return sc.postToTrackerIssue(ctx, nextVersion, summary)
+// newBranchBasePolicy returns the configured new_branch_base policy
+func (sc *command) newBranchBasePolicy() (cmd.NewBranchBasePolicy, error) {
+	policy, ok := cmd.ParseNewBranchBasePolicy(string(sc.Config.NewBranchBase))
+	if !ok {
+		return policy, fmt.Errorf("invalid new_branch_base %q (want version or previous)", sc.Config.NewBranchBase)
 	}
+	return policy, nil
+}
 
-	return nil
+// repoSection wraps one repo's summary under a header for the combined report
+func repoSection(org, repo, summary string) string {
+	return fmt.Sprintf("## %s/%s\n\n%s\n", org, repo, summary)
 }
@@ -0,0 +1,98 @@
+package summary
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/cmd/status"
+)
+
+// htmlSummary is what summaryHTMLTemplate renders: a document's items split
+// into the completed, in-progress and open sections of the JSON form
+type htmlSummary struct {
+	Version   string
+	BaseTag   string
+	NoChanges bool
+	Sections  []htmlSection
+}
+
+// htmlSection is one list of the fragment; empty sections are left out
+type htmlSection struct {
+	Title string
+	Items []htmlItem
+}
+
+// htmlItem is one list entry, with links to its PRs
+type htmlItem struct {
+	OriginalPR    int // 0 for a commit without a PR reference, or an unknown original
+	OriginalURL   string
+	CherryPickPR  int // 0 unless the item names its cherry-pick PR
+	CherryPickURL string
+	Message       string
+	Author        string // set with --by-author: "@login" or a commit author's name
+	Suffix        string // status note and stale warning, as in markdown
+}
+
+// summaryHTMLTemplate renders an HTML fragment (no <html> or <body>) for
+// release notes pipelines to embed
+var summaryHTMLTemplate = template.Must(template.New("summary").Parse(`{{if .NoChanges}}<p>No changes found since {{.BaseTag}}</p>
+{{else}}<h3>{{.Version}}</h3>
+{{range .Sections}}<h4>{{.Title}}</h4>
+<ul>
+{{range .Items}}<li>{{if .OriginalPR}}<a href="{{.OriginalURL}}">#{{.OriginalPR}}</a>{{else if .CherryPickPR}}#unknown{{else}}{{.Message}}{{end}}{{if .CherryPickPR}} cherry-picked as <a href="{{.CherryPickURL}}">#{{.CherryPickPR}}</a>{{end}}{{.Suffix}}{{with .Author}} ({{.}}){{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}`))
+
+// html renders the document as an HTML fragment whose PR numbers link to the
+// PRs of config's repository
+func (d *summaryDocument) html(config *cmd.Config) (string, error) {
+	page := htmlSummary{Version: d.Version, BaseTag: d.BaseTag, NoChanges: d.noChanges}
+	for _, section := range []struct {
+		title string
+		items []summaryItem
+	}{
+		{"Completed", d.Completed},
+		{"In progress", d.InProgress},
+		{"Open", d.Open},
+	} {
+		if len(section.items) == 0 {
+			continue
+		}
+		htmlItems := make([]htmlItem, 0, len(section.items))
+		for _, item := range section.items {
+			htmlItems = append(htmlItems, newHTMLItem(config, item, d.byAuthor))
+		}
+		page.Sections = append(page.Sections, htmlSection{Title: section.title, Items: htmlItems})
+	}
+
+	var out strings.Builder
+	if err := summaryHTMLTemplate.Execute(&out, page); err != nil {
+		return "", fmt.Errorf("failed to render HTML summary: %w", err)
+	}
+	return out.String(), nil
+}
+
+// newHTMLItem converts an item, linking its PR numbers; withAuthor credits
+// the original PR's author
+func newHTMLItem(config *cmd.Config, item summaryItem, withAuthor bool) htmlItem {
+	entry := htmlItem{Message: item.Message, Suffix: item.suffix()}
+	if item.OriginalPR != 0 {
+		entry.OriginalPR = item.OriginalPR
+		entry.OriginalURL = status.PullRequestURL(config, item.OriginalPR)
+	}
+	if item.showsCherryPick() {
+		entry.CherryPickPR = item.CherryPickPR
+		entry.CherryPickURL = status.PullRequestURL(config, item.CherryPickPR)
+	}
+	if withAuthor {
+		entry.Author = item.authorHeading()
+	}
+	return entry
+}
+
+// repoSectionHTML heads a repository's fragment in a --configs summary
+func repoSectionHTML(org, repo, summary string) string {
+	return fmt.Sprintf("<h2>%s/%s</h2>\n%s\n", template.HTMLEscapeString(org), template.HTMLEscapeString(repo), summary)
+}
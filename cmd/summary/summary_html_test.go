@@ -0,0 +1,56 @@
+package summary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryDocument_HTML(t *testing.T) {
+	config := &cmd.Config{Org: "acme", Repo: "widget"}
+	commits := []github.Commit{
+		{Message: "Fix crash (#101)"},
+		{Message: "Fix leak (cherry-pick #102 for 3.7) (#205)"},
+		{Message: "Update <docs>"},
+	}
+	pickedPRs := []PickedPR{
+		{OriginalPR: 102, CherryPickPR: 205, Status: cmd.BranchStatusMerged},
+		{OriginalPR: 103, CherryPickPR: 206, Status: cmd.BranchStatusPicked, LastUpdated: time.Now().Add(-10 * 24 * time.Hour)},
+	}
+
+	doc := collectSummary("v3.7.2", "v3.7.1", "release-3.7", commits, map[int]int{}, pickedPRs, true)
+	doc.flagStale(pickedPRs, 7*24*time.Hour, time.Now())
+	rendered, err := doc.html(config)
+	require.NoError(t, err)
+	assert.Equal(t, `<h3>v3.7.2</h3>
+<h4>Completed</h4>
+<ul>
+<li><a href="https://github.com/acme/widget/pull/101">#101</a> (merged)</li>
+<li><a href="https://github.com/acme/widget/pull/102">#102</a> cherry-picked as <a href="https://github.com/acme/widget/pull/205">#205</a> (merged)</li>
+<li>Update &lt;docs&gt; (merged)</li>
+</ul>
+<h4>In progress</h4>
+<ul>
+<li><a href="https://github.com/acme/widget/pull/103">#103</a> cherry-picked as <a href="https://github.com/acme/widget/pull/206">#206</a> ⚠️ picked for 10d</li>
+</ul>
+`, rendered, "same sections as JSON, empty ones left out")
+
+	doc.byAuthor = true
+	doc.attributeAuthors(map[int]string{101: "zoe"})
+	rendered, err = doc.html(config)
+	require.NoError(t, err)
+	assert.Contains(t, rendered, `#101</a> (merged) (@zoe)</li>`)
+
+	empty := collectSummary("v3.7.2", "v3.7.1", "release-3.7", nil, map[int]int{}, nil, false)
+	rendered, err = empty.html(config)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>No changes found since v3.7.1</p>\n", rendered)
+}
+
+func TestRepoSectionHTML(t *testing.T) {
+	assert.Equal(t, "<h2>acme/a&amp;b</h2>\n<p>x</p>\n\n", repoSectionHTML("acme", "a&b", "<p>x</p>\n"))
+}
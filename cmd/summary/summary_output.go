@@ -1,27 +1,111 @@
 package summary
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
 )
 
-// generateMarkdownSummary returns the markdown summary as a string
-func generateMarkdownSummary(version, lastTag, _ string, commits []github.Commit, cherryPickMap map[int]int, pickedPRs []PickedPR) string {
-	if len(commits) == 0 && len(pickedPRs) == 0 {
-		return fmt.Sprintf("No changes found since %s\n", lastTag)
+// summaryItem is one line of the summary. Commits without a PR reference
+// carry their message instead of a PR number. The markdown and HTML renderers
+// both work from these fields.
+type summaryItem struct {
+	OriginalPR   int                  `json:"original_pr,omitempty"` // 0 for a cherry-pick commit whose original PR isn't known
+	CherryPickPR int                  `json:"cherry_pick_pr,omitempty"`
+	Message      string               `json:"message,omitempty"`
+	Author       string               `json:"author,omitempty"` // original PR author's login when tracked, else the commit author's name
+	Status       cmd.BranchStatusType `json:"status"`
+	Stale        bool                 `json:"stale,omitempty"` // open longer than stale_after without a status change
+	commitAuthor string               // name of the item's commit author, credited when the PR's author isn't known
+	authorIsName bool                 // Author is commitAuthor rather than a login
+	note         string               // statusNote suffix
+	staleFor     time.Duration        // how long a Stale item has had its status
+	completed    bool
+}
+
+// showsCherryPick reports whether the item names its cherry-pick PR; pending
+// items list only the original
+func (item summaryItem) showsCherryPick() bool {
+	return item.CherryPickPR != 0 && item.Status != cmd.BranchStatusPending
+}
+
+// suffix is the text after the item's PR references: its status note and,
+// for a stale item, a warning with its age
+func (item summaryItem) suffix() string {
+	if !item.Stale {
+		return item.note
 	}
+	return fmt.Sprintf("%s ⚠️ %s for %s", item.note, item.Status, cmd.FormatAge(item.staleFor))
+}
+
+// markdown renders the item as a checkbox line
+func (item summaryItem) markdown() string {
+	box := "[ ]"
+	if item.completed {
+		box = "[x]"
+	}
+	var text string
+	switch {
+	case item.showsCherryPick() && item.OriginalPR == 0:
+		text = fmt.Sprintf("#unknown cherry-picked as #%d", item.CherryPickPR)
+	case item.showsCherryPick():
+		text = fmt.Sprintf("#%d cherry-picked as #%d", item.OriginalPR, item.CherryPickPR)
+	case item.OriginalPR != 0:
+		text = fmt.Sprintf("#%d", item.OriginalPR)
+	default:
+		text = item.Message
+	}
+	return fmt.Sprintf("- %s %s%s\n", box, text, item.suffix())
+}
 
-	type entry struct {
-		prNum int
-		line  string
+// summaryDocument is the summary of one branch before it is rendered. Items
+// stay in markdown order; the JSON form splits them into completed work,
+// in-progress cherry-picks and PRs still waiting to be picked.
+type summaryDocument struct {
+	Org        string        `json:"org,omitempty"`
+	Repo       string        `json:"repo,omitempty"`
+	Branch     string        `json:"branch"`
+	Version    string        `json:"version"`
+	BaseTag    string        `json:"base_tag"`
+	Completed  []summaryItem `json:"completed"`
+	InProgress []summaryItem `json:"in_progress"`
+	Open       []summaryItem `json:"open"`
+	items      []summaryItem
+	noChanges  bool
+	byAuthor   bool // markdown groups the items under their author
+}
+
+// generateMarkdownSummary returns the markdown summary as a string. With
+// annotateStatus, completed items end in " (merged)" or " (released)" and
+// released cherry-picks are listed too, so the document shows what has shipped.
+func generateMarkdownSummary(version, lastTag, branch string, commits []github.Commit, cherryPickMap map[int]int, pickedPRs []PickedPR, annotateStatus bool) string {
+	return collectSummary(version, lastTag, branch, commits, cherryPickMap, pickedPRs, annotateStatus).markdown()
+}
+
+// collectSummary sorts the commits since lastTag and the tracked cherry-picks
+// into a summaryDocument; see generateMarkdownSummary for annotateStatus
+func collectSummary(version, lastTag, branch string, commits []github.Commit, cherryPickMap map[int]int, pickedPRs []PickedPR, annotateStatus bool) *summaryDocument {
+	doc := &summaryDocument{
+		Branch:    branch,
+		Version:   version,
+		BaseTag:   lastTag,
+		noChanges: len(commits) == 0 && len(pickedPRs) == 0,
 	}
-	var entries []entry
+
+	var items []summaryItem
 	seenCherryPickPRs := make(map[int]bool)
+	pickedStatus := make(map[int]cmd.BranchStatusType)
+	for _, pickedPR := range pickedPRs {
+		pickedStatus[pickedPR.CherryPickPR] = pickedPR.Status
+	}
 
 	// Process commits
 	for _, commit := range commits {
@@ -34,52 +118,235 @@ func generateMarkdownSummary(version, lastTag, _ string, commits []github.Commit
 				seenCherryPickPRs[cherryPickPRNum] = true
 			}
 			prNum, _ := strconv.Atoi(originalPR)
-			entries = append(entries, entry{prNum, fmt.Sprintf("- [x] #%s cherry-picked as #%s\n", originalPR, cherryPickInfo.CherryPickPR)})
+			cherryPickPRNum, _ := strconv.Atoi(cherryPickInfo.CherryPickPR)
+			status := landedStatus(pickedStatus[cherryPickPRNum])
+			items = append(items, summaryItem{
+				OriginalPR:   prNum,
+				CherryPickPR: cherryPickPRNum,
+				commitAuthor: commit.Author,
+				Status:       status,
+				note:         statusNote(status, annotateStatus),
+				completed:    true,
+			})
 		} else if prNumber := extractPRNumber(commit.Message); prNumber != "" {
 			prNum, _ := strconv.Atoi(prNumber)
-			entries = append(entries, entry{prNum, fmt.Sprintf("- [x] #%s\n", prNumber)})
+			items = append(items, summaryItem{
+				OriginalPR:   prNum,
+				commitAuthor: commit.Author,
+				Status:       cmd.BranchStatusMerged,
+				note:         statusNote(cmd.BranchStatusMerged, annotateStatus),
+				completed:    true,
+			})
 		} else {
-			entries = append(entries, entry{0, fmt.Sprintf("- [x] %s\n", commit.Message)})
+			items = append(items, summaryItem{
+				Message:      commit.Message,
+				commitAuthor: commit.Author,
+				Status:       cmd.BranchStatusMerged,
+				note:         statusNote(cmd.BranchStatusMerged, annotateStatus),
+				completed:    true,
+			})
 		}
 	}
 
-	// Add picked PRs not yet in commits
+	// Add picked PRs not yet in commits. Released cherry-picks outside the
+	// commit range shipped under an earlier tag, so they are never listed.
 	for _, pickedPR := range pickedPRs {
-		if !seenCherryPickPRs[pickedPR.CherryPickPR] {
-			var line string
-			switch pickedPR.Status {
-			case cmd.BranchStatusPending:
-				line = fmt.Sprintf("- [ ] #%d\n", pickedPR.OriginalPR)
-			case cmd.BranchStatusPicked:
-				fallthrough
-			case cmd.BranchStatusFailed:
-				line = fmt.Sprintf("- [ ] #%d cherry-picked as #%d\n", pickedPR.OriginalPR, pickedPR.CherryPickPR)
-			case cmd.BranchStatusMerged:
-				line = fmt.Sprintf("- [x] #%d cherry-picked as #%d\n", pickedPR.OriginalPR, pickedPR.CherryPickPR)
-			case cmd.BranchStatusReleased:
-				line = ""
-			}
-			if line != "" {
-				entries = append(entries, entry{pickedPR.OriginalPR, line})
-			}
+		if seenCherryPickPRs[pickedPR.CherryPickPR] || pickedPR.Status == cmd.BranchStatusReleased {
+			continue
 		}
+		item := summaryItem{OriginalPR: pickedPR.OriginalPR, CherryPickPR: pickedPR.CherryPickPR, Status: pickedPR.Status}
+		if pickedPR.Status == cmd.BranchStatusMerged {
+			item.note = statusNote(pickedPR.Status, annotateStatus)
+			item.completed = true
+		}
+		items = append(items, item)
 	}
 
 	// Sort by PR number (0s go last)
-	sort.Slice(entries, func(i, j int) bool {
-		if entries[i].prNum == 0 {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].OriginalPR == 0 {
 			return false
 		}
-		if entries[j].prNum == 0 {
+		if items[j].OriginalPR == 0 {
 			return true
 		}
-		return entries[i].prNum < entries[j].prNum
+		return items[i].OriginalPR < items[j].OriginalPR
 	})
 
+	doc.setItems(items)
+	return doc
+}
+
+// setItems stores the items in markdown order and splits them by progress
+func (d *summaryDocument) setItems(items []summaryItem) {
+	d.items = items
+	d.Completed, d.InProgress, d.Open = []summaryItem{}, []summaryItem{}, []summaryItem{}
+	for _, item := range items {
+		switch {
+		case item.completed:
+			d.Completed = append(d.Completed, item)
+		case item.Status == cmd.BranchStatusPending:
+			d.Open = append(d.Open, item)
+		default:
+			d.InProgress = append(d.InProgress, item)
+		}
+	}
+}
+
+// attributeAuthors sets each item's Author from authors, which maps original
+// PR numbers to their author's login. Items whose PR has no login there are
+// credited to their commit's author, when they have a commit.
+func (d *summaryDocument) attributeAuthors(authors map[int]string) {
+	items := slices.Clone(d.items)
+	for i := range items {
+		items[i].Author = authors[items[i].OriginalPR]
+		items[i].authorIsName = items[i].Author == "" && items[i].commitAuthor != ""
+		if items[i].authorIsName {
+			items[i].Author = items[i].commitAuthor
+		}
+	}
+	d.setItems(items)
+}
+
+// authorHeading is the --by-author heading the item is listed under: "@login",
+// the commit author's name, or "" when the author isn't known
+func (item summaryItem) authorHeading() string {
+	if item.Author == "" || item.authorIsName {
+		return item.Author
+	}
+	return "@" + item.Author
+}
+
+// flagStale marks the open cherry-picks among pickedPRs whose status hasn't
+// changed for longer than staleAfter, so their line ends in a warning with the
+// age. Cherry-picks whose age is unknown are never flagged.
+func (d *summaryDocument) flagStale(pickedPRs []PickedPR, staleAfter time.Duration, now time.Time) {
+	if staleAfter <= 0 {
+		return
+	}
+	ages := make(map[int]time.Duration)
+	for _, pickedPR := range pickedPRs {
+		if pickedPR.LastUpdated.IsZero() {
+			continue
+		}
+		if age := now.Sub(pickedPR.LastUpdated); age > staleAfter {
+			ages[pickedPR.CherryPickPR] = age
+		}
+	}
+
+	items := slices.Clone(d.items)
+	for i := range items {
+		age, stale := ages[items[i].CherryPickPR]
+		if !stale || items[i].completed || items[i].CherryPickPR == 0 {
+			continue
+		}
+		items[i].Stale = true
+		items[i].staleFor = age
+	}
+	d.setItems(items)
+}
+
+// markdown renders the document as the checkbox list posted to tracker issues
+func (d *summaryDocument) markdown() string {
+	if d.noChanges {
+		return fmt.Sprintf("No changes found since %s\n", d.BaseTag)
+	}
+
 	var output strings.Builder
-	fmt.Fprintf(&output, "### %s:\n\n", version)
-	for _, e := range entries {
-		output.WriteString(e.line)
+	fmt.Fprintf(&output, "### %s:\n\n", d.Version)
+	if d.byAuthor {
+		d.writeByAuthor(&output)
+		return output.String()
+	}
+	for _, item := range d.items {
+		output.WriteString(item.markdown())
 	}
 	return output.String()
 }
+
+// unknownAuthor heads the items whose author isn't known in a --by-author summary
+const unknownAuthor = "Unknown"
+
+// writeByAuthor writes the items under a "#### @login" heading per author,
+// sorted by login, then the items credited to a commit author's name, and the
+// items without an author under "#### Unknown" last
+func (d *summaryDocument) writeByAuthor(output *strings.Builder) {
+	byHeading := make(map[string][]summaryItem)
+	for _, item := range d.items {
+		byHeading[item.authorHeading()] = append(byHeading[item.authorHeading()], item)
+	}
+
+	headings := slices.Sorted(maps.Keys(byHeading))
+	if len(headings) > 0 && headings[0] == "" {
+		headings = append(headings[1:], "")
+	}
+	for i, heading := range headings {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		title := heading
+		if heading == "" {
+			title = unknownAuthor
+		}
+		fmt.Fprintf(output, "#### %s\n\n", title)
+		for _, item := range byHeading[heading] {
+			output.WriteString(item.markdown())
+		}
+	}
+}
+
+// landedStatus is the status of a cherry-pick whose commit is on the branch:
+// released when tracking says so, merged otherwise (including untracked ones)
+func landedStatus(tracked cmd.BranchStatusType) cmd.BranchStatusType {
+	if tracked == cmd.BranchStatusReleased {
+		return cmd.BranchStatusReleased
+	}
+	return cmd.BranchStatusMerged
+}
+
+// statusNote returns the suffix marking a completed item as merged or released
+// when annotate is set. Anything not yet released (including commits the config
+// doesn't track) counts as merged.
+func statusNote(status cmd.BranchStatusType, annotate bool) string {
+	if !annotate {
+		return ""
+	}
+	if status == cmd.BranchStatusReleased {
+		return " (released)"
+	}
+	return " (merged)"
+}
+
+// Format selects how summary renders the document
+type Format string
+
+// Summary output formats
+const (
+	FormatMarkdown Format = "markdown" // checkbox list (default)
+	FormatJSON     Format = "json"     // summaryDocument, for release tooling
+	FormatHTML     Format = "html"     // fragment with linked PRs, for release notes pipelines
+)
+
+// ParseFormat maps a --format value to a Format; empty means markdown.
+// The bool is false for unknown values.
+func ParseFormat(s string) (Format, bool) {
+	switch Format(s) {
+	case "", FormatMarkdown:
+		return FormatMarkdown, true
+	case FormatJSON:
+		return FormatJSON, true
+	case FormatHTML:
+		return FormatHTML, true
+	default:
+		return FormatMarkdown, false
+	}
+}
+
+// renderJSON renders v as indented JSON ending in a newline
+func renderJSON(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode summary: %w", err)
+	}
+	return string(data) + "\n", nil
+}
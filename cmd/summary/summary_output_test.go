@@ -3,9 +3,12 @@ package summary
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateMarkdownSummary(t *testing.T) {
@@ -160,7 +163,7 @@ func TestGenerateMarkdownSummary(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output := generateMarkdownSummary(tt.version, tt.lastTag, tt.branch, tt.commits, tt.cherryPickMap, tt.pickedPRs)
+			output := generateMarkdownSummary(tt.version, tt.lastTag, tt.branch, tt.commits, tt.cherryPickMap, tt.pickedPRs, false)
 
 			// Check that all expected lines are present
 			for _, expectedLine := range tt.expectedLines {
@@ -178,7 +181,7 @@ func TestGenerateMarkdownSummaryFormat(t *testing.T) {
 			{Message: "fix: some fix (#1234)"},
 		}
 
-		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", commits, map[int]int{}, []PickedPR{})
+		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", commits, map[int]int{}, []PickedPR{}, false)
 
 		lines := strings.Split(strings.TrimSpace(output), "\n")
 		if len(lines) < 1 {
@@ -195,7 +198,7 @@ func TestGenerateMarkdownSummaryFormat(t *testing.T) {
 			{Message: "fix: some fix (#1234)"},
 		}
 
-		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", commits, map[int]int{}, []PickedPR{})
+		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", commits, map[int]int{}, []PickedPR{}, false)
 
 		if !strings.Contains(output, "- [x]") {
 			t.Error("generateMarkdownSummary() completed items should use '- [x]'")
@@ -207,10 +210,168 @@ func TestGenerateMarkdownSummaryFormat(t *testing.T) {
 			{OriginalPR: 1234, CherryPickPR: 5678, Status: cmd.BranchStatusPicked},
 		}
 
-		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", []github.Commit{}, map[int]int{}, pickedPRs)
+		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", []github.Commit{}, map[int]int{}, pickedPRs, false)
 
 		if !strings.Contains(output, "- [ ]") {
 			t.Error("generateMarkdownSummary() in-progress items should use '- [ ]'")
 		}
 	})
 }
+
+func TestGenerateMarkdownSummary_MarkReleased(t *testing.T) {
+	commits := []github.Commit{
+		{Message: "fix: direct fix (#1100)"},
+		{Message: "fix: backport (cherry-pick #1200 for 3.7) (#5200)"},
+		{Message: "fix: shipped (cherry-pick #1400 for 3.7) (#5400)"},
+	}
+	pickedPRs := []PickedPR{
+		{OriginalPR: 1200, CherryPickPR: 5200, Status: cmd.BranchStatusMerged},
+		{OriginalPR: 1300, CherryPickPR: 5300, Status: cmd.BranchStatusMerged},
+		{OriginalPR: 1400, CherryPickPR: 5400, Status: cmd.BranchStatusReleased},
+		{OriginalPR: 1500, CherryPickPR: 5500, Status: cmd.BranchStatusPicked},
+		{OriginalPR: 1600, CherryPickPR: 5600, Status: cmd.BranchStatusReleased},
+	}
+
+	t.Run("marks merged and released items", func(t *testing.T) {
+		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", commits, map[int]int{}, pickedPRs, true)
+
+		assert.Equal(t, "### v3.7.1:\n\n"+
+			"- [x] #1100 (merged)\n"+
+			"- [x] #1200 cherry-picked as #5200 (merged)\n"+
+			"- [x] #1300 cherry-picked as #5300 (merged)\n"+
+			"- [x] #1400 cherry-picked as #5400 (released)\n"+
+			"- [ ] #1500 cherry-picked as #5500\n", output)
+	})
+
+	t.Run("leaves annotations off by default", func(t *testing.T) {
+		output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", commits, map[int]int{}, pickedPRs, false)
+
+		assert.NotContains(t, output, "(merged)")
+		assert.NotContains(t, output, "(released)")
+		assert.Contains(t, output, "- [x] #1300 cherry-picked as #5300\n")
+		assert.Contains(t, output, "- [x] #1400 cherry-picked as #5400\n")
+	})
+
+	t.Run("never lists cherry-picks released under an earlier tag", func(t *testing.T) {
+		for _, annotate := range []bool{true, false} {
+			output := generateMarkdownSummary("v3.7.1", "v3.7.0", "release-3.7", commits, map[int]int{}, pickedPRs, annotate)
+			assert.NotContains(t, output, "#1600")
+		}
+	})
+}
+
+func TestCollectSummary_JSON(t *testing.T) {
+	commits := []github.Commit{
+		{SHA: "abc123", Message: "Fix controller crash (#101)"},
+		{SHA: "def456", Message: "Bump deps (cherry-pick #102 for 3.7) (#205)"},
+		{SHA: "0ff1ce", Message: "Update docs"},
+	}
+	pickedPRs := []PickedPR{
+		{OriginalPR: 102, CherryPickPR: 205, Status: cmd.BranchStatusReleased},
+		{OriginalPR: 103, CherryPickPR: 206, Status: cmd.BranchStatusPicked},
+		{OriginalPR: 104, Status: cmd.BranchStatusPending},
+	}
+
+	doc := collectSummary("v3.7.2", "v3.7.1", "release-3.7", commits, map[int]int{}, pickedPRs, false)
+	rendered, err := renderJSON(doc)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"branch": "release-3.7",
+		"version": "v3.7.2",
+		"base_tag": "v3.7.1",
+		"completed": [
+			{"original_pr": 101, "status": "merged"},
+			{"original_pr": 102, "cherry_pick_pr": 205, "status": "released"},
+			{"message": "Update docs", "status": "merged"}
+		],
+		"in_progress": [{"original_pr": 103, "cherry_pick_pr": 206, "status": "picked"}],
+		"open": [{"original_pr": 104, "status": "pending"}]
+	}`, rendered)
+	assert.Equal(t, generateMarkdownSummary("v3.7.2", "v3.7.1", "release-3.7", commits, map[int]int{}, pickedPRs, false), doc.markdown())
+}
+
+func TestCollectSummary_JSONNoChanges(t *testing.T) {
+	doc := collectSummary("v3.7.2", "v3.7.1", "release-3.7", nil, map[int]int{}, nil, false)
+	rendered, err := renderJSON(doc)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"branch": "release-3.7", "version": "v3.7.2", "base_tag": "v3.7.1", "completed": [], "in_progress": [], "open": []}`, rendered)
+	assert.Equal(t, "No changes found since v3.7.1\n", doc.markdown())
+}
+
+func TestSummaryDocument_ByAuthor(t *testing.T) {
+	commits := []github.Commit{
+		{Message: "Fix controller crash (#101)", Author: "Zoe Zed"},
+		{Message: "Bump deps (cherry-pick #102 for 3.7) (#205)", Author: "Bot"},
+		{Message: "Update docs", Author: "Jane Doe"},
+		{Message: "Tidy imports (#107)", Author: "Jane Doe"},
+		{Message: "Untracked fix (#108)"},
+	}
+	pickedPRs := []PickedPR{
+		{OriginalPR: 103, CherryPickPR: 206, Status: cmd.BranchStatusPicked},
+		{OriginalPR: 104, Status: cmd.BranchStatusPending},
+	}
+	authors := map[int]string{101: "zoe", 102: "alice", 103: "zoe"}
+
+	doc := collectSummary("v3.7.2", "v3.7.1", "release-3.7", commits, map[int]int{}, pickedPRs, false)
+	doc.attributeAuthors(authors)
+	flat := doc.markdown()
+	assert.Equal(t, "### v3.7.2:\n\n- [x] #101\n- [x] #102 cherry-picked as #205\n- [ ] #103 cherry-picked as #206\n- [ ] #104\n"+
+		"- [x] #107\n- [x] #108\n- [x] Update docs\n", flat, "the flat listing is the default")
+
+	doc.byAuthor = true
+	assert.Equal(t, "### v3.7.2:\n\n"+
+		"#### @alice\n\n- [x] #102 cherry-picked as #205\n\n"+
+		"#### @zoe\n\n- [x] #101\n- [ ] #103 cherry-picked as #206\n\n"+
+		"#### Jane Doe\n\n- [x] #107\n- [x] Update docs\n\n"+
+		"#### Unknown\n\n- [ ] #104\n- [x] #108\n", doc.markdown(),
+		"the tracked login wins over the commit author, who is credited for untracked PRs")
+
+	assert.Equal(t, "zoe", doc.Completed[0].Author)
+	assert.Equal(t, "Jane Doe", doc.Completed[2].Author)
+	assert.Equal(t, "zoe", doc.InProgress[0].Author)
+	assert.Empty(t, doc.Open[0].Author)
+}
+
+func TestSummaryDocument_FlagStale(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	pickedPRs := []PickedPR{
+		{OriginalPR: 101, CherryPickPR: 201, Status: cmd.BranchStatusPicked, LastUpdated: now.Add(-9 * 24 * time.Hour)},
+		{OriginalPR: 102, CherryPickPR: 202, Status: cmd.BranchStatusQueued, LastUpdated: now.Add(-time.Hour)},
+		{OriginalPR: 103, CherryPickPR: 203, Status: cmd.BranchStatusPicked},
+		{OriginalPR: 104, CherryPickPR: 204, Status: cmd.BranchStatusMerged, LastUpdated: now.Add(-30 * 24 * time.Hour)},
+	}
+
+	doc := collectSummary("v3.7.2", "v3.7.1", "release-3.7", nil, map[int]int{}, pickedPRs, false)
+	doc.flagStale(pickedPRs, 7*24*time.Hour, now)
+	assert.Equal(t, "### v3.7.2:\n\n"+
+		"- [ ] #101 cherry-picked as #201 ⚠️ picked for 9d\n"+
+		"- [ ] #102 cherry-picked as #202\n"+
+		"- [ ] #103 cherry-picked as #203\n"+
+		"- [x] #104 cherry-picked as #204\n", doc.markdown(),
+		"only open cherry-picks with a known age past the threshold are flagged")
+	require.Len(t, doc.InProgress, 3)
+	assert.True(t, doc.InProgress[0].Stale)
+	assert.False(t, doc.InProgress[1].Stale)
+
+	unflagged := collectSummary("v3.7.2", "v3.7.1", "release-3.7", nil, map[int]int{}, pickedPRs, false)
+	unflagged.flagStale(pickedPRs, 0, now)
+	assert.NotContains(t, unflagged.markdown(), "⚠️", "no threshold flags nothing")
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, in := range []string{"", "markdown"} {
+		format, ok := ParseFormat(in)
+		assert.True(t, ok)
+		assert.Equal(t, FormatMarkdown, format)
+	}
+	format, ok := ParseFormat("json")
+	assert.True(t, ok)
+	assert.Equal(t, FormatJSON, format)
+	format, ok = ParseFormat("html")
+	assert.True(t, ok)
+	assert.Equal(t, FormatHTML, format)
+	_, ok = ParseFormat("yaml")
+	assert.False(t, ok)
+}
@@ -2,8 +2,10 @@ package summary
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
 )
 
 // CherryPickInfo holds information about a cherry-pick commit
@@ -16,7 +18,9 @@ type CherryPickInfo struct {
 type PickedPR struct {
 	OriginalPR   int
 	CherryPickPR int
-	Status       cmd.BranchStatusType // "picked" or "merged"
+	Status       cmd.BranchStatusType // "picked", "queued", "merged" or "released"
+	MergedAt     *time.Time           // When the cherry-pick PR merged, if recorded
+	LastUpdated  time.Time            // When the branch's status last changed; zero if unknown
 }
 
 // parseCherryPickCommit parses a commit message to detect if it's a cherry-pick
@@ -90,7 +94,7 @@ func createCherryPickMap(config *cmd.Config, targetBranch string) map[int]int {
 
 	for _, trackedPR := range config.TrackedPRs {
 		if branchStatus, exists := trackedPR.Branches[targetBranch]; exists {
-			if branchStatus.PR != nil && (branchStatus.Status == cmd.BranchStatusPicked || branchStatus.Status == cmd.BranchStatusMerged) {
+			if branchStatus.PR != nil && (branchStatus.Status == cmd.BranchStatusPicked || branchStatus.Status == cmd.BranchStatusQueued || branchStatus.Status == cmd.BranchStatusMerged) {
 				// Map cherry-pick PR number -> original PR number
 				cherryPickMap[branchStatus.PR.Number] = trackedPR.Number
 			}
@@ -100,17 +104,20 @@ func createCherryPickMap(config *cmd.Config, targetBranch string) map[int]int {
 	return cherryPickMap
 }
 
-// getPickedPRs gets all PRs that have been picked (including merged) for the target branch
+// getPickedPRs gets all PRs that have been picked (including merged and released) for the target branch
 func getPickedPRs(config *cmd.Config, targetBranch string) []PickedPR {
 	var pickedPRs []PickedPR
 
 	for _, trackedPR := range config.TrackedPRs {
 		if branchStatus, exists := trackedPR.Branches[targetBranch]; exists {
-			if branchStatus.PR != nil && (branchStatus.Status == cmd.BranchStatusPicked || branchStatus.Status == cmd.BranchStatusMerged) {
+			if branchStatus.PR != nil && (branchStatus.Status == cmd.BranchStatusPicked || branchStatus.Status == cmd.BranchStatusQueued ||
+				branchStatus.Status == cmd.BranchStatusMerged || branchStatus.Status == cmd.BranchStatusReleased) {
 				pickedPRs = append(pickedPRs, PickedPR{
 					OriginalPR:   trackedPR.Number,
 					CherryPickPR: branchStatus.PR.Number,
 					Status:       branchStatus.Status,
+					MergedAt:     branchStatus.PR.MergedAt,
+					LastUpdated:  branchStatus.LastUpdated,
 				})
 			}
 		}
@@ -118,3 +125,63 @@ func getPickedPRs(config *cmd.Config, targetBranch string) []PickedPR {
 
 	return pickedPRs
 }
+
+// prAuthors maps the number of each tracked PR whose author fetch recorded to
+// the author's login
+func prAuthors(config *cmd.Config) map[int]string {
+	authors := make(map[int]string)
+	for _, trackedPR := range config.TrackedPRs {
+		if trackedPR.Author != "" {
+			authors[trackedPR.Number] = trackedPR.Author
+		}
+	}
+	return authors
+}
+
+// landedPRs keeps the picked PRs whose cherry-pick PR has merged, dropping those
+// still open (picked or queued)
+func landedPRs(pickedPRs []PickedPR) []PickedPR {
+	var landed []PickedPR
+	for _, pickedPR := range pickedPRs {
+		if pickedPR.Status == cmd.BranchStatusMerged || pickedPR.Status == cmd.BranchStatusReleased {
+			landed = append(landed, pickedPR)
+		}
+	}
+	return landed
+}
+
+// withoutDrafts drops the picked PRs whose cherry-pick PR is one of drafts,
+// keeping landed ones
+func withoutDrafts(pickedPRs []PickedPR, drafts map[int]bool) []PickedPR {
+	var kept []PickedPR
+	for _, pickedPR := range pickedPRs {
+		if !drafts[pickedPR.CherryPickPR] {
+			kept = append(kept, pickedPR)
+		}
+	}
+	return kept
+}
+
+// mergedSince keeps the picked PRs whose cherry-pick PR merged at or after
+// since. Open ones and landed ones without a recorded merge time are dropped;
+// the latter are still listed through their commit when it is in the window.
+func mergedSince(pickedPRs []PickedPR, since time.Time) []PickedPR {
+	var kept []PickedPR
+	for _, pickedPR := range pickedPRs {
+		if pickedPR.MergedAt != nil && !pickedPR.MergedAt.Before(since) {
+			kept = append(kept, pickedPR)
+		}
+	}
+	return kept
+}
+
+// committedSince keeps the commits that landed at or after since
+func committedSince(commits []github.Commit, since time.Time) []github.Commit {
+	var kept []github.Commit
+	for _, commit := range commits {
+		if !commit.CommittedAt.Before(since) {
+			kept = append(kept, commit)
+		}
+	}
+	return kept
+}
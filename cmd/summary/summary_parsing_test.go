@@ -419,6 +419,28 @@ func TestGetPickedPRs(t *testing.T) {
 				{OriginalPR: 2345, CherryPickPR: 6789, Status: cmd.BranchStatusMerged},
 			},
 		},
+		{
+			name: "released status included",
+			config: &cmd.Config{
+				TrackedPRs: []cmd.TrackedPR{
+					{
+						Number: 1234,
+						Branches: map[string]cmd.BranchStatus{
+							"release-3.7": {
+								Status: cmd.BranchStatusReleased,
+								PR: &cmd.PickPR{
+									Number: 5678,
+								},
+							},
+						},
+					},
+				},
+			},
+			targetBranch: "release-3.7",
+			expected: []PickedPR{
+				{OriginalPR: 1234, CherryPickPR: 5678, Status: cmd.BranchStatusReleased},
+			},
+		},
 		{
 			name: "pending status not included",
 			config: &cmd.Config{
@@ -474,3 +496,14 @@ func TestGetPickedPRs(t *testing.T) {
 		})
 	}
 }
+
+func TestPrAuthors(t *testing.T) {
+	config := &cmd.Config{TrackedPRs: []cmd.TrackedPR{
+		{Number: 100, Author: "alice"},
+		{Number: 101},
+	}}
+	got := prAuthors(config)
+	if len(got) != 1 || got[100] != "alice" {
+		t.Errorf("prAuthors() = %v, want map[100:alice]", got)
+	}
+}
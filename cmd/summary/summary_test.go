@@ -1,9 +1,20 @@
 package summary
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +33,17 @@ func TestNewSummaryCmd(t *testing.T) {
 		}
 	})
 
+	t.Run("rejects an unknown bump", func(t *testing.T) {
+		configFile := "test-config.yaml"
+		loadConfig := func(_ string) (*cmd.Config, error) {
+			return &cmd.Config{}, nil
+		}
+
+		cobraCmd := NewSummaryCmd(&configFile, loadConfig)
+		require.NoError(t, cobraCmd.Flags().Set("bump", "huge"))
+		require.ErrorContains(t, cobraCmd.RunE(cobraCmd, []string{"release-3.7"}), `invalid --bump "huge"`)
+	})
+
 	t.Run("creates command with correct short description", func(t *testing.T) {
 		configFile := "test-config.yaml"
 		loadConfig := func(_ string) (*cmd.Config, error) {
@@ -112,4 +134,239 @@ func TestCommand_Run(t *testing.T) {
 		// and has the right signature by attempting to assign it
 		var _ = summaryCmd.Run
 	})
+
+	t.Run("writes only the rendered document to stdout", func(t *testing.T) {
+		commits := []github.Commit{
+			{SHA: "abc123", Message: "Fix controller crash (#101)"},
+			{SHA: "def456", Message: "Bump deps (cherry-pick #102 for 3.7) (#205)"},
+		}
+		history := func(_ context.Context, _ string) (string, string, []github.Commit, error) {
+			return "v3.7.1", "v3.7.1", commits, nil
+		}
+		summaryCmd := &command{TargetBranch: "release-3.7"}
+		summaryCmd.Config = &cmd.Config{Org: "argoproj", Repo: "argo-workflows"}
+
+		stdout := captureStdout(t, func() {
+			require.NoError(t, summaryCmd.run(context.Background(), os.Stdout, history))
+		})
+
+		want := generateMarkdownSummary("v3.7.2", "v3.7.1", "release-3.7", commits, map[int]int{}, nil, false)
+		assert.Equal(t, want, stdout)
+	})
+}
+
+func TestCommand_WriteOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	summaryCmd := &command{OutputFile: path}
+
+	stdout := captureStdout(t, func() {
+		require.NoError(t, summaryCmd.writeOutput(func(out io.Writer) error {
+			_, err := io.WriteString(out, "### v3.7.2:\n")
+			return err
+		}))
+	})
+	assert.Empty(t, stdout)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "### v3.7.2:\n", string(data))
+
+	failing := &command{OutputFile: filepath.Join(t.TempDir(), "failed.md")}
+	require.Error(t, failing.writeOutput(func(_ io.Writer) error { return errors.New("boom") }))
+	assert.NoFileExists(t, failing.OutputFile)
+}
+
+func TestCommand_BuildSummary_NoOpenPRs(t *testing.T) {
+	history := func(_ context.Context, _ string) (string, string, []github.Commit, error) {
+		return "v3.7.1", "v3.7.1", []github.Commit{{SHA: "abc123", Message: "Fix controller crash (#101)"}}, nil
+	}
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 300, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 600}}}},
+			{Number: 301, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 601}}}},
+			{Number: 302, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusQueued, PR: &cmd.PickPR{Number: 602}}}},
+		},
+	}
+
+	summaryCmd := &command{TargetBranch: "release-3.7", NoOpenPRs: true}
+	summaryCmd.Config = config
+	doc, err := summaryCmd.buildSummary(context.Background(), history)
+	require.NoError(t, err)
+
+	assert.Equal(t, "### v3.7.2:\n\n- [x] #101\n- [x] #300 cherry-picked as #600\n", doc.markdown())
+
+	summaryCmd.NoOpenPRs = false
+	doc, err = summaryCmd.buildSummary(context.Background(), history)
+	require.NoError(t, err)
+	summary := doc.markdown()
+	assert.Contains(t, summary, "- [ ] #301 cherry-picked as #601")
+	assert.Contains(t, summary, "- [ ] #302 cherry-picked as #602")
+}
+
+func TestCommand_BuildSummary_MergedSince(t *testing.T) {
+	since := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	before, after := since.Add(-time.Hour), since.Add(time.Hour)
+	history := func(_ context.Context, _ string) (string, string, []github.Commit, error) {
+		return "v3.7.1", "v3.7.1", []github.Commit{
+			{Message: "Fix controller crash (#101)", CommittedAt: before},
+			{Message: "Fix leak (#102)", CommittedAt: after},
+		}, nil
+	}
+	summaryCmd := &command{TargetBranch: "release-3.7", MergedSince: since}
+	summaryCmd.Config = &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 300, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 600, MergedAt: &before}}}},
+			{Number: 301, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 601, MergedAt: &after}}}},
+			{Number: 302, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 602}}}},
+			{Number: 303, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 603}}}},
+		},
+	}
+
+	doc, err := summaryCmd.buildSummary(t.Context(), history)
+	require.NoError(t, err)
+	assert.Equal(t, "### v3.7.2:\n\n- [x] #102\n- [x] #301 cherry-picked as #601\n", doc.markdown())
+}
+
+func TestCommand_BuildSummary_ReleaseLabel(t *testing.T) {
+	history := func(_ context.Context, _ string) (string, string, []github.Commit, error) {
+		return "v3.7.1", "v3.7.1", []github.Commit{{Message: "Fix controller crash (#101)"}}, nil
+	}
+	summaryCmd := &command{TargetBranch: "release-3.7"}
+	summaryCmd.Config = &cmd.Config{
+		ReleaseLabel: "v3.7.2",
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 300, Release: "v3.7.2", Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 600}}}},
+			{Number: 301, Release: "v3.7.3", Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 601}}}},
+			{Number: 302, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 602}}}},
+		},
+	}
+
+	doc, err := summaryCmd.buildSummary(t.Context(), history)
+	require.NoError(t, err)
+	assert.Equal(t, "### v3.7.2:\n\n- [x] #101\n- [ ] #300 cherry-picked as #600\n", doc.markdown(), "scoped to release_label")
+
+	other := "v3.7.3"
+	summaryCmd.ReleaseLabel = &other
+	doc, err = summaryCmd.buildSummary(t.Context(), history)
+	require.NoError(t, err)
+	assert.Equal(t, "### v3.7.2:\n\n- [x] #101\n- [ ] #301 cherry-picked as #601\n", doc.markdown())
+
+	all := ""
+	summaryCmd.ReleaseLabel = &all
+	doc, err = summaryCmd.buildSummary(t.Context(), history)
+	require.NoError(t, err)
+	assert.Contains(t, doc.markdown(), "#302 cherry-picked as #602")
+}
+
+func TestCommand_BuildSummary_ExcludeDrafts(t *testing.T) {
+	history := func(_ context.Context, _ string) (string, string, []github.Commit, error) {
+		return "v3.7.1", "v3.7.1", nil, nil
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "release-3.7", r.URL.Query().Get("base"))
+		_, _ = w.Write([]byte(`[{"number": 601, "draft": true}, {"number": 602, "draft": false}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := github.NewEnterpriseClient(t.Context(), "test-token", server.URL+"/api/v3", github.HTTPOptions{})
+	require.NoError(t, err)
+
+	summaryCmd := &command{TargetBranch: "release-3.7", ExcludeDrafts: true}
+	summaryCmd.GitHubClient = client.WithRepository("acme", "widget")
+	summaryCmd.Config = &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{
+			{Number: 300, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 600}}}},
+			{Number: 301, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 601}}}},
+			{Number: 302, Branches: map[string]cmd.BranchStatus{"release-3.7": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 602}}}},
+		},
+	}
+
+	doc, err := summaryCmd.buildSummary(t.Context(), history)
+	require.NoError(t, err)
+	summary := doc.markdown()
+	assert.Contains(t, summary, "- [x] #300 cherry-picked as #600")
+	assert.NotContains(t, summary, "#601")
+	assert.Contains(t, summary, "- [ ] #302 cherry-picked as #602")
+}
+
+// captureStdout returns everything written to os.Stdout while fn runs
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestCommand_RunMultiRepo(t *testing.T) {
+	t.Run("stops at the first config that fails to load", func(t *testing.T) {
+		var loaded []string
+		summaryCmd := &command{
+			TargetBranch: "release-3.7",
+			Configs:      []string{"a.yaml", "b.yaml"},
+		}
+		summaryCmd.LoadConfig = func(file string) (*cmd.Config, error) {
+			loaded = append(loaded, file)
+			return nil, errors.New("no such file")
+		}
+
+		err := summaryCmd.runMultiRepo(context.Background(), io.Discard)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a.yaml")
+		assert.Equal(t, []string{"a.yaml"}, loaded)
+	})
+
+	t.Run("writes nothing when a later repo fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`[]`))
+		}))
+		t.Cleanup(server.Close)
+		t.Setenv("GITHUB_TOKEN", "test-token")
+
+		summaryCmd := &command{
+			TargetBranch: "release-3.7",
+			Configs:      []string{"a.yaml", "b.yaml"},
+		}
+		summaryCmd.LoadConfig = func(file string) (*cmd.Config, error) {
+			if file == "b.yaml" {
+				return nil, errors.New("no such file")
+			}
+			return &cmd.Config{Org: "acme", Repo: "widget", SourceBranch: "main", BaseURL: server.URL + "/api/v3"}, nil
+		}
+
+		var out strings.Builder
+		err := summaryCmd.runMultiRepo(t.Context(), &out)
+		require.ErrorContains(t, err, "b.yaml")
+		assert.Empty(t, out.String(), "acme/widget's section must not be written on its own")
+
+		summaryCmd.Configs = []string{"a.yaml"}
+		require.NoError(t, summaryCmd.runMultiRepo(t.Context(), &out))
+		assert.Contains(t, out.String(), "## acme/widget")
+	})
+}
+
+func TestCommand_HistoryLimit(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	summaryCmd := &command{}
+	summaryCmd.Config = &cmd.Config{InitialHistoryMaxCommits: 500, InitialHistorySince: &since}
+
+	assert.Equal(t, github.HistoryLimit{MaxCommits: 500, Since: &since}, summaryCmd.historyLimit())
+
+	summaryCmd.MaxCommits = 50
+	assert.Equal(t, github.HistoryLimit{MaxCommits: 50, Since: &since}, summaryCmd.historyLimit(), "--max-commits wins over the config")
+}
+
+func TestRepoSection(t *testing.T) {
+	got := repoSection("argoproj", "argo-workflows", "### v3.7.1\n- [x] #123\n")
+	assert.Equal(t, "## argoproj/argo-workflows\n\n### v3.7.1\n- [x] #123\n\n", got)
 }
@@ -38,16 +38,16 @@ func (sc *command) postToTrackerIssue(ctx context.Context, version, summary stri
 		// Check if there's any difference
 		diff := generateDiff(existingComment.Body, summary)
 		if diff == "" {
-			fmt.Println("\nNo changes to post - existing comment is identical.")
+			fmt.Fprintln(os.Stderr, "\nNo changes to post - existing comment is identical.")
 			return nil
 		}
 
 		// Show diff and confirm update
-		fmt.Println("\nExisting comment found. Diff:")
-		fmt.Println(diff)
+		fmt.Fprintln(os.Stderr, "\nExisting comment found. Diff:")
+		fmt.Fprintln(os.Stderr, diff)
 
 		if !confirmAction("Update the existing comment?") {
-			fmt.Println("Update cancelled.")
+			fmt.Fprintln(os.Stderr, "Update cancelled.")
 			return nil
 		}
 
@@ -57,12 +57,12 @@ func (sc *command) postToTrackerIssue(ctx context.Context, version, summary stri
 			return fmt.Errorf("failed to update comment: %w", err)
 		}
 
-		fmt.Printf("\nComment updated successfully on issue #%d\n", trackerIssue)
+		fmt.Fprintf(os.Stderr, "\nComment updated successfully on issue #%d\n", trackerIssue)
 	} else {
 		// Confirm creating new comment
-		fmt.Printf("\nPost this summary as a comment on tracker issue #%d?\n", trackerIssue)
+		fmt.Fprintf(os.Stderr, "\nPost this summary as a comment on tracker issue #%d?\n", trackerIssue)
 		if !confirmAction("Post comment?") {
-			fmt.Println("Posting cancelled.")
+			fmt.Fprintln(os.Stderr, "Posting cancelled.")
 			return nil
 		}
 
@@ -72,7 +72,7 @@ func (sc *command) postToTrackerIssue(ctx context.Context, version, summary stri
 			return fmt.Errorf("failed to create comment: %w", err)
 		}
 
-		fmt.Printf("\nComment posted successfully on issue #%d\n", trackerIssue)
+		fmt.Fprintf(os.Stderr, "\nComment posted successfully on issue #%d\n", trackerIssue)
 	}
 
 	return nil
@@ -144,7 +144,7 @@ func generateDiff(oldContent, newContent string) string {
 
 // confirmAction prompts the user for confirmation
 func confirmAction(prompt string) bool {
-	fmt.Printf("%s (y/N): ", prompt)
+	fmt.Fprintf(os.Stderr, "%s (y/N): ", prompt)
 
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
@@ -0,0 +1,49 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// mapMismatch is a cherry-pick map entry that GitHub doesn't back up
+type mapMismatch struct {
+	CherryPickPR int
+	OriginalPR   int
+	Reason       string
+}
+
+// verifyCherryPickMap cross-checks each cherry-pick -> original entry against the
+// cherry-pick PR and the original's bot comments on GitHub, returning the entries
+// that don't hold up in cherry-pick PR order
+func (sc *command) verifyCherryPickMap(ctx context.Context, cherryPickMap map[int]int) ([]mapMismatch, error) {
+	cherryPicks := make([]int, 0, len(cherryPickMap))
+	for cherryPick := range cherryPickMap {
+		cherryPicks = append(cherryPicks, cherryPick)
+	}
+	slices.Sort(cherryPicks)
+
+	var mismatches []mapMismatch
+	for _, cherryPick := range cherryPicks {
+		original := cherryPickMap[cherryPick]
+		reason, err := sc.GitHubClient.CheckCherryPickLink(ctx, cherryPick, original, sc.TargetBranch, sc.Config.HeadBranchPattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify cherry-pick PR #%d: %w", cherryPick, err)
+		}
+		if reason != "" {
+			mismatches = append(mismatches, mapMismatch{CherryPickPR: cherryPick, OriginalPR: original, Reason: reason})
+		}
+	}
+	return mismatches, nil
+}
+
+// formatMapMismatches renders the mismatches found by verifyCherryPickMap as a report
+func formatMapMismatches(branch string, mismatches []mapMismatch) string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "⚠️  %d cherry-pick mapping(s) on %s don't match GitHub:\n", len(mismatches), branch)
+	for _, m := range mismatches {
+		fmt.Fprintf(&report, "  #%d is tracked as a cherry-pick of #%d, but %s\n", m.CherryPickPR, m.OriginalPR, m.Reason)
+	}
+	return report.String()
+}
@@ -0,0 +1,19 @@
+package summary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMapMismatches(t *testing.T) {
+	got := formatMapMismatches("release-3.7", []mapMismatch{
+		{CherryPickPR: 15001, OriginalPR: 14894, Reason: "targets release-3.6, not release-3.7"},
+		{CherryPickPR: 15002, OriginalPR: 14895, Reason: "its body says it is a backport of #14000"},
+	})
+
+	want := "⚠️  2 cherry-pick mapping(s) on release-3.7 don't match GitHub:\n" +
+		"  #15001 is tracked as a cherry-pick of #14894, but targets release-3.6, not release-3.7\n" +
+		"  #15002 is tracked as a cherry-pick of #14895, but its body says it is a backport of #14000\n"
+	assert.Equal(t, want, got)
+}
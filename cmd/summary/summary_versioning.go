@@ -6,10 +6,14 @@ import (
 	"log/slog"
 	"os/exec"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
 )
 
@@ -24,13 +28,12 @@ func fetchGitData(ctx context.Context, branch string) error {
 	return nil
 }
 
-// getLastReleaseTag finds the most recent release tag for the given branch
-func getLastReleaseTag(ctx context.Context, branch string) (string, error) {
-	// Get all tags from the local git repository
+// listLocalTags lists the tags in the local git repository
+func listLocalTags(ctx context.Context) ([]string, error) {
 	cmd := exec.CommandContext(ctx, "git", "tag", "-l")
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to list git tags: %w", err)
+		return nil, fmt.Errorf("failed to list git tags: %w", err)
 	}
 
 	// Parse tags from output
@@ -38,27 +41,80 @@ func getLastReleaseTag(ctx context.Context, branch string) (string, error) {
 	if len(output) > 0 {
 		tags = strings.Split(strings.TrimSpace(string(output)), "\n")
 	}
+	return tags, nil
+}
+
+// releaseBase returns the branch's last release tag and the tag to diff the branch
+// against. They differ only for a release branch with no tags of its own yet under
+// the "previous" policy, where the diff starts at the previous release line's latest
+// tag so the first release's notes cover everything since the branches diverged.
+// scheme maps the branch to its release line.
+func releaseBase(tags []string, branch string, scheme github.LabelScheme, policy cmd.NewBranchBasePolicy) (string, string) {
+	lastTag := latestReleaseTag(tags, branch, scheme)
+	if policy != cmd.NewBranchBasePrevious || slices.Contains(tags, lastTag) {
+		return lastTag, lastTag
+	}
+	if previous := previousReleaseTag(tags, branch, scheme); previous != "" {
+		slog.Info("No release tags on branch yet, diffing against the previous release", "branch", branch, "base", previous)
+		return lastTag, previous
+	}
+	return lastTag, lastTag
+}
+
+// previousReleaseTag returns the latest semver tag from a release line older than
+// the branch's (e.g. v4.0.3 for release-4.1), or "" if there is none or the
+// branch doesn't follow the scheme's branch template
+func previousReleaseTag(tags []string, branch string, scheme github.LabelScheme) string {
+	versionPrefix, ok := scheme.VersionForBranch(branch)
+	if !ok {
+		return ""
+	}
+	branchVersion, err := semver.NewVersion(versionPrefix + ".0")
+	if err != nil {
+		return ""
+	}
+
+	previous := ""
+	for _, tag := range tags {
+		if !semverTagPattern.MatchString(tag) {
+			continue
+		}
+		v, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		older := v.Major() < branchVersion.Major() ||
+			(v.Major() == branchVersion.Major() && v.Minor() < branchVersion.Minor())
+		if older && (previous == "" || compareVersions(tag, previous) > 0) {
+			previous = tag
+		}
+	}
+	return previous
+}
 
+// semverTagPattern matches plain release tags such as v3.6.1 or 3.6.1
+var semverTagPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+
+// latestReleaseTag picks the most recent semver tag matching the branch's version,
+// read from the branch name through the scheme's branch template
+func latestReleaseTag(tags []string, branch string, scheme github.LabelScheme) string {
 	if len(tags) == 0 {
 		// No tags found, assume this is the first release
-		return "v0.0.0", nil
+		return "v0.0.0"
 	}
 
 	// Extract version prefix from branch name (e.g., "release-3.6" -> "3.6")
-	var versionPrefix string
-	if after, ok := strings.CutPrefix(branch, "release-"); ok {
-		versionPrefix = after
-	} else {
+	versionPrefix, ok := scheme.VersionForBranch(branch)
+	if !ok {
 		// For non-release branches, use the branch name as-is
 		versionPrefix = branch
 	}
 
 	// Filter tags that match the branch version prefix
 	var validTags []string
-	semverPattern := regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
 
 	for _, tag := range tags {
-		if semverPattern.MatchString(tag) {
+		if semverTagPattern.MatchString(tag) {
 			// Check if tag matches the branch version prefix
 			cleanTag := strings.TrimPrefix(tag, "v")
 			parts := strings.Split(cleanTag, ".")
@@ -70,7 +126,7 @@ func getLastReleaseTag(ctx context.Context, branch string) (string, error) {
 
 	if len(validTags) == 0 {
 		// No matching tags found for this branch, assume this is the first release
-		return fmt.Sprintf("v%s.0", versionPrefix), nil
+		return fmt.Sprintf("v%s.0", versionPrefix)
 	}
 
 	// Sort tags in descending order (most recent first)
@@ -78,18 +134,49 @@ func getLastReleaseTag(ctx context.Context, branch string) (string, error) {
 		return compareVersions(validTags[i], validTags[j]) > 0
 	})
 
-	return validTags[0], nil
+	return validTags[0]
+}
+
+// VersionBump is which part of the last release's version summary increments
+// to propose the next one
+type VersionBump string
+
+const (
+	BumpPatch VersionBump = "patch" // v3.7.2 -> v3.7.3 (default)
+	BumpMinor VersionBump = "minor" // v3.7.2 -> v3.8.0
+	BumpMajor VersionBump = "major" // v3.7.2 -> v4.0.0
+)
+
+// ParseVersionBump maps a --bump value to a VersionBump; empty means patch
+func ParseVersionBump(s string) (VersionBump, error) {
+	switch VersionBump(s) {
+	case "", BumpPatch:
+		return BumpPatch, nil
+	case BumpMinor:
+		return BumpMinor, nil
+	case BumpMajor:
+		return BumpMajor, nil
+	default:
+		return "", fmt.Errorf("invalid --bump %q (want patch, minor or major)", s)
+	}
 }
 
-// incrementPatchVersion takes a version string and increments the patch version
-func incrementPatchVersion(version string) (string, error) {
+// incrementVersion takes a version string and increments the part bump names
+func incrementVersion(version string, bump VersionBump) (string, error) {
 	v, err := semver.NewVersion(version)
 	if err != nil {
 		return "", fmt.Errorf("invalid version format: %s", version)
 	}
 
-	// Increment patch version
-	next := v.IncPatch()
+	var next semver.Version
+	switch bump {
+	case BumpMinor:
+		next = v.IncMinor()
+	case BumpMajor:
+		next = v.IncMajor()
+	default:
+		next = v.IncPatch()
+	}
 
 	// Return new version with 'v' prefix
 	return fmt.Sprintf("v%s", next.String()), nil
@@ -113,25 +200,44 @@ func compareVersions(v1, v2 string) int {
 // getCommitsSinceTag gets commits on the branch since the given tag
 func getCommitsSinceTag(ctx context.Context, branch, sinceTag string) ([]github.Commit, error) {
 	// Use git log to get commits since the tag
-	// Format: %s = subject (commit message)
+	// Format: %P = parent hashes, %cI = committer date, %an = author name, %s = subject (commit message)
 	// #nosec G204 - Arguments are passed separately to exec.CommandContext, not through shell
-	cmd := exec.CommandContext(ctx, "git", "log", "--format=%s", fmt.Sprintf("%s..%s", sinceTag, branch))
+	cmd := exec.CommandContext(ctx, "git", "log", "--format=%P%x09%cI%x09%an%x09%s", fmt.Sprintf("%s..%s", sinceTag, branch))
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git log: %w", err)
 	}
 
-	// Parse commits from output
+	return parseGitLogOutput(string(output)), nil
+}
+
+// parseGitLogOutput parses "<parents>\t<committer date>\t<author>\t<subject>"
+// lines produced by getCommitsSinceTag
+func parseGitLogOutput(output string) []github.Commit {
 	var commits []github.Commit
-	if len(output) > 0 {
-		for line := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
-			if line != "" {
-				commits = append(commits, github.Commit{
-					Message: line,
-				})
-			}
+	for line := range strings.SplitSeq(output, "\n") {
+		// Only the right is trimmed: a root commit's line starts with the tab
+		// after its empty parent list
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		parents, rest, found := strings.Cut(line, "\t")
+		if !found {
+			continue
 		}
+		date, rest, found := strings.Cut(rest, "\t")
+		if !found {
+			continue
+		}
+		author, subject, found := strings.Cut(rest, "\t")
+		if !found || subject == "" {
+			continue
+		}
+		committedAt, _ := time.Parse(time.RFC3339, date) // zero if git printed something else
+		commits = append(commits, github.Commit{
+			Message:     subject,
+			Author:      author,
+			CommittedAt: committedAt,
+			Parents:     strings.Fields(parents),
+		})
 	}
-
-	return commits, nil
+	return commits
 }
@@ -1,11 +1,16 @@
 package summary
 
 import (
-	"fmt"
 	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestIncrementPatchVersion(t *testing.T) {
+func TestIncrementVersion_Patch(t *testing.T) {
 	tests := []struct {
 		name     string
 		version  string
@@ -52,18 +57,48 @@ func TestIncrementPatchVersion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := incrementPatchVersion(tt.version)
+			got, err := incrementVersion(tt.version, BumpPatch)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("incrementPatchVersion() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("incrementVersion() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if got != tt.expected {
-				t.Errorf("incrementPatchVersion() = %v, want %v", got, tt.expected)
+				t.Errorf("incrementVersion() = %v, want %v", got, tt.expected)
 			}
 		})
 	}
 }
 
+func TestIncrementVersion_Bump(t *testing.T) {
+	tests := []struct {
+		bump     VersionBump
+		expected string
+	}{
+		{BumpPatch, "v3.7.3"},
+		{BumpMinor, "v3.8.0"},
+		{BumpMajor, "v4.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.bump), func(t *testing.T) {
+			got, err := incrementVersion("v3.7.2", tt.bump)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParseVersionBump(t *testing.T) {
+	for input, want := range map[string]VersionBump{"": BumpPatch, "patch": BumpPatch, "minor": BumpMinor, "major": BumpMajor} {
+		got, err := ParseVersionBump(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseVersionBump("prerelease")
+	require.ErrorContains(t, err, "want patch, minor or major")
+}
+
 func TestCompareVersions(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -176,9 +211,14 @@ func TestGetLastReleaseTag(t *testing.T) {
 		},
 	}
 
+	t.Run("branch_template names the branch", func(t *testing.T) {
+		scheme := github.LabelScheme{BranchTemplate: "stable/{version}"}
+		assert.Equal(t, "v3.6.1", latestReleaseTag([]string{"v3.6.0", "v3.6.1", "v3.7.0"}, "stable/3.6", scheme))
+	})
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getLastReleaseTagWithTags(tt.tags, tt.branch)
+			got := latestReleaseTag(tt.tags, tt.branch, github.LabelScheme{})
 
 			if got != tt.expected {
 				t.Errorf("getLastReleaseTag() = %v, want %v", got, tt.expected)
@@ -187,98 +227,43 @@ func TestGetLastReleaseTag(t *testing.T) {
 	}
 }
 
-// Helper function for testing that doesn't require GitHub client
-func getLastReleaseTagWithTags(tags []string, branch string) string {
-	if len(tags) == 0 {
-		return "v0.0.0"
-	}
-
-	// Extract version prefix from branch name (e.g., "release-3.6" -> "3.6")
-	var versionPrefix string
-	if len(branch) > 8 && branch[:8] == "release-" {
-		versionPrefix = branch[8:]
-	} else {
-		versionPrefix = branch
-	}
-
-	// Filter tags that match the branch version prefix
-	var validTags []string
-	for _, tag := range tags {
-		// Simple semver pattern check
-		if !isSemverTag(tag) {
-			continue
-		}
-
-		// Check if tag matches the branch version prefix
-		cleanTag := tag
-		if len(tag) > 0 && tag[0] == 'v' {
-			cleanTag = tag[1:]
-		}
-
-		parts := splitVersion(cleanTag)
-		if len(parts) >= 2 && parts[0]+"."+parts[1] == versionPrefix {
-			validTags = append(validTags, tag)
-		}
-	}
-
-	if len(validTags) == 0 {
-		return fmt.Sprintf("v%s.0", versionPrefix)
-	}
+func TestPreviousReleaseTag(t *testing.T) {
+	tags := []string{"v3.9.0", "v4.0.0", "v4.0.3", "v4.0.10", "v4.2.0", "latest", "v4.0-rc1"}
 
-	// Find the highest version
-	highest := validTags[0]
-	for _, tag := range validTags[1:] {
-		if compareVersions(tag, highest) > 0 {
-			highest = tag
-		}
-	}
+	assert.Equal(t, "v4.0.10", previousReleaseTag(tags, "release-4.1", github.LabelScheme{}))
+	assert.Equal(t, "v3.9.0", previousReleaseTag(tags, "release-4.0", github.LabelScheme{}))
+	assert.Equal(t, "v4.2.0", previousReleaseTag(tags, "release-5.0", github.LabelScheme{}))
+	assert.Empty(t, previousReleaseTag(tags, "release-3.0", github.LabelScheme{}), "no older release line")
+	assert.Empty(t, previousReleaseTag(tags, "main", github.LabelScheme{}), "not a release branch")
 
-	return highest
+	stable := github.LabelScheme{BranchTemplate: "stable/{version}"}
+	assert.Equal(t, "v4.0.10", previousReleaseTag(tags, "stable/4.1", stable), "branch_template names the branch")
+	assert.Empty(t, previousReleaseTag(tags, "release-4.1", stable), "outside the template")
 }
 
-func isSemverTag(tag string) bool {
-	cleanTag := tag
-	if len(tag) > 0 && tag[0] == 'v' {
-		cleanTag = tag[1:]
-	}
+func TestReleaseBase(t *testing.T) {
+	tags := []string{"v4.0.0", "v4.0.3", "v4.1.0", "v4.1.1"}
 
-	parts := splitVersion(cleanTag)
-	if len(parts) != 3 {
-		return false
-	}
-
-	// Check each part is numeric
-	for _, part := range parts {
-		if len(part) == 0 {
-			return false
-		}
-		for i := 0; i < len(part); i++ {
-			if part[i] < '0' || part[i] > '9' {
-				return false
-			}
-		}
+	tests := []struct {
+		name     string
+		branch   string
+		policy   cmd.NewBranchBasePolicy
+		wantLast string
+		wantBase string
+	}{
+		{name: "branch with tags", branch: "release-4.1", policy: cmd.NewBranchBasePrevious, wantLast: "v4.1.1", wantBase: "v4.1.1"},
+		{name: "new branch, version policy", branch: "release-4.2", policy: cmd.NewBranchBaseVersion, wantLast: "v4.2.0", wantBase: "v4.2.0"},
+		{name: "new branch, previous policy", branch: "release-4.2", policy: cmd.NewBranchBasePrevious, wantLast: "v4.2.0", wantBase: "v4.1.1"},
+		{name: "new branch without an older line", branch: "release-3.0", policy: cmd.NewBranchBasePrevious, wantLast: "v3.0.0", wantBase: "v3.0.0"},
 	}
 
-	return true
-}
-
-func splitVersion(version string) []string {
-	var parts []string
-	current := ""
-	for i := 0; i < len(version); i++ {
-		if version[i] == '.' {
-			if current != "" {
-				parts = append(parts, current)
-				current = ""
-			}
-		} else {
-			current += string(version[i])
-		}
-	}
-	if current != "" {
-		parts = append(parts, current)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last, base := releaseBase(tags, tt.branch, github.LabelScheme{}, tt.policy)
+			assert.Equal(t, tt.wantLast, last)
+			assert.Equal(t, tt.wantBase, base)
+		})
 	}
-	return parts
 }
 
 func TestGetCommitsSinceTag(t *testing.T) {
@@ -292,3 +277,33 @@ func TestGetCommitsSinceTag(t *testing.T) {
 		_ = fn
 	})
 }
+
+func TestParseGitLogOutput(t *testing.T) {
+	output := "aaa\t2025-03-04T10:00:00+01:00\tJane Doe\tfix: something (#123)\n" +
+		"bbb ccc\t2025-03-03T09:00:00Z\tJohn Roe\tMerge branch 'release-3.7' into feature\n" +
+		"\t2025-03-01T08:00:00Z\tJane Doe\tinitial commit\n"
+
+	commits := parseGitLogOutput(output)
+	require.Len(t, commits, 3)
+
+	assert.Equal(t, "fix: something (#123)", commits[0].Message)
+	assert.Equal(t, "Jane Doe", commits[0].Author)
+	assert.Equal(t, []string{"aaa"}, commits[0].Parents)
+	assert.Equal(t, time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC), commits[0].CommittedAt.UTC())
+	assert.False(t, commits[0].IsMerge())
+
+	assert.Equal(t, "Merge branch 'release-3.7' into feature", commits[1].Message)
+	assert.True(t, commits[1].IsMerge())
+
+	assert.Equal(t, "initial commit", commits[2].Message)
+	assert.Empty(t, commits[2].Parents)
+
+	assert.Empty(t, parseGitLogOutput(""))
+
+	// A root commit alone keeps its empty parent list, and CRLF endings are trimmed per line
+	commits = parseGitLogOutput("\t2025-03-01T08:00:00Z\tJane Doe\tinitial commit\r\n\n")
+	require.Len(t, commits, 1)
+	assert.Equal(t, "initial commit", commits[0].Message)
+	assert.Empty(t, commits[0].Parents)
+	assert.Equal(t, time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC), commits[0].CommittedAt.UTC())
+}
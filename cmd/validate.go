@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Validate checks that the config is coherent enough for commands to act on:
+// org, repo and source_branch are set and well-formed for every tracked
+// repository, tracked branch names are valid git branch names, and tracked PR
+// numbers are positive and unique per repository. It returns one error listing
+// every problem found, so a broken config can be fixed in a single pass.
+func (c *Config) Validate() error {
+	var problems []string
+	for i, view := range c.RepositoryViews() {
+		prefix := ""
+		if i > 0 {
+			prefix = fmt.Sprintf("repositories[%d]: ", i-1)
+		}
+		for _, problem := range view.repositoryProblems() {
+			problems = append(problems, prefix+problem)
+		}
+	}
+	return problemsError(problems)
+}
+
+// ValidatePick checks what pick needs beyond Validate: an ai_assistant_command
+// when the conflict_strategy launches the AI assistant
+func (c *Config) ValidatePick() error {
+	steps, err := c.ConflictSteps()
+	if err != nil {
+		return err
+	}
+	if slices.Contains(steps, ConflictStepAI) && strings.TrimSpace(c.AIAssistantCommand) == "" {
+		return problemsError([]string{"ai_assistant_command is empty but conflict_strategy uses the ai step; set it with 'cherry-picker config --ai-assistant <command>'"})
+	}
+	return nil
+}
+
+// repositoryProblems lists what is wrong with one repository view
+func (c *Config) repositoryProblems() []string {
+	var problems []string
+	if problem := nameProblem("org", c.Org); problem != "" {
+		problems = append(problems, problem)
+	}
+	if problem := nameProblem("repo", c.Repo); problem != "" {
+		problems = append(problems, problem)
+	}
+	if c.SourceBranch == "" {
+		problems = append(problems, "source_branch is empty")
+	} else if problem := branchNameProblem(c.SourceBranch); problem != "" {
+		problems = append(problems, "source_branch "+problem)
+	}
+
+	seen := make(map[int]bool, len(c.TrackedPRs))
+	for i, pr := range c.TrackedPRs {
+		switch {
+		case pr.Number <= 0:
+			problems = append(problems, fmt.Sprintf("tracked_prs[%d]: number %d is not positive", i, pr.Number))
+		case seen[pr.Number]:
+			problems = append(problems, fmt.Sprintf("tracked_prs[%d]: PR #%d is tracked more than once", i, pr.Number))
+		}
+		seen[pr.Number] = true
+		for _, branch := range slices.Sorted(maps.Keys(pr.Branches)) {
+			if problem := branchNameProblem(branch); problem != "" {
+				problems = append(problems, fmt.Sprintf("tracked_prs[%d]: branch %s", i, problem))
+			}
+		}
+	}
+	return problems
+}
+
+// nameProblem describes what is wrong with an org or repo name, or returns ""
+func nameProblem(field, value string) string {
+	switch {
+	case value == "":
+		return field + " is empty"
+	case strings.ContainsAny(value, "/ \t\n") || strings.Contains(value, "://"):
+		return fmt.Sprintf("%s %q must be a bare name, not a path or URL", field, value)
+	}
+	return ""
+}
+
+// branchNameProblem describes why name isn't a usable git branch name (the
+// rules of git check-ref-format that matter here), or returns ""
+func branchNameProblem(name string) string {
+	switch {
+	case name == "":
+		return "name is empty"
+	case strings.Contains(name, "://"):
+		return fmt.Sprintf("%q is a URL, not a branch name", name)
+	case strings.ContainsAny(name, " \t\n~^:?*[\\"):
+		return fmt.Sprintf("%q contains a character git doesn't allow in branch names", name)
+	case strings.HasPrefix(name, "-"), strings.HasPrefix(name, "/"), strings.HasSuffix(name, "/"),
+		strings.HasSuffix(name, "."), strings.HasSuffix(name, ".lock"),
+		strings.Contains(name, ".."), strings.Contains(name, "//"), strings.Contains(name, "@{"):
+		return fmt.Sprintf("%q is not a valid git branch name", name)
+	}
+	return ""
+}
+
+// problemsError joins problems into one error, or returns nil when there are none
+func problemsError(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid config (%d problem(s)):\n  - %s", len(problems), strings.Join(problems, "\n  - "))
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() *Config {
+	return &Config{
+		Org:          "argoproj",
+		Repo:         "argo-workflows",
+		SourceBranch: "main",
+		TrackedPRs: []TrackedPR{
+			{Number: 100, Branches: map[string]BranchStatus{"release-3.7": {Status: BranchStatusPending}}},
+			{Number: 101},
+		},
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, validConfig().Validate())
+	})
+
+	t.Run("lists every problem", func(t *testing.T) {
+		config := validConfig()
+		config.Org = ""
+		config.Repo = "argoproj/argo-workflows"
+		config.SourceBranch = "https://github.com/argoproj/argo-workflows"
+		config.TrackedPRs = append(config.TrackedPRs,
+			TrackedPR{Number: 100},
+			TrackedPR{Number: -1},
+			TrackedPR{Number: 102, Branches: map[string]BranchStatus{"release 3.7": {}}},
+		)
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Equal(t, "invalid config (6 problem(s)):\n"+
+			"  - org is empty\n"+
+			"  - repo \"argoproj/argo-workflows\" must be a bare name, not a path or URL\n"+
+			"  - source_branch \"https://github.com/argoproj/argo-workflows\" is a URL, not a branch name\n"+
+			"  - tracked_prs[2]: PR #100 is tracked more than once\n"+
+			"  - tracked_prs[3]: number -1 is not positive\n"+
+			"  - tracked_prs[4]: branch \"release 3.7\" contains a character git doesn't allow in branch names", err.Error())
+	})
+
+	t.Run("checks further repositories", func(t *testing.T) {
+		config := validConfig()
+		config.Repositories = []RepoConfig{{Org: "argoproj", Repo: "argo-events"}}
+
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "repositories[0]: source_branch is empty")
+	})
+}
+
+func TestBranchNameProblem(t *testing.T) {
+	for _, name := range []string{"main", "release-3.7", "release/v1.2", "feature.x"} {
+		assert.Empty(t, branchNameProblem(name), name)
+	}
+	for _, name := range []string{"", "-main", "release..3", "main.lock", "main/", "a:b", "ref@{1}", "git@github.com:org/repo"} {
+		assert.NotEmpty(t, branchNameProblem(name), name)
+	}
+}
+
+func TestConfigValidatePick(t *testing.T) {
+	config := validConfig()
+	err := config.ValidatePick()
+	require.ErrorContains(t, err, "ai_assistant_command is empty")
+
+	config.AIAssistantCommand = "claude"
+	require.NoError(t, config.ValidatePick())
+
+	config.AIAssistantCommand = ""
+	config.ConflictStrategy = []ConflictStep{ConflictStepRerere, ConflictStepManual}
+	require.NoError(t, config.ValidatePick(), "no ai step, no assistant needed")
+}
@@ -0,0 +1,145 @@
+// Package verifylinks implements the verify-links command for checking that tracked cherry-pick PRs still point at their original.
+package verifylinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/spf13/cobra"
+)
+
+// linkChecker is the part of github.Client verify-links reads
+type linkChecker interface {
+	CheckCherryPickLink(ctx context.Context, cherryPickPR, originalPR int, branch, headBranchPattern string) (string, error)
+}
+
+// command encapsulates the verify-links command with common functionality
+type command struct {
+	commands.BaseCommand
+}
+
+// linkMismatch is a tracked cherry-pick PR that GitHub doesn't link to its original
+type linkMismatch struct {
+	OriginalPR   int
+	Branch       string
+	CherryPickPR int
+	Reason       string
+}
+
+// NewVerifyLinksCmd creates the verify-links command
+func NewVerifyLinksCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error)) *cobra.Command {
+	verifyCmd := &command{}
+
+	return &cobra.Command{
+		Use:   "verify-links",
+		Short: "Check that every tracked cherry-pick PR still points at its original",
+		Long: `Check every picked, queued or merged branch against GitHub: the recorded
+cherry-pick PR must target the branch and name the tracked PR in its title,
+body, head branch (when head_branch_pattern is set) or commit messages, or be
+named by the bot comments on the tracked PR.
+
+Mismatches are written to stdout and the command exits non-zero when there
+are any. Nothing is saved.
+
+Examples:
+  cherry-picker verify-links`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			verifyCmd.ConfigFile = globalConfigFile
+			verifyCmd.LoadConfig = loadConfig
+			if err := verifyCmd.Init(cobraCmd.Context()); err != nil {
+				return err
+			}
+
+			return verifyCmd.Run(cobraCmd.Context(), os.Stdout)
+		},
+	}
+}
+
+// Run verifies the links and writes the mismatches to w
+func (vc *command) Run(ctx context.Context, w io.Writer) error {
+	mismatches, checked, err := verifyLinks(ctx, vc.GitHubClient, vc.Config)
+	if len(mismatches) == 0 {
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ All %d cherry-pick PR(s) link to their original\n", checked)
+		return nil
+	}
+
+	fmt.Fprint(w, formatMismatches(mismatches))
+	return errors.Join(fmt.Errorf("%d of %d cherry-pick PR(s) don't link to their original", len(mismatches), checked), err)
+}
+
+// verifyLinks checks the cherry-pick PR of every picked, queued or merged branch
+// in PR and branch order. It returns the mismatches and how many PRs were checked.
+// A PR that can't be checked doesn't stop the others; the failures are joined
+// into the returned error.
+func verifyLinks(ctx context.Context, client linkChecker, config *cmd.Config) ([]linkMismatch, int, error) {
+	trackedPRs := slices.Clone(config.TrackedPRs)
+	slices.SortFunc(trackedPRs, func(a, b cmd.TrackedPR) int { return a.Number - b.Number })
+
+	var mismatches []linkMismatch
+	var errs []error
+	checked := 0
+	for _, trackedPR := range trackedPRs {
+		branches := make([]string, 0, len(trackedPR.Branches))
+		for branch := range trackedPR.Branches {
+			branches = append(branches, branch)
+		}
+		slices.Sort(branches)
+
+		for _, branch := range branches {
+			status := trackedPR.Branches[branch]
+			if !hasLink(status) {
+				continue
+			}
+			checked++
+			reason, err := client.CheckCherryPickLink(ctx, status.PR.Number, trackedPR.Number, branch, config.HeadBranchPattern)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to verify cherry-pick PR #%d for PR #%d on %s: %w", status.PR.Number, trackedPR.Number, branch, err))
+				continue
+			}
+			if reason != "" {
+				mismatches = append(mismatches, linkMismatch{
+					OriginalPR:   trackedPR.Number,
+					Branch:       branch,
+					CherryPickPR: status.PR.Number,
+					Reason:       reason,
+				})
+			}
+		}
+	}
+	return mismatches, checked, errors.Join(errs...)
+}
+
+// hasLink reports whether a branch records a cherry-pick PR worth verifying
+func hasLink(status cmd.BranchStatus) bool {
+	if status.PR == nil || status.PR.Number == 0 {
+		return false
+	}
+	switch status.Status {
+	case cmd.BranchStatusPicked, cmd.BranchStatusQueued, cmd.BranchStatusMerged:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatMismatches renders the mismatches found by verifyLinks as a report
+func formatMismatches(mismatches []linkMismatch) string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "⚠️  %d cherry-pick PR(s) don't link to their original:\n", len(mismatches))
+	for _, m := range mismatches {
+		fmt.Fprintf(&report, "  PR #%d on %s: cherry-pick #%d is recorded, but %s\n", m.OriginalPR, m.Branch, m.CherryPickPR, m.Reason)
+	}
+	return report.String()
+}
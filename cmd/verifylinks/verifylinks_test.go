@@ -0,0 +1,101 @@
+package verifylinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLinkChecker answers CheckCherryPickLink from a map keyed by cherry-pick PR
+type fakeLinkChecker struct {
+	reasons map[int]string
+	errs    map[int]error
+	checked []string
+}
+
+func (f *fakeLinkChecker) CheckCherryPickLink(_ context.Context, cherryPickPR, originalPR int, branch, _ string) (string, error) {
+	f.checked = append(f.checked, fmt.Sprintf("%d->%d@%s", cherryPickPR, originalPR, branch))
+	return f.reasons[cherryPickPR], f.errs[cherryPickPR]
+}
+
+// TestNewVerifyLinksCmd tests command creation and argument validation
+func TestNewVerifyLinksCmd(t *testing.T) {
+	configFile := "cherry-picks.yaml"
+	loadConfig := func(_ string) (*cmd.Config, error) {
+		return &cmd.Config{}, nil
+	}
+
+	cobraCmd := NewVerifyLinksCmd(&configFile, loadConfig)
+
+	assert.Equal(t, "verify-links", cobraCmd.Name())
+	require.NoError(t, cobraCmd.Args(cobraCmd, []string{}))
+	require.Error(t, cobraCmd.Args(cobraCmd, []string{"123"}))
+}
+
+// TestVerifyLinks tests which branches are checked and how mismatches are collected
+func TestVerifyLinks(t *testing.T) {
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{
+			{
+				Number: 200,
+				Branches: map[string]cmd.BranchStatus{
+					"release-1.1": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 202}},
+					"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 201}},
+				},
+			},
+			{
+				Number: 100,
+				Branches: map[string]cmd.BranchStatus{
+					"release-1.0": {Status: cmd.BranchStatusQueued, PR: &cmd.PickPR{Number: 101}},
+					"release-1.1": {Status: cmd.BranchStatusPending},
+					"release-1.2": {Status: cmd.BranchStatusReleased, PR: &cmd.PickPR{Number: 103}},
+					"release-1.3": {Status: cmd.BranchStatusPicked},
+				},
+			},
+		},
+	}
+	checker := &fakeLinkChecker{reasons: map[int]string{202: "targets release-1.0, not release-1.1"}}
+
+	mismatches, checked, err := verifyLinks(t.Context(), checker, config)
+	require.NoError(t, err)
+	assert.Equal(t, 3, checked)
+	assert.Equal(t, []string{"101->100@release-1.0", "201->200@release-1.0", "202->200@release-1.1"}, checker.checked)
+	assert.Equal(t, []linkMismatch{
+		{OriginalPR: 200, Branch: "release-1.1", CherryPickPR: 202, Reason: "targets release-1.0, not release-1.1"},
+	}, mismatches)
+
+	assert.Equal(t, "⚠️  1 cherry-pick PR(s) don't link to their original:\n"+
+		"  PR #200 on release-1.1: cherry-pick #202 is recorded, but targets release-1.0, not release-1.1\n",
+		formatMismatches(mismatches))
+}
+
+// TestVerifyLinks_Error tests that a GitHub failure is reported without stopping the check
+func TestVerifyLinks_Error(t *testing.T) {
+	config := &cmd.Config{
+		TrackedPRs: []cmd.TrackedPR{{
+			Number: 100,
+			Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 101}},
+				"release-1.1": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 102}},
+				"release-1.2": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 103}},
+			},
+		}},
+	}
+	boom := errors.New("boom")
+	checker := &fakeLinkChecker{
+		reasons: map[int]string{103: "targets main, not release-1.2"},
+		errs:    map[int]error{101: boom, 102: errors.New("bang")},
+	}
+
+	mismatches, checked, err := verifyLinks(t.Context(), checker, config)
+	require.ErrorIs(t, err, boom)
+	assert.ErrorContains(t, err, "failed to verify cherry-pick PR #101 for PR #100 on release-1.0")
+	assert.ErrorContains(t, err, "failed to verify cherry-pick PR #102 for PR #100 on release-1.1")
+	assert.Equal(t, 3, checked)
+	assert.Equal(t, []linkMismatch{{OriginalPR: 100, Branch: "release-1.2", CherryPickPR: 103, Reason: "targets main, not release-1.2"}}, mismatches)
+}
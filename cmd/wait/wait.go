@@ -0,0 +1,180 @@
+// Package wait implements the wait command for blocking until picked cherry-pick PRs finish CI.
+package wait
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/cmd/fetch"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/alan/cherry-picker/internal/poll"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// DefaultTimeout is how long wait polls when --timeout isn't given
+	DefaultTimeout = 30 * time.Minute
+	// DefaultInterval is the first wait between polls when --interval isn't given
+	DefaultInterval = 15 * time.Second
+	// maxInterval caps the doubling of the poll interval
+	maxInterval = 2 * time.Minute
+)
+
+// prDetailsGetter is the part of github.Client wait polls
+type prDetailsGetter interface {
+	GetPRWithDetails(ctx context.Context, number int) (*github.PR, error)
+}
+
+// command encapsulates the wait command with common functionality
+type command struct {
+	commands.BaseCommand
+	PRNumber int
+	Timeout  time.Duration
+	Interval time.Duration
+	client   prDetailsGetter
+}
+
+// waitedPR is a picked branch's cherry-pick PR wait polls; the stored CI
+// status it starts from may predate the wait
+type waitedPR struct {
+	poll.CIPR
+	pr *cmd.PickPR
+}
+
+// NewWaitCmd creates the wait command
+func NewWaitCmd(globalConfigFile *string, loadConfig func(string) (*cmd.Config, error), saveConfig func(string, *cmd.Config) error) *cobra.Command {
+	waitCmd := &command{}
+
+	cobraCmd := &cobra.Command{
+		Use:   "wait [pr-number]",
+		Short: "Wait for picked cherry-pick PRs to pass or fail CI",
+		Long: `Poll the CI of every picked branch's cherry-pick PR until all are passing
+or any is failing, printing a progress line per PR after each poll.
+
+The stored CI statuses are updated as they change, so a following merge sees
+them without another fetch. Polls start --interval apart and back off
+exponentially (up to 2m). The command exits non-zero when a PR's CI fails or
+--timeout elapses first; PRs whose CI is unknown are waited for like pending ones.
+
+Examples:
+  cherry-picker wait                  # Wait for every picked cherry-pick PR
+  cherry-picker wait 123              # Wait for PR #123's cherry-pick PRs
+  cherry-picker wait && cherry-picker merge`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			prNumber, err := commands.ParsePRNumberFromArgs(args, false)
+			if err != nil {
+				return err
+			}
+			waitCmd.PRNumber = prNumber
+			if waitCmd.Timeout <= 0 {
+				return fmt.Errorf("--timeout must be positive, got %s", waitCmd.Timeout)
+			}
+			if waitCmd.Interval <= 0 {
+				return fmt.Errorf("--interval must be positive, got %s", waitCmd.Interval)
+			}
+
+			waitCmd.ConfigFile = globalConfigFile
+			waitCmd.LoadConfig = loadConfig
+			waitCmd.SaveConfig = saveConfig
+			if err := waitCmd.Init(cobraCmd.Context()); err != nil {
+				return err
+			}
+			waitCmd.client = waitCmd.GitHubClient
+
+			return waitCmd.Run(cobraCmd.Context())
+		},
+	}
+
+	cobraCmd.Flags().DurationVar(&waitCmd.Timeout, "timeout", DefaultTimeout, "How long to wait before giving up")
+	cobraCmd.Flags().DurationVar(&waitCmd.Interval, "interval", DefaultInterval, "Wait before the second poll; doubles after each poll up to 2m")
+
+	return cobraCmd
+}
+
+// Run polls the picked cherry-pick PRs until their CI is final or the timeout
+// elapses, saving status changes as it goes, and reports the outcome
+func (wc *command) Run(ctx context.Context) error {
+	waited, err := wc.pickedPRs()
+	if err != nil {
+		return err
+	}
+	if len(waited) == 0 {
+		fmt.Fprintln(os.Stderr, "No picked cherry-pick PRs to wait for")
+		return nil
+	}
+
+	prs := make([]*poll.CIPR, 0, len(waited))
+	picks := make(map[*poll.CIPR]*cmd.PickPR, len(waited))
+	for _, p := range waited {
+		prs = append(prs, &p.CIPR)
+		picks[&p.CIPR] = p.pr
+	}
+
+	changed := false
+	opts := poll.CIOptions{
+		Backoff:  poll.Backoff{Initial: wc.Interval, Max: max(wc.Interval, maxInterval)},
+		Timeout:  wc.Timeout,
+		FailFast: true,
+		Progress: os.Stderr,
+		AfterPoll: func(context.Context) error {
+			if !changed {
+				return nil
+			}
+			changed = false
+			if err := wc.SaveConfig(*wc.ConfigFile, wc.Config); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			return nil
+		},
+	}
+
+	slog.Info("Waiting for cherry-pick CI", "prs", len(waited), "timeout", wc.Timeout)
+	return poll.UntilCIFinished(ctx, os.Stdout, prs, opts, func(ctx context.Context, ci *poll.CIPR) (cmd.CIStatus, error) {
+		pick := picks[ci]
+		details, err := wc.client.GetPRWithDetails(ctx, pick.Number)
+		if err != nil {
+			return "", err
+		}
+		if updated, _ := fetch.RefreshPickPRCI(pick, details); updated {
+			changed = true
+		}
+		return pick.CIStatus, nil
+	})
+}
+
+// pickedPRs returns the cherry-pick PRs of the picked branches of the tracked
+// PR wait was given, or of every tracked PR
+func (wc *command) pickedPRs() ([]*waitedPR, error) {
+	trackedPRs := wc.Config.TrackedPRs
+	if wc.PRNumber != 0 {
+		trackedPR, err := commands.FindAndValidatePR(wc.Config, wc.PRNumber)
+		if err != nil {
+			return nil, err
+		}
+		trackedPRs = []cmd.TrackedPR{*trackedPR}
+	}
+
+	var waited []*waitedPR
+	for _, trackedPR := range trackedPRs {
+		for _, branch := range slices.Sorted(maps.Keys(trackedPR.Branches)) {
+			status := trackedPR.Branches[branch]
+			if status.Status != cmd.BranchStatusPicked || status.PR == nil {
+				continue
+			}
+			waited = append(waited, &waitedPR{
+				CIPR: poll.CIPR{OriginalPR: trackedPR.Number, Branch: branch, CherryPickPR: status.PR.Number, Status: status.PR.CIStatus},
+				pr:   status.PR,
+			})
+		}
+	}
+	return waited, nil
+}
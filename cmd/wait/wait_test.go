@@ -0,0 +1,116 @@
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient answers GetPRWithDetails from a queue of CI statuses per PR
+type fakeClient struct {
+	statuses map[int][]string
+	reads    map[int]int
+}
+
+func (f *fakeClient) GetPRWithDetails(_ context.Context, number int) (*github.PR, error) {
+	f.reads[number]++
+	queue := f.statuses[number]
+	status := "pending"
+	if len(queue) > 0 {
+		status = queue[0]
+		if len(queue) > 1 {
+			f.statuses[number] = queue[1:]
+		}
+	}
+	return &github.PR{Number: number, HeadSHA: "head", CIStatus: status}, nil
+}
+
+func testCommand(client *fakeClient, saves *int) *command {
+	configFile := "cherry-picks.yaml"
+	wc := &command{Timeout: time.Second, Interval: time.Millisecond, client: client}
+	wc.ConfigFile = &configFile
+	wc.Config = &cmd.Config{TrackedPRs: []cmd.TrackedPR{
+		{Number: 100, Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 201, CIStatus: cmd.CIStatusPending, HeadSHA: "head"}},
+			"release-1.1": {Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 202, CIStatus: cmd.CIStatusFailing, HeadSHA: "head"}},
+			"release-1.2": {Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{Number: 203, CIStatus: cmd.CIStatusPassing}},
+		}},
+		{Number: 101, Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusPending},
+		}},
+	}}
+	wc.SaveConfig = func(_ string, _ *cmd.Config) error {
+		*saves++
+		return nil
+	}
+	return wc
+}
+
+// TestPickedPRs tests which branches wait polls
+func TestPickedPRs(t *testing.T) {
+	saves := 0
+	wc := testCommand(&fakeClient{}, &saves)
+
+	waited, err := wc.pickedPRs()
+	require.NoError(t, err)
+	require.Len(t, waited, 2)
+	assert.Equal(t, 201, waited[0].pr.Number)
+	assert.Equal(t, 202, waited[1].pr.Number)
+
+	wc.PRNumber = 101
+	waited, err = wc.pickedPRs()
+	require.NoError(t, err)
+	assert.Empty(t, waited)
+
+	wc.PRNumber = 999
+	_, err = wc.pickedPRs()
+	require.Error(t, err)
+}
+
+// TestRun tests polling until CI is final and the exit status
+func TestRun(t *testing.T) {
+	t.Run("all passing", func(t *testing.T) {
+		client := &fakeClient{
+			statuses: map[int][]string{201: {"pending", "passing"}, 202: {"passing"}},
+			reads:    map[int]int{},
+		}
+		saves := 0
+		wc := testCommand(client, &saves)
+
+		require.NoError(t, wc.Run(t.Context()))
+		assert.Equal(t, cmd.CIStatusPassing, wc.Config.TrackedPRs[0].Branches["release-1.0"].PR.CIStatus)
+		assert.Equal(t, cmd.CIStatusPassing, wc.Config.TrackedPRs[0].Branches["release-1.1"].PR.CIStatus,
+			"a stored status is read again before it counts")
+		assert.Equal(t, 1, client.reads[202], "finished PRs aren't read again")
+		assert.Equal(t, 2, saves)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		client := &fakeClient{
+			statuses: map[int][]string{201: {"pending"}, 202: {"failing"}},
+			reads:    map[int]int{},
+		}
+		saves := 0
+		wc := testCommand(client, &saves)
+
+		err := wc.Run(t.Context())
+		require.ErrorContains(t, err, "CI failing on cherry-pick PR #202")
+		assert.NotContains(t, err.Error(), "timed out")
+		assert.Equal(t, 1, client.reads[201])
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		client := &fakeClient{statuses: map[int][]string{202: {"passing"}}, reads: map[int]int{}}
+		saves := 0
+		wc := testCommand(client, &saves)
+		wc.Timeout = 20 * time.Millisecond
+
+		err := wc.Run(t.Context())
+		require.ErrorContains(t, err, "timed out after 20ms waiting for CI on cherry-pick PR #201")
+	})
+}
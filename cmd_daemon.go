@@ -8,6 +8,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/alan/cherry-picker/cmd/fetch"
 	"github.com/alan/cherry-picker/internal/commands"
 	"github.com/alan/cherry-picker/internal/github"
 	"github.com/alan/cherry-picker/internal/refresh"
@@ -24,6 +25,7 @@ func newDaemonCmd(configFile *string) *cobra.Command {
 		Long: `Run an initial full scrape of both subsystems, then re-scrape on an
 interval. After each tick the unified state file is updated atomically, so CLI
 commands (status, merge, ...) always read fresh data without waiting on GitHub.
+A successful tick runs cherry_picks.post_fetch_command, as fetch does.
 Runs in the foreground; stop with Ctrl-C.
 
 Requires GITHUB_TOKEN environment variable to be set.`,
@@ -83,17 +85,24 @@ func daemonTick(ctx context.Context, client *github.Client, configFile string) {
 		return
 	}
 
-	refreshErr := refresh.All(ctx, client, snap)
+	// The client outlives the tick; don't let it answer from the last one's lists
+	client.ClearCache()
+	tickCtx, counts := fetch.CountEvents(ctx)
+	refreshErr := refresh.All(tickCtx, client, snap)
 
-	if err := state.Update(configFile, func(cur *state.Config) error {
+	saveErr := updateState(configFile, func(cur *state.Config) error {
 		cur.MergeFetched(snap)
 		return nil
-	}); err != nil {
-		slog.Error("tick: failed to save state", "error", err)
+	})
+	if saveErr != nil {
+		slog.Error("tick: failed to save state", "error", saveErr)
 	}
 	if refreshErr != nil {
 		slog.Warn("tick: refresh had errors", "error", refreshErr)
 	}
+	if saveErr == nil && refreshErr == nil {
+		fetch.RunPostFetchCommand(ctx, snap.CherryPicks.PostFetchCommand, savePath(configFile), *counts)
+	}
 
 	slog.Info("tick complete", "duration", time.Since(start).String())
 }
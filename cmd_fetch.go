@@ -1,43 +1,195 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/cmd/fetch"
+	"github.com/alan/cherry-picker/cmd/pick"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/github"
 	"github.com/alan/cherry-picker/internal/refresh"
 	"github.com/alan/cherry-picker/internal/state"
 	"github.com/spf13/cobra"
 )
 
 func newFetchCmd(configFile *string) *cobra.Command {
-	return &cobra.Command{
+	var jsonl, notifySlack, autoPickClean bool
+	var sinceDate, sinceTag, author, extraQuery string
+
+	fetchCmd := &cobra.Command{
 		Use:   "fetch",
 		Short: "Fetch cherry-pick and dependency PRs from GitHub",
 		Long: `Scrape both subsystems from GitHub and update the tracking file:
 merged PRs with cherry-pick/* labels and open PRs with the type/dependencies
 label. Partial results are saved even if one subsystem errors.
 
+With --jsonl, cherry-pick progress is written to stdout as newline-delimited
+JSON events (new_pr, pr_synced, status_changed, new_commits, released, done)
+and logs go to stderr.
+
+Cherry-pick PRs merged since the last fetch are looked for (30 days back on
+the first run). --since YYYY-MM-DD or --since-tag <tag> moves that start,
+the latter to the date of the tag's commit; --since-tag is handy right after
+cutting a release to pick up everything merged since. The two can't be combined.
+
+Cherry-picks are fetched for the top-level org/repo and for every repository
+listed under cherry_picks.repositories; with more than one, each --jsonl event
+names its repository in a "repo" field.
+
+If cherry_picks.post_fetch_command is set, it is run through sh after a
+successful fetch, with CHERRY_PICKER_NEW_PRS, CHERRY_PICKER_TRANSITIONS,
+CHERRY_PICKER_RELEASED and CHERRY_PICKER_CONFIG in its environment. A failing
+command is logged as a warning.
+
+With --auto-pick-clean, fetch cherry-picks the pending branches of newly
+found PRs itself, each in a scratch worktree of the local checkout (which must
+be of the top-level org/repo; your working tree isn't touched). A pick that
+applies without conflicts is pushed and gets a PR, and the branch is recorded
+as picked; anything else stays pending for the bot or 'pick'. Further
+repositories aren't auto-picked.
+
+--author <login> and --extra-query '<qualifiers>' narrow the search for new
+cherry-pick PRs, e.g. to one team's PRs; they are added to the fixed repo,
+is:merged, base and cherry-pick label terms. Tracked PRs are still all
+updated, and a narrowed fetch leaves the last fetch date alone so the next
+full fetch still finds everyone else's PRs.
+
+With --notify, a short report is posted to cherry_picks.slack_webhook_url once
+the fetch is done: how many branches moved to picked, merged and released, the
+status summary line, and the cherry-pick PRs whose CI is failing.
+
 Requires GITHUB_TOKEN environment variable to be set.`,
 		SilenceUsage: true,
 		RunE: func(cobraCmd *cobra.Command, _ []string) error {
 			ctx := cobraCmd.Context()
+			if jsonl {
+				ctx = fetch.WithEventSink(ctx, fetch.NewJSONLSink(os.Stdout))
+			}
+			ctx, counts := fetch.CountEvents(ctx)
+			qualifiers, err := fetch.SearchQualifiers(author, extraQuery)
+			if err != nil {
+				fetch.Emit(ctx, fetch.Event{Type: fetch.EventDone, Error: err.Error()})
+				return err
+			}
+
 			client, st, err := loadStateAndClient(ctx, *configFile)
 			if err != nil {
-				return fmt.Errorf("failed to load config: %w (run 'config' or 'migrate' first)", err)
+				err = fmt.Errorf("failed to load config: %w (run 'config' or 'migrate' first)", err)
+				fetch.Emit(ctx, fetch.Event{Type: fetch.EventDone, Error: err.Error()})
+				return err
 			}
+			if autoPickClean && !commands.IsGitRepository() {
+				err := fmt.Errorf("--auto-pick-clean needs a local checkout of %s/%s: run fetch from inside it", st.Org, st.Repo)
+				fetch.Emit(ctx, fetch.Event{Type: fetch.EventDone, Error: err.Error()})
+				return err
+			}
+			known := trackedPRNumbers(st.CherryPicks.TrackedPRs)
 
-			refreshErr := refresh.All(ctx, client, st)
+			var notifier *runNotifier
+			if notifySlack {
+				if notifier, err = newRunNotifier("fetch", *configFile, st); err != nil {
+					fetch.Emit(ctx, fetch.Event{Type: fetch.EventDone, Error: err.Error()})
+					return err
+				}
+			}
+
+			// Without --since/--since-tag, each repository's window starts at the
+			// last fetch; --since-tag is looked up in each repository
+			window := refresh.Window{Tag: sinceTag}
+			if sinceDate != "" {
+				if window.Since, err = fetch.ResolveSince(ctx, client, sinceDate, sinceTag, nil); err != nil {
+					fetch.Emit(ctx, fetch.Event{Type: fetch.EventDone, Error: err.Error()})
+					return err
+				}
+			}
+
+			lastFetchDate := st.LastFetchDate
+			if len(qualifiers) > 0 {
+				client = client.WithSearchQualifiers(qualifiers)
+			}
+			refreshErr := refresh.AllSince(ctx, client, st, window)
+			if len(qualifiers) > 0 {
+				// Other PRs merged in this window weren't searched for
+				st.LastFetchDate = lastFetchDate
+			}
+			if autoPickClean {
+				if err := autoPick(ctx, client, st, known); err != nil {
+					refreshErr = errors.Join(refreshErr, fmt.Errorf("auto-pick: %w", err))
+				}
+			}
 
 			// Commit whatever was fetched, merging onto the freshly-reloaded
 			// on-disk state so a concurrent writer is not clobbered.
-			saveErr := state.Update(*configFile, func(cur *state.Config) error {
+			saveErr := updateState(*configFile, func(cur *state.Config) error {
 				cur.MergeFetched(st)
 				return nil
 			})
 			if saveErr != nil {
-				return errors.Join(refreshErr, fmt.Errorf("failed to save config: %w", saveErr))
+				refreshErr = errors.Join(refreshErr, fmt.Errorf("failed to save config: %w", saveErr))
+			}
+
+			tracked := len(st.CherryPicks.TrackedPRs)
+			for _, repo := range st.CherryPicks.Repositories {
+				tracked += len(repo.TrackedPRs)
+			}
+			done := fetch.Event{Type: fetch.EventDone, TrackedPRs: tracked}
+			if refreshErr != nil {
+				done.Error = refreshErr.Error()
+			}
+			fetch.Emit(ctx, done)
+
+			if refreshErr == nil {
+				fetch.RunPostFetchCommand(ctx, st.CherryPicks.PostFetchCommand, savePath(*configFile), *counts)
+			}
+			if notifier != nil {
+				if err := notifier.send(ctx); err != nil {
+					return errors.Join(refreshErr, err)
+				}
 			}
 			return refreshErr
 		},
 	}
+
+	fetchCmd.Flags().StringVarP(&sinceDate, "since", "s", "", "Look for cherry-pick PRs merged since this date (YYYY-MM-DD) instead of the last fetch")
+	fetchCmd.Flags().StringVar(&sinceTag, "since-tag", "", "Look for cherry-pick PRs merged since this tag's commit date, e.g. right after cutting a release")
+	fetchCmd.Flags().StringVar(&author, "author", "", "Only look for new cherry-pick PRs by this GitHub login")
+	fetchCmd.Flags().StringVar(&extraQuery, "extra-query", "", "Raw GitHub search qualifiers added to the new cherry-pick PR search (e.g. 'label:area/ui')")
+	fetchCmd.Flags().BoolVar(&autoPickClean, "auto-pick-clean", false, "Cherry-pick newly found PRs that apply without conflicts and open their PRs (needs a local checkout)")
+	fetchCmd.Flags().BoolVar(&notifySlack, "notify", false, "Post a summary of what changed to cherry_picks.slack_webhook_url when done")
+	fetchCmd.Flags().BoolVar(&jsonl, "jsonl", false, "Stream progress events to stdout as newline-delimited JSON (logs go to stderr)")
+
+	return fetchCmd
+}
+
+// trackedPRNumbers returns the set of PR numbers in prs
+func trackedPRNumbers(prs []cmd.TrackedPR) map[int]bool {
+	numbers := make(map[int]bool, len(prs))
+	for _, pr := range prs {
+		numbers[pr.Number] = true
+	}
+	return numbers
+}
+
+// autoPick runs fetch --auto-pick-clean over the top-level repository's PRs
+// that weren't tracked before this fetch, reporting each pick as a status change
+func autoPick(ctx context.Context, client *github.Client, st *state.Config, known map[int]bool) error {
+	cv := st.CherryView()
+	var found []int
+	for _, pr := range cv.TrackedPRs {
+		if !known[pr.Number] {
+			found = append(found, pr.Number)
+		}
+	}
+
+	picked, err := pick.AutoPickClean(ctx, client, cv, found)
+	for _, p := range picked {
+		fetch.Emit(ctx, fetch.Event{Type: fetch.EventStatusChanged, PR: p.PR, Branch: p.Branch,
+			From: cmd.BranchStatusPending, To: cmd.BranchStatusPicked, PickPR: p.CherryPickPR})
+	}
+	st.ApplyCherryView(cv)
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/cmd/merge"
 	"github.com/alan/cherry-picker/internal/commands"
 	"github.com/alan/cherry-picker/internal/depmerger"
@@ -14,7 +15,10 @@ import (
 )
 
 func newMergeCmd(configFile *string) *cobra.Command {
-	return &cobra.Command{
+	var useMergeQueue, deleteBranch, allowUnknownCI, check, notifySlack bool
+	var only, allowCIFlag, mergeMethod, repo string
+
+	mergeCmd := &cobra.Command{
 		Use:   "merge [pr-number] [target-branch]",
 		Short: "Squash-merge eligible cherry-pick or dependency PRs with passing CI",
 		Long: `Squash-merge PRs with passing CI. With no PR number, merges all eligible
@@ -22,6 +26,40 @@ PRs in both subsystems. With a PR number, dispatches to whichever subsystem
 tracks it (cherry-pick or dependency). A target branch applies only to
 cherry-pick PRs.
 
+With --merge-queue (or use_merge_queue: true under cherry_picks), cherry-pick
+PRs are added to GitHub's merge queue instead and marked 'queued'.
+
+Cherry-pick PRs are squash-merged unless merge_method under cherry_picks or
+--merge-method says merge (merge commit) or rebase.
+
+With --delete-branch (or delete_merged_branches: true under cherry_picks), each
+cherry-pick PR's head branch is deleted once the PR merges; a failed delete is
+only a warning.
+
+With --allow-unknown-ci, cherry-pick PRs whose CI status is 'unknown' or
+'no_checks' (the commit has no checks at all) are merged too; use it only where
+branch protection is the real merge gate.
+
+With --allow-ci passing,unknown,no_checks,pending (any subset), cherry-pick PRs
+are merged at any of the listed CI states; failing PRs never are.
+--allow-unknown-ci adds unknown to the list, and unknown brings no_checks along.
+
+With --only passing|unknown|no_checks, only cherry-pick PRs at exactly that CI
+status are merged (--only needs its status allowed).
+
+With --check, nothing is merged: a readiness report of the cherry-pick PRs is
+printed and the command exits non-zero unless at least one branch is eligible
+and no picked branch has failing CI. Use it as a release pipeline gate.
+Dependency PRs aren't part of the report; 'status' shows those.
+
+When cherry_picks.repositories tracks further repositories, their cherry-pick
+PRs are merged too; --repo org/repo limits merging to one of them (and picks
+the repository --check reports on, the top-level one by default).
+
+With --notify, a short report is posted to cherry_picks.slack_webhook_url once
+merging is done: how many branches moved to picked, merged and released, the
+status summary line, and the cherry-pick PRs whose CI is failing.
+
 Requires GITHUB_TOKEN environment variable to be set.`,
 		Args:         cobra.RangeArgs(0, 2),
 		SilenceUsage: true,
@@ -32,29 +70,89 @@ Requires GITHUB_TOKEN environment variable to be set.`,
 				return err
 			}
 			targetBranch := commands.GetTargetBranchFromArgs(args)
+			onlyStatus, err := merge.ParseOnlyFilter(only)
+			if err != nil {
+				return err
+			}
+			allowCI, err := merge.ParseAllowCI(allowCIFlag, allowUnknownCI)
+			if err != nil {
+				return err
+			}
+			method, ok := cmd.ParseMergeMethod(mergeMethod)
+			if !ok {
+				return fmt.Errorf("invalid --merge-method %q: must be squash, merge or rebase", mergeMethod)
+			}
+
+			if check {
+				if onlyStatus != "" {
+					return errors.New("--only doesn't apply to --check")
+				}
+				if notifySlack {
+					return errors.New("--notify doesn't apply to --check")
+				}
+				st, err := state.Load(*configFile)
+				if err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+				root := st.CherryView()
+				i, ok := root.FindRepositoryView(repo)
+				if !ok {
+					return fmt.Errorf("repository %s is not tracked in %s", repo, *configFile)
+				}
+				return merge.Check(root.RepositoryViews()[i], prNumber, targetBranch, allowCI)
+			}
 
 			client, st, err := loadStateAndClient(ctx, *configFile)
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
-			return dispatchMerge(ctx, client, st, *configFile, prNumber, targetBranch)
+			if useMergeQueue {
+				st.CherryPicks.UseMergeQueue = true
+			}
+			if mergeMethod != "" {
+				st.CherryPicks.MergeMethod = method
+			}
+			if deleteBranch {
+				st.CherryPicks.DeleteMergedBranches = true
+			}
+			if !notifySlack {
+				return dispatchMerge(ctx, client, st, *configFile, repo, prNumber, targetBranch, allowCI, onlyStatus)
+			}
+
+			notifier, err := newRunNotifier("merge", *configFile, st)
+			if err != nil {
+				return err
+			}
+			mergeErr := dispatchMerge(ctx, client, st, *configFile, repo, prNumber, targetBranch, allowCI, onlyStatus)
+			return errors.Join(mergeErr, notifier.send(ctx))
 		},
 	}
+
+	mergeCmd.Flags().BoolVar(&useMergeQueue, "merge-queue", false, "Add cherry-pick PRs to GitHub's merge queue instead of merging directly")
+	mergeCmd.Flags().StringVar(&mergeMethod, "merge-method", "", "Merge method for cherry-pick PRs: squash, merge or rebase (overrides merge_method; default squash)")
+	mergeCmd.Flags().BoolVar(&deleteBranch, "delete-branch", false, "Delete each cherry-pick PR's head branch after it merges (overrides delete_merged_branches)")
+	mergeCmd.Flags().BoolVar(&allowUnknownCI, "allow-unknown-ci", false, "Also merge cherry-pick PRs whose CI status is unknown or no_checks")
+	mergeCmd.Flags().StringVar(&allowCIFlag, "allow-ci", "", "Comma-separated CI states cherry-pick PRs may be merged at: passing, unknown, no_checks, pending (default passing)")
+	mergeCmd.Flags().StringVar(&only, "only", "", "Merge only eligible cherry-pick PRs whose CI status is this (passing, unknown or no_checks)")
+	mergeCmd.Flags().StringVar(&repo, "repo", "", "Only merge cherry-pick PRs of this repository (org/repo) when the config tracks several")
+	mergeCmd.Flags().BoolVar(&notifySlack, "notify", false, "Post a summary of what changed to cherry_picks.slack_webhook_url when done")
+	mergeCmd.Flags().BoolVar(&check, "check", false, "Report cherry-pick merge readiness without merging (dependency PRs aren't checked); exit non-zero if nothing is ready or any picked PR has failing CI")
+
+	return mergeCmd
 }
 
-func dispatchMerge(ctx context.Context, client *github.Client, st *state.Config, configFile string, prNumber int, targetBranch string) error {
-	base := commands.BaseCommand{
-		ConfigFile:   &configFile,
-		LoadConfig:   loadCherry,
-		SaveConfig:   saveCherry,
-		GitHubClient: client,
-		Config:       st.CherryView(),
+func dispatchMerge(ctx context.Context, client *github.Client, st *state.Config, configFile, repo string, prNumber int, targetBranch string, allowCI []cmd.CIStatus, only cmd.CIStatus) error {
+	bases, err := cherryRepoBases(client, st, &configFile, repo)
+	if err != nil {
+		return err
 	}
 
 	if prNumber == 0 {
 		var errs []error
-		if err := merge.Execute(ctx, base, 0, ""); err != nil {
-			errs = append(errs, err)
+		for _, base := range bases {
+			if err := merge.Execute(ctx, base, 0, "", allowCI, only); err != nil {
+				errs = append(errs, err)
+			}
 		}
 		if err := runDepMerge(ctx, client, configFile, st.DepView(), 0); err != nil {
 			errs = append(errs, err)
@@ -62,8 +160,12 @@ func dispatchMerge(ctx context.Context, client *github.Client, st *state.Config,
 		return errors.Join(errs...)
 	}
 
-	if prTrackedInCherry(st, prNumber) {
-		return merge.Execute(ctx, base, prNumber, targetBranch)
+	base, tracked, err := cherryBaseForPR(bases, prNumber)
+	if err != nil {
+		return err
+	}
+	if tracked {
+		return merge.Execute(ctx, base, prNumber, targetBranch, allowCI, only)
 	}
 	if depmerger.FindTrackedPR(st.DepView(), prNumber) != nil {
 		return runDepMerge(ctx, client, configFile, st.DepView(), prNumber)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/commands"
 	"github.com/alan/cherry-picker/internal/config"
 	"github.com/alan/cherry-picker/internal/depmerger"
 	"github.com/alan/cherry-picker/internal/state"
@@ -37,7 +38,7 @@ place.`,
 
 func runMigrate(unifiedFile, cherryFile, depFile string) error {
 	if _, err := os.Stat(unifiedFile); err == nil {
-		fmt.Printf("%s already exists; nothing to migrate.\n", unifiedFile)
+		fmt.Fprintf(os.Stderr, "%s already exists; nothing to migrate.\n", unifiedFile)
 		return nil
 	}
 
@@ -57,12 +58,48 @@ func runMigrate(unifiedFile, cherryFile, depFile string) error {
 
 	if cherryCfg != nil {
 		unified.LastFetchDate = cherryCfg.LastFetchDate
+		unified.TokenEnvVar = cherryCfg.TokenEnvVar
+		unified.BaseURL = cherryCfg.BaseURL
+		unified.HTTPTimeout = cherryCfg.HTTPTimeout
+		unified.HTTPRetries = cherryCfg.HTTPRetries
+		unified.RateLimitRetries = cherryCfg.RateLimitRetries
+		unified.RateLimitDelay = cherryCfg.RateLimitDelay
+		unified.IgnoredCIContexts = cherryCfg.IgnoredCIContexts
+		unified.GraphQLCIStatus = cherryCfg.GraphQLCIStatus
 		unified.CherryPicks = state.CherryPickSection{
-			SourceBranch:       cherryCfg.SourceBranch,
-			AIAssistantCommand: cherryCfg.AIAssistantCommand,
-			LastCheckedRelease: cherryCfg.LastCheckedRelease,
-			TrackerIssues:      cherryCfg.TrackerIssues,
-			TrackedPRs:         cherryCfg.TrackedPRs,
+			SourceBranch:              cherryCfg.SourceBranch,
+			AIAssistantCommand:        cherryCfg.AIAssistantCommand,
+			OnLabelRemoved:            cherryCfg.OnLabelRemoved,
+			UseMergeQueue:             cherryCfg.UseMergeQueue,
+			MergeMethod:               cherryCfg.MergeMethod,
+			DeleteMergedBranches:      cherryCfg.DeleteMergedBranches,
+			StaleAfter:                cherryCfg.StaleAfter,
+			ManualSearchMaxCandidates: cherryCfg.ManualSearchMaxCandidates,
+			CommitTrailers:            cherryCfg.CommitTrailers,
+			InitialHistoryMaxCommits:  cherryCfg.InitialHistoryMaxCommits,
+			InitialHistorySince:       cherryCfg.InitialHistorySince,
+			NewBranchBase:             cherryCfg.NewBranchBase,
+			HeadBranchPattern:         cherryCfg.HeadBranchPattern,
+			LabelPrefix:               cherryCfg.LabelPrefix,
+			BranchTemplate:            cherryCfg.BranchTemplate,
+			TitleFormat:               cherryCfg.TitleFormat,
+			PostFetchCommand:          cherryCfg.PostFetchCommand,
+			FetchConcurrency:          cherryCfg.FetchConcurrency,
+			IgnoredChecks:             cherryCfg.IgnoredChecks,
+			PendingGracePeriod:        cherryCfg.PendingGracePeriod,
+			AIAssistantAutoLaunch:     cherryCfg.AIAssistantAutoLaunch,
+			Signoff:                   cherryCfg.Signoff,
+			SignoffIdentity:           cherryCfg.SignoffIdentity,
+			ReleaseLabel:              cherryCfg.ReleaseLabel,
+			SlackWebhookURL:           cherryCfg.SlackWebhookURL,
+			RequiredChecks:            cherryCfg.RequiredChecks,
+			ConflictStrategy:          cherryCfg.ConflictStrategy,
+			ConflictAutoResolve:       cherryCfg.ConflictAutoResolve,
+			LastCheckedRelease:        cherryCfg.LastCheckedRelease,
+			UnscannedReleases:         cherryCfg.UnscannedReleases,
+			TrackerIssues:             cherryCfg.TrackerIssues,
+			TrackedPRs:                cherryCfg.TrackedPRs,
+			Repositories:              cherryCfg.Repositories,
 		}
 	}
 	if depCfg != nil {
@@ -70,11 +107,16 @@ func runMigrate(unifiedFile, cherryFile, depFile string) error {
 		unified.LastFetchDate = minTime(unified.LastFetchDate, depCfg.LastFetchDate)
 	}
 
+	if commands.DryRun() {
+		fmt.Fprintf(os.Stderr, "Dry run: would migrate into %s (cherry-picks: %d PRs, dependencies: %d PRs)\n",
+			unifiedFile, len(unified.CherryPicks.TrackedPRs), len(unified.Dependencies.TrackedPRs))
+		return nil
+	}
 	if err := state.Save(unifiedFile, unified); err != nil {
 		return fmt.Errorf("failed to write %s: %w", unifiedFile, err)
 	}
 
-	fmt.Printf("Migrated into %s (cherry-picks: %d PRs, dependencies: %d PRs)\n",
+	fmt.Fprintf(os.Stderr, "Migrated into %s (cherry-picks: %d PRs, dependencies: %d PRs)\n",
 		unifiedFile, len(unified.CherryPicks.TrackedPRs), len(unified.Dependencies.TrackedPRs))
 	return nil
 }
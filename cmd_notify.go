@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/alan/cherry-picker/cmd/status"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/notify"
+	"github.com/alan/cherry-picker/internal/state"
+)
+
+// runNotifier posts a --notify report of what a fetch or merge changed to the
+// configured Slack webhook
+type runNotifier struct {
+	command    string
+	configFile string
+	webhookURL string
+	before     status.Snapshot
+}
+
+// newRunNotifier records the cherry-pick statuses before command runs. It
+// errors when cherry_picks.slack_webhook_url isn't set.
+func newRunNotifier(command, configFile string, st *state.Config) (*runNotifier, error) {
+	if st.CherryPicks.SlackWebhookURL == "" {
+		return nil, fmt.Errorf("--notify needs cherry_picks.slack_webhook_url to be set in %s", configFile)
+	}
+	return &runNotifier{
+		command:    command,
+		configFile: configFile,
+		webhookURL: st.CherryPicks.SlackWebhookURL,
+		before:     status.TakeSnapshot(st.CherryView()),
+	}, nil
+}
+
+// send reloads the saved state and posts what changed since newRunNotifier.
+// Under --dry-run the message is printed instead of posted.
+func (n *runNotifier) send(ctx context.Context) error {
+	st, err := state.Load(savePath(n.configFile))
+	if err != nil {
+		return fmt.Errorf("failed to load config for --notify: %w", err)
+	}
+	message := status.NotifyMessage(n.command, n.before, status.TakeSnapshot(st.CherryView()))
+	if commands.DryRun() {
+		fmt.Fprintf(os.Stderr, "Dry run: would post to Slack:\n%s\n", message)
+		return nil
+	}
+	if err := notify.Slack(ctx, n.webhookURL, message); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "📣 Posted the results to Slack")
+	return nil
+}
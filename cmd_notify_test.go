@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunNotifier_DryRunDoesNotPost(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	path := writeState(t, func(c *state.Config) {
+		c.Org, c.Repo = "acme", "widget"
+		c.CherryPicks.SlackWebhookURL = server.URL
+	})
+	st, err := state.Load(path)
+	require.NoError(t, err)
+	notifier, err := newRunNotifier("fetch", path, st)
+	require.NoError(t, err)
+
+	commands.SetDryRun(true)
+	t.Cleanup(func() { commands.SetDryRun(false) })
+	require.NoError(t, notifier.send(t.Context()))
+	assert.Equal(t, 0, posts)
+
+	commands.SetDryRun(false)
+	require.NoError(t, notifier.send(t.Context()))
+	assert.Equal(t, 1, posts)
+}
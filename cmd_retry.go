@@ -14,13 +14,24 @@ import (
 )
 
 func newRetryCmd(configFile *string) *cobra.Command {
-	return &cobra.Command{
+	var repo string
+	var wait retry.WaitOptions
+
+	retryCmd := &cobra.Command{
 		Use:   "retry [pr-number] [target-branch]",
 		Short: "Retry failed CI for cherry-pick or dependency PRs",
 		Long: `Retry failed CI workflows. With no PR number, retries all PRs with
 failing CI in both subsystems. With a PR number, dispatches to whichever
 subsystem tracks it. A target branch applies only to cherry-pick PRs.
 
+When cherry_picks.repositories tracks further repositories, their cherry-pick
+PRs are retried too; --repo org/repo limits retrying to one of them.
+
+With --wait, retry then polls the CI status of each retried cherry-pick PR
+until it passes or fails, or --timeout elapses, and prints the final states.
+It exits non-zero when any of them is still failing or didn't finish.
+Dependency PRs are retried but not waited for.
+
 Requires GITHUB_TOKEN environment variable to be set.`,
 		Args:         cobra.RangeArgs(0, 2),
 		SilenceUsage: true,
@@ -31,29 +42,36 @@ Requires GITHUB_TOKEN environment variable to be set.`,
 				return err
 			}
 			targetBranch := commands.GetTargetBranchFromArgs(args)
+			if err := retry.ValidateWaitOptions(wait); err != nil {
+				return err
+			}
 
 			client, st, err := loadStateAndClient(ctx, *configFile)
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
-			return dispatchRetry(ctx, client, st, *configFile, prNumber, targetBranch)
+			return dispatchRetry(ctx, client, st, *configFile, repo, prNumber, targetBranch, wait)
 		},
 	}
+
+	retryCmd.Flags().StringVar(&repo, "repo", "", "Only retry cherry-pick PRs of this repository (org/repo) when the config tracks several")
+	retry.AddWaitFlags(retryCmd, &wait)
+
+	return retryCmd
 }
 
-func dispatchRetry(ctx context.Context, client *github.Client, st *state.Config, configFile string, prNumber int, targetBranch string) error {
-	base := commands.BaseCommand{
-		ConfigFile:   &configFile,
-		LoadConfig:   loadCherry,
-		SaveConfig:   saveCherry,
-		GitHubClient: client,
-		Config:       st.CherryView(),
+func dispatchRetry(ctx context.Context, client *github.Client, st *state.Config, configFile, repo string, prNumber int, targetBranch string, wait retry.WaitOptions) error {
+	bases, err := cherryRepoBases(client, st, &configFile, repo)
+	if err != nil {
+		return err
 	}
 
 	if prNumber == 0 {
 		var errs []error
-		if err := retry.Execute(ctx, base, 0, ""); err != nil {
-			errs = append(errs, err)
+		for _, base := range bases {
+			if err := retry.Execute(ctx, base, 0, "", wait); err != nil {
+				errs = append(errs, err)
+			}
 		}
 		// Retry does not mutate tracked state, so no save is needed.
 		if err := depmerger.RetryPRs(ctx, client, st.DepView(), 0); err != nil {
@@ -62,8 +80,12 @@ func dispatchRetry(ctx context.Context, client *github.Client, st *state.Config,
 		return errors.Join(errs...)
 	}
 
-	if prTrackedInCherry(st, prNumber) {
-		return retry.Execute(ctx, base, prNumber, targetBranch)
+	base, tracked, err := cherryBaseForPR(bases, prNumber)
+	if err != nil {
+		return err
+	}
+	if tracked {
+		return retry.Execute(ctx, base, prNumber, targetBranch, wait)
 	}
 	if depmerger.FindTrackedPR(st.DepView(), prNumber) != nil {
 		return depmerger.RetryPRs(ctx, client, st.DepView(), prNumber)
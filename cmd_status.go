@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd/status"
 	"github.com/alan/cherry-picker/internal/depmerger"
@@ -12,54 +14,125 @@ import (
 )
 
 func newStatusCmd(configFile *string) *cobra.Command {
-	var showReleased, showMerged, doFetch bool
+	var showReleased, showMerged, doFetch, watch bool
+	var sortBy, output, releaseLabel string
+	var interval time.Duration
 
 	statusCmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show status of tracked cherry-pick and dependency PRs",
 		Long: `Display the current status of all tracked PRs across both subsystems:
 cherry-picks (per target branch) and dependencies. Reads the local state file
-without contacting GitHub unless --fetch is given.`,
+without contacting GitHub unless --fetch is given.
+
+With --output json, the cherry-pick PRs are written to stdout as a JSON
+document with a summary of the branch counts instead; dependency PRs are
+left out. --output html writes the same cherry-pick PRs as a self-contained
+HTML page (a table per repository, linking each PR) for a static dashboard.
+
+With --watch, the status is redrawn every --interval (re-fetching first when
+--fetch is given) until every tracked cherry-pick is released or Ctrl-C is
+pressed. A Ctrl-C during a fetch lets it finish saving before exiting.
+
+With --release-label (or cherry_picks.release_label), only the cherry-picks
+that set-release annotated with that release effort are shown;
+--release-label "" shows every one despite release_label.`,
 		SilenceUsage: true,
 		RunE: func(cobraCmd *cobra.Command, _ []string) error {
-			ctx := cobraCmd.Context()
-
-			if doFetch {
-				client, st, err := loadStateAndClient(ctx, *configFile)
-				if err != nil {
-					return fmt.Errorf("failed to load config: %w", err)
-				}
-				refreshErr := refresh.All(ctx, client, st)
-				if err := state.Update(*configFile, func(cur *state.Config) error {
-					cur.MergeFetched(st)
-					return nil
-				}); err != nil {
-					return fmt.Errorf("failed to save config: %w", err)
-				}
-				if refreshErr != nil {
-					fmt.Fprintf(os.Stderr, "warning: fetch had errors: %v\n", refreshErr)
-				}
+			var release *string
+			if cobraCmd.Flags().Changed("release-label") {
+				release = &releaseLabel
 			}
-
-			st, err := state.Load(*configFile)
+			order, err := status.ParseSortOrder(sortBy)
+			if err != nil {
+				return err
+			}
+			format, err := status.ParseOutputFormat(output)
 			if err != nil {
-				return fmt.Errorf("failed to load config: %w", err)
+				return err
 			}
 
-			status.Render(st.CherryView(), *configFile, showReleased)
-			fmt.Println()
-			configFlag := ""
-			if *configFile != defaultConfigFile {
-				configFlag = " --config " + *configFile
+			show := func(ctx context.Context) (*state.Config, error) {
+				return showStatus(ctx, *configFile, doFetch, showReleased, showMerged, order, format, release)
 			}
-			depmerger.RenderStatus(os.Stdout, st.DepView(), os.Args[0], configFlag, showMerged)
-			return nil
+			if !watch {
+				_, err := show(cobraCmd.Context())
+				return err
+			}
+			if format != status.OutputText {
+				return fmt.Errorf("--watch only works with text output")
+			}
+			return status.Watch(cobraCmd.Context(), os.Stdout, interval, func(ctx context.Context) (bool, error) {
+				st, err := show(ctx)
+				if err != nil {
+					return false, err
+				}
+				cherries := st.CherryView()
+				return status.AllReleased(cherries.ScopedToRelease(cherries.ReleaseScope(release))), nil
+			})
 		},
 	}
 
 	statusCmd.Flags().BoolVar(&showReleased, "show-released", false, "Show cherry-picks that are completely released")
 	statusCmd.Flags().BoolVar(&showMerged, "show-merged", false, "Show dependency PRs that are merged")
 	statusCmd.Flags().BoolVar(&doFetch, "fetch", false, "Fetch latest data from GitHub before showing status")
+	statusCmd.Flags().StringVar(&output, "output", string(status.OutputText), "Output format: text, or json or html for the cherry-pick PRs only")
+	statusCmd.Flags().StringVar(&sortBy, "sort", string(status.SortByNumber), "Order cherry-pick PRs by number, status (most actionable first) or ci (failing CI first)")
+	statusCmd.Flags().BoolVar(&watch, "watch", false, "Redraw the status every --interval until all cherry-picks are released or Ctrl-C")
+	statusCmd.Flags().DurationVar(&interval, "interval", status.DefaultWatchInterval, "How often --watch redraws (and fetches, with --fetch)")
+	statusCmd.Flags().StringVar(&releaseLabel, "release-label", "", "Only show cherry-picks annotated with this release effort by set-release (default cherry_picks.release_label)")
 
 	return statusCmd
 }
+
+// showStatus prints the status once, fetching first if doFetch, and returns
+// the state it showed. release scopes the cherry-picks as for
+// cmd.Config.ReleaseScope.
+func showStatus(ctx context.Context, configFile string, doFetch, showReleased, showMerged bool, order status.SortOrder, format status.OutputFormat, release *string) (*state.Config, error) {
+	if doFetch {
+		client, st, err := loadStateAndClient(ctx, configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		refreshErr := refresh.All(ctx, client, st)
+		// Whatever reads GitHub after the fetch must not see its cached lists
+		client.ClearCache()
+		if err := updateState(configFile, func(cur *state.Config) error {
+			cur.MergeFetched(st)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to save config: %w", err)
+		}
+		if refreshErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: fetch had errors: %v\n", refreshErr)
+		}
+	}
+
+	// After a fetch, show what was written (--config-out if set).
+	readPath := configFile
+	if doFetch {
+		readPath = savePath(configFile)
+	}
+	st, err := state.Load(readPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cherries := st.CherryView()
+	cherries = cherries.ScopedToRelease(cherries.ReleaseScope(release))
+	switch format {
+	case status.OutputJSON:
+		return st, status.RenderJSON(os.Stdout, cherries, showReleased, order)
+	case status.OutputHTML:
+		return st, status.RenderHTML(os.Stdout, cherries, showReleased, order)
+	}
+
+	status.Render(cherries, configFile, showReleased, order)
+	fmt.Println()
+	configFlag := ""
+	if configFile != defaultConfigFile {
+		configFlag = " --config " + configFile
+	}
+	depmerger.RenderStatus(os.Stdout, st.DepView(), os.Args[0], configFlag, showMerged)
+	return st, nil
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
@@ -23,6 +24,10 @@ func (bc *BaseCommand) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// Fail on an incoherent config now rather than with a confusing GitHub error later
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", *bc.ConfigFile, err)
+	}
 	bc.Config = config
 
 	// Initialize GitHub client using common initialization function
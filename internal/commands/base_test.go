@@ -21,8 +21,9 @@ func TestBaseCommand_Init(t *testing.T) {
 			token: "test-token",
 			loadConfig: func(_ string) (*cmd.Config, error) {
 				return &cmd.Config{
-					Org:  "testorg",
-					Repo: "testrepo",
+					Org:          "testorg",
+					Repo:         "testrepo",
+					SourceBranch: "main",
 				}, nil
 			},
 			wantErr: false,
@@ -40,8 +41,9 @@ func TestBaseCommand_Init(t *testing.T) {
 			token: "",
 			loadConfig: func(_ string) (*cmd.Config, error) {
 				return &cmd.Config{
-					Org:  "testorg",
-					Repo: "testrepo",
+					Org:          "testorg",
+					Repo:         "testrepo",
+					SourceBranch: "main",
 				}, nil
 			},
 			wantErr: true,
@@ -75,8 +77,9 @@ func TestBaseCommand_InitSetsFields(t *testing.T) {
 	t.Setenv("GITHUB_TOKEN", "test-token")
 
 	expectedConfig := &cmd.Config{
-		Org:  "myorg",
-		Repo: "myrepo",
+		Org:          "myorg",
+		Repo:         "myrepo",
+		SourceBranch: "main",
 	}
 
 	configFile := "test-config.yaml"
@@ -1,22 +1,101 @@
 package commands
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"os"
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/github"
+	"github.com/alan/cherry-picker/internal/redact"
 )
 
-// InitializeGitHubClient creates a GitHub client with proper token validation and repository context
+// DefaultTokenEnvVar is the environment variable the GitHub token is read from
+// when the config doesn't name another one
+const DefaultTokenEnvVar = "GITHUB_TOKEN"
+
+// githubToken is the --github-token flag value, preferred over any env var when set
+var githubToken string
+
+// SetGitHubToken makes InitializeGitHubClient use token instead of reading one
+// from the environment. The token is registered for redaction right away, so it
+// is masked in any log line written before a client exists.
+func SetGitHubToken(token string) {
+	redact.Register(token)
+	githubToken = token
+}
+
+// dryRun is the --dry-run flag value
+var dryRun bool
+
+// SetDryRun makes InitializeGitHubClient return clients whose mutating calls
+// are logged no-ops, and tells commands not to save the config or push
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// DryRun reports whether --dry-run is in effect
+func DryRun() bool {
+	return dryRun
+}
+
+// InitializeGitHubClient creates a GitHub client with proper token validation and repository context.
+// The token comes from --github-token if given, else the config's token_env_var, or
+// GITHUB_TOKEN when that is unset.
+// Requests go to github.com unless base_url names a GitHub Enterprise Server, over a
+// transport tuned by http_timeout and http_retries, retrying rate limits as
+// rate_limit_retries and rate_limit_delay say. Under --dry-run the client
+// skips mutating calls.
 func InitializeGitHubClient(ctx context.Context, config *cmd.Config) (*github.Client, context.Context, error) {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return nil, nil, fmt.Errorf("GITHUB_TOKEN environment variable is required")
+	token, err := resolveToken(config)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	client := github.NewClient(ctx, token).WithRepository(config.Org, config.Repo)
+	labelScheme, err := github.ParseLabelScheme(config.LabelPrefix, config.BranchTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpOpts := github.HTTPOptions{Timeout: config.HTTPTimeout, TransportRetries: config.HTTPRetries}
+	var client *github.Client
+	if config.BaseURL != "" {
+		client, err = github.NewEnterpriseClient(ctx, token, config.BaseURL, httpOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		client = github.NewClientWithHTTPOptions(ctx, token, httpOpts)
+	}
+
+	client = client.
+		WithRepository(config.Org, config.Repo).
+		WithIgnoredCIContexts(config.IgnoredCIContexts).
+		WithIgnoredChecks(config.IgnoredChecks).
+		WithGraphQLCIStatus(config.GraphQLCIStatus).
+		WithRequiredChecks(config.RequiredChecks).
+		WithLabelScheme(labelScheme).
+		WithDryRun(dryRun)
+	if config.RateLimitRetries > 0 || config.RateLimitDelay > 0 {
+		client = client.WithRetryPolicy(cmp.Or(config.RateLimitRetries, github.DefaultMaxRetries), cmp.Or(config.RateLimitDelay, github.DefaultRetryBaseDelay))
+	}
 
 	return client, ctx, nil
 }
+
+// resolveToken returns the --github-token value or the token from the config's env var
+func resolveToken(config *cmd.Config) (string, error) {
+	if githubToken != "" {
+		return githubToken, nil
+	}
+	envVar := config.TokenEnvVar
+	if envVar == "" {
+		envVar = DefaultTokenEnvVar
+	}
+	token := os.Getenv(envVar)
+	if token == "" {
+		return "", fmt.Errorf("%w: %s environment variable is required (or pass --github-token)", github.ErrMissingToken, envVar)
+	}
+	return token, nil
+}
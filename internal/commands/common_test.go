@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/alan/cherry-picker/internal/redact"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +30,16 @@ func TestInitializeGitHubClient(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "token from the configured env var",
+			token: "",
+			config: &cmd.Config{
+				Org:         "testorg",
+				Repo:        "testrepo",
+				TokenEnvVar: "CHERRY_PICKER_TEST_ACME_TOKEN",
+			},
+			wantErr: false,
+		},
 		{
 			name:  "missing GITHUB_TOKEN",
 			token: "",
@@ -47,6 +63,7 @@ func TestInitializeGitHubClient(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Setenv("GITHUB_TOKEN", tt.token)
+			t.Setenv("CHERRY_PICKER_TEST_ACME_TOKEN", "acme-token")
 
 			client, ctx, err := InitializeGitHubClient(t.Context(), tt.config)
 
@@ -62,3 +79,60 @@ func TestInitializeGitHubClient(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializeGitHubClient_MissingConfiguredToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "global-token")
+	t.Setenv("CHERRY_PICKER_TEST_ACME_TOKEN", "")
+
+	_, _, err := InitializeGitHubClient(t.Context(), &cmd.Config{TokenEnvVar: "CHERRY_PICKER_TEST_ACME_TOKEN"})
+	require.ErrorContains(t, err, "CHERRY_PICKER_TEST_ACME_TOKEN", "a configured env var must not fall back to GITHUB_TOKEN")
+	assert.True(t, github.IsAuthError(err), "a missing token is an auth error")
+}
+
+func TestInitializeGitHubClient_BaseURL(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	client, _, err := InitializeGitHubClient(t.Context(), &cmd.Config{BaseURL: "https://ghe.internal/api/v3"})
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+
+	_, _, err = InitializeGitHubClient(t.Context(), &cmd.Config{BaseURL: "ghe.internal"})
+	require.ErrorContains(t, err, "invalid base URL")
+}
+
+func TestInitializeGitHubClient_FlagToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("CHERRY_PICKER_TEST_ACME_TOKEN", "")
+	SetGitHubToken("flag-token-value")
+	t.Cleanup(func() { SetGitHubToken("") })
+
+	client, _, err := InitializeGitHubClient(t.Context(), &cmd.Config{TokenEnvVar: "CHERRY_PICKER_TEST_ACME_TOKEN"})
+	require.NoError(t, err, "--github-token is used when no env var is set")
+	assert.NotNil(t, client)
+
+	t.Setenv("GITHUB_TOKEN", "env-token-value")
+	token, err := resolveToken(&cmd.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "flag-token-value", token, "the flag wins over the env var")
+	assert.Equal(t, "token=***", redact.String("token=flag-token-value"), "the flag token is masked in logs")
+}
+
+func TestInitializeGitHubClient_RateLimitRetries(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := InitializeGitHubClient(t.Context(), &cmd.Config{
+		Org: "acme", Repo: "widget", BaseURL: server.URL + "/api/v3",
+		RateLimitRetries: 1, RateLimitDelay: time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetPR(t.Context(), 42)
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load(), "rate_limit_retries 1 means one retry")
+}
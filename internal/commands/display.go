@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"strings"
 )
 
@@ -21,19 +22,19 @@ func formatSuccessMessage(action string, prNumber int, targetBranch string, bran
 
 // DisplaySuccessMessage displays a formatted success message
 func DisplaySuccessMessage(action string, prNumber int, targetBranch string, branches []string) {
-	fmt.Print(formatSuccessMessage(action, prNumber, targetBranch, branches))
+	fmt.Fprint(os.Stderr, formatSuccessMessage(action, prNumber, targetBranch, branches))
 }
 
 // DisplayBulkOperationSuccess displays success messages for bulk operations (merge/retry all)
 func DisplayBulkOperationSuccess(operation string, count int, errors []error, scope string) {
 	if len(errors) > 0 {
-		fmt.Printf("⚠️  Some %ss failed: %v\n", operation, errors)
+		fmt.Fprintf(os.Stderr, "⚠️  Some %ss failed: %v\n", operation, errors)
 	}
 
 	if scope == "all" {
-		fmt.Printf("✅ Successfully %s %d PR(s) across all tracked PRs\n", getOperationPastTense(operation), count)
+		fmt.Fprintf(os.Stderr, "✅ Successfully %s %d PR(s) across all tracked PRs\n", getOperationPastTense(operation), count)
 	} else {
-		fmt.Printf("✅ Successfully %s %d PR(s)\n", getOperationPastTense(operation), count)
+		fmt.Fprintf(os.Stderr, "✅ Successfully %s %d PR(s)\n", getOperationPastTense(operation), count)
 	}
 }
 
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/alan/cherry-picker/cmd"
@@ -24,7 +26,7 @@ type ExecuteAllResult struct {
 func HandleExecuteAllResult(result *ExecuteAllResult, targetDescription string) error {
 	if result.TotalProcessed == 0 {
 		if len(result.Errors) > 0 {
-			return fmt.Errorf("no operations completed due to errors: %v", result.Errors)
+			return fmt.Errorf("no operations completed due to errors: %w", errors.Join(result.Errors...))
 		}
 		return fmt.Errorf("no eligible items found for %s", result.OperationName)
 	}
@@ -51,10 +53,10 @@ func ExecuteOnAllEligibleBranches(
 	}
 
 	var totalProcessed int
-	var errors []error
+	var errs []error
 	var configChanged bool
 
-	fmt.Printf("🔍 Scanning all tracked PRs for %s operations...\n", operationName)
+	fmt.Fprintf(os.Stderr, "🔍 Scanning all tracked PRs for %s operations...\n", operationName)
 
 	// Iterate through all tracked PRs
 	for prIndex := range config.TrackedPRs {
@@ -70,7 +72,7 @@ func ExecuteOnAllEligibleBranches(
 
 			err := operation(ctx, client, config, trackedPR, branchName, branchStatus)
 			if err != nil {
-				errors = append(errors, fmt.Errorf("PR #%d branch %s: %w", trackedPR.Number, branchName, err))
+				errs = append(errs, fmt.Errorf("PR #%d branch %s: %w", trackedPR.Number, branchName, err))
 				continue
 			}
 
@@ -80,20 +82,20 @@ func ExecuteOnAllEligibleBranches(
 		}
 
 		if prProcessedCount > 0 {
-			fmt.Printf("📊 Processed %d branch(es) for PR #%d\n", prProcessedCount, trackedPR.Number)
+			fmt.Fprintf(os.Stderr, "📊 Processed %d branch(es) for PR #%d\n", prProcessedCount, trackedPR.Number)
 		}
 	}
 
 	// Save the updated configuration if any changes were made and required
 	if requiresConfigSave && configChanged && saveConfig != nil {
 		if err := saveConfig(configFile, config); err != nil {
-			fmt.Printf("⚠️  Operations completed successfully but failed to update config: %v\n", err)
+			fmt.Fprintf(os.Stderr, "⚠️  Operations completed successfully but failed to update config: %v\n", err)
 		}
 	}
 
 	result := &ExecuteAllResult{
 		TotalProcessed: totalProcessed,
-		Errors:         errors,
+		Errors:         errs,
 		OperationName:  operationName,
 	}
 
@@ -119,7 +121,7 @@ func ExecuteOnEligibleBranchesForPR(
 	}
 
 	var processedCount int
-	var errors []error
+	var errs []error
 	var configChanged bool
 
 	// Check each branch for this PR
@@ -131,7 +133,7 @@ func ExecuteOnEligibleBranchesForPR(
 
 		err := operation(ctx, client, config, trackedPR, branchName, branchStatus)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("branch %s: %w", branchName, err))
+			errs = append(errs, fmt.Errorf("branch %s: %w", branchName, err))
 			continue
 		}
 
@@ -142,18 +144,18 @@ func ExecuteOnEligibleBranchesForPR(
 	// Save the updated configuration if any changes were made and required
 	if requiresConfigSave && configChanged && saveConfig != nil {
 		if err := saveConfig(configFile, config); err != nil {
-			fmt.Printf("⚠️  Operations completed successfully but failed to update config: %v\n", err)
+			fmt.Fprintf(os.Stderr, "⚠️  Operations completed successfully but failed to update config: %v\n", err)
 		}
 	}
 
 	if processedCount == 0 {
-		if len(errors) > 0 {
-			return fmt.Errorf("no operations completed due to errors: %v", errors)
+		if len(errs) > 0 {
+			return fmt.Errorf("no operations completed due to errors: %w", errors.Join(errs...))
 		}
 		return fmt.Errorf("no eligible branches found for %s for PR #%d", operationName, trackedPR.Number)
 	}
 
-	DisplayBulkOperationSuccess(operationName, processedCount, errors, fmt.Sprintf("PR #%d", trackedPR.Number))
+	DisplayBulkOperationSuccess(operationName, processedCount, errs, fmt.Sprintf("PR #%d", trackedPR.Number))
 	return nil
 }
 
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -11,6 +12,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+func TestHandleExecuteAllResult_KeepsErrorChain(t *testing.T) {
+	revoked := fmt.Errorf("PR #1 branch release-1.0: %w", github.ErrUnauthorized)
+	err := HandleExecuteAllResult(&ExecuteAllResult{Errors: []error{revoked}, OperationName: "merge"}, "all")
+	if !github.IsAuthError(err) {
+		t.Errorf("HandleExecuteAllResult() error = %v, want it to wrap the auth failure", err)
+	}
+}
+
 func TestHandleExecuteAllResult(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/alan/cherry-picker/cmd"
 )
@@ -77,12 +78,28 @@ func ValidateAnyBranchForOperation(trackedPR *cmd.TrackedPR, operation string, p
 
 // Common validation predicates
 
+// MergeEligibility returns the merge predicate accepting picked branches whose
+// cherry-pick PR's CI status is one of allowed; with none given, passing only
+func MergeEligibility(allowed ...cmd.CIStatus) BranchValidationPredicate {
+	if len(allowed) == 0 {
+		allowed = []cmd.CIStatus{cmd.CIStatusPassing}
+	}
+	return func(branchStatus cmd.BranchStatus) bool {
+		return branchStatus.Status == cmd.BranchStatusPicked &&
+			branchStatus.PR != nil &&
+			slices.Contains(allowed, branchStatus.PR.CIStatus)
+	}
+}
+
 // IsEligibleForMerge checks if a branch is eligible for merging (CI passing, not already merged)
 func IsEligibleForMerge(branchStatus cmd.BranchStatus) bool {
-	return branchStatus.Status == cmd.BranchStatusPicked &&
-		branchStatus.PR != nil &&
-		branchStatus.PR.CIStatus == cmd.CIStatusPassing &&
-		branchStatus.Status != cmd.BranchStatusMerged
+	return MergeEligibility(cmd.CIStatusPassing)(branchStatus)
+}
+
+// IsEligibleForMergeAllowingUnknownCI is IsEligibleForMerge, but also accepts picked
+// branches whose CI status is unknown (for repos where branch protection is the real gate)
+func IsEligibleForMergeAllowingUnknownCI(branchStatus cmd.BranchStatus) bool {
+	return MergeEligibility(cmd.CIStatusPassing, cmd.CIStatusUnknown)(branchStatus)
 }
 
 // IsEligibleForRetry checks if a branch is eligible for CI retry (CI failing)
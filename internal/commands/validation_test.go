@@ -290,6 +290,49 @@ func TestIsEligibleForMerge(t *testing.T) {
 	}
 }
 
+func TestIsEligibleForMergeAllowingUnknownCI(t *testing.T) {
+	tests := []struct {
+		name   string
+		status cmd.BranchStatus
+		want   bool
+	}{
+		{
+			name:   "CI passing",
+			status: cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 789, CIStatus: cmd.CIStatusPassing}},
+			want:   true,
+		},
+		{
+			name:   "CI unknown",
+			status: cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 789, CIStatus: cmd.CIStatusUnknown}},
+			want:   true,
+		},
+		{
+			name:   "CI failing",
+			status: cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 789, CIStatus: cmd.CIStatusFailing}},
+			want:   false,
+		},
+		{
+			name:   "CI pending",
+			status: cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 789, CIStatus: cmd.CIStatusPending}},
+			want:   false,
+		},
+		{
+			name:   "unknown CI but not picked",
+			status: cmd.BranchStatus{Status: cmd.BranchStatusQueued, PR: &cmd.PickPR{Number: 789, CIStatus: cmd.CIStatusUnknown}},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsEligibleForMergeAllowingUnknownCI(tt.status))
+			if tt.status.PR.CIStatus == cmd.CIStatusUnknown {
+				assert.False(t, IsEligibleForMerge(tt.status), "default merge eligibility stays strict")
+			}
+		})
+	}
+}
+
 func TestIsEligibleForRetry(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -342,3 +385,19 @@ func TestIsEligibleForRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeEligibility(t *testing.T) {
+	picked := func(ci cmd.CIStatus) cmd.BranchStatus {
+		return cmd.BranchStatus{Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 1, CIStatus: ci}}
+	}
+
+	passingOnly := MergeEligibility()
+	assert.True(t, passingOnly(picked(cmd.CIStatusPassing)))
+	assert.False(t, passingOnly(picked(cmd.CIStatusUnknown)))
+
+	relaxed := MergeEligibility(cmd.CIStatusPassing, cmd.CIStatusUnknown)
+	assert.True(t, relaxed(picked(cmd.CIStatusUnknown)))
+	assert.False(t, relaxed(picked(cmd.CIStatusPending)))
+	assert.False(t, relaxed(cmd.BranchStatus{Status: cmd.BranchStatusMerged, PR: &cmd.PickPR{CIStatus: cmd.CIStatusPassing}}))
+	assert.False(t, relaxed(cmd.BranchStatus{Status: cmd.BranchStatusPicked}), "no PR to merge")
+}
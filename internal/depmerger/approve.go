@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/alan/cherry-picker/internal/github"
 )
@@ -44,11 +45,11 @@ func ApprovePRs(ctx context.Context, client *github.Client, config *Config, prNu
 	}, "approve")
 
 	if approved == 0 {
-		fmt.Println("No dependency PRs with passing CI found to approve.")
+		fmt.Fprintln(os.Stderr, "No dependency PRs with passing CI found to approve.")
 		return nil
 	}
 
-	fmt.Printf("Approved %d dependency PR(s)\n", approved)
+	fmt.Fprintf(os.Stderr, "Approved %d dependency PR(s)\n", approved)
 	return nil
 }
 
@@ -59,6 +60,6 @@ func approveSinglePR(ctx context.Context, client *github.Client, pr *TrackedPR)
 		return fmt.Errorf("failed to approve PR #%d: %w", pr.Number, err)
 	}
 
-	fmt.Printf("Successfully approved PR #%d\n", pr.Number)
+	fmt.Fprintf(os.Stderr, "Successfully approved PR #%d\n", pr.Number)
 	return nil
 }
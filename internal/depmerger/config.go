@@ -16,10 +16,11 @@ type CIStatus = types.CIStatus
 
 // CI status constants from internal/types package
 const (
-	CIStatusPassing = types.CIStatusPassing
-	CIStatusFailing = types.CIStatusFailing
-	CIStatusPending = types.CIStatusPending
-	CIStatusUnknown = types.CIStatusUnknown
+	CIStatusPassing  = types.CIStatusPassing
+	CIStatusFailing  = types.CIStatusFailing
+	CIStatusPending  = types.CIStatusPending
+	CIStatusUnknown  = types.CIStatusUnknown
+	CIStatusNoChecks = types.CIStatusNoChecks
 )
 
 // ParseCIStatus is an alias for types.ParseCIStatus
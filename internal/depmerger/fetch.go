@@ -3,6 +3,7 @@ package depmerger
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/alan/cherry-picker/internal/github"
 )
@@ -13,7 +14,7 @@ const dependenciesLabel = "type/dependencies"
 // tracked-PR list in config in place. It performs no file I/O and does not set
 // LastFetchDate; the caller owns persistence and the shared timestamp.
 func RefreshDeps(ctx context.Context, client *github.Client, config *Config) error {
-	fmt.Printf("Fetching open PRs with label '%s' from %s/%s...\n", dependenciesLabel, config.Org, config.Repo)
+	slog.Info("Fetching open dependency PRs", "label", dependenciesLabel, "org", config.Org, "repo", config.Repo)
 
 	prs, err := client.GetOpenPRsWithLabel(ctx, dependenciesLabel)
 	if err != nil {
@@ -21,12 +22,12 @@ func RefreshDeps(ctx context.Context, client *github.Client, config *Config) err
 	}
 
 	if len(prs) == 0 {
-		fmt.Println("No open dependency PRs found.")
+		slog.Info("No open dependency PRs found")
 		markClosedPRs(config, prs)
 		return nil
 	}
 
-	fmt.Printf("Found %d open dependency PR(s)\n", len(prs))
+	slog.Info("Found open dependency PRs", "count", len(prs))
 
 	newCount := 0
 	updatedCount := 0
@@ -34,13 +35,13 @@ func RefreshDeps(ctx context.Context, client *github.Client, config *Config) err
 	for _, pr := range prs {
 		prDetails, err := client.GetPRWithDetailsNoDCOFilter(ctx, pr.Number)
 		if err != nil {
-			fmt.Printf("  Warning: failed to get details for PR #%d: %v\n", pr.Number, err)
+			slog.Warn("Failed to get dependency PR details", "pr", pr.Number, "error", err)
 			continue
 		}
 
 		approved, err := client.IsPRApproved(ctx, pr.Number)
 		if err != nil {
-			fmt.Printf("  Warning: failed to check approval for PR #%d: %v\n", pr.Number, err)
+			slog.Warn("Failed to check dependency PR approval", "pr", pr.Number, "error", err)
 		}
 
 		if existing := FindTrackedPR(config, pr.Number); existing != nil {
@@ -61,14 +62,14 @@ func RefreshDeps(ctx context.Context, client *github.Client, config *Config) err
 				Merged:        false,
 			})
 			newCount++
-			fmt.Printf("  Added: #%d %s\n", prDetails.Number, prDetails.Title)
+			slog.Info("Added dependency PR", "pr", prDetails.Number, "title", prDetails.Title)
 		}
 	}
 
 	// Mark PRs that are no longer open (merged or closed externally).
 	markClosedPRs(config, prs)
 
-	fmt.Printf("Dependency fetch complete: %d new, %d updated\n", newCount, updatedCount)
+	slog.Info("Dependency fetch complete", "new", newCount, "updated", updatedCount)
 	return nil
 }
 
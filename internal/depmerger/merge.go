@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/alan/cherry-picker/internal/github"
 )
@@ -25,22 +26,22 @@ func MergePRs(ctx context.Context, client *github.Client, config *Config, prNumb
 	}, "merge")
 
 	if merged == 0 {
-		fmt.Println("No dependency PRs with passing CI found to merge.")
+		fmt.Fprintln(os.Stderr, "No dependency PRs with passing CI found to merge.")
 		return nil
 	}
 
-	fmt.Printf("Merged %d dependency PR(s)\n", merged)
+	fmt.Fprintf(os.Stderr, "Merged %d dependency PR(s)\n", merged)
 	return nil
 }
 
 func mergeSinglePR(ctx context.Context, client *github.Client, pr *TrackedPR) error {
 	slog.Info("Merging PR", "pr", pr.Number)
 
-	if err := client.MergePR(ctx, pr.Number, "squash"); err != nil {
+	if err := client.MergePR(ctx, pr.Number, "squash", false); err != nil {
 		return fmt.Errorf("failed to merge PR #%d: %w", pr.Number, err)
 	}
 
 	pr.Merged = true
-	fmt.Printf("Successfully merged PR #%d\n", pr.Number)
+	fmt.Fprintf(os.Stderr, "Successfully merged PR #%d\n", pr.Number)
 	return nil
 }
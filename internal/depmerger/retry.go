@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/alan/cherry-picker/internal/github"
 )
@@ -25,11 +26,11 @@ func RetryPRs(ctx context.Context, client *github.Client, config *Config, prNumb
 	}, "retry")
 
 	if retried == 0 {
-		fmt.Println("No dependency PRs with failing CI found to retry.")
+		fmt.Fprintln(os.Stderr, "No dependency PRs with failing CI found to retry.")
 		return nil
 	}
 
-	fmt.Printf("Retried CI for %d dependency PR(s)\n", retried)
+	fmt.Fprintf(os.Stderr, "Retried CI for %d dependency PR(s)\n", retried)
 	return nil
 }
 
@@ -40,6 +41,6 @@ func retrySinglePR(ctx context.Context, client *github.Client, pr *TrackedPR) er
 		return fmt.Errorf("failed to retry CI for PR #%d: %w", pr.Number, err)
 	}
 
-	fmt.Printf("Successfully triggered retry for PR #%d\n", pr.Number)
+	fmt.Fprintf(os.Stderr, "Successfully triggered retry for PR #%d\n", pr.Number)
 	return nil
 }
@@ -85,6 +85,8 @@ func displayPRStatus(w io.Writer, pr TrackedPR, config *Config, execPath, config
 			fmt.Fprintf(w, "  Status: 🔄 CI pending\n")
 		case CIStatusUnknown:
 			fmt.Fprintf(w, "  Status: ❓ CI unknown\n")
+		case CIStatusNoChecks:
+			fmt.Fprintf(w, "  Status: ⚪ no CI configured\n")
 		}
 	}
 	fmt.Fprintln(w)
@@ -112,7 +114,7 @@ func displaySummary(w io.Writer, prs []TrackedPR) {
 			failing++
 		case CIStatusPending:
 			pending++
-		case CIStatusUnknown:
+		case CIStatusUnknown, CIStatusNoChecks:
 			// Don't count unknown status PRs in any category
 		}
 	}
@@ -57,17 +57,18 @@ func parseGitRemote() (string, string, error) {
 	return ParseRemoteURL(remoteURL)
 }
 
-// ParseRemoteURL extracts org and repo from various GitHub URL formats
+// ParseRemoteURL extracts org and repo from various GitHub URL formats. Any host
+// is accepted, so GitHub Enterprise Server remotes parse the same as github.com.
 func ParseRemoteURL(remoteURL string) (string, string, error) {
-	// Handle SSH format: git@github.com:org/repo.git
-	sshRegex := regexp.MustCompile(`git@github\.com:([^/]+)/([^/]+?)(?:\.git)?$`)
+	// Handle SCP-like SSH format: git@github.com:org/repo.git, git@ghe.internal:org/repo.git
+	sshRegex := regexp.MustCompile(`^[\w.-]+@[^:/]+:([^/]+)/([^/]+?)(?:\.git)?/?$`)
 	if matches := sshRegex.FindStringSubmatch(remoteURL); len(matches) == 3 {
 		return matches[1], matches[2], nil
 	}
 
-	// Handle HTTPS format: https://github.com/org/repo.git
-	httpsRegex := regexp.MustCompile(`https://github\.com/([^/]+)/([^/]+?)(?:\.git)?/?$`)
-	if matches := httpsRegex.FindStringSubmatch(remoteURL); len(matches) == 3 {
+	// Handle URL format: https://github.com/org/repo.git, ssh://git@ghe.internal:2222/org/repo.git
+	urlRegex := regexp.MustCompile(`^(?:https?|ssh)://(?:[^@/]+@)?[^/]+/([^/]+)/([^/]+?)(?:\.git)?/?$`)
+	if matches := urlRegex.FindStringSubmatch(remoteURL); len(matches) == 3 {
 		return matches[1], matches[2], nil
 	}
 
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"slices"
+	"sync"
+)
+
+// listCache memoizes whole-repository list calls (labels, tags, releases, the
+// open PRs of a base branch) for the life of a client, keyed by method and arguments. Clients derived with the
+// With* builders share their parent's cache; the keys include org/repo.
+type listCache struct {
+	mu      sync.Mutex
+	entries map[string]any
+}
+
+func newListCache() *listCache {
+	return &listCache{entries: make(map[string]any)}
+}
+
+// cachedList returns the list stored under key, loading and storing it on a
+// miss. Errors aren't cached. Callers get their own copy of the slice, so
+// sorting or filtering it doesn't affect later calls. A client without a cache
+// (as built in tests) always loads.
+func cachedList[T any](c *Client, key string, load func() ([]T, error)) ([]T, error) {
+	if c.cache == nil {
+		return load()
+	}
+
+	c.cache.mu.Lock()
+	entry, ok := c.cache.entries[key]
+	c.cache.mu.Unlock()
+	if ok {
+		return slices.Clone(entry.([]T)), nil
+	}
+
+	items, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.cache.mu.Lock()
+	c.cache.entries[key] = items
+	c.cache.mu.Unlock()
+	return slices.Clone(items), nil
+}
+
+// cacheKey builds the key of a list call on the client's repository
+func (c *Client) cacheKey(method string) string {
+	return method + " " + c.org + "/" + c.repo
+}
+
+// ClearCache drops the cached label, tag, release and open PR lists, so the next calls
+// read them from GitHub again. Long-lived clients (the daemon, status --fetch)
+// call it between fetches.
+func (c *Client) ClearCache() {
+	if c.cache == nil {
+		return
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	clear(c.cache.entries)
+}
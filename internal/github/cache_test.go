@@ -0,0 +1,75 @@
+package github
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCache(t *testing.T) {
+	var labelCalls, tagCalls, gadgetCalls atomic.Int32
+	failTags := true
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/labels", func(w http.ResponseWriter, _ *http.Request) {
+		labelCalls.Add(1)
+		_, _ = w.Write([]byte(`[{"name": "cherry-pick/3.7"}, {"name": "bug"}]`))
+	})
+	mux.HandleFunc("GET /repos/acme/gadget/labels", func(w http.ResponseWriter, _ *http.Request) {
+		gadgetCalls.Add(1)
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/tags", func(w http.ResponseWriter, _ *http.Request) {
+		tagCalls.Add(1)
+		if failTags {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name": "v1.1.0"}, {"name": "v1.0.0"}]`))
+	})
+
+	client := newTestClient(t, mux)
+	client.cache = newListCache()
+
+	t.Run("repeated calls are served from the cache", func(t *testing.T) {
+		labels, err := client.ListLabels(t.Context())
+		require.NoError(t, err)
+		require.Len(t, labels, 2)
+		labels[0] = nil // callers own their copy
+
+		labels, err = client.ListLabels(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "cherry-pick/3.7", labels[0].GetName())
+		assert.Equal(t, int32(1), labelCalls.Load())
+	})
+
+	t.Run("derived clients share the cache per repository", func(t *testing.T) {
+		_, err := client.WithLabelScheme(LabelScheme{}).ListLabels(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), labelCalls.Load())
+
+		_, err = client.WithRepository("acme", "gadget").ListLabels(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), gadgetCalls.Load())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		_, err := client.ListTags(t.Context())
+		require.Error(t, err)
+
+		failTags = false
+		tags, err := client.ListTags(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1.1.0", "v1.0.0"}, tags)
+		assert.Equal(t, int32(2), tagCalls.Load())
+	})
+
+	t.Run("ClearCache reloads", func(t *testing.T) {
+		client.ClearCache()
+		_, err := client.ListLabels(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), labelCalls.Load())
+	})
+}
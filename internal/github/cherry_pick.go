@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"path"
 	"slices"
+	"strings"
+	"text/template"
 
 	"github.com/google/go-github/v80/github"
 )
@@ -17,7 +21,7 @@ func (c *Client) GetCherryPickPRsFromComments(ctx context.Context, prNumber int)
 	slog.Debug("GitHub API: Listing comments", "org", c.org, "repo", c.repo, "pr", prNumber)
 	comments, _, err := c.client.Issues.ListComments(ctx, c.org, c.repo, prNumber, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch comments for PR #%d: %w", prNumber, err)
+		return nil, fmt.Errorf("failed to fetch comments for PR #%d: %w", prNumber, apiError(err))
 	}
 
 	var cherryPickPRs []CherryPickPR
@@ -41,7 +45,7 @@ func (c *Client) GetCherryPickPRsFromComments(ctx context.Context, prNumber int)
 
 				cherryPickPRs = append(cherryPickPRs, CherryPickPR{
 					Number:     cherryPickNum,
-					Branch:     "release-" + version,
+					Branch:     c.labelScheme.BranchForVersion(version),
 					OriginalPR: prNumber,
 					Failed:     false,
 				})
@@ -55,7 +59,7 @@ func (c *Client) GetCherryPickPRsFromComments(ctx context.Context, prNumber int)
 				version := match[1]
 				cherryPickPRs = append(cherryPickPRs, CherryPickPR{
 					Number:     0, // No PR number for failures
-					Branch:     "release-" + version,
+					Branch:     c.labelScheme.BranchForVersion(version),
 					OriginalPR: prNumber,
 					Failed:     true,
 				})
@@ -66,76 +70,386 @@ func (c *Client) GetCherryPickPRsFromComments(ctx context.Context, prNumber int)
 	return cherryPickPRs, nil
 }
 
+// DefaultManualSearchMaxCandidates caps how many search results
+// SearchManualCherryPickPRs inspects when no cap is configured
+const DefaultManualSearchMaxCandidates = 300
+
+// manualSearchBaseBatch is how many PRs' base branches one GraphQL query reads
+const manualSearchBaseBatch = 100
+
 // SearchManualCherryPickPRs searches for manually created cherry-pick PRs by title pattern
-// Looks for PRs with titles like "cherry-pick: ... (#14894)" targeting release branches
-func (c *Client) SearchManualCherryPickPRs(ctx context.Context, prNumber int, branches []string) ([]CherryPickPR, error) {
+// Looks for PRs with titles like "cherry-pick: ... (#14894)" targeting release branches.
+// At most maxCandidates search results are inspected (DefaultManualSearchMaxCandidates
+// if 0), with a warning when more were found.
+func (c *Client) SearchManualCherryPickPRs(ctx context.Context, prNumber int, branches []string, maxCandidates int) ([]CherryPickPR, error) {
+	candidates, err := c.searchManualCandidates(ctx, prNumber, maxCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to extract each branch from the title first (e.g., "cherry-pick #14894 for 3.7");
+	// the base branches of the others are then looked up together
+	targets := make(map[int]string, len(candidates))
+	var unresolved []int
+	for _, issue := range candidates {
+		if version, found := ExtractVersionFromCherryPickTitle(issue.GetTitle(), prNumber); found {
+			if extractedBranch := c.labelScheme.BranchForVersion(version); slices.Contains(branches, extractedBranch) {
+				targets[issue.GetNumber()] = extractedBranch
+				slog.Debug("Extracted branch from title", "pr", issue.GetNumber(), "branch", extractedBranch)
+				continue
+			}
+		}
+		unresolved = append(unresolved, issue.GetNumber())
+	}
+	maps.Copy(targets, c.baseBranches(ctx, unresolved))
+
 	var cherryPickPRs []CherryPickPR
+	for _, issue := range candidates {
+		targetBranch, ok := targets[issue.GetNumber()]
+		if !ok {
+			continue
+		}
+
+		// Check if this PR targets one of our tracked branches
+		if slices.Contains(branches, targetBranch) {
+			slog.Debug("Found manual cherry-pick PR", "pr", issue.GetNumber(), "branch", targetBranch, "original", prNumber)
+			cherryPickPRs = append(cherryPickPRs, CherryPickPR{
+				Number:     issue.GetNumber(),
+				Branch:     targetBranch,
+				OriginalPR: prNumber,
+				Failed:     false,
+			})
+		} else {
+			slog.Debug("Manual cherry-pick PR targets different branch", "pr", issue.GetNumber(), "branch", targetBranch, "tracked", branches)
+		}
+	}
+
+	return cherryPickPRs, nil
+}
+
+// searchManualCandidates pages through the PRs whose title mentions prNumber
+// and returns those with a cherry-pick reference to it, inspecting at most
+// maxCandidates results
+func (c *Client) searchManualCandidates(ctx context.Context, prNumber, maxCandidates int) ([]*github.Issue, error) {
+	if maxCandidates <= 0 {
+		maxCandidates = DefaultManualSearchMaxCandidates
+	}
 
 	// Search for PRs containing "cherry-pick" and the PR number in title
 	query := fmt.Sprintf("repo:%s/%s is:pr cherry-pick %d in:title", c.org, c.repo, prNumber)
 
 	opts := &github.SearchOptions{
 		ListOptions: github.ListOptions{
-			PerPage: 100,
+			PerPage: min(100, maxCandidates),
 		},
 	}
 
-	slog.Debug("GitHub API: Searching for manual cherry-pick PRs", "org", c.org, "repo", c.repo, "pr", prNumber, "query", query)
-	result, _, err := c.client.Search.Issues(ctx, query, opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search for manual cherry-pick PRs: %w", err)
+	var candidates []*github.Issue
+	inspected := 0
+	for {
+		slog.Debug("GitHub API: Searching for manual cherry-pick PRs", "org", c.org, "repo", c.repo, "pr", prNumber, "query", query, "page", opts.Page)
+		result, resp, err := c.client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search for manual cherry-pick PRs: %w", apiError(err))
+		}
+
+		for _, issue := range result.Issues {
+			if inspected == maxCandidates {
+				slog.Warn("Manual cherry-pick search truncated; raise manual_search_max_candidates to inspect more results",
+					"pr", prNumber, "max_candidates", maxCandidates, "total", result.GetTotal())
+				return candidates, nil
+			}
+			inspected++
+
+			// Skip issues and the original PR itself
+			if !issue.IsPullRequest() || issue.GetNumber() == prNumber {
+				continue
+			}
+
+			// Check if title contains a cherry-pick reference for this PR
+			if !ContainsCherryPickForPR(issue.GetTitle(), prNumber) {
+				slog.Debug("Title does not match cherry-pick pattern", "pr", issue.GetNumber(), "title", issue.GetTitle())
+				continue
+			}
+			candidates = append(candidates, issue)
+		}
+
+		if resp.NextPage == 0 {
+			return candidates, nil
+		}
+		opts.Page = resp.NextPage
 	}
+}
 
-	for _, issue := range result.Issues {
-		if !issue.IsPullRequest() {
+// baseBranches returns the base branch of each PR in numbers, read with one
+// GraphQL query per manualSearchBaseBatch PRs. A batch whose query fails is
+// read PR by PR over REST instead; PRs that can't be read are left out.
+func (c *Client) baseBranches(ctx context.Context, numbers []int) map[int]string {
+	bases := make(map[int]string, len(numbers))
+	for batch := range slices.Chunk(numbers, manualSearchBaseBatch) {
+		batchBases, err := c.baseBranchesGraphQL(ctx, batch)
+		if err == nil {
+			maps.Copy(bases, batchBases)
 			continue
 		}
+		slog.Debug("Batched base branch lookup failed, reading PRs one by one", "prs", len(batch), "error", err)
+		for _, number := range batch {
+			slog.Debug("GitHub API: Getting PR details for manual cherry-pick", "org", c.org, "repo", c.repo, "pr", number)
+			pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, number)
+			if err != nil {
+				slog.Debug("Failed to get PR details", "pr", number, "error", err)
+				continue
+			}
+			bases[number] = pr.GetBase().GetRef()
+		}
+	}
+	return bases
+}
+
+// baseBranchesGraphQL reads the base branches of numbers with a single GraphQL
+// query, one aliased pullRequest field per PR
+func (c *Client) baseBranchesGraphQL(ctx context.Context, numbers []int) (map[int]string, error) {
+	var fields strings.Builder
+	for _, number := range numbers {
+		fmt.Fprintf(&fields, "    pr%d: pullRequest(number: %d) { baseRefName }\n", number, number)
+	}
+	body := &graphQLRequest{
+		Query:     "query($owner: String!, $name: String!) {\n  repository(owner: $owner, name: $name) {\n" + fields.String() + "  }\n}",
+		Variables: map[string]any{"owner": c.org, "name": c.repo},
+	}
+	req, err := c.client.NewRequest("POST", c.graphQLPath(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build base branch query: %w", err)
+	}
+
+	slog.Debug("GitHub API: Querying PR base branches", "org", c.org, "repo", c.repo, "prs", len(numbers))
+	var resp struct {
+		Data struct {
+			Repository map[string]*struct {
+				BaseRefName string `json:"baseRefName"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []graphQLError `json:"errors"`
+	}
+	if _, err := c.client.Do(ctx, req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to query base branches: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("failed to query base branches: %s", resp.Errors[0].Message)
+	}
+
+	bases := make(map[int]string, len(numbers))
+	for _, number := range numbers {
+		if pr := resp.Data.Repository[fmt.Sprintf("pr%d", number)]; pr != nil {
+			bases[number] = pr.BaseRefName
+		}
+	}
+	return bases, nil
+}
 
-		// Skip the original PR itself
-		if issue.GetNumber() == prNumber {
+// SearchCherryPickPRsByBody finds cherry-pick PRs that link to prNumber only in their body
+// (e.g. "Backport of #14894"), for bots that leave neither a comment nor a title reference.
+// The bodies come with the search results; the base branches of the PRs that
+// reference prNumber are read in one batch (see baseBranches). Only PRs
+// targeting one of branches are returned
+func (c *Client) SearchCherryPickPRsByBody(ctx context.Context, prNumber int, branches []string) ([]CherryPickPR, error) {
+	query := fmt.Sprintf("repo:%s/%s is:pr %d in:body", c.org, c.repo, prNumber)
+
+	issues, err := paginatedList(func(page int) ([]*github.Issue, *github.Response, error) {
+		opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100, Page: page}}
+		slog.Debug("GitHub API: Searching PR bodies for cherry-pick references", "org", c.org, "repo", c.repo, "pr", prNumber, "query", query, "page", page)
+		result, resp, err := c.client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Issues, resp, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search PR bodies for cherry-picks of #%d: %w", prNumber, apiError(err))
+	}
+
+	var referencing []int
+	for _, issue := range issues {
+		if !issue.IsPullRequest() || issue.GetNumber() == prNumber {
+			continue
+		}
+		if original, found := ParseOriginalPRFromBody(issue.GetBody()); !found || original != prNumber {
+			slog.Debug("PR body does not reference original PR", "pr", issue.GetNumber(), "original", prNumber)
 			continue
 		}
+		referencing = append(referencing, issue.GetNumber())
+	}
+	bases := c.baseBranches(ctx, referencing)
 
-		// Check if title contains a cherry-pick reference for this PR
-		title := issue.GetTitle()
-		if !ContainsCherryPickForPR(title, prNumber) {
-			slog.Debug("Title does not match cherry-pick pattern", "pr", issue.GetNumber(), "title", title)
+	var cherryPickPRs []CherryPickPR
+	for _, number := range referencing {
+		base, ok := bases[number]
+		if !ok {
 			continue
 		}
+		if slices.Contains(branches, base) {
+			slog.Debug("Found cherry-pick PR from body", "pr", number, "branch", base, "original", prNumber)
+			cherryPickPRs = append(cherryPickPRs, CherryPickPR{
+				Number:     number,
+				Branch:     base,
+				OriginalPR: prNumber,
+				Failed:     false,
+			})
+		}
+	}
 
-		// Try to extract branch from title first (e.g., "cherry-pick #14894 for 3.7")
-		var targetBranch string
-		if extractedBranch, found := ExtractBranchFromCherryPickTitle(title, prNumber); found {
-			if slices.Contains(branches, extractedBranch) {
-				targetBranch = extractedBranch
-				slog.Debug("Extracted branch from title", "pr", issue.GetNumber(), "branch", targetBranch)
-			}
+	return cherryPickPRs, nil
+}
+
+// SearchCherryPickPRsByHeadBranch finds open cherry-pick PRs whose head branch matches
+// pattern, for bots that name branches after the original PR (e.g.
+// "cherry-pick-14894-to-release-3.7") but give the PR a generic title. pattern is
+// a Go template with {{.OriginalPR}} and {{.Branch}} that may use path.Match
+// wildcards once rendered. Only PRs targeting one of branches are returned. The
+// open PRs of each branch are listed once per fetch (see listOpenPRsForBase).
+func (c *Client) SearchCherryPickPRsByHeadBranch(ctx context.Context, prNumber int, branches []string, pattern string) ([]CherryPickPR, error) {
+	tmpl, err := template.New("head_branch_pattern").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid head branch pattern %q: %w", pattern, err)
+	}
+
+	var cherryPickPRs []CherryPickPR
+	for _, branch := range branches {
+		headPattern, err := renderHeadBranchPattern(tmpl, prNumber, branch)
+		if err != nil {
+			return nil, err
 		}
 
-		// If branch not extracted from title, get the full PR to determine target branch
-		if targetBranch == "" {
-			slog.Debug("GitHub API: Getting PR details for manual cherry-pick", "org", c.org, "repo", c.repo, "pr", issue.GetNumber())
-			pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, issue.GetNumber())
-			if err != nil {
-				slog.Debug("Failed to get PR details", "pr", issue.GetNumber(), "error", err)
-				continue
-			}
-			targetBranch = pr.GetBase().GetRef()
+		prs, err := c.listOpenPRsForBase(ctx, branch)
+		if err != nil {
+			return nil, err
 		}
 
-		// Check if this PR targets one of our tracked branches
-		if slices.Contains(branches, targetBranch) {
-			slog.Debug("Found manual cherry-pick PR", "pr", issue.GetNumber(), "branch", targetBranch, "original", prNumber)
+		for _, pr := range prs {
+			if pr.GetNumber() == prNumber {
+				continue
+			}
+			// The pattern was validated by renderHeadBranchPattern
+			if matched, _ := path.Match(headPattern, pr.GetHead().GetRef()); !matched {
+				continue
+			}
+			slog.Debug("Found cherry-pick PR from head branch", "pr", pr.GetNumber(), "head", pr.GetHead().GetRef(), "branch", branch, "original", prNumber)
 			cherryPickPRs = append(cherryPickPRs, CherryPickPR{
-				Number:     issue.GetNumber(),
-				Branch:     targetBranch,
+				Number:     pr.GetNumber(),
+				Branch:     branch,
 				OriginalPR: prNumber,
 				Failed:     false,
 			})
-		} else {
-			slog.Debug("Manual cherry-pick PR targets different branch", "pr", issue.GetNumber(), "branch", targetBranch, "tracked", branches)
 		}
 	}
 
 	return cherryPickPRs, nil
 }
+
+// listOpenPRsForBase lists the open PRs targeting base. The listing is cached,
+// as every tracked PR missing a cherry-pick onto base reads the same one; the
+// daemon and status --fetch clear it between fetches.
+func (c *Client) listOpenPRsForBase(ctx context.Context, base string) ([]*github.PullRequest, error) {
+	return cachedList(c, c.cacheKey("ListOpenPRs "+base), func() ([]*github.PullRequest, error) {
+		prs, err := paginatedList(func(page int) ([]*github.PullRequest, *github.Response, error) {
+			opts := &github.PullRequestListOptions{
+				State:       "open",
+				Base:        base,
+				ListOptions: github.ListOptions{PerPage: 100, Page: page},
+			}
+			slog.Debug("GitHub API: Listing pull requests", "org", c.org, "repo", c.repo, "base", base, "state", "open", "page", page)
+			return c.client.PullRequests.List(ctx, c.org, c.repo, opts)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list open pull requests for %s: %w", base, apiError(err))
+		}
+		return prs, nil
+	})
+}
+
+// renderHeadBranchPattern expands the head branch template for a cherry-pick of
+// originalPR onto branch and checks the result is a valid path.Match pattern
+func renderHeadBranchPattern(tmpl *template.Template, originalPR int, branch string) (string, error) {
+	data := struct {
+		OriginalPR int
+		Branch     string
+	}{OriginalPR: originalPR, Branch: branch}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render head branch pattern: %w", err)
+	}
+	if _, err := path.Match(rendered.String(), ""); err != nil {
+		return "", fmt.Errorf("invalid head branch pattern %q: %w", rendered.String(), err)
+	}
+	return rendered.String(), nil
+}
+
+// CheckCherryPickLink reports whether GitHub links cherryPickPR, a cherry-pick onto
+// branch, to originalPR. It returns "" when the cherry-pick PR targets branch and
+// names the original in its title, body, head branch (when headBranchPattern is
+// set) or one of its commit messages, or the original's bot comments name it;
+// otherwise it returns why not.
+func (c *Client) CheckCherryPickLink(ctx context.Context, cherryPickPR, originalPR int, branch, headBranchPattern string) (string, error) {
+	slog.Debug("GitHub API: Getting PR for cherry-pick link check", "org", c.org, "repo", c.repo, "pr", cherryPickPR)
+	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, cherryPickPR)
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR #%d: %w", cherryPickPR, apiError(err))
+	}
+
+	if base := pr.GetBase().GetRef(); base != branch {
+		return fmt.Sprintf("targets %s, not %s", base, branch), nil
+	}
+	if ContainsCherryPickForPR(pr.GetTitle(), originalPR) {
+		return "", nil
+	}
+	bodyOriginal, bodyFound := ParseOriginalPRFromBody(pr.GetBody())
+	if bodyFound && bodyOriginal == originalPR {
+		return "", nil
+	}
+	if headBranchPattern != "" {
+		tmpl, err := template.New("head_branch_pattern").Option("missingkey=error").Parse(headBranchPattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid head branch pattern %q: %w", headBranchPattern, err)
+		}
+		headPattern, err := renderHeadBranchPattern(tmpl, originalPR, branch)
+		if err != nil {
+			return "", err
+		}
+		if matched, _ := path.Match(headPattern, pr.GetHead().GetRef()); matched {
+			return "", nil
+		}
+	}
+
+	commits, err := paginatedList(func(page int) ([]*github.RepositoryCommit, *github.Response, error) {
+		slog.Debug("GitHub API: Listing PR commits for cherry-pick link check", "org", c.org, "repo", c.repo, "pr", cherryPickPR, "page", page)
+		return c.client.PullRequests.ListCommits(ctx, c.org, c.repo, cherryPickPR, &github.ListOptions{PerPage: 100, Page: page})
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list commits of PR #%d: %w", cherryPickPR, apiError(err))
+	}
+	for _, commit := range commits {
+		message := commit.GetCommit().GetMessage()
+		if ContainsCherryPickForPR(message, originalPR) {
+			return "", nil
+		}
+		if original, found := ParseOriginalPRFromBody(message); found && original == originalPR {
+			return "", nil
+		}
+	}
+
+	botCherryPicks, err := c.GetCherryPickPRsFromComments(ctx, originalPR)
+	if err != nil {
+		return "", err
+	}
+	for _, cp := range botCherryPicks {
+		if !cp.Failed && cp.Number == cherryPickPR {
+			return "", nil
+		}
+	}
+
+	if bodyFound {
+		return fmt.Sprintf("its body says it is a backport of #%d", bodyOriginal), nil
+	}
+	return fmt.Sprintf("nothing on GitHub links it to #%d", originalPR), nil
+}
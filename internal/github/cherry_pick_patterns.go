@@ -30,6 +30,10 @@ var prNumberInContextPattern = regexp.MustCompile(`\b(\d{4,})\b`)
 // Example: "(cherry picked from commit abc123def456)"
 var gitCherryPickPattern = regexp.MustCompile(`\(cherry picked from commit ([a-f0-9]+)\)`)
 
+// backportOfPattern matches a cherry-pick PR body's link to its original PR
+// Examples: "Backport of #14894", "Backport-of: #14894", "Cherry-picked from #14894"
+var backportOfPattern = regexp.MustCompile(`(?i)\b(?:backport|cherry[- ]?pick(?:ed)?)[- ](?:of|from):?\s*#(\d+)`)
+
 // CherryPickMatch represents a detected cherry-pick reference
 type CherryPickMatch struct {
 	PRNumber int
@@ -125,18 +129,32 @@ func ContainsCherryPickForPR(text string, prNumber int) bool {
 	return false
 }
 
-// ExtractBranchFromCherryPickTitle extracts the target branch from a cherry-pick title/message
-// Returns the branch name (e.g., "release-3.7") and whether it was found
-func ExtractBranchFromCherryPickTitle(text string, prNumber int) (string, bool) {
+// ExtractVersionFromCherryPickTitle extracts the target release version from a cherry-pick title/message
+// Returns the version (e.g., "3.7") and whether it was found; LabelScheme.BranchForVersion names its branch
+func ExtractVersionFromCherryPickTitle(text string, prNumber int) (string, bool) {
 	// Try bot pattern first: "(cherry-pick #15033 for 3.6)"
 	matches := botCherryPickPattern.FindAllStringSubmatch(text, -1)
 	for _, match := range matches {
 		if len(match) >= 3 {
 			prNum, err := strconv.Atoi(match[1])
 			if err == nil && prNum == prNumber {
-				return "release-" + match[2], true
+				return match[2], true
 			}
 		}
 	}
 	return "", false
 }
+
+// ParseOriginalPRFromBody extracts the original PR number from a cherry-pick PR body
+// (e.g. "Backport of #14894"). Returns the first reference found and whether there was one
+func ParseOriginalPRFromBody(body string) (int, bool) {
+	match := backportOfPattern.FindStringSubmatch(body)
+	if len(match) < 2 {
+		return 0, false
+	}
+	prNum, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return prNum, true
+}
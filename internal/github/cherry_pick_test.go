@@ -1,11 +1,15 @@
 package github
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
+	"text/template"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCherryPickSuccessPattern(t *testing.T) {
@@ -355,3 +359,305 @@ func TestCherryPickPRPriorityMerging(t *testing.T) {
 		})
 	}
 }
+
+func TestParseOriginalPRFromBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   int
+		wantOK bool
+	}{
+		{"backport of", "Backport of #14894 to release-3.7.", 14894, true},
+		{"trailer style", "Fixes a crash.\n\nBackport-of: #14894", 14894, true},
+		{"cherry-picked from", "Cherry-picked from #14894", 14894, true},
+		{"cherry pick of", "cherry pick of #14894", 14894, true},
+		{"first reference wins", "Backport of #14894\n\nRelated: backport of #14001", 14894, true},
+		{"plain reference", "Fixes #14894", 0, false},
+		{"empty", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseOriginalPRFromBody(tt.body)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchManualCherryPickPRs(t *testing.T) {
+	// searchResults serves two pages: the original PR, a title naming the
+	// version, two whose base must be looked up, an issue and an unrelated PR
+	searchResults := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "repo:acme/widget is:pr cherry-pick 14894 in:title", r.URL.Query().Get("q"))
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			_, _ = w.Write([]byte(`{"total_count": 6, "items": [
+				{"number": 14894, "title": "fix: crash (#14894)", "pull_request": {}},
+				{"number": 15001, "title": "fix: crash (cherry-pick #14894 for 3.7)", "pull_request": {}},
+				{"number": 15002, "title": "cherry-pick: fix crash (#14894)", "pull_request": {}}
+			]}`))
+			return
+		}
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`{"total_count": 6, "items": [
+			{"number": 15003, "title": "cherry-pick: fix crash (#14894)", "pull_request": {}},
+			{"number": 15004, "title": "cherry-pick: fix crash (#14894)"},
+			{"number": 15005, "title": "cherry-pick: other fix (#99)", "pull_request": {}}
+		]}`))
+	}
+	branches := []string{"release-3.6", "release-3.7"}
+
+	t.Run("paginates and batches the base branch lookup", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /search/issues", searchResults)
+		graphQLCalls := 0
+		mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, r *http.Request) {
+			graphQLCalls++
+			var req graphQLRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Contains(t, req.Query, "pr15002: pullRequest(number: 15002)")
+			assert.Contains(t, req.Query, "pr15003: pullRequest(number: 15003)")
+			assert.NotContains(t, req.Query, "15001", "the title already names the branch")
+			_, _ = w.Write([]byte(`{"data": {"repository": {
+				"pr15002": {"baseRefName": "release-3.6"},
+				"pr15003": {"baseRefName": "main"}
+			}}}`))
+		})
+		client := newTestClient(t, mux)
+
+		cherryPicks, err := client.SearchManualCherryPickPRs(t.Context(), 14894, branches, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []CherryPickPR{
+			{Number: 15001, Branch: "release-3.7", OriginalPR: 14894},
+			{Number: 15002, Branch: "release-3.6", OriginalPR: 14894},
+		}, cherryPicks)
+		assert.Equal(t, 1, graphQLCalls)
+	})
+
+	t.Run("falls back to REST and stops at the cap", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /search/issues", searchResults)
+		mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"errors": [{"message": "Something went wrong"}]}`))
+		})
+		mux.HandleFunc("GET /repos/acme/widget/pulls/15002", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"number": 15002, "base": {"ref": "release-3.6"}}`))
+		})
+		client := newTestClient(t, mux)
+
+		// The cap of 3 leaves the second page uninspected
+		cherryPicks, err := client.SearchManualCherryPickPRs(t.Context(), 14894, branches, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []CherryPickPR{
+			{Number: 15001, Branch: "release-3.7", OriginalPR: 14894},
+			{Number: 15002, Branch: "release-3.6", OriginalPR: 14894},
+		}, cherryPicks)
+	})
+}
+
+func TestSearchCherryPickPRsByBody(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "repo:acme/widget is:pr 14894 in:body", r.URL.Query().Get("q"))
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			_, _ = w.Write([]byte(`{"total_count": 6, "items": [
+				{"number": 14894, "body": "fix: crash", "pull_request": {}},
+				{"number": 15001, "body": "Backport of #14894", "pull_request": {}},
+				{"number": 15002, "body": "Backport of #14894", "pull_request": {}}
+			]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"total_count": 6, "items": [
+			{"number": 15003, "body": "Follow-up to #14894", "pull_request": {}},
+			{"number": 15004, "body": "Backport of #14894"},
+			{"number": 15005, "body": "Backport of #14894", "pull_request": {}}
+		]}`))
+	})
+	graphQLCalls := 0
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, r *http.Request) {
+		graphQLCalls++
+		var req graphQLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.NotContains(t, req.Query, "15003", "bodies not referencing the original aren't looked up")
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"pr15001": {"baseRefName": "release-3.7"},
+			"pr15002": {"baseRefName": "release-3.5"},
+			"pr15005": {"baseRefName": "release-3.6"}
+		}}}`))
+	})
+	client := newTestClient(t, mux)
+
+	cherryPicks, err := client.SearchCherryPickPRsByBody(t.Context(), 14894, []string{"release-3.6", "release-3.7"})
+	require.NoError(t, err)
+	assert.Equal(t, []CherryPickPR{
+		{Number: 15001, Branch: "release-3.7", OriginalPR: 14894},
+		{Number: 15005, Branch: "release-3.6", OriginalPR: 14894},
+	}, cherryPicks)
+	assert.Equal(t, 1, graphQLCalls, "one batched base branch lookup")
+}
+
+func TestSearchCherryPickPRsByHeadBranch(t *testing.T) {
+	listings := make(map[string]int)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		listings[r.URL.Query().Get("base")]++
+		switch r.URL.Query().Get("base") {
+		case "release-3.7":
+			_, _ = w.Write([]byte(`[
+				{"number": 15001, "head": {"ref": "cherry-pick-14894-to-release-3.7"}},
+				{"number": 15002, "head": {"ref": "cherry-pick-14895-to-release-3.7"}},
+				{"number": 15003, "head": {"ref": "fix-flaky-test"}}
+			]`))
+		case "release-3.6":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected base %q", r.URL.Query().Get("base"))
+		}
+	})
+	client := newTestClient(t, mux)
+	client.cache = newListCache()
+
+	cherryPicks, err := client.SearchCherryPickPRsByHeadBranch(t.Context(), 14894, []string{"release-3.6", "release-3.7"}, "cherry-pick-{{.OriginalPR}}-to-{{.Branch}}")
+	require.NoError(t, err)
+	assert.Equal(t, []CherryPickPR{{Number: 15001, Branch: "release-3.7", OriginalPR: 14894}}, cherryPicks)
+
+	cherryPicks, err = client.SearchCherryPickPRsByHeadBranch(t.Context(), 14895, []string{"release-3.7"}, "cherry-pick-{{.OriginalPR}}-to-{{.Branch}}")
+	require.NoError(t, err)
+	assert.Equal(t, []CherryPickPR{{Number: 15002, Branch: "release-3.7", OriginalPR: 14895}}, cherryPicks)
+	assert.Equal(t, map[string]int{"release-3.6": 1, "release-3.7": 1}, listings, "each branch is listed once")
+}
+
+func TestRenderHeadBranchPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+		wantErr bool
+	}{
+		{name: "PR and branch", pattern: "cherry-pick-{{.OriginalPR}}-to-{{.Branch}}", want: "cherry-pick-14894-to-release-3.7"},
+		{name: "wildcard suffix", pattern: "backport/{{.OriginalPR}}-*", want: "backport/14894-*"},
+		{name: "unknown field", pattern: "{{.Number}}", wantErr: true},
+		{name: "bad glob", pattern: "cherry-pick-[{{.OriginalPR}}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Option("missingkey=error").Parse(tt.pattern)
+			require.NoError(t, err)
+
+			got, err := renderHeadBranchPattern(tmpl, 14894, "release-3.7")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckCherryPickLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		pr         string
+		comments   string
+		commits    string
+		nextPage   string // commits served on a second page
+		pattern    string
+		wantReason string
+	}{
+		{
+			name:     "title names the original",
+			pr:       `{"number": 15001, "title": "fix: crash (cherry-pick #14894 for 3.7)", "base": {"ref": "release-3.7"}}`,
+			comments: `[]`,
+		},
+		{
+			name:     "body names the original",
+			pr:       `{"number": 15001, "title": "fix: crash", "body": "Backport of #14894", "base": {"ref": "release-3.7"}}`,
+			comments: `[]`,
+		},
+		{
+			name:     "head branch matches the pattern",
+			pr:       `{"number": 15001, "title": "Automated backport", "head": {"ref": "cherry-pick-14894-to-release-3.7"}, "base": {"ref": "release-3.7"}}`,
+			comments: `[]`,
+			pattern:  "cherry-pick-{{.OriginalPR}}-to-{{.Branch}}",
+		},
+		{
+			name:     "commit message names the original",
+			pr:       `{"number": 15001, "title": "Automated backport", "base": {"ref": "release-3.7"}}`,
+			comments: `[]`,
+			commits:  `[{"sha": "abc123", "commit": {"message": "fix: crash (#14894)\n\n(cherry picked from commit def456)"}}]`,
+		},
+		{
+			name:     "commit on the second page names the original",
+			pr:       `{"number": 15001, "title": "Automated backport", "base": {"ref": "release-3.7"}}`,
+			comments: `[]`,
+			commits:  `[{"sha": "abc123", "commit": {"message": "chore: bump deps"}}]`,
+			nextPage: `[{"sha": "abc124", "commit": {"message": "fix: crash (#14894)\n\n(cherry picked from commit def456)"}}]`,
+		},
+		{
+			name:       "commit message names a different original",
+			pr:         `{"number": 15001, "title": "Automated backport", "base": {"ref": "release-3.7"}}`,
+			comments:   `[]`,
+			commits:    `[{"sha": "abc123", "commit": {"message": "fix: crash (#14000)\n\n(cherry picked from commit def456)"}}]`,
+			wantReason: "nothing on GitHub links it to #14894",
+		},
+		{
+			name:     "bot comment on the original names it",
+			pr:       `{"number": 15001, "title": "Automated backport", "base": {"ref": "release-3.7"}}`,
+			comments: `[{"body": "🍒 Cherry-pick PR created for 3.7: #15001"}]`,
+		},
+		{
+			name:       "targets another branch",
+			pr:         `{"number": 15001, "title": "fix: crash (cherry-pick #14894 for 3.6)", "base": {"ref": "release-3.6"}}`,
+			comments:   `[]`,
+			wantReason: "targets release-3.6, not release-3.7",
+		},
+		{
+			name:       "body names a different original",
+			pr:         `{"number": 15001, "title": "fix: crash", "body": "Backport of #14000", "base": {"ref": "release-3.7"}}`,
+			comments:   `[]`,
+			wantReason: "its body says it is a backport of #14000",
+		},
+		{
+			name:       "no link at all",
+			pr:         `{"number": 15001, "title": "Automated backport", "base": {"ref": "release-3.7"}}`,
+			comments:   `[{"body": "🍒 Cherry-pick PR created for 3.7: #15999"}]`,
+			wantReason: "nothing on GitHub links it to #14894",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /repos/acme/widget/pulls/15001", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.pr))
+			})
+			mux.HandleFunc("GET /repos/acme/widget/issues/14894/comments", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.comments))
+			})
+			mux.HandleFunc("GET /repos/acme/widget/pulls/15001/commits", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("page") == "2" {
+					_, _ = w.Write([]byte(tt.nextPage))
+					return
+				}
+				if tt.nextPage != "" {
+					w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+				}
+				commits := tt.commits
+				if commits == "" {
+					commits = `[]`
+				}
+				_, _ = w.Write([]byte(commits))
+			})
+			client := newTestClient(t, mux)
+
+			reason, err := client.CheckCherryPickLink(t.Context(), 15001, 14894, "release-3.7", tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v80/github"
+)
+
+// prCIRollupQuery fetches a PR together with its head commit's check rollup. It
+// replaces the REST PR, combined status and check runs calls with one query.
+// The run attempt isn't exposed over GraphQL, so it still comes from the REST
+// workflow runs listing.
+const prCIRollupQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      number
+      title
+      url
+      merged
+      mergedAt
+      mergeable
+      baseRefName
+      headRefOid
+      mergeCommit { oid }
+      commits(last: 1) {
+        nodes {
+          commit {
+            statusCheckRollup {
+              contexts(first: 100) {
+                pageInfo { hasNextPage }
+                nodes {
+                  __typename
+                  ... on CheckRun { name status conclusion }
+                  ... on StatusContext { context state }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// prCIRollupResponse is the GraphQL response to prCIRollupQuery
+type prCIRollupResponse struct {
+	Data struct {
+		Repository struct {
+			PullRequest *rollupPullRequest `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+// rollupPullRequest is the pull request payload of prCIRollupResponse
+type rollupPullRequest struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Merged      bool       `json:"merged"`
+	MergedAt    *time.Time `json:"mergedAt"`
+	Mergeable   string     `json:"mergeable"` // MERGEABLE, CONFLICTING or UNKNOWN
+	BaseRefName string     `json:"baseRefName"`
+	HeadRefOid  string     `json:"headRefOid"`
+	MergeCommit *struct {
+		Oid string `json:"oid"`
+	} `json:"mergeCommit"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					Contexts struct {
+						PageInfo struct {
+							HasNextPage bool `json:"hasNextPage"`
+						} `json:"pageInfo"`
+						Nodes []rollupContext `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// rollupContext is either a CheckRun or a StatusContext, told apart by Typename
+type rollupContext struct {
+	Typename   string `json:"__typename"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	Context    string `json:"context"`
+	State      string `json:"state"`
+}
+
+// prDetails is a PR as read by getPRDetailsGraphQL
+type prDetails struct {
+	Number    int
+	Title     string
+	URL       string
+	Merged    bool
+	MergedAt  time.Time
+	Mergeable *bool
+	HeadSHA   string
+	MergeSHA  string
+	CI        *CIStatusResult
+}
+
+// getPRDetailsGraphQL reads a PR and its CI status with a single GraphQL query
+// plus the REST run attempt lookup. It errors (so callers fall back to REST)
+// when the rollup has more contexts than one page holds.
+func (c *Client) getPRDetailsGraphQL(ctx context.Context, number int, checker *CIStatusChecker) (*prDetails, error) {
+	body := &graphQLRequest{
+		Query:     prCIRollupQuery,
+		Variables: map[string]any{"owner": c.org, "name": c.repo, "number": number},
+	}
+	req, err := c.client.NewRequest("POST", c.graphQLPath(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CI rollup query for PR #%d: %w", number, err)
+	}
+
+	slog.Debug("GitHub API: Querying PR CI rollup", "org", c.org, "repo", c.repo, "pr", number)
+	var resp prCIRollupResponse
+	if _, err := c.client.Do(ctx, req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to query CI rollup for PR #%d: %w", number, err)
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("failed to query CI rollup for PR #%d: %s", number, resp.Errors[0].Message)
+	}
+	pr := resp.Data.Repository.PullRequest
+	if pr == nil {
+		return nil, fmt.Errorf("PR #%d not found", number)
+	}
+
+	var contexts []rollupContext
+	if nodes := pr.Commits.Nodes; len(nodes) > 0 && nodes[0].Commit.StatusCheckRollup != nil {
+		rollup := nodes[0].Commit.StatusCheckRollup
+		if rollup.Contexts.PageInfo.HasNextPage {
+			return nil, fmt.Errorf("PR #%d has more than 100 CI contexts", number)
+		}
+		contexts = rollup.Contexts.Nodes
+	}
+
+	checker.useBranch(pr.BaseRefName)
+	details := &prDetails{
+		Number:  pr.Number,
+		Title:   pr.Title,
+		URL:     pr.URL,
+		Merged:  pr.Merged,
+		HeadSHA: pr.HeadRefOid,
+		CI:      checker.evaluateRollup(contexts),
+	}
+	if pr.MergedAt != nil {
+		details.MergedAt = *pr.MergedAt
+	}
+	details.Mergeable = parseMergeable(pr.Mergeable)
+	if pr.MergeCommit != nil {
+		details.MergeSHA = pr.MergeCommit.Oid
+	}
+	details.CI.RunAttempt, _ = checker.GetRunAttempt(ctx, pr.HeadRefOid)
+	return details, nil
+}
+
+// toPR converts the details to a PR, reporting sha as its SHA
+func (d *prDetails) toPR(sha string) *PR {
+	return &PR{
+		Number:        d.Number,
+		Title:         d.Title,
+		URL:           d.URL,
+		SHA:           sha,
+		HeadSHA:       d.HeadSHA,
+		Merged:        d.Merged,
+		MergedAt:      d.MergedAt,
+		Mergeable:     d.Mergeable,
+		CIStatus:      d.CI.Status,
+		RunAttempt:    d.CI.RunAttempt,
+		FailingChecks: d.CI.FailingChecks,
+	}
+}
+
+// parseMergeable converts GraphQL's MergeableState to the REST tri-state:
+// nil for UNKNOWN (GitHub is still computing it)
+func parseMergeable(state string) *bool {
+	var mergeable bool
+	switch state {
+	case "MERGEABLE":
+		mergeable = true
+	case "CONFLICTING":
+		mergeable = false
+	default:
+		return nil
+	}
+	return &mergeable
+}
+
+// evaluateRollup applies the REST status and check run rules (or the required
+// checks of the PR's base branch) to a GraphQL rollup, so both paths report the
+// same CI status. GraphQL enums are the REST values upper-cased.
+func (checker *CIStatusChecker) evaluateRollup(contexts []rollupContext) *CIStatusResult {
+	var statuses []*github.RepoStatus
+	var runs []*github.CheckRun
+	for _, rc := range contexts {
+		switch rc.Typename {
+		case "StatusContext":
+			statuses = append(statuses, &github.RepoStatus{
+				Context: new(rc.Context),
+				State:   new(strings.ToLower(rc.State)),
+			})
+		case "CheckRun":
+			runs = append(runs, &github.CheckRun{
+				Name:       new(rc.Name),
+				Status:     new(strings.ToLower(rc.Status)),
+				Conclusion: new(strings.ToLower(rc.Conclusion)),
+			})
+		}
+	}
+
+	if len(checker.required) > 0 {
+		return checker.evaluateRequired(statuses, runs)
+	}
+
+	combinedStatus, combinedFailing := checker.evaluateStatusesWithFailing(statuses)
+	checkRunsStatus, checkRunsFailing := checker.evaluateCheckRunsWithFailing(runs)
+
+	result := &CIStatusResult{Status: checker.aggregateStatus(combinedStatus, checkRunsStatus)}
+	result.FailingChecks = append(result.FailingChecks, combinedFailing...)
+	result.FailingChecks = append(result.FailingChecks, checkRunsFailing...)
+	return result
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rollupResponse = `{"data": {"repository": {"pullRequest": {
+  "number": 42, "title": "Fix bug", "url": "https://github.com/acme/widget/pull/42",
+  "merged": false, "mergeable": "CONFLICTING", "baseRefName": "release-1.0", "headRefOid": "head42", "mergeCommit": {"oid": "merge42"},
+  "commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {
+    "pageInfo": {"hasNextPage": false},
+    "nodes": [
+      {"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS"},
+      {"__typename": "CheckRun", "name": "e2e", "status": "COMPLETED", "conclusion": "FAILURE"},
+      {"__typename": "StatusContext", "context": "DCO", "state": "FAILURE"},
+      {"__typename": "StatusContext", "context": "ci/lint", "state": "SUCCESS"}
+    ]}}}}]}
+}}}}`
+
+func handleRunAttempt(mux *http.ServeMux) {
+	mux.HandleFunc("GET /repos/acme/widget/actions/runs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"workflow_runs": [{"run_attempt": 1}, {"run_attempt": 2}]}`))
+	})
+}
+
+func TestGetPRWithDetails_GraphQL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(rollupResponse))
+	})
+	handleRunAttempt(mux)
+
+	client := newTestClient(t, mux).WithGraphQLCIStatus(true)
+
+	pr, err := client.GetPRWithDetails(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix bug", pr.Title)
+	assert.Equal(t, "merge42", pr.SHA)
+	assert.Equal(t, "head42", pr.HeadSHA)
+	assert.Equal(t, "failing", pr.CIStatus)
+	assert.Equal(t, []string{"e2e"}, pr.FailingChecks, "DCO is filtered for cherry-picks")
+	assert.Equal(t, 2, pr.RunAttempt)
+	require.NotNil(t, pr.Mergeable)
+	assert.False(t, *pr.Mergeable)
+
+	pr, err = client.GetPRWithDetailsNoDCOFilter(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "head42", pr.SHA)
+	assert.Equal(t, []string{"DCO", "e2e"}, pr.FailingChecks)
+}
+
+func TestGetPRWithDetails_GraphQLRequiredChecks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(rollupResponse))
+	})
+	handleRunAttempt(mux)
+
+	client := newTestClient(t, mux).WithGraphQLCIStatus(true).
+		WithRequiredChecks(map[string][]string{"release-1.0": {"build", "ci/lint"}})
+
+	pr, err := client.GetPRWithDetails(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "passing", pr.CIStatus, "e2e isn't required on release-1.0")
+	assert.Empty(t, pr.FailingChecks)
+}
+
+func TestGetPRWithDetails_MergedAt(t *testing.T) {
+	mergedAt := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strings.Replace(rollupResponse, `"merged": false,`, `"merged": true, "mergedAt": "2025-03-04T10:30:00Z",`, 1)))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42, "head": {"sha": "head42"}, "merged_at": "2025-03-04T10:30:00Z", "mergeable": true}`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/commits/head42/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses": []}`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/commits/head42/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"total_count": 0, "check_runs": []}`))
+	})
+	handleRunAttempt(mux)
+
+	pr, err := newTestClient(t, mux).WithGraphQLCIStatus(true).GetPRWithDetails(t.Context(), 42)
+	require.NoError(t, err)
+	assert.True(t, pr.Merged)
+	assert.True(t, mergedAt.Equal(pr.MergedAt), "GraphQL: %s", pr.MergedAt)
+
+	pr, err = newTestClient(t, mux).GetPRWithDetails(t.Context(), 42)
+	require.NoError(t, err)
+	assert.True(t, pr.Merged)
+	assert.True(t, mergedAt.Equal(pr.MergedAt), "REST: %s", pr.MergedAt)
+	require.NotNil(t, pr.Mergeable)
+	assert.True(t, *pr.Mergeable)
+}
+
+func TestGetPRWithDetails_GraphQLFallsBackToREST(t *testing.T) {
+	tests := []struct {
+		name    string
+		graphQL string
+	}{
+		{name: "GraphQL error", graphQL: `{"errors": [{"message": "complexity limit exceeded"}]}`},
+		{name: "too many contexts", graphQL: `{"data": {"repository": {"pullRequest": {"number": 42,
+			"commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {"pageInfo": {"hasNextPage": true}, "nodes": []}}}}]}}}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.graphQL))
+			})
+			mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"number": 42, "title": "Fix bug", "head": {"sha": "head42"}}`))
+			})
+			mux.HandleFunc("GET /repos/acme/widget/commits/head42/status", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"statuses": [{"context": "ci/lint", "state": "success"}]}`))
+			})
+			mux.HandleFunc("GET /repos/acme/widget/commits/head42/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"total_count": 1, "check_runs": [{"name": "build", "status": "completed", "conclusion": "success"}]}`))
+			})
+			handleRunAttempt(mux)
+
+			client := newTestClient(t, mux).WithGraphQLCIStatus(true)
+
+			pr, err := client.GetPRWithDetails(t.Context(), 42)
+			require.NoError(t, err)
+			assert.Equal(t, "Fix bug", pr.Title)
+			assert.Equal(t, "passing", pr.CIStatus)
+		})
+	}
+}
+
+func TestEvaluateRollup(t *testing.T) {
+	checker := (&Client{ignoredCIContexts: []string{"license/cla"}}).newCIStatusChecker()
+
+	tests := []struct {
+		name     string
+		contexts []rollupContext
+		want     string
+	}{
+		{name: "no contexts", want: "no_checks"},
+		{
+			name: "running check",
+			contexts: []rollupContext{
+				{Typename: "StatusContext", Context: "ci/lint", State: "SUCCESS"},
+				{Typename: "CheckRun", Name: "build", Status: "IN_PROGRESS"},
+			},
+			want: "pending",
+		},
+		{
+			name: "ignored context failing",
+			contexts: []rollupContext{
+				{Typename: "StatusContext", Context: "ci/lint", State: "SUCCESS"},
+				{Typename: "StatusContext", Context: "license/cla", State: "ERROR"},
+				{Typename: "CheckRun", Name: "build", Status: "COMPLETED", Conclusion: "SUCCESS"},
+			},
+			want: "passing",
+		},
+		{
+			name: "timed out check run",
+			contexts: []rollupContext{
+				{Typename: "CheckRun", Name: "build", Status: "COMPLETED", Conclusion: "TIMED_OUT"},
+			},
+			want: "failing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checker.evaluateRollup(tt.contexts).Status)
+		})
+	}
+}
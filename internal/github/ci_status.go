@@ -4,16 +4,32 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/google/go-github/v80/github"
 )
 
-// CIStatusChecker handles checking CI status for commits, optionally filtering out DCO checks
+// CIStatusChecker handles checking CI status for commits, optionally filtering out DCO checks.
+// Contexts in ignoredContexts (bot gates such as CLA checks) are always skipped.
+// When required is set, only those checks decide the status (see evaluateRequired).
 type CIStatusChecker struct {
-	client      *Client
-	dcoPatterns []string
-	filterDCO   bool
+	client          *Client
+	dcoPatterns     []string
+	filterDCO       bool
+	ignoredContexts []string
+	requiredChecks  map[string][]string // base branch -> required checks; cherry-pick path only
+	required        []string            // required checks of the PR being read
+}
+
+// defaultDCOPatterns are the check name substrings always treated as DCO checks
+var defaultDCOPatterns = []string{
+	"dco",
+	"DCO",
+	"developer-certificate-of-origin",
+	"signoff",
+	"sign-off",
+	"signed-off-by",
 }
 
 // newCIStatusChecker creates a new CI status checker with DCO filtering enabled (for cherry-picker)
@@ -24,23 +40,126 @@ func (c *Client) newCIStatusChecker() *CIStatusChecker {
 // newCIStatusCheckerWithOptions creates a new CI status checker with configurable DCO filtering
 func (c *Client) newCIStatusCheckerWithOptions(filterDCO bool) *CIStatusChecker {
 	checker := &CIStatusChecker{
-		client:    c,
-		filterDCO: filterDCO,
+		client:          c,
+		filterDCO:       filterDCO,
+		ignoredContexts: c.ignoredCIContexts,
 	}
 	if filterDCO {
-		checker.dcoPatterns = []string{
-			"dco",
-			"DCO",
-			"developer-certificate-of-origin",
-			"signoff",
-			"sign-off",
-			"signed-off-by",
-		}
+		checker.dcoPatterns = append(slices.Clone(defaultDCOPatterns), c.ignoredChecks...)
+		checker.requiredChecks = c.requiredChecks
 	}
 	return checker
 }
 
-// isDCOCheck determines if a check name matches DCO patterns
+// useBranch selects the required checks of the base branch of the PR being read
+func (checker *CIStatusChecker) useBranch(branch string) {
+	checker.required = checker.requiredChecks[branch]
+}
+
+// isRequiredCheck determines if a check name is one of the selected required checks
+func (checker *CIStatusChecker) isRequiredCheck(checkName string) bool {
+	return slices.ContainsFunc(checker.required, func(required string) bool {
+		return strings.EqualFold(checkName, required)
+	})
+}
+
+// evaluateRequired determines the status from the required checks alone: any
+// still running (or not reported yet) is pending, else any failed is failing,
+// else passing. Checks that aren't required are ignored, even when failing.
+func (checker *CIStatusChecker) evaluateRequired(statuses []*github.RepoStatus, runs []*github.CheckRun) *CIStatusResult {
+	states := make(map[string]string, len(checker.required))
+	for _, s := range statuses {
+		if !checker.isRequiredCheck(s.GetContext()) {
+			continue
+		}
+		switch s.GetState() {
+		case "success":
+			states[strings.ToLower(s.GetContext())] = "passing"
+		case "failure", "error":
+			states[strings.ToLower(s.GetContext())] = "failing"
+		default:
+			states[strings.ToLower(s.GetContext())] = "pending"
+		}
+	}
+	for _, run := range runs {
+		if !checker.isRequiredCheck(run.GetName()) {
+			continue
+		}
+		switch {
+		case run.GetStatus() != "completed":
+			states[strings.ToLower(run.GetName())] = "pending"
+		case run.GetConclusion() == "failure" || run.GetConclusion() == "cancelled" || run.GetConclusion() == "timed_out":
+			states[strings.ToLower(run.GetName())] = "failing"
+		default:
+			states[strings.ToLower(run.GetName())] = "passing"
+		}
+	}
+
+	result := &CIStatusResult{Status: "passing"}
+	hasPending := false
+	for _, required := range checker.required {
+		switch states[strings.ToLower(required)] {
+		case "":
+			slog.Debug("Required check has not reported", "check", required)
+			hasPending = true
+		case "pending":
+			hasPending = true
+		case "failing":
+			result.FailingChecks = append(result.FailingChecks, required)
+		}
+	}
+	if hasPending {
+		result.Status = "pending"
+	} else if len(result.FailingChecks) > 0 {
+		result.Status = "failing"
+	}
+	return result
+}
+
+// getRequiredStatus reads the commit's statuses and check runs and judges them
+// by the required checks alone. Every page is read, since a required check
+// missing from the first page would otherwise count as not reported.
+func (checker *CIStatusChecker) getRequiredStatus(ctx context.Context, sha string) (*CIStatusResult, error) {
+	statuses, err := checker.listStatuses(ctx, sha)
+	if err != nil {
+		return &CIStatusResult{Status: "unknown"}, fmt.Errorf("failed to fetch CI status for commit %s: %w", sha, apiError(err))
+	}
+
+	runs, err := checker.listCheckRuns(ctx, sha)
+	if err != nil {
+		return &CIStatusResult{Status: "unknown"}, fmt.Errorf("failed to fetch check runs for commit %s: %w", sha, apiError(err))
+	}
+
+	return checker.evaluateRequired(statuses, runs), nil
+}
+
+// listStatuses reads every page of the commit's combined status entries
+func (checker *CIStatusChecker) listStatuses(ctx context.Context, sha string) ([]*github.RepoStatus, error) {
+	return paginatedList(func(page int) ([]*github.RepoStatus, *github.Response, error) {
+		slog.Debug("GitHub API: Getting combined status", "org", checker.client.org, "repo", checker.client.repo, "sha", sha, "page", page)
+		status, resp, err := checker.client.client.Repositories.GetCombinedStatus(ctx, checker.client.org, checker.client.repo, sha,
+			&github.ListOptions{PerPage: 100, Page: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return status.Statuses, resp, nil
+	})
+}
+
+// listCheckRuns reads every page of the commit's check runs
+func (checker *CIStatusChecker) listCheckRuns(ctx context.Context, sha string) ([]*github.CheckRun, error) {
+	return paginatedList(func(page int) ([]*github.CheckRun, *github.Response, error) {
+		slog.Debug("GitHub API: Listing check runs", "org", checker.client.org, "repo", checker.client.repo, "sha", sha, "page", page)
+		checkRuns, resp, err := checker.client.client.Checks.ListCheckRunsForRef(ctx, checker.client.org, checker.client.repo, sha,
+			&github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100, Page: page}})
+		if err != nil {
+			return nil, nil, err
+		}
+		return checkRuns.CheckRuns, resp, nil
+	})
+}
+
+// isDCOCheck determines if a check name matches DCO patterns (including configured ignored_checks)
 // Returns false if DCO filtering is disabled
 func (checker *CIStatusChecker) isDCOCheck(checkName string) bool {
 	if !checker.filterDCO {
@@ -55,18 +174,41 @@ func (checker *CIStatusChecker) isDCOCheck(checkName string) bool {
 	return false
 }
 
-// GetStatus returns the overall CI status for a commit SHA
+// isIgnoredContext determines if a check name is in the configured ignore list
+func (checker *CIStatusChecker) isIgnoredContext(checkName string) bool {
+	for _, ignored := range checker.ignoredContexts {
+		if strings.EqualFold(checkName, ignored) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSkippedCheck determines if a check should be left out of the pass/fail read
+func (checker *CIStatusChecker) isSkippedCheck(checkName string) bool {
+	return checker.isDCOCheck(checkName) || checker.isIgnoredContext(checkName)
+}
+
+// ciNoChecks is the status of a commit with no checks that count: no commit
+// statuses or check runs at all, or only DCO and ignored ones. The repository
+// runs no CI for it, as opposed to "unknown", where the checks that exist
+// report nothing conclusive
+const ciNoChecks = "no_checks"
+
+// GetStatus returns the overall CI status for a commit SHA. API failures are
+// returned as errors, never folded into a status.
 func (checker *CIStatusChecker) GetStatus(ctx context.Context, sha string) (string, error) {
 	// Get both combined status and check runs for more accurate status
 	combinedStatus, checkRunsStatus, err := checker.getDetailedStatus(ctx, sha)
 	if err != nil {
-		return "unknown", fmt.Errorf("failed to fetch CI status for commit %s: %w", sha, err)
+		return "unknown", fmt.Errorf("failed to fetch CI status for commit %s: %w", sha, apiError(err))
 	}
 
 	return checker.aggregateStatus(combinedStatus, checkRunsStatus), nil
 }
 
-// aggregateStatus combines combined status and check runs status with priority rules
+// aggregateStatus combines combined status and check runs status with priority rules.
+// A source with no checks defers to the other; both without checks is no_checks.
 func (*CIStatusChecker) aggregateStatus(combinedStatus, checkRunsStatus string) string {
 	// Priority: pending > failing > passing
 	if combinedStatus == "pending" || checkRunsStatus == "pending" {
@@ -77,6 +219,13 @@ func (*CIStatusChecker) aggregateStatus(combinedStatus, checkRunsStatus string)
 		return "failing"
 	}
 
+	if combinedStatus == ciNoChecks {
+		return checkRunsStatus
+	}
+	if checkRunsStatus == ciNoChecks {
+		return combinedStatus
+	}
+
 	if combinedStatus == "passing" && checkRunsStatus == "passing" {
 		return "passing"
 	}
@@ -100,7 +249,7 @@ func (checker *CIStatusChecker) getDetailedStatus(ctx context.Context, sha strin
 	return combinedStatus, checkRunsStatus, nil
 }
 
-// getCombinedStatus gets traditional commit status, filtering DCO checks
+// getCombinedStatus gets traditional commit status, filtering DCO and ignored checks
 func (checker *CIStatusChecker) getCombinedStatus(ctx context.Context, sha string) (string, error) {
 	slog.Debug("GitHub API: Getting combined status", "org", checker.client.org, "repo", checker.client.repo, "sha", sha)
 	status, _, err := checker.client.client.Repositories.GetCombinedStatus(ctx, checker.client.org, checker.client.repo, sha, nil)
@@ -108,16 +257,16 @@ func (checker *CIStatusChecker) getCombinedStatus(ctx context.Context, sha strin
 		return "unknown", err
 	}
 
-	// Filter out DCO-related statuses
+	// Filter out DCO-related and ignored statuses
 	var relevantStatuses []*github.RepoStatus
 	for _, s := range status.Statuses {
-		if !checker.isDCOCheck(s.GetContext()) {
+		if !checker.isSkippedCheck(s.GetContext()) {
 			relevantStatuses = append(relevantStatuses, s)
 		}
 	}
 
 	if len(relevantStatuses) == 0 {
-		return "unknown", nil
+		return ciNoChecks, nil
 	}
 
 	return checker.evaluateStatuses(relevantStatuses), nil
@@ -162,19 +311,17 @@ func (checker *CIStatusChecker) getCheckRunsStatus(ctx context.Context, sha stri
 		return "unknown", err
 	}
 
-	if len(checkRuns.CheckRuns) == 0 {
-		return "unknown", nil
-	}
-
+	relevant := 0
 	hasRunning := false
 	hasFailed := false
 	hasCompleted := false
 
 	for _, run := range checkRuns.CheckRuns {
-		// Skip DCO checks
-		if checker.isDCOCheck(run.GetName()) {
+		// Skip DCO and ignored checks
+		if checker.isSkippedCheck(run.GetName()) {
 			continue
 		}
+		relevant++
 
 		switch run.GetStatus() {
 		case "queued", "in_progress":
@@ -187,6 +334,10 @@ func (checker *CIStatusChecker) getCheckRunsStatus(ctx context.Context, sha stri
 		}
 	}
 
+	if relevant == 0 {
+		return ciNoChecks, nil
+	}
+
 	// Priority: running > failed > completed
 	if hasRunning {
 		return "pending", nil
@@ -238,22 +389,24 @@ type CIStatusResult struct {
 
 // GetStatusWithFailingChecks returns CI status along with names of failing checks
 func (checker *CIStatusChecker) GetStatusWithFailingChecks(ctx context.Context, sha string) (*CIStatusResult, error) {
+	if len(checker.required) > 0 {
+		return checker.getRequiredStatus(ctx, sha)
+	}
+
 	result := &CIStatusResult{}
 
 	// Get combined status with failing check names
 	combinedStatus, combinedFailing, err := checker.getCombinedStatusWithFailing(ctx, sha)
 	if err != nil {
-		return &CIStatusResult{Status: "unknown"}, fmt.Errorf("failed to fetch CI status for commit %s: %w", sha, err)
+		return &CIStatusResult{Status: "unknown"}, fmt.Errorf("failed to fetch CI status for commit %s: %w", sha, apiError(err))
 	}
 
-	// Get check runs status with failing check names
+	// Get check runs status with failing check names. Without them a commit
+	// whose CI runs only as check runs would read as having none, so a failure
+	// here is an error too.
 	checkRunsStatus, checkRunsFailing, err := checker.getCheckRunsStatusWithFailing(ctx, sha)
 	if err != nil {
-		// Fall back to combined status only - check runs are not critical
-		slog.Debug("Failed to get check runs, using combined status only", "error", err)
-		result.Status = combinedStatus
-		result.FailingChecks = combinedFailing
-		return result, nil //nolint:nilerr // Intentional fallback behavior
+		return &CIStatusResult{Status: "unknown"}, fmt.Errorf("failed to fetch check runs for commit %s: %w", sha, apiError(err))
 	}
 
 	result.Status = checker.aggregateStatus(combinedStatus, checkRunsStatus)
@@ -273,11 +426,18 @@ func (checker *CIStatusChecker) getCombinedStatusWithFailing(ctx context.Context
 		return "unknown", nil, err
 	}
 
+	combinedStatus, failingChecks := checker.evaluateStatusesWithFailing(status.Statuses)
+	return combinedStatus, failingChecks, nil
+}
+
+// evaluateStatusesWithFailing determines overall status and failing check names from
+// commit statuses, skipping DCO and ignored contexts; no statuses left is no_checks
+func (checker *CIStatusChecker) evaluateStatusesWithFailing(statuses []*github.RepoStatus) (string, []string) {
 	var relevantStatuses []*github.RepoStatus
 	var failingChecks []string
 
-	for _, s := range status.Statuses {
-		if checker.isDCOCheck(s.GetContext()) {
+	for _, s := range statuses {
+		if checker.isSkippedCheck(s.GetContext()) {
 			continue
 		}
 		relevantStatuses = append(relevantStatuses, s)
@@ -287,10 +447,10 @@ func (checker *CIStatusChecker) getCombinedStatusWithFailing(ctx context.Context
 	}
 
 	if len(relevantStatuses) == 0 {
-		return "unknown", nil, nil
+		return ciNoChecks, nil
 	}
 
-	return checker.evaluateStatuses(relevantStatuses), failingChecks, nil
+	return checker.evaluateStatuses(relevantStatuses), failingChecks
 }
 
 // getCheckRunsStatusWithFailing gets check runs status with failing check names
@@ -301,19 +461,24 @@ func (checker *CIStatusChecker) getCheckRunsStatusWithFailing(ctx context.Contex
 		return "unknown", nil, err
 	}
 
-	if len(checkRuns.CheckRuns) == 0 {
-		return "unknown", nil, nil
-	}
+	checkRunsStatus, failingChecks := checker.evaluateCheckRunsWithFailing(checkRuns.CheckRuns)
+	return checkRunsStatus, failingChecks, nil
+}
 
+// evaluateCheckRunsWithFailing determines overall status and failing check names from
+// check runs, skipping DCO and ignored checks; no check runs left is no_checks
+func (checker *CIStatusChecker) evaluateCheckRunsWithFailing(runs []*github.CheckRun) (string, []string) {
+	relevant := 0
 	hasRunning := false
 	hasFailed := false
 	hasCompleted := false
 	var failingChecks []string
 
-	for _, run := range checkRuns.CheckRuns {
-		if checker.isDCOCheck(run.GetName()) {
+	for _, run := range runs {
+		if checker.isSkippedCheck(run.GetName()) {
 			continue
 		}
+		relevant++
 
 		switch run.GetStatus() {
 		case "queued", "in_progress":
@@ -327,17 +492,32 @@ func (checker *CIStatusChecker) getCheckRunsStatusWithFailing(ctx context.Contex
 		}
 	}
 
+	if relevant == 0 {
+		return ciNoChecks, nil
+	}
 	if hasRunning {
-		return "pending", nil, nil
+		return "pending", nil
 	}
 	if hasFailed {
-		return "failing", failingChecks, nil
+		return "failing", failingChecks
 	}
 	if hasCompleted {
-		return "passing", nil, nil
+		return "passing", nil
 	}
 
-	return "unknown", nil, nil
+	return "unknown", nil
+}
+
+// GetCIStatus returns CI status for a SHA with DCO filtering (for cherry-picker),
+// judged by the required checks of baseBranch, the branch the commit's PR targets
+func (c *Client) GetCIStatus(ctx context.Context, sha, baseBranch string) (string, error) {
+	checker := c.newCIStatusChecker()
+	checker.useBranch(baseBranch)
+	result, err := checker.GetStatusWithFailingChecks(ctx, sha)
+	if err != nil {
+		return "unknown", err
+	}
+	return result.Status, nil
 }
 
 // GetCIStatusWithoutDCOFilter returns CI status for a SHA without filtering out DCO checks
@@ -364,7 +544,11 @@ func (c *Client) GetCIStatusAndRunAttemptWithoutDCOFilter(ctx context.Context, s
 
 // GetFullCIStatus returns complete CI status including failing check names (with DCO filtering for cherry-picker)
 func (c *Client) GetFullCIStatus(ctx context.Context, sha string) (*CIStatusResult, error) {
-	checker := c.newCIStatusChecker()
+	return c.fullCIStatus(ctx, c.newCIStatusChecker(), sha)
+}
+
+// fullCIStatus reads the CI status, failing checks and run attempt of sha with checker
+func (c *Client) fullCIStatus(ctx context.Context, checker *CIStatusChecker, sha string) (*CIStatusResult, error) {
 	result, err := checker.GetStatusWithFailingChecks(ctx, sha)
 	if err != nil {
 		return result, err
@@ -1,9 +1,12 @@
 package github
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCIStatusChecker_IsDCOCheck(t *testing.T) {
@@ -102,6 +105,24 @@ func TestCIStatusChecker_AggregateStatus(t *testing.T) {
 			checkRunsStatus:   "passing",
 			expectedAggregate: "unknown",
 		},
+		{
+			name:              "no statuses defers to check runs",
+			combinedStatus:    "no_checks",
+			checkRunsStatus:   "passing",
+			expectedAggregate: "passing",
+		},
+		{
+			name:              "no check runs defers to combined",
+			combinedStatus:    "failing",
+			checkRunsStatus:   "no_checks",
+			expectedAggregate: "failing",
+		},
+		{
+			name:              "no checks anywhere",
+			combinedStatus:    "no_checks",
+			checkRunsStatus:   "no_checks",
+			expectedAggregate: "no_checks",
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,3 +135,281 @@ func TestCIStatusChecker_AggregateStatus(t *testing.T) {
 
 // Note: evaluateStatuses is tested indirectly through integration tests
 // as it requires github.RepoStatus objects which are hard to mock
+
+// newCIStatusTestClient serves the given combined statuses and check runs for sha "abc"
+func newCIStatusTestClient(t *testing.T, statuses, checkRuns string) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/commits/abc/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses": ` + statuses + `}`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/commits/abc/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"check_runs": ` + checkRuns + `}`))
+	})
+	return newTestClient(t, mux)
+}
+
+func TestCIStatusChecker_NoChecks(t *testing.T) {
+	checker := newCIStatusTestClient(t, `[]`, `[]`).newCIStatusChecker()
+
+	status, err := checker.GetStatus(t.Context(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "no_checks", status)
+
+	result, err := checker.GetStatusWithFailingChecks(t.Context(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "no_checks", result.Status)
+}
+
+func TestCIStatusChecker_CheckRunsErrorIsNotNoChecks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/commits/abc/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses": []}`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/commits/abc/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+	checker := newTestClient(t, mux).newCIStatusChecker()
+
+	status, err := checker.GetStatus(t.Context(), "abc")
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "unknown", status)
+
+	result, err := checker.GetStatusWithFailingChecks(t.Context(), "abc")
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "unknown", result.Status)
+}
+
+func TestCIStatusChecker_IsIgnoredContext(t *testing.T) {
+	checker := &CIStatusChecker{ignoredContexts: []string{"license/cla", "cla-bot"}}
+
+	assert.True(t, checker.isIgnoredContext("license/cla"))
+	assert.True(t, checker.isIgnoredContext("CLA-Bot"), "matching is case-insensitive")
+	assert.False(t, checker.isIgnoredContext("license/cla-extra"), "matching is exact, not substring")
+	assert.False(t, checker.isIgnoredContext("build"))
+}
+
+func TestCIStatusChecker_IgnoredContexts(t *testing.T) {
+	ignored := []string{"license/cla", "cla-bot"}
+
+	tests := []struct {
+		name        string
+		statuses    string
+		checkRuns   string
+		filterDCO   bool
+		wantStatus  string
+		wantFailing []string
+	}{
+		{
+			name:       "failing CLA status does not fail passing CI",
+			statuses:   `[{"context": "license/cla", "state": "failure"}, {"context": "ci/build", "state": "success"}]`,
+			checkRuns:  `[{"name": "cla-bot", "status": "completed", "conclusion": "failure"}, {"name": "test", "status": "completed", "conclusion": "success"}]`,
+			filterDCO:  true,
+			wantStatus: "passing",
+		},
+		{
+			name:       "pending CLA status does not hold CI pending",
+			statuses:   `[{"context": "license/cla", "state": "pending"}, {"context": "ci/build", "state": "success"}]`,
+			checkRuns:  `[{"name": "cla-bot", "status": "in_progress"}, {"name": "test", "status": "completed", "conclusion": "success"}]`,
+			filterDCO:  true,
+			wantStatus: "passing",
+		},
+		{
+			name:        "real failures still count alongside ignored ones",
+			statuses:    `[{"context": "license/cla", "state": "failure"}, {"context": "ci/build", "state": "failure"}]`,
+			checkRuns:   `[{"name": "cla-bot", "status": "completed", "conclusion": "failure"}, {"name": "test", "status": "completed", "conclusion": "failure"}]`,
+			filterDCO:   true,
+			wantStatus:  "failing",
+			wantFailing: []string{"ci/build", "test"},
+		},
+		{
+			name:       "only ignored contexts means no checks",
+			statuses:   `[{"context": "license/cla", "state": "success"}]`,
+			checkRuns:  `[{"name": "cla-bot", "status": "completed", "conclusion": "success"}]`,
+			filterDCO:  true,
+			wantStatus: "no_checks",
+		},
+		{
+			name:       "DCO-only status defers to passing check runs",
+			statuses:   `[{"context": "DCO", "state": "success"}]`,
+			checkRuns:  `[{"name": "test", "status": "completed", "conclusion": "success"}]`,
+			filterDCO:  true,
+			wantStatus: "passing",
+		},
+		{
+			name:        "DCO-only check runs defer to failing statuses",
+			statuses:    `[{"context": "ci/build", "state": "failure"}]`,
+			checkRuns:   `[{"name": "DCO", "status": "completed", "conclusion": "success"}]`,
+			filterDCO:   true,
+			wantStatus:  "failing",
+			wantFailing: []string{"ci/build"},
+		},
+		{
+			name:        "ignore list applies without DCO filtering, DCO still counts",
+			statuses:    `[{"context": "license/cla", "state": "failure"}, {"context": "DCO", "state": "failure"}]`,
+			checkRuns:   `[{"name": "test", "status": "completed", "conclusion": "success"}]`,
+			filterDCO:   false,
+			wantStatus:  "failing",
+			wantFailing: []string{"DCO"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newCIStatusTestClient(t, tt.statuses, tt.checkRuns).WithIgnoredCIContexts(ignored)
+			checker := client.newCIStatusCheckerWithOptions(tt.filterDCO)
+
+			status, err := checker.GetStatus(t.Context(), "abc")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+
+			result, err := checker.GetStatusWithFailingChecks(t.Context(), "abc")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantFailing, result.FailingChecks)
+		})
+	}
+}
+
+func TestWithRepositoryKeepsIgnoredCIContexts(t *testing.T) {
+	client := (&Client{}).WithIgnoredCIContexts([]string{"license/cla"}).WithRepository("acme", "widget")
+	assert.Equal(t, []string{"license/cla"}, client.ignoredCIContexts)
+}
+
+func TestCIStatusChecker_IgnoredChecks(t *testing.T) {
+	statuses := `[{"context": "license/cla", "state": "failure"}, {"context": "ci/build", "state": "success"}]`
+	checkRuns := `[{"name": "test", "status": "completed", "conclusion": "success"}]`
+
+	t.Run("configured pattern is ignored like DCO", func(t *testing.T) {
+		checker := newCIStatusTestClient(t, statuses, checkRuns).WithIgnoredChecks([]string{"CLA"}).newCIStatusChecker()
+
+		assert.True(t, checker.isDCOCheck("license/cla"), "matching is case-insensitive substring")
+		assert.True(t, checker.isDCOCheck("DCO"), "built-in patterns still apply")
+
+		result, err := checker.GetStatusWithFailingChecks(t.Context(), "abc")
+		require.NoError(t, err)
+		assert.Equal(t, "passing", result.Status)
+		assert.Empty(t, result.FailingChecks)
+	})
+
+	t.Run("unconfigured check still counts", func(t *testing.T) {
+		checker := newCIStatusTestClient(t, statuses, checkRuns).newCIStatusChecker()
+
+		assert.Equal(t, defaultDCOPatterns, checker.dcoPatterns)
+		status, err := checker.GetStatus(t.Context(), "abc")
+		require.NoError(t, err)
+		assert.Equal(t, "failing", status)
+	})
+
+	t.Run("not applied without DCO filtering", func(t *testing.T) {
+		checker := newCIStatusTestClient(t, statuses, checkRuns).WithIgnoredChecks([]string{"license/cla"}).newCIStatusCheckerWithOptions(false)
+
+		status, err := checker.GetStatus(t.Context(), "abc")
+		require.NoError(t, err)
+		assert.Equal(t, "failing", status)
+	})
+}
+
+func TestCIStatusChecker_RequiredChecksPaginated(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/commits/abc/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			_, _ = w.Write([]byte(`{"statuses": [{"context": "ci/lint", "state": "success"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"statuses": [{"context": "ci/build", "state": "success"}]}`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			_, _ = w.Write([]byte(`{"check_runs": [{"name": "lint", "status": "completed", "conclusion": "success"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"check_runs": [{"name": "test", "status": "completed", "conclusion": "success"}]}`))
+	})
+	client := newTestClient(t, mux).WithRequiredChecks(map[string][]string{"release-1.0": {"ci/build", "test"}})
+	checker := client.newCIStatusChecker()
+	checker.useBranch("release-1.0")
+
+	result, err := checker.GetStatusWithFailingChecks(t.Context(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "passing", result.Status, "required checks on the second page are reported")
+}
+
+func TestCIStatusChecker_RequiredChecks(t *testing.T) {
+	statuses := `[{"context": "ci/lint", "state": "failure"}, {"context": "ci/build", "state": "success"}]`
+	required := map[string][]string{"release-1.0": {"CI/Build", "test"}}
+
+	tests := []struct {
+		name        string
+		branch      string
+		checkRuns   string
+		wantStatus  string
+		wantFailing []string
+	}{
+		{
+			name:       "optional failure is ignored",
+			branch:     "release-1.0",
+			checkRuns:  `[{"name": "test", "status": "completed", "conclusion": "success"}]`,
+			wantStatus: "passing",
+		},
+		{
+			name:        "required failure fails",
+			branch:      "release-1.0",
+			checkRuns:   `[{"name": "test", "status": "completed", "conclusion": "timed_out"}]`,
+			wantStatus:  "failing",
+			wantFailing: []string{"test"},
+		},
+		{
+			name:       "required check not reported yet is pending",
+			branch:     "release-1.0",
+			checkRuns:  `[]`,
+			wantStatus: "pending",
+		},
+		{
+			name:       "required check still running is pending",
+			branch:     "release-1.0",
+			checkRuns:  `[{"name": "test", "status": "in_progress"}]`,
+			wantStatus: "pending",
+		},
+		{
+			name:        "branch without required checks aggregates all checks",
+			branch:      "release-2.0",
+			checkRuns:   `[{"name": "test", "status": "completed", "conclusion": "success"}]`,
+			wantStatus:  "failing",
+			wantFailing: []string{"ci/lint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newCIStatusTestClient(t, statuses, tt.checkRuns).WithRequiredChecks(required).newCIStatusChecker()
+			checker.useBranch(tt.branch)
+
+			result, err := checker.GetStatusWithFailingChecks(t.Context(), "abc")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantFailing, result.FailingChecks)
+		})
+	}
+
+	t.Run("GetCIStatus applies the base branch's required checks", func(t *testing.T) {
+		client := newCIStatusTestClient(t, statuses, `[{"name": "test", "status": "completed", "conclusion": "success"}]`).WithRequiredChecks(required)
+
+		status, err := client.GetCIStatus(t.Context(), "abc", "release-1.0")
+		require.NoError(t, err)
+		assert.Equal(t, "passing", status, "optional ci/lint failure is ignored")
+
+		status, err = client.GetCIStatus(t.Context(), "abc", "release-2.0")
+		require.NoError(t, err)
+		assert.Equal(t, "failing", status)
+	})
+
+	t.Run("not applied without DCO filtering", func(t *testing.T) {
+		checker := newCIStatusTestClient(t, statuses, `[]`).WithRequiredChecks(required).newCIStatusCheckerWithOptions(false)
+		checker.useBranch("release-1.0")
+
+		assert.Empty(t, checker.required)
+	})
+}
@@ -3,16 +3,30 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/alan/cherry-picker/internal/redact"
 	"github.com/google/go-github/v80/github"
 	"golang.org/x/oauth2"
 )
 
 // Client wraps the GitHub API client with repository context
 type Client struct {
-	client *github.Client
-	org    string
-	repo   string
+	client            *github.Client
+	org               string
+	repo              string
+	ignoredCIContexts []string
+	ignoredChecks     []string
+	graphQLCIStatus   bool
+	labelScheme       LabelScheme
+	requiredChecks    map[string][]string
+	searchQualifiers  []string
+	cache             *listCache
+	dryRun            bool
 }
 
 // paginatedList handles paginated list operations
@@ -38,23 +52,199 @@ func paginatedList[T any](fetchPage func(page int) ([]T, *github.Response, error
 	return allItems, nil
 }
 
-// NewClient creates a new GitHub client with token authentication
+// NewClient creates a new GitHub client with token authentication. Rate limited
+// and failed requests are retried with DefaultMaxRetries and DefaultRetryBaseDelay;
+// see WithRetryPolicy.
 func NewClient(ctx context.Context, token string) *Client {
+	return NewClientWithHTTPOptions(ctx, token, HTTPOptions{})
+}
+
+// NewClientWithHTTPOptions creates a client like NewClient whose authenticated
+// HTTP client sits on a transport tuned with opts (timeout, connection retries)
+func NewClientWithHTTPOptions(ctx context.Context, token string, opts HTTPOptions) *Client {
+	// The token is only ever sent in the Authorization header; make sure it
+	// is masked should it surface in a logged error or URL
+	redact.Register(token)
+
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	if auth, ok := tc.Transport.(*oauth2.Transport); ok {
+		auth.Base = newHTTPTransport(opts)
+	}
+	rt := newRetryTransport(tc.Transport, DefaultMaxRetries, DefaultRetryBaseDelay)
+	rt.retryServerErrors = opts.TransportRetries <= 0
+	tc.Transport = rt
 
 	return &Client{
 		client: github.NewClient(tc),
+		cache:  newListCache(),
+	}
+}
+
+// NewEnterpriseClient creates a client like NewClient whose requests go to a
+// GitHub Enterprise Server instead of github.com. baseURL is the REST API root
+// (e.g. https://ghe.example.com/api/v3) or just the host; uploads and GraphQL
+// go to the same host. opts tunes the transport as for NewClientWithHTTPOptions.
+func NewEnterpriseClient(ctx context.Context, token, baseURL string, opts HTTPOptions) (*Client, error) {
+	root, err := EnterpriseHost(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewClientWithHTTPOptions(ctx, token, opts)
+	gh, err := c.client.WithEnterpriseURLs(root, root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	c.client = gh
+	return c, nil
+}
+
+// EnterpriseHost returns the web root of a GitHub Enterprise Server, e.g.
+// https://ghe.example.com for an API base URL of https://ghe.example.com/api/v3
+func EnterpriseHost(baseURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: want an http(s) URL like https://ghe.example.com/api/v3", baseURL)
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v3")
+	u.RawQuery = ""
+	u.Fragment = ""
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// graphQLPath returns the GraphQL endpoint relative to the REST base URL:
+// api.github.com/graphql on github.com, <host>/api/graphql on Enterprise Server
+func (c *Client) graphQLPath() string {
+	if strings.HasSuffix(c.client.BaseURL.Path, "/api/v3/") {
+		return "../graphql"
 	}
+	return "graphql"
+}
+
+// clone returns a shallow copy of the client for the With* builders to set
+// one field on; the underlying API client and list cache stay shared
+func (c *Client) clone() *Client {
+	scoped := *c
+	return &scoped
 }
 
 // WithRepository returns a new client with org/repo context set
 func (c *Client) WithRepository(org, repo string) *Client {
-	return &Client{
-		client: c.client,
-		org:    org,
-		repo:   repo,
+	scoped := c.clone()
+	scoped.org = org
+	scoped.repo = repo
+	return scoped
+}
+
+// WithIgnoredCIContexts returns a new client whose CI status reads skip the named
+// status contexts and check runs (matched case-insensitively), e.g. CLA bots
+func (c *Client) WithIgnoredCIContexts(contexts []string) *Client {
+	scoped := c.clone()
+	scoped.ignoredCIContexts = contexts
+	return scoped
+}
+
+// WithIgnoredChecks returns a new client whose DCO-filtered CI reads (the
+// cherry-pick path) also skip checks whose name contains one of patterns,
+// matched case-insensitively like the built-in DCO patterns
+func (c *Client) WithIgnoredChecks(patterns []string) *Client {
+	scoped := c.clone()
+	scoped.ignoredChecks = patterns
+	return scoped
+}
+
+// WithGraphQLCIStatus returns a new client whose GetPRWithDetails calls read the PR
+// and its CI rollup with one GraphQL query, falling back to REST if that fails
+func (c *Client) WithGraphQLCIStatus(enabled bool) *Client {
+	scoped := c.clone()
+	scoped.graphQLCIStatus = enabled
+	return scoped
+}
+
+// WithLabelScheme returns a new client that maps cherry-pick labels, bot comments
+// and cherry-pick titles to release branches with the given scheme
+func (c *Client) WithLabelScheme(scheme LabelScheme) *Client {
+	scoped := c.clone()
+	scoped.labelScheme = scheme
+	return scoped
+}
+
+// WithRequiredChecks returns a new client whose DCO-filtered CI reads (the
+// cherry-pick path) judge a PR by only the checks its base branch requires
+// (branch -> check names, matched case-insensitively). Branches without an
+// entry keep the all-checks aggregation.
+func (c *Client) WithRequiredChecks(required map[string][]string) *Client {
+	scoped := c.clone()
+	scoped.requiredChecks = required
+	return scoped
+}
+
+// WithSearchQualifiers returns a new client whose merged cherry-pick PR search
+// (GetMergedPRs) also ANDs in the given raw search qualifiers, e.g.
+// "author:octocat", to narrow which PRs a fetch finds
+func (c *Client) WithSearchQualifiers(qualifiers []string) *Client {
+	scoped := c.clone()
+	scoped.searchQualifiers = qualifiers
+	return scoped
+}
+
+// WithDryRun returns a new client whose mutating calls (creating, merging,
+// approving, enqueuing, reopening and labeling PRs, commenting, retrying
+// workflows) log what they would do and return synthetic success without
+// calling the API. Reads still go to GitHub.
+func (c *Client) WithDryRun(enabled bool) *Client {
+	scoped := c.clone()
+	scoped.dryRun = enabled
+	return scoped
+}
+
+// DryRun reports whether the client skips mutating calls
+func (c *Client) DryRun() bool {
+	return c.dryRun
+}
+
+// skipForDryRun logs the mutation a dry run leaves out and reports whether to
+// skip it
+func (c *Client) skipForDryRun(action string, args ...any) bool {
+	if !c.dryRun {
+		return false
 	}
+	slog.Info("Dry run: would "+action, append([]any{"org", c.org, "repo", c.repo}, args...)...)
+	return true
+}
+
+// LabelScheme returns the scheme the client maps cherry-pick labels to branches with
+func (c *Client) LabelScheme() LabelScheme {
+	return c.labelScheme
+}
+
+// WithRetryPolicy returns a new client that retries rate limited (403/429) and,
+// for reads, failed (5xx) requests up to maxRetries times, waiting as long as
+// GitHub's Retry-After or X-RateLimit-Reset headers ask and otherwise backing
+// off exponentially from baseDelay. maxRetries 0 disables retries.
+func (c *Client) WithRetryPolicy(maxRetries int, baseDelay time.Duration) *Client {
+	httpClient := c.client.Client()
+	base := httpClient.Transport
+	retryServerErrors := true
+	if rt, ok := base.(*retryTransport); ok {
+		base = rt.base
+		retryServerErrors = rt.retryServerErrors
+	}
+	rt := newRetryTransport(base, maxRetries, baseDelay)
+	rt.retryServerErrors = retryServerErrors
+	httpClient.Transport = rt
+
+	gh := github.NewClient(httpClient)
+	gh.BaseURL = c.client.BaseURL
+	gh.UploadURL = c.client.UploadURL
+
+	scoped := c.clone()
+	scoped.client = gh
+	return scoped
 }
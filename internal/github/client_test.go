@@ -2,6 +2,7 @@ package github
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -82,6 +83,71 @@ func TestWithRepository_ChainedCalls(t *testing.T) {
 	assert.Empty(t, client.repo)
 }
 
+func TestWithBuilders_KeepOtherSettings(t *testing.T) {
+	scheme, err := ParseLabelScheme("backport/", "stable-{version}")
+	require.NoError(t, err)
+
+	client := NewClient(t.Context(), "test-token").
+		WithRepository("acme", "widget").
+		WithIgnoredCIContexts([]string{"cla"}).
+		WithIgnoredChecks([]string{"lint"}).
+		WithGraphQLCIStatus(true).
+		WithLabelScheme(scheme).
+		WithRequiredChecks(map[string][]string{"release-1.0": {"build"}}).
+		WithSearchQualifiers([]string{"author:octocat"}).
+		WithDryRun(true).
+		WithRetryPolicy(1, time.Millisecond)
+
+	assert.Equal(t, "acme", client.org)
+	assert.Equal(t, "widget", client.repo)
+	assert.Equal(t, []string{"cla"}, client.ignoredCIContexts)
+	assert.Equal(t, []string{"lint"}, client.ignoredChecks)
+	assert.True(t, client.graphQLCIStatus)
+	assert.Equal(t, scheme, client.labelScheme)
+	assert.Equal(t, map[string][]string{"release-1.0": {"build"}}, client.requiredChecks)
+	assert.Equal(t, []string{"author:octocat"}, client.searchQualifiers)
+	assert.True(t, client.dryRun)
+	assert.NotNil(t, client.cache)
+}
+
 // Note: Integration tests for GetMergedPRs and GetPR would require a real GitHub token
 // and network access, so we're keeping these as unit tests for the basic functionality.
 // For integration testing, we would create separate test files or use build tags.
+
+func TestNewEnterpriseClient(t *testing.T) {
+	for _, baseURL := range []string{"https://ghe.internal/api/v3", "https://ghe.internal/api/v3/", "https://ghe.internal"} {
+		t.Run(baseURL, func(t *testing.T) {
+			client, err := NewEnterpriseClient(t.Context(), "test-token", baseURL, HTTPOptions{})
+			require.NoError(t, err)
+
+			assert.Equal(t, "https://ghe.internal/api/v3/", client.client.BaseURL.String())
+			assert.Equal(t, "https://ghe.internal/api/uploads/", client.client.UploadURL.String())
+			assert.Equal(t, "../graphql", client.graphQLPath())
+
+			req, err := client.client.NewRequest("POST", client.graphQLPath(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, "https://ghe.internal/api/graphql", req.URL.String())
+		})
+	}
+
+	_, err := NewEnterpriseClient(t.Context(), "test-token", "ghe.internal", HTTPOptions{})
+	require.ErrorContains(t, err, "invalid base URL")
+}
+
+func TestGraphQLPath_GitHubCom(t *testing.T) {
+	client := NewClient(t.Context(), "test-token")
+
+	req, err := client.client.NewRequest("POST", client.graphQLPath(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.github.com/graphql", req.URL.String())
+}
+
+func TestEnterpriseHost(t *testing.T) {
+	host, err := EnterpriseHost("https://ghe.internal/api/v3/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ghe.internal", host)
+
+	host, err = EnterpriseHost("https://example.com/github/api/v3")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/github", host)
+}
@@ -21,7 +21,7 @@ func (c *Client) GetIssueComments(ctx context.Context, issueNumber int) ([]Comme
 		slog.Debug("GitHub API: Listing issue comments", "org", c.org, "repo", c.repo, "issue", issueNumber, "page", opts.Page)
 		comments, resp, err := c.client.Issues.ListComments(ctx, c.org, c.repo, issueNumber, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list issue comments: %w", err)
+			return nil, fmt.Errorf("failed to list issue comments: %w", apiError(err))
 		}
 
 		for _, comment := range comments {
@@ -49,10 +49,14 @@ func (c *Client) CreateIssueComment(ctx context.Context, issueNumber int, body s
 		Body: new(body),
 	}
 
+	if c.skipForDryRun("comment", "issue", issueNumber) {
+		return &Comment{Body: body}, nil
+	}
+
 	slog.Debug("GitHub API: Creating issue comment", "org", c.org, "repo", c.repo, "issue", issueNumber)
 	comment, _, err := c.client.Issues.CreateComment(ctx, c.org, c.repo, issueNumber, commentInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+		return nil, fmt.Errorf("failed to create comment: %w", apiError(err))
 	}
 
 	return &Comment{
@@ -70,10 +74,14 @@ func (c *Client) UpdateIssueComment(ctx context.Context, commentID int64, body s
 		Body: new(body),
 	}
 
+	if c.skipForDryRun("update comment", "comment_id", commentID) {
+		return &Comment{ID: commentID, Body: body}, nil
+	}
+
 	slog.Debug("GitHub API: Updating issue comment", "org", c.org, "repo", c.repo, "comment_id", commentID)
 	comment, _, err := c.client.Issues.EditComment(ctx, c.org, c.repo, commentID, commentInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update comment: %w", err)
+		return nil, fmt.Errorf("failed to update comment: %w", apiError(err))
 	}
 
 	return &Comment{
@@ -90,7 +98,7 @@ func (c *Client) GetAuthenticatedUser(ctx context.Context) (string, error) {
 	slog.Debug("GitHub API: Getting authenticated user")
 	user, _, err := c.client.Users.Get(ctx, "")
 	if err != nil {
-		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+		return "", fmt.Errorf("failed to get authenticated user: %w", apiError(err))
 	}
 
 	return user.GetLogin(), nil
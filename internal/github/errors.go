@@ -0,0 +1,108 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v80/github"
+)
+
+// ErrMissingToken is wrapped by the error of a command that needs GitHub but
+// wasn't given a token
+var ErrMissingToken = errors.New("no GitHub token")
+
+// Kinds of GitHub API failure. Client methods wrap the API's error in an
+// *APIError that matches one of these with errors.Is, so callers can tell a
+// PR that is gone from a rate limit or an outage without parsing messages.
+var (
+	// ErrNotFound means the object doesn't exist, or the token can't see it (404)
+	ErrNotFound = errors.New("not found on GitHub")
+	// ErrRateLimited means GitHub's primary or secondary rate limit was hit
+	ErrRateLimited = errors.New("GitHub rate limit exceeded")
+	// ErrUnauthorized means GitHub rejected the token (401)
+	ErrUnauthorized = errors.New("GitHub rejected the credentials")
+	// ErrServerError means GitHub failed to handle the request (5xx); worth retrying later
+	ErrServerError = errors.New("GitHub server error")
+)
+
+// APIError is a failed GitHub API call classified by its status code. Its
+// message is the underlying error's; errors.Is matches its Kind and errors.As
+// still reaches the go-github error it wraps.
+type APIError struct {
+	StatusCode int   // HTTP status of the response, 0 if unknown
+	Kind       error // ErrNotFound, ErrRateLimited, ErrUnauthorized, ErrServerError or nil
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the kind (when classified) and the underlying error
+func (e *APIError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// apiError classifies an error returned by go-github as an *APIError. Other
+// errors (e.g. network failures), nil and errors already classified are
+// returned unchanged.
+func apiError(err error) error {
+	var classified *APIError
+	if err == nil || errors.As(err, &classified) {
+		return err
+	}
+
+	var (
+		rateLimit  *github.RateLimitError
+		abuseLimit *github.AbuseRateLimitError
+		errResp    *github.ErrorResponse
+	)
+	switch {
+	case errors.As(err, &rateLimit):
+		return &APIError{StatusCode: statusCode(rateLimit.Response), Kind: ErrRateLimited, Err: err}
+	case errors.As(err, &abuseLimit):
+		return &APIError{StatusCode: statusCode(abuseLimit.Response), Kind: ErrRateLimited, Err: err}
+	case errors.As(err, &errResp):
+		code := statusCode(errResp.Response)
+		return &APIError{StatusCode: code, Kind: statusKind(code), Err: err}
+	default:
+		return err
+	}
+}
+
+// statusKind maps an HTTP status to the kind of API failure it means
+func statusKind(code int) error {
+	switch {
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code >= http.StatusInternalServerError:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// statusCode returns resp's status code, or 0 without a response
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// IsAuthError reports whether err means the GitHub credentials need fixing
+// rather than a retry: no token was given, or GitHub rejected it (401).
+func IsAuthError(err error) bool {
+	if errors.Is(err, ErrMissingToken) || errors.Is(err, ErrUnauthorized) {
+		return true
+	}
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusUnauthorized
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAuthError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/1", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message": "Bad credentials"}`, http.StatusUnauthorized)
+	})
+	mux.HandleFunc("GET /repos/acme/widget/pulls/2", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+	client := newTestClient(t, mux)
+
+	_, unauthorized := client.GetPR(t.Context(), 1)
+	assert.True(t, IsAuthError(unauthorized), "401 from the API")
+	assert.True(t, IsAuthError(fmt.Errorf("fetch: %w", unauthorized)), "wrapped 401")
+
+	_, notFound := client.GetPR(t.Context(), 2)
+	assert.False(t, IsAuthError(notFound), "404 from the API")
+
+	assert.True(t, IsAuthError(fmt.Errorf("%w: GITHUB_TOKEN is unset", ErrMissingToken)))
+	assert.False(t, IsAuthError(errors.New("connection reset")))
+	assert.False(t, IsAuthError(nil))
+}
+
+func TestAPIErrorKinds(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("number") {
+		case "1":
+			http.Error(w, `{"message": "Bad credentials"}`, http.StatusUnauthorized)
+		case "2":
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		case "3":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			http.Error(w, `{"message": "API rate limit exceeded"}`, http.StatusForbidden)
+		case "4":
+			http.Error(w, `{"message": "Server Error"}`, http.StatusBadGateway)
+		default:
+			http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+		}
+	})
+	client := newTestClient(t, mux)
+
+	tests := []struct {
+		number int
+		kind   error
+		status int
+	}{
+		{1, ErrUnauthorized, http.StatusUnauthorized},
+		{2, ErrNotFound, http.StatusNotFound},
+		{3, ErrRateLimited, http.StatusForbidden},
+		{4, ErrServerError, http.StatusBadGateway},
+		{5, nil, http.StatusForbidden},
+	}
+	kinds := []error{ErrUnauthorized, ErrNotFound, ErrRateLimited, ErrServerError}
+	for _, tt := range tests {
+		_, err := client.GetPR(t.Context(), tt.number)
+		require.Error(t, err)
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr, "PR %d", tt.number)
+		assert.Equal(t, tt.status, apiErr.StatusCode, "PR %d", tt.number)
+		for _, kind := range kinds {
+			assert.Equal(t, kind == tt.kind, errors.Is(err, kind), "PR %d matching %v", tt.number, kind)
+		}
+		assert.Contains(t, err.Error(), fmt.Sprintf("failed to fetch PR #%d", tt.number), "messages stay readable")
+	}
+
+	assert.NoError(t, apiError(nil))
+	plain := errors.New("connection reset")
+	assert.Same(t, plain, apiError(plain))
+}
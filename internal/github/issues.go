@@ -26,7 +26,7 @@ func (c *Client) SearchIssuesByText(ctx context.Context, searchText string) ([]I
 		slog.Debug("GitHub API: Searching issues", "query", query, "page", opts.Page)
 		result, resp, err := c.client.Search.Issues(ctx, query, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to search issues: %w", err)
+			return nil, fmt.Errorf("failed to search issues: %w", apiError(err))
 		}
 
 		for _, issue := range result.Issues {
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultLabelPrefix is the cherry-pick label prefix used when the config doesn't set one
+	DefaultLabelPrefix = "cherry-pick/"
+	// DefaultBranchTemplate is the release branch template used when the config doesn't set one
+	DefaultBranchTemplate = "release-{version}"
+	// versionPlaceholder is replaced with the release version in a branch template
+	versionPlaceholder = "{version}"
+)
+
+// LabelScheme maps cherry-pick labels to the release branches they target and
+// back: with the defaults, "cherry-pick/3.6" targets "release-3.6". The zero
+// value uses the defaults, so existing configs keep working.
+type LabelScheme struct {
+	Prefix         string // label prefix, e.g. "backport/"
+	BranchTemplate string // target branch with a {version} placeholder, e.g. "stable/{version}"
+}
+
+// ParseLabelScheme builds a LabelScheme from the config's label_prefix and
+// branch_template, either of which may be empty to use the default. It errors
+// unless the template holds exactly one {version} placeholder.
+func ParseLabelScheme(prefix, branchTemplate string) (LabelScheme, error) {
+	scheme := LabelScheme{Prefix: prefix, BranchTemplate: branchTemplate}
+	if n := strings.Count(scheme.template(), versionPlaceholder); n != 1 {
+		return LabelScheme{}, fmt.Errorf("branch_template %q must contain %s exactly once", branchTemplate, versionPlaceholder)
+	}
+	return scheme, nil
+}
+
+// prefix returns the label prefix, falling back to the default
+func (s LabelScheme) prefix() string {
+	if s.Prefix == "" {
+		return DefaultLabelPrefix
+	}
+	return s.Prefix
+}
+
+// template returns the branch template, falling back to the default
+func (s LabelScheme) template() string {
+	if s.BranchTemplate == "" {
+		return DefaultBranchTemplate
+	}
+	return s.BranchTemplate
+}
+
+// BranchForVersion returns the release branch for a version: "3.6" becomes "release-3.6"
+func (s LabelScheme) BranchForVersion(version string) string {
+	return strings.Replace(s.template(), versionPlaceholder, version, 1)
+}
+
+// VersionForBranch returns the version a release branch was named after:
+// "release-3.6" becomes "3.6". It reports false for branches that don't match
+// the template.
+func (s LabelScheme) VersionForBranch(branch string) (string, bool) {
+	before, after, _ := strings.Cut(s.template(), versionPlaceholder)
+	version, ok := strings.CutPrefix(branch, before)
+	if !ok {
+		return "", false
+	}
+	version, ok = strings.CutSuffix(version, after)
+	if !ok || version == "" {
+		return "", false
+	}
+	return version, true
+}
+
+// BranchForLabel returns the release branch a cherry-pick label targets:
+// "cherry-pick/3.6" becomes "release-3.6". It reports false for other labels.
+func (s LabelScheme) BranchForLabel(label string) (string, bool) {
+	version, ok := strings.CutPrefix(label, s.prefix())
+	if !ok || version == "" {
+		return "", false
+	}
+	return s.BranchForVersion(version), true
+}
+
+// LabelForBranch returns the cherry-pick label that targets a release branch,
+// the inverse of BranchForLabel: "release-3.6" becomes "cherry-pick/3.6". It
+// reports false for branches that don't match the template.
+func (s LabelScheme) LabelForBranch(branch string) (string, bool) {
+	version, ok := s.VersionForBranch(branch)
+	if !ok {
+		return "", false
+	}
+	return s.prefix() + version, true
+}
+
+// String describes the branch names the scheme accepts, e.g. "release-<version>"
+func (s LabelScheme) String() string {
+	return strings.Replace(s.template(), versionPlaceholder, "<version>", 1)
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelScheme(t *testing.T) {
+	t.Run("empty fields use the defaults", func(t *testing.T) {
+		scheme, err := ParseLabelScheme("", "")
+		require.NoError(t, err)
+
+		branch, ok := scheme.BranchForLabel("cherry-pick/3.6")
+		assert.True(t, ok)
+		assert.Equal(t, "release-3.6", branch)
+	})
+
+	t.Run("rejects a template without a version placeholder", func(t *testing.T) {
+		_, err := ParseLabelScheme("backport/", "stable")
+		assert.ErrorContains(t, err, "{version}")
+	})
+
+	t.Run("rejects a template with two version placeholders", func(t *testing.T) {
+		_, err := ParseLabelScheme("", "{version}/{version}")
+		assert.Error(t, err)
+	})
+}
+
+func TestLabelScheme_CustomMapping(t *testing.T) {
+	scheme, err := ParseLabelScheme("backport/", "stable/{version}")
+	require.NoError(t, err)
+
+	branch, ok := scheme.BranchForLabel("backport/3.6")
+	assert.True(t, ok)
+	assert.Equal(t, "stable/3.6", branch)
+
+	_, ok = scheme.BranchForLabel("cherry-pick/3.6")
+	assert.False(t, ok)
+
+	label, ok := scheme.LabelForBranch("stable/3.6")
+	assert.True(t, ok)
+	assert.Equal(t, "backport/3.6", label)
+
+	_, ok = scheme.LabelForBranch("release-3.6")
+	assert.False(t, ok)
+}
+
+func TestLabelScheme_VersionForBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		branch   string
+		version  string
+		ok       bool
+	}{
+		{name: "default template", branch: "release-3.7", version: "3.7", ok: true},
+		{name: "default template, other branch", branch: "main", ok: false},
+		{name: "empty version", branch: "release-", ok: false},
+		{name: "suffix template", template: "v{version}-stable", branch: "v2.1-stable", version: "2.1", ok: true},
+		{name: "suffix template, missing suffix", template: "v{version}-stable", branch: "v2.1", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok := LabelScheme{BranchTemplate: tt.template}.VersionForBranch(tt.branch)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
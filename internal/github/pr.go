@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -15,31 +16,34 @@ import (
 func (c *Client) GetMergedPRs(ctx context.Context, branch string, _since time.Time) ([]PR, error) {
 	labels, err := c.ListLabels(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list labels: %w", err)
+		return nil, fmt.Errorf("failed to list labels: %w", apiError(err))
 	}
 
-	cherryPickLabels := filterCherryPickLabels(labels)
+	cherryPickLabels := filterCherryPickLabels(labels, c.labelScheme)
 	if len(cherryPickLabels) == 0 {
 		return []PR{}, nil
 	}
 
-	query := buildSearchQuery(c.org, c.repo, branch, cherryPickLabels)
+	query := buildSearchQuery(c.org, c.repo, branch, cherryPickLabels, c.searchQualifiers)
 	return c.searchPRs(ctx, query)
 }
 
-// filterCherryPickLabels filters labels to only those starting with "cherry-pick"
-func filterCherryPickLabels(labels []*github.Label) []string {
+// filterCherryPickLabels filters labels to only those starting with the scheme's
+// prefix, without its trailing slash (so "cherry-pick-beta" matches "cherry-pick/")
+func filterCherryPickLabels(labels []*github.Label, scheme LabelScheme) []string {
+	prefix := strings.TrimSuffix(scheme.prefix(), "/")
 	var cherryPickLabels []string
 	for _, label := range labels {
-		if strings.HasPrefix(label.GetName(), "cherry-pick") {
+		if strings.HasPrefix(label.GetName(), prefix) {
 			cherryPickLabels = append(cherryPickLabels, label.GetName())
 		}
 	}
 	return cherryPickLabels
 }
 
-// buildSearchQuery constructs a GitHub search query for merged PRs with cherry-pick labels
-func buildSearchQuery(org, repo, branch string, labels []string) string {
+// buildSearchQuery constructs a GitHub search query for merged PRs with
+// cherry-pick labels, narrowed by any extra qualifiers
+func buildSearchQuery(org, repo, branch string, labels, qualifiers []string) string {
 	var parts []string
 	parts = append(parts, fmt.Sprintf("repo:%s/%s", org, repo))
 	parts = append(parts, "is:pr")
@@ -50,6 +54,7 @@ func buildSearchQuery(org, repo, branch string, labels []string) string {
 	if len(labels) > 0 {
 		parts = append(parts, fmt.Sprintf("label:%s", strings.Join(labels, ",")))
 	}
+	parts = append(parts, qualifiers...)
 
 	return strings.Join(parts, " ")
 }
@@ -70,7 +75,7 @@ func (c *Client) searchPRs(ctx context.Context, query string) ([]PR, error) {
 		slog.Debug("GitHub API: Searching issues/PRs", "query", query, "page", opts.Page)
 		result, resp, err := c.client.Search.Issues(ctx, query, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to search PRs: %w", err)
+			return nil, fmt.Errorf("failed to search PRs: %w", apiError(err))
 		}
 
 		slog.Debug("GitHub search results", "total_count", result.GetTotal(), "returned_count", len(result.Issues), "page", opts.Page)
@@ -81,13 +86,14 @@ func (c *Client) searchPRs(ctx context.Context, query string) ([]PR, error) {
 				continue
 			}
 
-			cherryPickBranches := extractCherryPickBranchesFromLabels(issue.Labels)
+			cherryPickBranches := extractCherryPickBranchesFromLabels(issue.Labels, c.labelScheme)
 			if len(cherryPickBranches) == 0 {
 				slog.Debug("Skipping PR with no cherry-pick labels", "pr", issue.GetNumber(), "label_count", len(issue.Labels))
 				continue
 			}
 
 			var sha string
+			mergedAt := issue.GetClosedAt().Time
 			if issue.PullRequestLinks != nil && issue.PullRequestLinks.URL != nil {
 				prNum := issue.GetNumber()
 				slog.Debug("GitHub API: Getting PR details", "org", extractOrgFromIssue(issue), "repo", extractRepoFromIssue(issue), "pr", prNum)
@@ -95,14 +101,19 @@ func (c *Client) searchPRs(ctx context.Context, query string) ([]PR, error) {
 				if err == nil && pr.MergeCommitSHA != nil {
 					sha = pr.GetMergeCommitSHA()
 				}
+				if err == nil && pr.MergedAt != nil {
+					mergedAt = pr.GetMergedAt().Time
+				}
 			}
 
 			allPRs = append(allPRs, PR{
 				Number:        issue.GetNumber(),
 				Title:         issue.GetTitle(),
 				URL:           issue.GetHTMLURL(),
+				Author:        issue.GetUser().GetLogin(),
 				SHA:           sha,
 				Merged:        issue.ClosedAt != nil,
+				MergedAt:      mergedAt,
 				CIStatus:      "unknown",
 				CherryPickFor: cherryPickBranches,
 			})
@@ -117,21 +128,31 @@ func (c *Client) searchPRs(ctx context.Context, query string) ([]PR, error) {
 	return allPRs, nil
 }
 
-// extractCherryPickBranchesFromLabels extracts target branches from cherry-pick/* labels
-// For example, "cherry-pick/3.6" becomes "release-3.6"
-func extractCherryPickBranchesFromLabels(labels []*github.Label) []string {
+// extractCherryPickBranchesFromLabels extracts target branches from the scheme's cherry-pick labels
+// For example, "cherry-pick/3.6" becomes "release-3.6" with the default scheme
+func extractCherryPickBranchesFromLabels(labels []*github.Label, scheme LabelScheme) []string {
 	var branches []string
 	for _, label := range labels {
-		labelName := label.GetName()
-		if after, ok := strings.CutPrefix(labelName, "cherry-pick/"); ok {
-			version := after
-			branch := "release-" + version
+		if branch, ok := scheme.BranchForLabel(label.GetName()); ok {
 			branches = append(branches, branch)
 		}
 	}
 	return branches
 }
 
+// AddLabelsToPR adds labels to a PR (PRs share the issues label API)
+func (c *Client) AddLabelsToPR(ctx context.Context, prNumber int, labels ...string) error {
+	if c.skipForDryRun("add labels", "pr", prNumber, "labels", labels) {
+		return nil
+	}
+	slog.Debug("GitHub API: Adding labels", "org", c.org, "repo", c.repo, "pr", prNumber, "labels", labels)
+	_, _, err := c.client.Issues.AddLabelsToIssue(ctx, c.org, c.repo, prNumber, labels)
+	if err != nil {
+		return fmt.Errorf("failed to add labels to PR #%d: %w", prNumber, apiError(err))
+	}
+	return nil
+}
+
 // extractOrgFromIssue extracts org from issue repository URL
 func extractOrgFromIssue(issue *github.Issue) string {
 	if issue.Repository == nil {
@@ -168,7 +189,7 @@ func (c *Client) GetOpenPRs(ctx context.Context, branch string) ([]PR, error) {
 		return c.client.PullRequests.List(ctx, c.org, c.repo, opts)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch open pull requests: %w", err)
+		return nil, fmt.Errorf("failed to fetch open pull requests: %w", apiError(err))
 	}
 
 	// Convert to our PR type
@@ -180,7 +201,8 @@ func (c *Client) GetOpenPRs(ctx context.Context, branch string) ([]PR, error) {
 			URL:      pr.GetHTMLURL(),
 			SHA:      pr.GetHead().GetSHA(), // Use head SHA for open PRs
 			Merged:   false,                 // Open PRs are not merged
-			CIStatus: "unknown",             // CI status not fetched for listing
+			Draft:    pr.GetDraft(),
+			CIStatus: "unknown", // CI status not fetched for listing
 		})
 	}
 
@@ -192,7 +214,7 @@ func (c *Client) GetPR(ctx context.Context, number int) (*PR, error) {
 	slog.Debug("GitHub API: Getting PR", "org", c.org, "repo", c.repo, "pr", number)
 	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, number)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+		return nil, fmt.Errorf("failed to fetch PR #%d: %w", number, apiError(err))
 	}
 
 	return &PR{
@@ -200,25 +222,52 @@ func (c *Client) GetPR(ctx context.Context, number int) (*PR, error) {
 		Title:    pr.GetTitle(),
 		URL:      pr.GetHTMLURL(),
 		SHA:      pr.GetMergeCommitSHA(),
+		HeadSHA:  pr.GetHead().GetSHA(),
 		Merged:   pr.MergedAt != nil,
+		Draft:    pr.GetDraft(),
 		CIStatus: "unknown", // CI status not fetched in simple PR fetch
 	}, nil
 }
 
+// PRDeleted reports whether PR number is confirmed gone: GitHub answers 404
+// for it while the repository itself is readable. A 404 alone proves nothing,
+// since GitHub returns it too for a private repository the token can't see.
+func (c *Client) PRDeleted(ctx context.Context, number int) (bool, error) {
+	if _, err := c.GetPR(ctx, number); !errors.Is(err, ErrNotFound) {
+		return false, err
+	}
+	if _, err := c.GetDefaultBranch(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // GetPRWithDetails fetches detailed information for a specific PR including CI status, retry count, and failing checks
 func (c *Client) GetPRWithDetails(ctx context.Context, number int) (*PR, error) {
+	if c.graphQLCIStatus {
+		details, err := c.getPRDetailsGraphQL(ctx, number, c.newCIStatusChecker())
+		if err == nil {
+			return details.toPR(details.MergeSHA), nil
+		}
+		slog.Warn("GraphQL CI rollup failed, falling back to REST", "pr", number, "error", err)
+	}
+
 	slog.Debug("GitHub API: Getting PR with details", "org", c.org, "repo", c.repo, "pr", number)
 	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, number)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+		return nil, fmt.Errorf("failed to fetch PR #%d: %w", number, apiError(err))
 	}
 
 	sha := pr.GetHead().GetSHA()
 
-	// Get full CI status including failing check names
-	ciResult, err := c.GetFullCIStatus(ctx, sha)
+	// Get full CI status including failing check names, judged by the base branch's required checks
+	checker := c.newCIStatusChecker()
+	checker.useBranch(pr.GetBase().GetRef())
+	ciResult, err := c.fullCIStatus(ctx, checker, sha)
 	if err != nil {
-		// Don't fail the whole request if we can't get CI status
+		// Don't fail the whole request if we can't get CI status; unknown then
+		// means the read failed, unlike no_checks
+		slog.Warn("Failed to read CI status", "pr", number, "error", err)
 		ciResult = &CIStatusResult{Status: "unknown"}
 	}
 
@@ -227,7 +276,10 @@ func (c *Client) GetPRWithDetails(ctx context.Context, number int) (*PR, error)
 		Title:         pr.GetTitle(),
 		URL:           pr.GetHTMLURL(),
 		SHA:           pr.GetMergeCommitSHA(),
+		HeadSHA:       sha,
 		Merged:        pr.MergedAt != nil,
+		MergedAt:      pr.GetMergedAt().Time,
+		Mergeable:     pr.Mergeable,
 		CIStatus:      ciResult.Status,
 		RunAttempt:    ciResult.RunAttempt,
 		FailingChecks: ciResult.FailingChecks,
@@ -239,11 +291,21 @@ func (c *Client) GetPRHeadBranch(ctx context.Context, number int) (string, error
 	slog.Debug("GitHub API: Getting PR head branch", "org", c.org, "repo", c.repo, "pr", number)
 	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, number)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+		return "", fmt.Errorf("failed to fetch PR #%d: %w", number, apiError(err))
 	}
 	return pr.GetHead().GetRef(), nil
 }
 
+// GetPRDiff returns a PR's changes as a unified diff
+func (c *Client) GetPRDiff(ctx context.Context, number int) (string, error) {
+	slog.Debug("GitHub API: Getting PR diff", "org", c.org, "repo", c.repo, "pr", number)
+	diff, _, err := c.client.PullRequests.GetRaw(ctx, c.org, c.repo, number, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch diff of PR #%d: %w", number, apiError(err))
+	}
+	return diff, nil
+}
+
 // GetOpenPRsWithLabel fetches open PRs with a specific label
 func (c *Client) GetOpenPRsWithLabel(ctx context.Context, label string) ([]PR, error) {
 	query := fmt.Sprintf("repo:%s/%s is:pr is:open label:%s", c.org, c.repo, label)
@@ -262,7 +324,7 @@ func (c *Client) GetOpenPRsWithLabel(ctx context.Context, label string) ([]PR, e
 		slog.Debug("GitHub API: Searching open PRs with label", "query", query, "page", opts.Page)
 		result, resp, err := c.client.Search.Issues(ctx, query, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to search PRs with label %s: %w", label, err)
+			return nil, fmt.Errorf("failed to search PRs with label %s: %w", label, apiError(err))
 		}
 
 		for _, issue := range result.Issues {
@@ -290,10 +352,18 @@ func (c *Client) GetOpenPRsWithLabel(ctx context.Context, label string) ([]PR, e
 
 // GetPRWithDetailsNoDCOFilter fetches PR details with CI status that respects DCO checks
 func (c *Client) GetPRWithDetailsNoDCOFilter(ctx context.Context, number int) (*PR, error) {
+	if c.graphQLCIStatus {
+		details, err := c.getPRDetailsGraphQL(ctx, number, c.newCIStatusCheckerWithOptions(false))
+		if err == nil {
+			return details.toPR(details.HeadSHA), nil
+		}
+		slog.Warn("GraphQL CI rollup failed, falling back to REST", "pr", number, "error", err)
+	}
+
 	slog.Debug("GitHub API: Getting PR with details (no DCO filter)", "org", c.org, "repo", c.repo, "pr", number)
 	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, number)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+		return nil, fmt.Errorf("failed to fetch PR #%d: %w", number, apiError(err))
 	}
 
 	sha := pr.GetHead().GetSHA()
@@ -301,6 +371,7 @@ func (c *Client) GetPRWithDetailsNoDCOFilter(ctx context.Context, number int) (*
 	// Get full CI status without DCO filtering, including failing check names
 	ciResult, err := c.GetFullCIStatusWithoutDCOFilter(ctx, sha)
 	if err != nil {
+		slog.Warn("Failed to read CI status", "pr", number, "error", err)
 		ciResult = &CIStatusResult{Status: "unknown"}
 	}
 
@@ -309,7 +380,10 @@ func (c *Client) GetPRWithDetailsNoDCOFilter(ctx context.Context, number int) (*
 		Title:         pr.GetTitle(),
 		URL:           pr.GetHTMLURL(),
 		SHA:           sha,
+		HeadSHA:       sha,
 		Merged:        pr.MergedAt != nil,
+		MergedAt:      pr.GetMergedAt().Time,
+		Mergeable:     pr.Mergeable,
 		CIStatus:      ciResult.Status,
 		RunAttempt:    ciResult.RunAttempt,
 		FailingChecks: ciResult.FailingChecks,
@@ -324,7 +398,7 @@ func (c *Client) IsPRApproved(ctx context.Context, number int) (bool, error) {
 		PerPage: 100,
 	})
 	if err != nil {
-		return false, fmt.Errorf("failed to list reviews for PR #%d: %w", number, err)
+		return false, fmt.Errorf("failed to list reviews for PR #%d: %w", number, apiError(err))
 	}
 
 	for _, review := range reviews {
@@ -336,20 +410,54 @@ func (c *Client) IsPRApproved(ctx context.Context, number int) (bool, error) {
 	return false, nil
 }
 
-// CreatePR creates a new pull request
-func (c *Client) CreatePR(ctx context.Context, title, body, head, base string) (int, error) {
+// CreatePR creates a new pull request, as a draft when draft is set, and
+// returns its number, title and URL
+func (c *Client) CreatePR(ctx context.Context, title, body, head, base string, draft bool) (*PR, error) {
 	newPR := &github.NewPullRequest{
 		Title: &title,
 		Body:  &body,
 		Head:  &head,
 		Base:  &base,
+		Draft: &draft,
+	}
+
+	if c.skipForDryRun("create PR", "title", title, "head", head, "base", base, "draft", draft) {
+		return &PR{Title: title, Draft: draft}, nil
 	}
 
-	slog.Debug("GitHub API: Creating PR", "org", c.org, "repo", c.repo, "head", head, "base", base)
+	slog.Debug("GitHub API: Creating PR", "org", c.org, "repo", c.repo, "head", head, "base", base, "draft", draft)
 	pr, _, err := c.client.PullRequests.Create(ctx, c.org, c.repo, newPR)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return pr.GetNumber(), nil
+	return &PR{
+		Number: pr.GetNumber(),
+		Title:  pr.GetTitle(),
+		URL:    pr.GetHTMLURL(),
+		Draft:  pr.GetDraft(),
+	}, nil
+}
+
+// GetPRState reports whether a PR is open and whether it was merged
+func (c *Client) GetPRState(ctx context.Context, number int) (bool, bool, error) {
+	slog.Debug("GitHub API: Getting PR state", "org", c.org, "repo", c.repo, "pr", number)
+	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, number)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to fetch PR #%d: %w", number, apiError(err))
+	}
+	return pr.GetState() == "open", pr.GetMerged() || pr.MergedAt != nil, nil
+}
+
+// ReopenPR reopens a closed pull request, keeping its review history
+func (c *Client) ReopenPR(ctx context.Context, number int) error {
+	if c.skipForDryRun("reopen PR", "pr", number) {
+		return nil
+	}
+	slog.Debug("GitHub API: Reopening PR", "org", c.org, "repo", c.repo, "pr", number)
+	_, _, err := c.client.PullRequests.Edit(ctx, c.org, c.repo, number, &github.PullRequest{State: new("open")})
+	if err != nil {
+		return fmt.Errorf("failed to reopen PR #%d: %w", number, apiError(err))
+	}
+	return nil
 }
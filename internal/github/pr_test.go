@@ -1,10 +1,14 @@
 package github
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/google/go-github/v80/github"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractCherryPickBranchesFromLabels(t *testing.T) {
@@ -58,12 +62,107 @@ func TestExtractCherryPickBranchesFromLabels(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := extractCherryPickBranchesFromLabels(tt.labels)
+			result := extractCherryPickBranchesFromLabels(tt.labels, LabelScheme{})
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+func TestCherryPickLabelForBranch(t *testing.T) {
+	var scheme LabelScheme
+	label, ok := scheme.LabelForBranch("release-4.1")
+	assert.True(t, ok)
+	assert.Equal(t, "cherry-pick/4.1", label)
+
+	// Round-trips with label parsing
+	assert.Equal(t, []string{"release-4.1"}, extractCherryPickBranchesFromLabels([]*github.Label{{Name: github.Ptr(label)}}, scheme))
+
+	_, ok = scheme.LabelForBranch("main")
+	assert.False(t, ok)
+	_, ok = scheme.LabelForBranch("release-")
+	assert.False(t, ok)
+}
+
+func TestExtractCherryPickBranchesFromLabels_CustomScheme(t *testing.T) {
+	scheme := LabelScheme{Prefix: "backport/", BranchTemplate: "stable/{version}"}
+	labels := []*github.Label{
+		{Name: new("backport/3.6")},
+		{Name: new("cherry-pick/3.7")},
+		{Name: new("bug")},
+	}
+
+	assert.Equal(t, []string{"stable/3.6"}, extractCherryPickBranchesFromLabels(labels, scheme))
+	assert.Equal(t, []string{"backport/3.6"}, filterCherryPickLabels(labels, scheme))
+}
+
+func TestAddLabelsToPR(t *testing.T) {
+	var got []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repos/acme/widget/issues/42/labels", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name": "cherry-pick/4.1"}]`))
+	})
+
+	client := newTestClient(t, mux)
+	require.NoError(t, client.AddLabelsToPR(t.Context(), 42, "cherry-pick/4.1"))
+	assert.Equal(t, []string{"cherry-pick/4.1"}, got)
+
+	require.Error(t, client.AddLabelsToPR(t.Context(), 7, "cherry-pick/4.1"), "404 should surface as an error")
+}
+
+func TestGetPRStateAndReopenPR(t *testing.T) {
+	var edited map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42, "state": "closed", "merged": false}`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/pulls/43", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 43, "state": "closed", "merged": true, "merged_at": "2024-01-15T10:30:00Z"}`))
+	})
+	mux.HandleFunc("PATCH /repos/acme/widget/pulls/42", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&edited); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"number": 42, "state": "open"}`))
+	})
+
+	client := newTestClient(t, mux)
+
+	open, merged, err := client.GetPRState(t.Context(), 42)
+	require.NoError(t, err)
+	assert.False(t, open)
+	assert.False(t, merged)
+
+	_, merged, err = client.GetPRState(t.Context(), 43)
+	require.NoError(t, err)
+	assert.True(t, merged)
+
+	_, _, err = client.GetPRState(t.Context(), 7)
+	require.ErrorIs(t, err, ErrNotFound, "404 should be classified like the other PR getters")
+
+	require.NoError(t, client.ReopenPR(t.Context(), 42))
+	assert.Equal(t, "open", edited["state"])
+
+	require.ErrorIs(t, client.ReopenPR(t.Context(), 7), ErrNotFound, "404 should surface as an error")
+}
+
+func TestGetOpenPRs_Draft(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"number": 10, "draft": true}, {"number": 11}]`))
+	})
+
+	prs, err := newTestClient(t, mux).GetOpenPRs(t.Context(), "release-1.0")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.True(t, prs[0].Draft)
+	assert.False(t, prs[1].Draft)
+}
+
 func TestFilterCherryPickLabels(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -96,7 +195,7 @@ func TestFilterCherryPickLabels(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := filterCherryPickLabels(tt.labels)
+			result := filterCherryPickLabels(tt.labels, LabelScheme{})
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -104,12 +203,13 @@ func TestFilterCherryPickLabels(t *testing.T) {
 
 func TestBuildSearchQuery(t *testing.T) {
 	tests := []struct {
-		name     string
-		org      string
-		repo     string
-		branch   string
-		labels   []string
-		expected string
+		name       string
+		org        string
+		repo       string
+		branch     string
+		labels     []string
+		qualifiers []string
+		expected   string
 	}{
 		{
 			name:     "single label",
@@ -135,11 +235,20 @@ func TestBuildSearchQuery(t *testing.T) {
 			labels:   []string{},
 			expected: "repo:test-org/test-repo is:pr is:merged base:main",
 		},
+		{
+			name:       "extra qualifiers after the fixed terms",
+			org:        "test-org",
+			repo:       "test-repo",
+			branch:     "main",
+			labels:     []string{"cherry-pick/3.6"},
+			qualifiers: []string{"author:octocat", "label:area/ui -label:wip"},
+			expected:   `repo:test-org/test-repo is:pr is:merged base:main label:cherry-pick/3.6 author:octocat label:area/ui -label:wip`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildSearchQuery(tt.org, tt.repo, tt.branch, tt.labels)
+			result := buildSearchQuery(tt.org, tt.repo, tt.branch, tt.labels, tt.qualifiers)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -209,3 +318,40 @@ func TestExtractRepoFromIssue(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPRDiff(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.github.v3.diff", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte("diff --git a/main.go b/main.go\n"))
+	})
+	client := newTestClient(t, mux)
+
+	diff, err := client.GetPRDiff(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "diff --git a/main.go b/main.go\n", diff)
+
+	_, err = client.GetPRDiff(t.Context(), 43)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCreatePR_Draft(t *testing.T) {
+	var drafts []bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repos/acme/widget/pulls", func(w http.ResponseWriter, r *http.Request) {
+		var req github.NewPullRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		drafts = append(drafts, req.GetDraft())
+		_, _ = fmt.Fprintf(w, `{"number": 7, "title": %q, "draft": %t}`, req.GetTitle(), req.GetDraft())
+	})
+	client := newTestClient(t, mux)
+
+	pr, err := client.CreatePR(t.Context(), "Fix", "body", "cherry-pick-42-release-1.0", "release-1.0", true)
+	require.NoError(t, err)
+	assert.True(t, pr.Draft)
+
+	pr, err = client.CreatePR(t.Context(), "Fix", "body", "cherry-pick-42-release-1.0", "release-1.0", false)
+	require.NoError(t, err)
+	assert.False(t, pr.Draft)
+	assert.Equal(t, []bool{true, false}, drafts)
+}
@@ -5,12 +5,20 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v80/github"
 )
 
-// ListTags gets all tags from the repository
+// ListTags gets all tags from the repository (cached per client, see ClearCache)
 func (c *Client) ListTags(ctx context.Context) ([]string, error) {
+	return cachedList(c, c.cacheKey("ListTags"), func() ([]string, error) {
+		return c.listTags(ctx)
+	})
+}
+
+// listTags reads all tags of the repository from GitHub
+func (c *Client) listTags(ctx context.Context) ([]string, error) {
 	tags, err := paginatedList(func(page int) ([]*github.RepositoryTag, *github.Response, error) {
 		opts := &github.ListOptions{
 			PerPage: 100,
@@ -20,7 +28,7 @@ func (c *Client) ListTags(ctx context.Context) ([]string, error) {
 		return c.client.Repositories.ListTags(ctx, c.org, c.repo, opts)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list tags: %w", err)
+		return nil, fmt.Errorf("failed to list tags: %w", apiError(err))
 	}
 
 	// Convert to string slice
@@ -32,8 +40,35 @@ func (c *Client) ListTags(ctx context.Context) ([]string, error) {
 	return tagNames, nil
 }
 
-// ListLabels fetches all labels from the repository
+// GetTagDate returns the committer date of the commit a tag points at
+func (c *Client) GetTagDate(ctx context.Context, tag string) (time.Time, error) {
+	slog.Debug("GitHub API: Getting tag commit", "org", c.org, "repo", c.repo, "tag", tag)
+	commit, _, err := c.client.Repositories.GetCommit(ctx, c.org, c.repo, "tags/"+tag, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get commit of tag %s: %w", tag, apiError(err))
+	}
+	return commit.GetCommit().GetCommitter().GetDate().Time, nil
+}
+
+// GetDefaultBranch returns the repository's default branch (e.g. "main", "master")
+func (c *Client) GetDefaultBranch(ctx context.Context) (string, error) {
+	slog.Debug("GitHub API: Getting repository", "org", c.org, "repo", c.repo)
+	repository, _, err := c.client.Repositories.Get(ctx, c.org, c.repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository %s/%s: %w", c.org, c.repo, apiError(err))
+	}
+	return repository.GetDefaultBranch(), nil
+}
+
+// ListLabels fetches all labels from the repository (cached per client, see ClearCache)
 func (c *Client) ListLabels(ctx context.Context) ([]*github.Label, error) {
+	return cachedList(c, c.cacheKey("ListLabels"), func() ([]*github.Label, error) {
+		return c.listLabels(ctx)
+	})
+}
+
+// listLabels reads all labels of the repository from GitHub
+func (c *Client) listLabels(ctx context.Context) ([]*github.Label, error) {
 	labels, err := paginatedList(func(page int) ([]*github.Label, *github.Response, error) {
 		opts := &github.ListOptions{
 			PerPage: 100,
@@ -43,85 +78,125 @@ func (c *Client) ListLabels(ctx context.Context) ([]*github.Label, error) {
 		return c.client.Issues.ListLabels(ctx, c.org, c.repo, opts)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list labels: %w", err)
+		return nil, fmt.Errorf("failed to list labels: %w", apiError(err))
 	}
 
 	return labels, nil
 }
 
-// GetCommitsSince gets commits on a branch since a specific tag/commit (equivalent to git log tag..branch)
-func (c *Client) GetCommitsSince(ctx context.Context, branch, sinceTag string) ([]Commit, error) {
-	var base string
+// GetCommitsSince gets commits on a branch since a specific tag/commit (equivalent to git log tag..branch).
+// For the initial "v0.0.0" base the whole branch history is listed, bounded by limit
+func (c *Client) GetCommitsSince(ctx context.Context, branch, sinceTag string, limit HistoryLimit) ([]Commit, error) {
+	var repoCommits []*github.RepositoryCommit
 
 	if sinceTag == "v0.0.0" {
-		// Special case for initial version - get all commits on the branch
-		// We'll use an empty base which means "from the beginning"
-		base = ""
-	} else {
-		// Use the tag as the base for comparison
-		base = sinceTag
-	}
-
-	// Use GitHub's Compare API to get commits between base and head
-	// This is equivalent to "git log base..head"
-	var comparison *github.CommitsComparison
-	var err error
-
-	if base == "" {
-		// For initial version, get all commits on the branch
-		commits, err := paginatedList(func(page int) ([]*github.RepositoryCommit, *github.Response, error) {
-			opts := &github.CommitsListOptions{
-				SHA: branch,
-				ListOptions: github.ListOptions{
-					PerPage: 100,
-					Page:    page,
-				},
-			}
-			slog.Debug("GitHub API: Listing commits", "org", c.org, "repo", c.repo, "branch", branch, "page", page)
-			return c.client.Repositories.ListCommits(ctx, c.org, c.repo, opts)
-		})
+		// Special case for initial version - list the branch history from the newest commit back
+		commits, err := c.listInitialHistory(ctx, branch, limit)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list commits: %w", err)
-		}
-
-		// Convert to our Commit type
-		var allCommits []Commit
-		for _, commit := range commits {
-			allCommits = append(allCommits, Commit{
-				SHA:     commit.GetSHA(),
-				Message: strings.Split(commit.GetCommit().GetMessage(), "\n")[0], // First line only
-				Author:  commit.GetCommit().GetAuthor().GetName(),
-				Date:    commit.GetCommit().GetAuthor().GetDate().Time,
-			})
+			return nil, err
 		}
-		return allCommits, nil
+		repoCommits = commits
 	} else {
-		// Use Compare API for tag..branch comparison
-		slog.Debug("GitHub API: Comparing commits", "org", c.org, "repo", c.repo, "base", base, "head", branch)
-		comparison, _, err = c.client.Repositories.CompareCommits(ctx, c.org, c.repo, base, branch, &github.ListOptions{
+		// Use GitHub's Compare API to get commits between base and head
+		// This is equivalent to "git log base..head"
+		slog.Debug("GitHub API: Comparing commits", "org", c.org, "repo", c.repo, "base", sinceTag, "head", branch)
+		comparison, _, err := c.client.Repositories.CompareCommits(ctx, c.org, c.repo, sinceTag, branch, &github.ListOptions{
 			PerPage: 100,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to compare %s..%s: %w", base, branch, err)
+			return nil, fmt.Errorf("failed to compare %s..%s: %w", sinceTag, branch, apiError(err))
 		}
+		repoCommits = comparison.Commits
 	}
 
 	// Convert GitHub commits to our Commit struct
 	var commits []Commit
-	for _, commit := range comparison.Commits {
+	for _, commit := range repoCommits {
 		commits = append(commits, Commit{
-			SHA:     commit.GetSHA(),
-			Message: strings.Split(commit.GetCommit().GetMessage(), "\n")[0], // First line only
-			Author:  commit.GetCommit().GetAuthor().GetName(),
-			Date:    commit.GetCommit().GetAuthor().GetDate().Time,
+			SHA:         commit.GetSHA(),
+			Message:     strings.Split(commit.GetCommit().GetMessage(), "\n")[0], // First line only
+			Author:      commit.GetCommit().GetAuthor().GetName(),
+			Date:        commit.GetCommit().GetAuthor().GetDate().Time,
+			CommittedAt: commit.GetCommit().GetCommitter().GetDate().Time,
+			Parents:     parentSHAs(commit.Parents),
 		})
 	}
 
 	return commits, nil
 }
 
-// ListReleases gets all releases from the repository, sorted by creation date (newest first)
+// listInitialHistory lists a branch's commits newest first, stopping at limit so a
+// long-lived branch's first scan doesn't page through its entire history
+func (c *Client) listInitialHistory(ctx context.Context, branch string, limit HistoryLimit) ([]*github.RepositoryCommit, error) {
+	maxCommits := limit.MaxCommits
+	if maxCommits <= 0 {
+		maxCommits = DefaultInitialHistoryMaxCommits
+	}
+
+	opts := &github.CommitsListOptions{
+		SHA: branch,
+		ListOptions: github.ListOptions{
+			PerPage: min(100, maxCommits),
+		},
+	}
+	if limit.Since != nil {
+		opts.Since = *limit.Since
+	}
+
+	var commits []*github.RepositoryCommit
+	for {
+		slog.Debug("GitHub API: Listing commits", "org", c.org, "repo", c.repo, "branch", branch, "page", opts.Page)
+		page, resp, err := c.client.Repositories.ListCommits(ctx, c.org, c.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits: %w", apiError(err))
+		}
+		commits = append(commits, page...)
+
+		if len(commits) >= maxCommits {
+			if len(commits) > maxCommits || resp.NextPage != 0 {
+				slog.Warn("Initial commit history truncated; raise --max-commits or initial_history_max_commits, or set initial_history_since, to change the window",
+					"branch", branch, "max_commits", maxCommits)
+			}
+			return commits[:maxCommits], nil
+		}
+		if resp.NextPage == 0 {
+			return commits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// FilterMergeCommits returns the commits that have at most one parent, dropping
+// "Merge branch" style commits that only add noise to release summaries
+func FilterMergeCommits(commits []Commit) []Commit {
+	var filtered []Commit
+	for _, commit := range commits {
+		if !commit.IsMerge() {
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered
+}
+
+// parentSHAs extracts the SHAs from a commit's parent list
+func parentSHAs(parents []*github.Commit) []string {
+	var shas []string
+	for _, parent := range parents {
+		shas = append(shas, parent.GetSHA())
+	}
+	return shas
+}
+
+// ListReleases gets all releases from the repository, sorted by creation date
+// (newest first). Cached per client, see ClearCache.
 func (c *Client) ListReleases(ctx context.Context) ([]Release, error) {
+	return cachedList(c, c.cacheKey("ListReleases"), func() ([]Release, error) {
+		return c.listReleases(ctx)
+	})
+}
+
+// listReleases reads all releases of the repository from GitHub
+func (c *Client) listReleases(ctx context.Context) ([]Release, error) {
 	releases, err := paginatedList(func(page int) ([]*github.RepositoryRelease, *github.Response, error) {
 		opts := &github.ListOptions{
 			PerPage: 100,
@@ -131,7 +206,7 @@ func (c *Client) ListReleases(ctx context.Context) ([]Release, error) {
 		return c.client.Repositories.ListReleases(ctx, c.org, c.repo, opts)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list releases: %w", err)
+		return nil, fmt.Errorf("failed to list releases: %w", apiError(err))
 	}
 
 	// Convert to our Release type
@@ -155,19 +230,34 @@ func (c *Client) GetCommitsBetweenTags(ctx context.Context, oldTag, newTag strin
 		PerPage: 250, // Get more commits per page for releases
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to compare %s..%s: %w", oldTag, newTag, err)
+		return nil, fmt.Errorf("failed to compare %s..%s: %w", oldTag, newTag, apiError(err))
 	}
 
 	// Convert GitHub commits to our Commit struct, including full message
 	var commits []Commit
 	for _, commit := range comparison.Commits {
 		commits = append(commits, Commit{
-			SHA:     commit.GetSHA(),
-			Message: commit.GetCommit().GetMessage(), // Full message to parse cherry-pick info
-			Author:  commit.GetCommit().GetAuthor().GetName(),
-			Date:    commit.GetCommit().GetAuthor().GetDate().Time,
+			SHA:         commit.GetSHA(),
+			Message:     commit.GetCommit().GetMessage(), // Full message to parse cherry-pick info
+			Author:      commit.GetCommit().GetAuthor().GetName(),
+			Date:        commit.GetCommit().GetAuthor().GetDate().Time,
+			CommittedAt: commit.GetCommit().GetCommitter().GetDate().Time,
+			Parents:     parentSHAs(commit.Parents),
 		})
 	}
 
 	return commits, nil
 }
+
+// DeleteBranch deletes a branch (e.g. a merged cherry-pick PR's head branch)
+// through the git data API
+func (c *Client) DeleteBranch(ctx context.Context, ref string) error {
+	if c.skipForDryRun("delete branch", "ref", ref) {
+		return nil
+	}
+	slog.Debug("GitHub API: Deleting branch", "org", c.org, "repo", c.repo, "ref", ref)
+	if _, err := c.client.Git.DeleteRef(ctx, c.org, c.repo, "heads/"+ref); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", ref, apiError(err))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMergeCommits(t *testing.T) {
+	commits := []Commit{
+		{SHA: "a", Message: "fix: one (#1)", Parents: []string{"p1"}},
+		{SHA: "b", Message: "Merge branch 'main'", Parents: []string{"p1", "p2"}},
+		{SHA: "c", Message: "root commit"},
+		{SHA: "d", Message: "Merge pull request #2", Parents: []string{"p3", "p4", "p5"}},
+	}
+
+	filtered := FilterMergeCommits(commits)
+
+	var shas []string
+	for _, c := range filtered {
+		shas = append(shas, c.SHA)
+	}
+	assert.Equal(t, []string{"a", "c"}, shas)
+	assert.Empty(t, FilterMergeCommits(nil))
+}
+
+// commitPages serves n commits at /repos/acme/widget/commits, paginated with Link headers
+func commitPages(t *testing.T, n int, requests *[]url.Values) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/commits", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*requests = append(*requests, q)
+		perPage, _ := strconv.Atoi(q.Get("per_page"))
+		page, _ := strconv.Atoi(q.Get("page"))
+		page = max(page, 1)
+
+		start := (page - 1) * perPage
+		end := min(start+perPage, n)
+		if end < n {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d&per_page=%d>; rel="next"`, r.URL.Path, page+1, perPage))
+		}
+		var items []string
+		for i := start; i < end; i++ {
+			items = append(items, fmt.Sprintf(`{"sha": "c%d", "commit": {"message": "commit %d\n\nbody"}}`, i, i))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	})
+	return mux
+}
+
+func TestGetCommitsSince_InitialHistoryLimit(t *testing.T) {
+	t.Run("truncates at max commits", func(t *testing.T) {
+		var requests []url.Values
+		client := newTestClient(t, commitPages(t, 500, &requests))
+
+		commits, err := client.GetCommitsSince(t.Context(), "release-1.0", "v0.0.0", HistoryLimit{MaxCommits: 150})
+		require.NoError(t, err)
+		require.Len(t, commits, 150)
+		assert.Equal(t, "c0", commits[0].SHA)
+		assert.Equal(t, "commit 0", commits[0].Message)
+		assert.Len(t, requests, 2, "stops paging once the cap is reached")
+	})
+
+	t.Run("default cap", func(t *testing.T) {
+		var requests []url.Values
+		client := newTestClient(t, commitPages(t, DefaultInitialHistoryMaxCommits+50, &requests))
+
+		commits, err := client.GetCommitsSince(t.Context(), "release-1.0", "v0.0.0", HistoryLimit{})
+		require.NoError(t, err)
+		assert.Len(t, commits, DefaultInitialHistoryMaxCommits)
+	})
+
+	t.Run("short history and since date", func(t *testing.T) {
+		var requests []url.Values
+		client := newTestClient(t, commitPages(t, 30, &requests))
+		since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		commits, err := client.GetCommitsSince(t.Context(), "release-1.0", "v0.0.0", HistoryLimit{MaxCommits: 150, Since: &since})
+		require.NoError(t, err)
+		assert.Len(t, commits, 30)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "release-1.0", requests[0].Get("sha"))
+		assert.Equal(t, "2024-01-01T00:00:00Z", requests[0].Get("since"))
+	})
+}
+
+func TestGetTagDate(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/commits/tags/v3.7.0", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"sha": "abc", "commit": {"committer": {"date": "2024-05-01T12:00:00Z"}}}`))
+	})
+	client := newTestClient(t, mux)
+
+	date, err := client.GetTagDate(t.Context(), "v3.7.0")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), date.UTC())
+
+	_, err = client.GetTagDate(t.Context(), "v9.9.9")
+	require.ErrorContains(t, err, "tag v9.9.9")
+}
+
+func TestDeleteBranch(t *testing.T) {
+	var deleted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /repos/acme/widget/git/refs/heads/", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/repos/acme/widget/git/refs/heads/"))
+		if strings.HasSuffix(r.URL.Path, "/gone") {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message":"Reference does not exist"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newTestClient(t, mux)
+
+	require.NoError(t, client.DeleteBranch(t.Context(), "cherry-pick-123-release-3.7"))
+	err := client.DeleteBranch(t.Context(), "gone")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete branch gone")
+	assert.Equal(t, []string{"cherry-pick-123-release-3.7", "gone"}, deleted)
+
+	require.NoError(t, client.WithDryRun(true).DeleteBranch(t.Context(), "cherry-pick-123-release-3.7"))
+	assert.Len(t, deleted, 2)
+}
+
+func TestGetDefaultBranch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "widget", "default_branch": "develop"}`))
+	})
+	client := newTestClient(t, mux)
+
+	branch, err := client.GetDefaultBranch(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "develop", branch)
+
+	_, err = client.WithRepository("acme", "missing").GetDefaultBranch(t.Context())
+	require.ErrorIs(t, err, ErrNotFound)
+}
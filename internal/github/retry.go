@@ -0,0 +1,157 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times NewClient's transport retries a rate-limited or failed request
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the first backoff delay when GitHub doesn't say how long to wait; it doubles per retry
+	DefaultRetryBaseDelay = time.Second
+	// maxRetryWait caps how long a single retry waits. A primary rate limit can
+	// be most of an hour away; rather than hang, the request fails as before.
+	maxRetryWait = 15 * time.Minute
+	// maxRateLimitBodyBytes is how much of a 403 body is read to tell a
+	// secondary rate limit apart from a permissions error
+	maxRateLimitBodyBytes = 64 << 10
+)
+
+// retryTransport retries requests GitHub rejected for rate limiting (403 with
+// rate limit headers or message, 429) and, for GET and HEAD requests, server
+// errors (5xx) unless the transport beneath retries those (HTTPOptions). It
+// waits for Retry-After or X-RateLimit-Reset when GitHub sends them and backs
+// off exponentially otherwise.
+type retryTransport struct {
+	base              http.RoundTripper
+	maxRetries        int
+	baseDelay         time.Duration
+	retryServerErrors bool
+	sleep             func(ctx context.Context, d time.Duration) error
+}
+
+// newRetryTransport wraps base (http.DefaultTransport if nil) with retries
+func newRetryTransport(base http.RoundTripper, maxRetries int, baseDelay time.Duration) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: base, maxRetries: maxRetries, baseDelay: baseDelay, retryServerErrors: true, sleep: sleepContext}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil || attempt >= t.maxRetries || !retryable(req, resp, t.retryServerErrors) {
+			return resp, err
+		}
+		// A request whose body can't be replayed is only ever sent once
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := t.retryDelay(resp, attempt)
+		if wait > maxRetryWait {
+			slog.Warn("GitHub asked to wait longer than the retry limit, giving up", "url", req.URL.Path, "status", resp.StatusCode, "wait", wait)
+			return resp, nil
+		}
+		slog.Warn("GitHub request rate limited or failed, retrying", "url", req.URL.Path, "status", resp.StatusCode,
+			"attempt", attempt+1, "max_retries", t.maxRetries, "wait", wait)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether resp is worth retrying. Rate limited requests were
+// never processed, so any method is retried; server errors only when
+// serverErrors is set and for methods that are safe to repeat, as a POST may
+// have taken effect.
+func retryable(req *http.Request, resp *http.Response, serverErrors bool) bool {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusForbidden:
+		return isRateLimited(resp)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return serverErrors && (req.Method == http.MethodGet || req.Method == http.MethodHead)
+	default:
+		return false
+	}
+}
+
+// isRateLimited tells a rate limited 403 apart from a permissions error, by its
+// headers or, for secondary rate limits, its message. The body is restored.
+func isRateLimited(resp *http.Response) bool {
+	if resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return true
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRateLimitBodyBytes))
+	rest := resp.Body
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), rest), rest}
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(body)), "rate limit")
+}
+
+// retryDelay returns how long to wait before retrying: Retry-After, then the
+// primary rate limit reset time, then exponential backoff from baseDelay
+func (t *retryTransport) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			// A second of slack for clock skew between us and GitHub
+			return max(time.Until(time.Unix(reset, 0))+time.Second, 0)
+		}
+	}
+	return t.baseDelay << attempt
+}
+
+// rewindRequest returns a copy of req with a fresh body for another attempt
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// sleepContext waits for d, returning early with the context's error if it is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRecordingRetryTransport returns a retry transport that records its waits instead of sleeping
+func newRecordingRetryTransport(maxRetries int, waits *[]time.Duration) *retryTransport {
+	rt := newRetryTransport(nil, maxRetries, 10*time.Millisecond)
+	rt.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return rt
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		fail      func(w http.ResponseWriter)
+		failures  int
+		wantCalls int32
+		wantCode  int
+		wantWaits []time.Duration
+	}{
+		{
+			name:   "secondary rate limit with Retry-After",
+			method: http.MethodGet,
+			fail: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			failures:  1,
+			wantCalls: 2,
+			wantCode:  http.StatusOK,
+			wantWaits: []time.Duration{7 * time.Second},
+		},
+		{
+			name:   "secondary rate limit message only",
+			method: http.MethodPost,
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit."}`))
+			},
+			failures:  2,
+			wantCalls: 3,
+			wantCode:  http.StatusOK,
+			wantWaits: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		{
+			name:      "429 backs off exponentially",
+			method:    http.MethodGet,
+			fail:      func(w http.ResponseWriter) { w.WriteHeader(http.StatusTooManyRequests) },
+			failures:  3,
+			wantCalls: 4,
+			wantCode:  http.StatusOK,
+			wantWaits: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond},
+		},
+		{
+			name:      "gives up after max retries",
+			method:    http.MethodGet,
+			fail:      func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+			failures:  10,
+			wantCalls: 4,
+			wantCode:  http.StatusBadGateway,
+			wantWaits: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond},
+		},
+		{
+			name:      "server error on POST is not retried",
+			method:    http.MethodPost,
+			fail:      func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+			failures:  1,
+			wantCalls: 1,
+			wantCode:  http.StatusBadGateway,
+		},
+		{
+			name:   "permission error is not retried",
+			method: http.MethodGet,
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+			},
+			failures:  1,
+			wantCalls: 1,
+			wantCode:  http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := calls.Add(1)
+				if r.Body != nil {
+					body, _ := io.ReadAll(r.Body)
+					if r.Method == http.MethodPost {
+						assert.Equal(t, `{"query": "q"}`, string(body), "body is replayed on every attempt")
+					}
+				}
+				if int(n) <= tt.failures {
+					tt.fail(w)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			t.Cleanup(server.Close)
+
+			var waits []time.Duration
+			client := &http.Client{Transport: newRecordingRetryTransport(3, &waits)}
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, server.URL, strings.NewReader(`{"query": "q"}`))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, tt.wantCode, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			assert.Equal(t, tt.wantWaits, waits)
+		})
+	}
+}
+
+func TestRetryTransport_RateLimitReset(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second)
+	resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	rt := newRetryTransport(nil, 3, time.Second)
+	wait := rt.retryDelay(resp, 0)
+
+	assert.InDelta(t, 31*time.Second, wait, float64(2*time.Second))
+}
+
+func TestRetryTransport_GivesUpOnLongWait(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newRecordingRetryTransport(3, &waits)}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, waits)
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"number": 42, "title": "Fix bug"}`))
+	})
+
+	client := newTestClient(t, mux).WithLabelScheme(LabelScheme{Prefix: "backport/"}).WithRetryPolicy(2, time.Millisecond)
+
+	pr, err := client.GetPR(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix bug", pr.Title)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, "backport/", client.LabelScheme().Prefix, "other options are kept")
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPOptions tunes the HTTP transport a client's requests go through,
+// independently of the rate limit handling in retryTransport
+type HTTPOptions struct {
+	// Timeout bounds each attempt's dial, TLS handshake and wait for response
+	// headers. It doesn't cover retry waits or reading the body; 0 means no limit.
+	Timeout time.Duration
+	// TransportRetries is how many times a GET or HEAD request that failed to
+	// connect or got a server error (5xx) is retried, beneath authentication.
+	// 0 leaves connection errors unretried and server errors to retryTransport.
+	TransportRetries int
+}
+
+// newHTTPTransport returns the transport requests are sent with: a copy of
+// http.DefaultTransport with opts' timeout, wrapped in connection/server error
+// retries when opts asks for them
+func newHTTPTransport(opts HTTPOptions) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.Timeout > 0 {
+		dialer := &net.Dialer{Timeout: opts.Timeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+		transport.TLSHandshakeTimeout = opts.Timeout
+		transport.ResponseHeaderTimeout = opts.Timeout
+	}
+	if opts.TransportRetries <= 0 {
+		return transport
+	}
+	return &errorRetryTransport{
+		base:       transport,
+		maxRetries: opts.TransportRetries,
+		baseDelay:  DefaultRetryBaseDelay,
+		sleep:      sleepContext,
+	}
+}
+
+// errorRetryTransport retries GET and HEAD requests that failed with a
+// connection error (including a timed out attempt) or a server error (5xx),
+// backing off exponentially from baseDelay
+type errorRetryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *errorRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if !idempotent || attempt >= t.maxRetries || req.Context().Err() != nil {
+			return resp, err
+		}
+		switch {
+		case err != nil:
+			if errors.Is(err, context.Canceled) {
+				return resp, err
+			}
+			slog.Warn("GitHub request failed to connect, retrying", "url", req.URL.Path, "error", err,
+				"attempt", attempt+1, "max_retries", t.maxRetries)
+		case resp.StatusCode >= http.StatusInternalServerError:
+			slog.Warn("GitHub request failed, retrying", "url", req.URL.Path, "status", resp.StatusCode,
+				"attempt", attempt+1, "max_retries", t.maxRetries)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if err := t.sleep(req.Context(), t.baseDelay<<attempt); err != nil {
+			return nil, err
+		}
+	}
+}
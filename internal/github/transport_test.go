@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestErrorRetryTransport(t *testing.T) {
+	errConn := errors.New("connection reset by peer")
+	tests := []struct {
+		name      string
+		method    string
+		failures  []error // nil entries answer 502
+		wantCalls int
+		wantErr   bool
+		wantCode  int
+	}{
+		{name: "connection error is retried", method: http.MethodGet, failures: []error{errConn}, wantCalls: 2, wantCode: http.StatusOK},
+		{name: "server error is retried", method: http.MethodGet, failures: []error{nil, nil}, wantCalls: 3, wantCode: http.StatusOK},
+		{name: "gives up after max retries", method: http.MethodGet, failures: []error{errConn, errConn, errConn, errConn}, wantCalls: 3, wantErr: true},
+		{name: "POST is sent once", method: http.MethodPost, failures: []error{errConn}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+				calls++
+				if calls <= len(tt.failures) {
+					if err := tt.failures[calls-1]; err != nil {
+						return nil, err
+					}
+					return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}, nil
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+			})
+			var waits []time.Duration
+			rt := &errorRetryTransport{base: base, maxRetries: 2, baseDelay: 10 * time.Millisecond, sleep: func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}}
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, "https://api.github.com/repos/acme/widget", nil)
+			require.NoError(t, err)
+			resp, err := rt.RoundTrip(req)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantCode, resp.StatusCode)
+				_ = resp.Body.Close()
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Len(t, waits, tt.wantCalls-1)
+		})
+	}
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	plain, ok := newHTTPTransport(HTTPOptions{}).(*http.Transport)
+	require.True(t, ok, "no retries means no retry wrapper")
+	assert.Zero(t, plain.ResponseHeaderTimeout)
+
+	tuned, ok := newHTTPTransport(HTTPOptions{Timeout: 20 * time.Second, TransportRetries: 2}).(*errorRetryTransport)
+	require.True(t, ok)
+	assert.Equal(t, 2, tuned.maxRetries)
+	transport, ok := tuned.base.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 20*time.Second, transport.ResponseHeaderTimeout)
+	assert.Equal(t, 20*time.Second, transport.TLSHandshakeTimeout)
+}
+
+func TestNewClientWithHTTPOptions_LeavesServerErrorsToTransport(t *testing.T) {
+	client := NewClientWithHTTPOptions(t.Context(), "test-token", HTTPOptions{TransportRetries: 2})
+	rt, ok := client.client.Client().Transport.(*retryTransport)
+	require.True(t, ok)
+	assert.False(t, rt.retryServerErrors, "5xx retries are not stacked on the transport's")
+	assert.False(t, client.WithRetryPolicy(1, time.Millisecond).client.Client().Transport.(*retryTransport).retryServerErrors,
+		"WithRetryPolicy keeps the setting")
+
+	rt, ok = NewClient(t.Context(), "test-token").client.Client().Transport.(*retryTransport)
+	require.True(t, ok)
+	assert.True(t, rt.retryServerErrors)
+}
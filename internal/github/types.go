@@ -7,20 +7,42 @@ type PR struct {
 	Number        int
 	Title         string
 	URL           string
+	Author        string // Login of the PR's author
 	SHA           string
+	HeadSHA       string // Head commit of the PR, whose CI CIStatus describes
 	Merged        bool
-	CIStatus      string   // "passing", "failing", "pending", or "unknown"
-	RunAttempt    int      // Maximum run_attempt from workflow runs (1 = first run, 2 = one retry, etc.)
-	FailingChecks []string // Names of failing CI checks (only populated when CIStatus is "failing")
-	CherryPickFor []string // Target branches extracted from cherry-pick/* labels
+	Draft         bool      // Whether the PR is still a draft (only populated for open PRs)
+	Mergeable     *bool     // Whether GitHub can merge the PR into its base; nil while GitHub is still computing it
+	MergedAt      time.Time // When the PR was merged (zero if unknown)
+	CIStatus      string    // "passing", "failing", "pending", or "unknown"
+	RunAttempt    int       // Maximum run_attempt from workflow runs (1 = first run, 2 = one retry, etc.)
+	FailingChecks []string  // Names of failing CI checks (only populated when CIStatus is "failing")
+	CherryPickFor []string  // Target branches extracted from cherry-pick/* labels
 }
 
 // Commit represents a commit from GitHub
 type Commit struct {
-	SHA     string
-	Message string
-	Author  string
-	Date    time.Time
+	SHA         string
+	Message     string
+	Author      string
+	Date        time.Time
+	CommittedAt time.Time // Committer date, i.e. when the commit landed on its branch
+	Parents     []string  // Parent commit SHAs (more than one indicates a merge commit)
+}
+
+// IsMerge reports whether the commit has more than one parent
+func (c Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// DefaultInitialHistoryMaxCommits caps the initial v0.0.0 history listing when no cap is configured
+const DefaultInitialHistoryMaxCommits = 1000
+
+// HistoryLimit bounds how much history GetCommitsSince lists when there is no
+// previous release to compare against (the initial "v0.0.0" base)
+type HistoryLimit struct {
+	MaxCommits int        // at most this many commits (DefaultInitialHistoryMaxCommits if 0)
+	Since      *time.Time // only commits after this date (no date limit if nil)
 }
 
 // CherryPickPR represents a cherry-pick PR created by a bot
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -14,7 +15,7 @@ func (c *Client) RetryFailedWorkflows(ctx context.Context, prNumber int) error {
 	slog.Debug("GitHub API: Getting PR for workflow retry", "org", c.org, "repo", c.repo, "pr", prNumber)
 	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, prNumber)
 	if err != nil {
-		return fmt.Errorf("failed to get PR #%d: %w", prNumber, err)
+		return fmt.Errorf("failed to get PR #%d: %w", prNumber, apiError(err))
 	}
 
 	headSHA := pr.GetHead().GetSHA()
@@ -22,7 +23,7 @@ func (c *Client) RetryFailedWorkflows(ctx context.Context, prNumber int) error {
 	// Get workflow runs for the PR's head commit
 	workflowRuns, err := c.getWorkflowRunsForCommit(ctx, headSHA)
 	if err != nil {
-		return fmt.Errorf("failed to get workflow runs for commit %s: %w", headSHA, err)
+		return fmt.Errorf("failed to get workflow runs for commit %s: %w", headSHA, apiError(err))
 	}
 
 	if len(workflowRuns) == 0 {
@@ -83,6 +84,10 @@ func (c *Client) getWorkflowRunsForCommit(ctx context.Context, sha string) ([]*g
 
 // retryWorkflowRun retries a specific workflow run by re-running failed jobs
 func (c *Client) retryWorkflowRun(ctx context.Context, runID int64) error {
+	if c.skipForDryRun("rerun failed jobs", "run_id", runID) {
+		return nil
+	}
+
 	// Try to re-run failed jobs first (more targeted approach)
 	slog.Debug("GitHub API: Rerunning failed jobs", "org", c.org, "repo", c.repo, "run_id", runID)
 	_, err := c.client.Actions.RerunFailedJobsByID(ctx, c.org, c.repo, runID)
@@ -98,19 +103,24 @@ func (c *Client) retryWorkflowRun(ctx context.Context, runID int64) error {
 	return nil
 }
 
-// MergePR merges a pull request using the specified merge method
-func (c *Client) MergePR(ctx context.Context, prNumber int, mergeMethod string) error {
+// MergePR merges a pull request using the specified merge method. allowUnstable
+// lets through PRs whose non-required checks are failing or pending (mergeable
+// state "unstable"), for callers whose --allow-ci accepts more than passing CI
+func (c *Client) MergePR(ctx context.Context, prNumber int, mergeMethod string, allowUnstable bool) error {
 	// Get the PR to find its head SHA for merge validation
 	slog.Debug("GitHub API: Getting PR for merge", "org", c.org, "repo", c.repo, "pr", prNumber)
 	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, prNumber)
 	if err != nil {
-		return fmt.Errorf("failed to get PR #%d: %w", prNumber, err)
+		return fmt.Errorf("failed to get PR #%d: %w", prNumber, apiError(err))
 	}
 
 	// Check if PR is mergeable (can be nil, true, or false)
 	if pr.Mergeable != nil && !*pr.Mergeable {
 		return fmt.Errorf("PR #%d is not mergeable (conflicts may exist)", prNumber)
 	}
+	if err := checkMergeableState(pr.GetMergeableState(), allowUnstable); err != nil {
+		return fmt.Errorf("PR #%d %w", prNumber, err)
+	}
 
 	// Prepare merge options with squash method
 	commitTitle := fmt.Sprintf("%s (#%d)", pr.GetTitle(), prNumber)
@@ -119,11 +129,15 @@ func (c *Client) MergePR(ctx context.Context, prNumber int, mergeMethod string)
 		MergeMethod: mergeMethod,
 	}
 
+	if c.skipForDryRun("merge PR", "pr", prNumber, "method", mergeMethod) {
+		return nil
+	}
+
 	// Perform the merge
 	slog.Debug("GitHub API: Merging PR", "org", c.org, "repo", c.repo, "pr", prNumber, "method", mergeMethod)
 	mergeResult, _, err := c.client.PullRequests.Merge(ctx, c.org, c.repo, prNumber, "", mergeOptions)
 	if err != nil {
-		return fmt.Errorf("failed to merge PR #%d: %w", prNumber, err)
+		return fmt.Errorf("failed to merge PR #%d: %w", prNumber, apiError(err))
 	}
 
 	if !mergeResult.GetMerged() {
@@ -133,8 +147,157 @@ func (c *Client) MergePR(ctx context.Context, prNumber int, mergeMethod string)
 	return nil
 }
 
+// checkMergeableState returns an error describing why a PR in the given
+// mergeable_state can't be merged. Only known-good states are allowed; an empty
+// or "unknown" state means GitHub hasn't computed it yet, so the merge call
+// itself decides.
+func checkMergeableState(state string, allowUnstable bool) error {
+	switch state {
+	case "", "unknown", "clean", "has_hooks":
+		return nil
+	case "unstable":
+		if allowUnstable {
+			return nil
+		}
+		return errors.New("has failing or pending checks (mergeable state unstable); use --allow-ci with unknown, no_checks or pending (e.g. --allow-ci passing,pending) to merge anyway")
+	case "dirty":
+		return errors.New("has merge conflicts with its base branch (mergeable state dirty)")
+	case "blocked":
+		return errors.New("is blocked by branch protection, e.g. missing reviews or required checks (mergeable state blocked)")
+	case "behind":
+		return errors.New("is behind its base branch and must be updated first (mergeable state behind)")
+	case "draft":
+		return errors.New("is a draft (mergeable state draft)")
+	default:
+		return fmt.Errorf("has unsupported mergeable state %q", state)
+	}
+}
+
+// enqueuePullRequestMutation adds a pull request to its base branch's merge queue
+const enqueuePullRequestMutation = `mutation($pullRequestId: ID!) {
+  enqueuePullRequest(input: {pullRequestId: $pullRequestId}) {
+    mergeQueueEntry { position }
+  }
+}`
+
+// graphQLRequest is the body of a GitHub GraphQL API request
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphQLError is a single entry of a GraphQL response's errors list
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+// enqueuePullRequestResponse is the GraphQL response to enqueuePullRequestMutation
+type enqueuePullRequestResponse struct {
+	Data   enqueuePullRequestData `json:"data"`
+	Errors []graphQLError         `json:"errors"`
+}
+
+// enqueuePullRequestData is the data payload of enqueuePullRequestResponse
+type enqueuePullRequestData struct {
+	EnqueuePullRequest enqueuePullRequestPayload `json:"enqueuePullRequest"`
+}
+
+// enqueuePullRequestPayload is the result of the enqueuePullRequest mutation
+type enqueuePullRequestPayload struct {
+	MergeQueueEntry mergeQueueEntry `json:"mergeQueueEntry"`
+}
+
+// mergeQueueEntry describes a PR's place in the merge queue
+type mergeQueueEntry struct {
+	Position int `json:"position"`
+}
+
+// EnqueuePR adds a pull request to the repository's merge queue instead of merging it
+// directly. Repositories that require a merge queue reject REST merges. It returns the
+// PR's position in the queue.
+func (c *Client) EnqueuePR(ctx context.Context, prNumber int) (int, error) {
+	// The GraphQL mutation needs the PR's node ID rather than its number
+	slog.Debug("GitHub API: Getting PR for merge queue", "org", c.org, "repo", c.repo, "pr", prNumber)
+	pr, _, err := c.client.PullRequests.Get(ctx, c.org, c.repo, prNumber)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get PR #%d: %w", prNumber, apiError(err))
+	}
+
+	if c.skipForDryRun("add PR to merge queue", "pr", prNumber) {
+		return 0, nil
+	}
+
+	body := &graphQLRequest{
+		Query:     enqueuePullRequestMutation,
+		Variables: map[string]any{"pullRequestId": pr.GetNodeID()},
+	}
+	req, err := c.client.NewRequest("POST", c.graphQLPath(), body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build merge queue request for PR #%d: %w", prNumber, err)
+	}
+
+	slog.Debug("GitHub API: Enqueuing PR", "org", c.org, "repo", c.repo, "pr", prNumber)
+	var resp enqueuePullRequestResponse
+	if _, err := c.client.Do(ctx, req, &resp); err != nil {
+		return 0, fmt.Errorf("failed to add PR #%d to merge queue: %w", prNumber, apiError(err))
+	}
+	if len(resp.Errors) > 0 {
+		return 0, fmt.Errorf("failed to add PR #%d to merge queue: %s", prNumber, resp.Errors[0].Message)
+	}
+
+	return resp.Data.EnqueuePullRequest.MergeQueueEntry.Position, nil
+}
+
+// isInMergeQueueQuery reads whether a pull request is in its base branch's merge queue
+const isInMergeQueueQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) { isInMergeQueue }
+  }
+}`
+
+// isInMergeQueueResponse is the GraphQL response to isInMergeQueueQuery
+type isInMergeQueueResponse struct {
+	Data struct {
+		Repository struct {
+			PullRequest *struct {
+				IsInMergeQueue bool `json:"isInMergeQueue"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+// IsInMergeQueue reports whether a pull request is still in the merge queue. The
+// queue drops a PR whose checks fail or that someone removes, leaving it open.
+func (c *Client) IsInMergeQueue(ctx context.Context, prNumber int) (bool, error) {
+	body := &graphQLRequest{
+		Query:     isInMergeQueueQuery,
+		Variables: map[string]any{"owner": c.org, "name": c.repo, "number": prNumber},
+	}
+	req, err := c.client.NewRequest("POST", c.graphQLPath(), body)
+	if err != nil {
+		return false, fmt.Errorf("failed to build merge queue query for PR #%d: %w", prNumber, err)
+	}
+
+	slog.Debug("GitHub API: Checking merge queue", "org", c.org, "repo", c.repo, "pr", prNumber)
+	var resp isInMergeQueueResponse
+	if _, err := c.client.Do(ctx, req, &resp); err != nil {
+		return false, fmt.Errorf("failed to check merge queue for PR #%d: %w", prNumber, apiError(err))
+	}
+	if len(resp.Errors) > 0 {
+		return false, fmt.Errorf("failed to check merge queue for PR #%d: %s", prNumber, resp.Errors[0].Message)
+	}
+	if resp.Data.Repository.PullRequest == nil {
+		return false, fmt.Errorf("PR #%d not found", prNumber)
+	}
+	return resp.Data.Repository.PullRequest.IsInMergeQueue, nil
+}
+
 // ApprovePR approves a pull request
 func (c *Client) ApprovePR(ctx context.Context, prNumber int) error {
+	if c.skipForDryRun("approve PR", "pr", prNumber) {
+		return nil
+	}
 	slog.Debug("GitHub API: Approving PR", "org", c.org, "repo", c.repo, "pr", prNumber)
 
 	review := &github.PullRequestReviewRequest{
@@ -143,7 +306,7 @@ func (c *Client) ApprovePR(ctx context.Context, prNumber int) error {
 
 	_, _, err := c.client.PullRequests.CreateReview(ctx, c.org, c.repo, prNumber, review)
 	if err != nil {
-		return fmt.Errorf("failed to approve PR #%d: %w", prNumber, err)
+		return fmt.Errorf("failed to approve PR #%d: %w", prNumber, apiError(err))
 	}
 
 	return nil
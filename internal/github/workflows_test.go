@@ -0,0 +1,197 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a Client for org/repo whose API calls go to handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	gh := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	gh.BaseURL = baseURL
+
+	return &Client{client: gh, org: "acme", repo: "widget"}
+}
+
+func TestEnqueuePR(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42, "node_id": "PR_node42"}`))
+	})
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Variables["pullRequestId"] != "PR_node42" {
+			_, _ = w.Write([]byte(`{"errors": [{"message": "unexpected pull request id"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"enqueuePullRequest": {"mergeQueueEntry": {"position": 3}}}}`))
+	})
+
+	client := newTestClient(t, mux)
+
+	position, err := client.EnqueuePR(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, 3, position)
+}
+
+func TestEnqueuePR_GraphQLError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42, "node_id": "PR_node42"}`))
+	})
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"errors": [{"message": "Merge queue is not enabled"}]}`))
+	})
+
+	client := newTestClient(t, mux)
+
+	_, err := client.EnqueuePR(t.Context(), 42)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Merge queue is not enabled")
+}
+
+func TestIsInMergeQueue(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch req.Variables["number"] {
+		case float64(42):
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"isInMergeQueue": true}}}}`))
+		case float64(43):
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"isInMergeQueue": false}}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": null}}}`))
+		}
+	})
+
+	client := newTestClient(t, mux)
+
+	queued, err := client.IsInMergeQueue(t.Context(), 42)
+	require.NoError(t, err)
+	assert.True(t, queued)
+
+	queued, err = client.IsInMergeQueue(t.Context(), 43)
+	require.NoError(t, err)
+	assert.False(t, queued)
+
+	_, err = client.IsInMergeQueue(t.Context(), 44)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestCheckMergeableState(t *testing.T) {
+	tests := []struct {
+		state         string
+		allowUnstable bool
+		wantErr       string
+	}{
+		{state: "clean"},
+		{state: "has_hooks"},
+		{state: "unknown"},
+		{state: ""},
+		{state: "unstable", wantErr: "--allow-ci with unknown, no_checks or pending"},
+		{state: "unstable", allowUnstable: true},
+		{state: "dirty", wantErr: "merge conflicts"},
+		{state: "dirty", allowUnstable: true, wantErr: "merge conflicts"},
+		{state: "blocked", wantErr: "branch protection"},
+		{state: "blocked", allowUnstable: true, wantErr: "branch protection"},
+		{state: "behind", wantErr: "behind its base branch"},
+		{state: "draft", wantErr: "is a draft"},
+		{state: "something_new", wantErr: `unsupported mergeable state "something_new"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			err := checkMergeableState(tt.state, tt.allowUnstable)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestMergePR_RefusesDirtyState(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42, "mergeable_state": "dirty"}`))
+	})
+	mux.HandleFunc("PUT /repos/acme/widget/pulls/42/merge", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("dirty PR should not be merged")
+	})
+
+	client := newTestClient(t, mux)
+
+	err := client.MergePR(t.Context(), 42, "squash", true)
+	require.Error(t, err)
+	assert.Equal(t, "PR #42 has merge conflicts with its base branch (mergeable state dirty)", err.Error())
+}
+
+func TestMergePR_AllowsUnstableWhenRequested(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42, "title": "Fix", "mergeable_state": "unstable"}`))
+	})
+	mux.HandleFunc("PUT /repos/acme/widget/pulls/42/merge", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"merged": true}`))
+	})
+
+	client := newTestClient(t, mux)
+
+	require.Error(t, client.MergePR(t.Context(), 42, "squash", false))
+	require.NoError(t, client.MergePR(t.Context(), 42, "squash", true))
+}
+
+func TestDryRun_SkipsMutations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/widget/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42, "title": "Fix", "mergeable_state": "clean", "head": {"sha": "abc123"}}`))
+	})
+	mux.HandleFunc("GET /repos/acme/widget/actions/runs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"total_count": 1, "workflow_runs": [{"id": 7, "conclusion": "failure"}]}`))
+	})
+	mux.HandleFunc("/", func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+	})
+
+	client := newTestClient(t, mux).WithDryRun(true)
+	require.True(t, client.DryRun())
+
+	pr, err := client.CreatePR(t.Context(), "[release-1.0] Fix", "body", "cherry-pick-42-release-1.0", "release-1.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, "[release-1.0] Fix", pr.Title)
+	assert.Zero(t, pr.Number)
+
+	require.NoError(t, client.MergePR(t.Context(), 42, "squash", false))
+	require.NoError(t, client.RetryFailedWorkflows(t.Context(), 42))
+	require.NoError(t, client.ApprovePR(t.Context(), 42))
+	require.NoError(t, client.ReopenPR(t.Context(), 42))
+	require.NoError(t, client.AddLabelsToPR(t.Context(), 42, "cherry-pick"))
+	position, err := client.EnqueuePR(t.Context(), 42)
+	require.NoError(t, err)
+	assert.Zero(t, position)
+	_, err = client.CreateIssueComment(t.Context(), 42, "summary")
+	require.NoError(t, err)
+}
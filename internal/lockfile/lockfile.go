@@ -4,8 +4,10 @@
 package lockfile
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"golang.org/x/sys/unix"
 )
@@ -15,6 +17,13 @@ type Lock struct {
 	file *os.File
 }
 
+// ErrTimeout is returned by AcquireTimeout when another process kept the lock
+// for the whole timeout
+var ErrTimeout = errors.New("timed out waiting for lock")
+
+// pollInterval is how often AcquireTimeout retries a lock held elsewhere
+const pollInterval = 50 * time.Millisecond
+
 // Acquire takes an exclusive (LOCK_EX) advisory lock on "<path>.lock" and
 // blocks until it is available. The lock is held until Release is called.
 //
@@ -22,18 +31,41 @@ type Lock struct {
 // the state file's inode changes on every atomic save (os.Rename), which would
 // invalidate a lock held on the original inode.
 func Acquire(path string) (*Lock, error) {
+	return AcquireTimeout(path, 0)
+}
+
+// AcquireTimeout is Acquire giving up with ErrTimeout once timeout has passed
+// without the lock becoming free. A timeout of 0 waits forever.
+func AcquireTimeout(path string, timeout time.Duration) (*Lock, error) {
 	lockPath := path + ".lock"
 	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600) //nolint:gosec // lock path derived from config flag
 	if err != nil {
 		return nil, fmt.Errorf("failed to open lock file %s: %w", lockPath, err)
 	}
-
-	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
-		_ = f.Close()
-		return nil, fmt.Errorf("failed to acquire lock on %s: %w", lockPath, err)
+	if timeout <= 0 {
+		if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to acquire lock on %s: %w", lockPath, err)
+		}
+		return &Lock{file: f}, nil
 	}
 
-	return &Lock{file: f}, nil
+	deadline := time.Now().Add(timeout)
+	for {
+		err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+		if err == nil {
+			return &Lock{file: f}, nil
+		}
+		if !errors.Is(err, unix.EWOULDBLOCK) {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to acquire lock on %s: %w", lockPath, err)
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, fmt.Errorf("%w on %s after %s", ErrTimeout, lockPath, timeout)
+		}
+		time.Sleep(pollInterval)
+	}
 }
 
 // Release unlocks and closes the sidecar lock file. It is safe to call once.
@@ -45,3 +45,22 @@ func TestReleaseIsIdempotentOnNil(t *testing.T) {
 	var l *Lock
 	require.NoError(t, l.Release())
 }
+
+func TestAcquireTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yaml")
+
+	first, err := Acquire(path)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = AcquireTimeout(path, 200*time.Millisecond)
+	require.ErrorIs(t, err, ErrTimeout)
+	require.ErrorContains(t, err, "state.yaml.lock after 200ms")
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	require.NoError(t, first.Release())
+
+	second, err := AcquireTimeout(path, 200*time.Millisecond)
+	require.NoError(t, err, "a free lock is taken at once")
+	require.NoError(t, second.Release())
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlack(t *testing.T) {
+	var got struct {
+		Text string `json:"text"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	require.NoError(t, Slack(t.Context(), server.URL+"/services/T000/B000/secret", "2 moved to merged"))
+	assert.Equal(t, "2 moved to merged", got.Text)
+}
+
+func TestSlack_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no_service"))
+	}))
+	defer server.Close()
+
+	err := Slack(t.Context(), server.URL+"/services/T000/B000/secret", "hi")
+	require.ErrorContains(t, err, "404 Not Found: no_service")
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestSlack_ConnectionErrorHidesURL(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	webhookURL := server.URL + "/services/T000/B000/secret"
+	server.Close()
+
+	err := Slack(t.Context(), webhookURL, "hi")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
@@ -0,0 +1,42 @@
+// Package output provides a writer that is safe to share between goroutines.
+// Commands that do work in parallel (fetch's worker pool, status --watch's
+// signal handler) send their logs and progress lines through Stderr, so a line
+// from one goroutine is never cut in half by another's.
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Writer serializes writes to an underlying writer: each Write reaches it whole,
+// before or after any concurrent Write, never interleaved with one
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter returns a Writer that serializes writes to w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes p to the underlying writer while holding the lock
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// Printf formats a message and writes it with a single Write, so it can't be
+// split by concurrent output the way several fmt.Fprint calls could be
+func (w *Writer) Printf(format string, args ...any) {
+	_, _ = w.Write([]byte(fmt.Sprintf(format, args...)))
+}
+
+// Stderr is the process's shared, synchronized stderr. The logger writes
+// through it; code that may run alongside other goroutines should write its
+// progress lines through it rather than to os.Stderr directly.
+var Stderr = NewWriter(os.Stderr)
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkedWriter writes each buffer a byte at a time, like a slow terminal, so
+// unsynchronized writers would interleave mid-line
+type chunkedWriter struct {
+	buf bytes.Buffer
+}
+
+func (c *chunkedWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		c.buf.WriteByte(b)
+	}
+	return len(p), nil
+}
+
+func TestWriter_ConcurrentLinesStayWhole(t *testing.T) {
+	target := &chunkedWriter{}
+	w := NewWriter(target)
+	logger := slog.New(slog.NewTextHandler(w, nil))
+
+	const goroutines, lines = 8, 50
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Go(func() {
+			for i := range lines {
+				if i%2 == 0 {
+					w.Printf("progress worker=%d line=%d\n", g, i)
+				} else {
+					logger.Info("log", "worker", g, "line", i)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	got := strings.Split(strings.TrimSuffix(target.buf.String(), "\n"), "\n")
+	require.Len(t, got, goroutines*lines)
+	seen := make(map[string]bool, len(got))
+	for _, line := range got {
+		switch {
+		case strings.HasPrefix(line, "progress "):
+			seen[strings.TrimPrefix(line, "progress ")] = true
+		case strings.Contains(line, "msg=log "):
+			_, fields, _ := strings.Cut(line, "msg=log ")
+			seen[fields] = true
+		default:
+			t.Errorf("garbled line %q", line)
+		}
+	}
+	for g := range goroutines {
+		for i := range lines {
+			assert.True(t, seen[fmt.Sprintf("worker=%d line=%d", g, i)], "worker %d line %d", g, i)
+		}
+	}
+}
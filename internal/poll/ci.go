@@ -0,0 +1,156 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/alan/cherry-picker/internal/types"
+)
+
+// CIPR is a cherry-pick PR whose CI a command waits on
+type CIPR struct {
+	OriginalPR   int
+	Branch       string
+	CherryPickPR int
+	Status       types.CIStatus
+	Polled       bool // Status was read during the wait; one carried in may be stale
+}
+
+// CIOptions controls UntilCIFinished
+type CIOptions struct {
+	Backoff  Backoff
+	Timeout  time.Duration
+	Settle   bool      // wait one interval before the first read, e.g. right after a re-run, when GitHub may still report the old runs
+	FailFast bool      // stop as soon as any PR fails instead of once every PR is final
+	Progress io.Writer // when set, gets a line per PR after each poll
+	// AfterPoll, when set, runs after each poll (e.g. to save the statuses read);
+	// an error ends the wait
+	AfterPoll func(ctx context.Context) error
+}
+
+// UntilCIFinished reads the CI of each PR with read until every PR is passing,
+// failing or without checks (or, with FailFast, any is failing) or
+// opts.Timeout elapses. A
+// PR is read again until read has succeeded once and its status is final;
+// read errors are logged and retried on the next poll. It then writes the
+// final state of each PR to w and returns an error naming the PRs that are
+// failing or didn't finish.
+func UntilCIFinished(ctx context.Context, w io.Writer, prs []*CIPR, opts CIOptions, read func(ctx context.Context, pr *CIPR) (types.CIStatus, error)) error {
+	if len(prs) == 0 {
+		return nil
+	}
+	if err := opts.Backoff.Validate(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	err := settle(ctx, opts)
+	if err == nil {
+		err = Until(ctx, opts.Backoff, func(ctx context.Context) (bool, error) {
+			return pollCI(ctx, prs, opts, read)
+		})
+	}
+	timedOut := errors.Is(err, context.DeadlineExceeded)
+	if err != nil && !timedOut {
+		return err
+	}
+	return ReportCI(w, prs, opts.Timeout, timedOut)
+}
+
+// settle waits one interval when opts.Settle is set
+func settle(ctx context.Context, opts CIOptions) error {
+	if !opts.Settle {
+		return nil
+	}
+	timer := time.NewTimer(opts.Backoff.Initial)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pollCI reads the PRs that haven't finished and reports whether the wait is done
+func pollCI(ctx context.Context, prs []*CIPR, opts CIOptions, read func(ctx context.Context, pr *CIPR) (types.CIStatus, error)) (bool, error) {
+	for _, pr := range prs {
+		if !pr.Polled || !CIFinished(pr.Status) {
+			status, err := read(ctx, pr)
+			if err != nil {
+				slog.Warn("Failed to read CI status, will retry", "cherry_pick_pr", pr.CherryPickPR, "error", err)
+			} else {
+				pr.Status = status
+				pr.Polled = true
+			}
+		}
+		if opts.Progress != nil {
+			fmt.Fprintf(opts.Progress, "%s PR #%d on %s: CI %s (cherry-pick PR #%d)\n", CIIcon(pr.Status), pr.OriginalPR, pr.Branch, pr.Status, pr.CherryPickPR)
+		}
+	}
+
+	if opts.AfterPoll != nil {
+		if err := opts.AfterPoll(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	allFinished := true
+	for _, pr := range prs {
+		if opts.FailFast && pr.Polled && pr.Status == types.CIStatusFailing {
+			return true, nil
+		}
+		allFinished = allFinished && pr.Polled && CIFinished(pr.Status)
+	}
+	return allFinished, nil
+}
+
+// CIFinished reports whether a CI status is final for a wait. A commit with
+// no checks has nothing to wait for.
+func CIFinished(status types.CIStatus) bool {
+	return status == types.CIStatusPassing || status == types.CIStatusFailing || status == types.CIStatusNoChecks
+}
+
+// CIIcon is the line marker for a CI status
+func CIIcon(status types.CIStatus) string {
+	switch status {
+	case types.CIStatusPassing:
+		return "✅"
+	case types.CIStatusFailing:
+		return "❌"
+	case types.CIStatusNoChecks:
+		return "⚪"
+	default:
+		return "⏳"
+	}
+}
+
+// ReportCI writes the final CI state of each PR to w and returns an error
+// naming the PRs that are failing, and those that hadn't finished when the
+// wait timed out after timeout. Unfinished PRs of a wait that stopped at a
+// failure are listed without an error.
+func ReportCI(w io.Writer, prs []*CIPR, timeout time.Duration, timedOut bool) error {
+	var errs []error
+	for _, pr := range prs {
+		switch {
+		case pr.Status == types.CIStatusPassing:
+			fmt.Fprintf(w, "✅ PR #%d on %s: CI passing (cherry-pick PR #%d)\n", pr.OriginalPR, pr.Branch, pr.CherryPickPR)
+		case pr.Status == types.CIStatusFailing:
+			fmt.Fprintf(w, "❌ PR #%d on %s: CI failing (cherry-pick PR #%d)\n", pr.OriginalPR, pr.Branch, pr.CherryPickPR)
+			errs = append(errs, fmt.Errorf("CI failing on cherry-pick PR #%d", pr.CherryPickPR))
+		case pr.Status == types.CIStatusNoChecks:
+			fmt.Fprintf(w, "⚪ PR #%d on %s: no CI checks (cherry-pick PR #%d)\n", pr.OriginalPR, pr.Branch, pr.CherryPickPR)
+		case !timedOut:
+			fmt.Fprintf(w, "⏳ PR #%d on %s: CI %s (cherry-pick PR #%d)\n", pr.OriginalPR, pr.Branch, pr.Status, pr.CherryPickPR)
+		default:
+			fmt.Fprintf(w, "⏳ PR #%d on %s: CI not finished after %s (cherry-pick PR #%d)\n", pr.OriginalPR, pr.Branch, timeout, pr.CherryPickPR)
+			errs = append(errs, fmt.Errorf("timed out after %s waiting for CI on cherry-pick PR #%d", timeout, pr.CherryPickPR))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package poll
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readQueue answers reads from a queue of statuses per cherry-pick PR, pending once drained
+func readQueue(statuses map[int][]types.CIStatus, reads map[int]int) func(context.Context, *CIPR) (types.CIStatus, error) {
+	return func(_ context.Context, pr *CIPR) (types.CIStatus, error) {
+		reads[pr.CherryPickPR]++
+		queue := statuses[pr.CherryPickPR]
+		if len(queue) == 0 {
+			return types.CIStatusPending, nil
+		}
+		statuses[pr.CherryPickPR] = queue[1:]
+		return queue[0], nil
+	}
+}
+
+func testCIPRs() []*CIPR {
+	return []*CIPR{
+		{OriginalPR: 100, Branch: "release-1.0", CherryPickPR: 201},
+		{OriginalPR: 100, Branch: "release-1.1", CherryPickPR: 202, Status: types.CIStatusFailing},
+	}
+}
+
+func TestUntilCIFinished(t *testing.T) {
+	opts := CIOptions{Backoff: Backoff{Initial: time.Millisecond}, Timeout: time.Second}
+
+	t.Run("waits for every PR", func(t *testing.T) {
+		reads := map[int]int{}
+		read := readQueue(map[int][]types.CIStatus{201: {"pending", "passing"}, 202: {"failing"}}, reads)
+
+		var out bytes.Buffer
+		err := UntilCIFinished(t.Context(), &out, testCIPRs(), opts, read)
+		require.ErrorContains(t, err, "CI failing on cherry-pick PR #202")
+		assert.Equal(t, "✅ PR #100 on release-1.0: CI passing (cherry-pick PR #201)\n"+
+			"❌ PR #100 on release-1.1: CI failing (cherry-pick PR #202)\n", out.String())
+		assert.Equal(t, map[int]int{201: 2, 202: 1}, reads, "a carried-in status is read once, finished PRs aren't read again")
+	})
+
+	t.Run("fail fast stops at the first failure", func(t *testing.T) {
+		reads := map[int]int{}
+		read := readQueue(map[int][]types.CIStatus{202: {"failing"}}, reads)
+		failFast := opts
+		failFast.FailFast = true
+
+		var out bytes.Buffer
+		err := UntilCIFinished(t.Context(), &out, testCIPRs(), failFast, read)
+		require.ErrorContains(t, err, "CI failing on cherry-pick PR #202")
+		assert.NotContains(t, err.Error(), "timed out")
+		assert.Contains(t, out.String(), "⏳ PR #100 on release-1.0: CI pending (cherry-pick PR #201)\n")
+		assert.Equal(t, 1, reads[201])
+	})
+
+	t.Run("progress and after-poll run each poll", func(t *testing.T) {
+		polls := 0
+		var progress bytes.Buffer
+		withHooks := opts
+		withHooks.Progress = &progress
+		withHooks.AfterPoll = func(context.Context) error {
+			polls++
+			return nil
+		}
+		read := readQueue(map[int][]types.CIStatus{201: {"pending", "passing"}, 202: {"passing"}}, map[int]int{})
+
+		require.NoError(t, UntilCIFinished(t.Context(), &bytes.Buffer{}, testCIPRs(), withHooks, read))
+		assert.Equal(t, 2, polls)
+		assert.Contains(t, progress.String(), "⏳ PR #100 on release-1.0: CI pending (cherry-pick PR #201)\n")
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		short := CIOptions{Backoff: Backoff{Initial: time.Millisecond}, Timeout: 20 * time.Millisecond, Settle: true}
+		read := readQueue(map[int][]types.CIStatus{}, map[int]int{})
+
+		var out bytes.Buffer
+		err := UntilCIFinished(t.Context(), &out, testCIPRs()[:1], short, read)
+		require.ErrorContains(t, err, "timed out after 20ms waiting for CI on cherry-pick PR #201")
+		assert.Equal(t, "⏳ PR #100 on release-1.0: CI not finished after 20ms (cherry-pick PR #201)\n", out.String())
+	})
+
+	t.Run("no checks is final", func(t *testing.T) {
+		reads := map[int]int{}
+		read := readQueue(map[int][]types.CIStatus{201: {"no_checks"}, 202: {"passing"}}, reads)
+
+		var out bytes.Buffer
+		require.NoError(t, UntilCIFinished(t.Context(), &out, testCIPRs(), opts, read))
+		assert.Equal(t, "⚪ PR #100 on release-1.0: no CI checks (cherry-pick PR #201)\n"+
+			"✅ PR #100 on release-1.1: CI passing (cherry-pick PR #202)\n", out.String())
+		assert.Equal(t, 1, reads[201])
+	})
+
+	t.Run("nothing to wait for", func(t *testing.T) {
+		require.NoError(t, UntilCIFinished(t.Context(), &bytes.Buffer{}, nil, CIOptions{}, nil))
+	})
+}
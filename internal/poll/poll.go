@@ -0,0 +1,60 @@
+// Package poll runs a check repeatedly with exponential backoff between tries,
+// for commands that wait on GitHub (CI, merges) to reach a final state.
+package poll
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Backoff is the schedule Until polls on: Initial after the first check,
+// doubling after each further one up to Max
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration // Initial when smaller
+}
+
+// Validate checks that the schedule waits between tries
+func (b Backoff) Validate() error {
+	if b.Initial <= 0 {
+		return fmt.Errorf("poll interval must be positive, got %s", b.Initial)
+	}
+	return nil
+}
+
+// delay returns how long to wait after the attempt-th check (0 for the first)
+func (b Backoff) delay(attempt int) time.Duration {
+	limit := max(b.Max, b.Initial)
+	d := b.Initial
+	for range attempt {
+		if d >= limit/2 {
+			return limit
+		}
+		d *= 2
+	}
+	return d
+}
+
+// Until calls check, waiting per b between calls, until it reports done or
+// fails. It returns ctx's error when ctx ends first; give ctx a deadline to
+// bound the wait.
+func Until(ctx context.Context, b Backoff, check func(ctx context.Context) (bool, error)) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	for attempt := 0; ; attempt++ {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		timer := time.NewTimer(b.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
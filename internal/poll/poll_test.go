@@ -0,0 +1,60 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	b := Backoff{Initial: time.Second, Max: 5 * time.Second}
+	var delays []time.Duration
+	for attempt := range 5 {
+		delays = append(delays, b.delay(attempt))
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+
+	assert.Equal(t, time.Second, Backoff{Initial: time.Second}.delay(3), "no Max keeps the interval fixed")
+}
+
+func TestUntil(t *testing.T) {
+	b := Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond}
+
+	t.Run("stops once done", func(t *testing.T) {
+		calls := 0
+		err := Until(t.Context(), b, func(context.Context) (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := Until(t.Context(), b, func(context.Context) (bool, error) {
+			return false, boom
+		})
+		require.ErrorIs(t, err, boom)
+	})
+
+	t.Run("stops at the deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		err := Until(ctx, b, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("rejects a zero interval", func(t *testing.T) {
+		err := Until(t.Context(), Backoff{}, func(context.Context) (bool, error) {
+			return true, nil
+		})
+		require.Error(t, err)
+	})
+}
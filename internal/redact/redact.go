@@ -0,0 +1,94 @@
+// Package redact scrubs credentials out of text before it reaches the logs, so
+// debug output can be pasted into bug reports safely.
+package redact
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Mask replaces every redacted secret
+const Mask = "***"
+
+// minSecretLen keeps very short values from being registered and masking ordinary text
+const minSecretLen = 8
+
+// tokenPatterns match credentials that are recognizable on their own
+var tokenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{20,}`), // GitHub tokens
+	regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{20,}`),             // GitHub fine-grained tokens
+}
+
+// contextPatterns match credentials by what surrounds them; group 1 is kept
+var contextPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)([?&](?:access_token|token|client_secret|password)=)[^&\s"']+`), // query parameters
+	regexp.MustCompile(`(?i)(authorization:\s*(?:bearer|token|basic)\s+)[^\s"']+`),          // Authorization headers
+	regexp.MustCompile(`(?i)(\bbearer\s+)[^\s"']+`),                                         // bare bearer credentials
+	regexp.MustCompile(`(://[^/\s:@]+:)[^@\s/]+@`),                                          // URL userinfo passwords
+}
+
+var (
+	mu      sync.RWMutex
+	secrets []string
+)
+
+// Register adds a known secret (such as the GitHub token in use) to be masked
+// wherever it appears, even if it matches none of the built-in patterns
+func Register(secret string) {
+	if len(secret) < minSecretLen {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, s := range secrets {
+		if s == secret {
+			return
+		}
+	}
+	secrets = append(secrets, secret)
+}
+
+// String returns s with registered secrets and token-like substrings replaced by Mask
+func String(s string) string {
+	mu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	mu.RUnlock()
+
+	for _, p := range tokenPatterns {
+		s = p.ReplaceAllString(s, Mask)
+	}
+	for _, p := range contextPatterns {
+		s = p.ReplaceAllStringFunc(s, func(match string) string {
+			kept := p.FindStringSubmatch(match)[1]
+			if strings.HasSuffix(match, "@") {
+				return kept + Mask + "@"
+			}
+			return kept + Mask
+		})
+	}
+	return s
+}
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr that redacts every attribute,
+// including the message. Non-string values are only rewritten when redaction
+// changes their formatted form, so ordinary structured values keep their type.
+func ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(String(a.Value.String()))
+	case slog.KindAny:
+		formatted := fmt.Sprint(a.Value.Any())
+		if redacted := String(formatted); redacted != formatted {
+			a.Value = slog.StringValue(redacted)
+		}
+	case slog.KindBool, slog.KindDuration, slog.KindFloat64, slog.KindInt64,
+		slog.KindUint64, slog.KindTime, slog.KindGroup, slog.KindLogValuer:
+		// Cannot carry a credential (groups are visited member by member)
+	}
+	return a
+}
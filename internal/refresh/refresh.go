@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/cmd/fetch"
 	"github.com/alan/cherry-picker/internal/depmerger"
 	"github.com/alan/cherry-picker/internal/github"
@@ -21,15 +22,53 @@ import (
 // applies whatever each produced, sets LastFetchDate last, and returns the
 // joined errors. Callers persist the result via state.Update.
 func All(ctx context.Context, client *github.Client, c *state.Config) error {
+	return AllSince(ctx, client, c, Window{})
+}
+
+// Window is where the cherry-pick search of each repository starts, from
+// fetch --since or --since-tag. The zero Window keeps the default: the last
+// fetch date.
+type Window struct {
+	Since time.Time // a fixed start
+	Tag   string    // the commit date of this tag, looked up in each repository
+}
+
+// start returns the start of the search for view, read through client
+func (w Window) start(ctx context.Context, client *github.Client, view *cmd.Config) (time.Time, error) {
+	switch {
+	case w.Tag != "":
+		return fetch.ResolveSince(ctx, client, "", w.Tag, nil)
+	case !w.Since.IsZero():
+		return w.Since, nil
+	default:
+		return fetch.SinceForFetch(view)
+	}
+}
+
+// AllSince is All with the cherry-pick search of each repository starting where
+// window says instead of at the last fetch date
+func AllSince(ctx context.Context, client *github.Client, c *state.Config, window Window) error {
 	var errs []error
 
-	// Cherry-picks. Compute the search window from LastFetchDate before it is
-	// overwritten below.
+	// Cherry-picks, one repository at a time. Compute the search window from
+	// LastFetchDate before it is overwritten below.
 	cv := c.CherryView()
-	if since, err := fetch.SinceForFetch(cv); err != nil {
-		errs = append(errs, fmt.Errorf("cherry-pick since date: %w", err))
-	} else if err := fetch.RefreshCherry(ctx, client, cv, since); err != nil {
-		errs = append(errs, fmt.Errorf("cherry-pick refresh: %w", err))
+	views := cv.RepositoryViews()
+	for i, view := range views {
+		repoCtx, repoClient := ctx, client
+		if len(views) > 1 {
+			repoCtx = fetch.WithEventRepo(ctx, view.Org+"/"+view.Repo)
+			repoClient = client.WithRepository(view.Org, view.Repo)
+		}
+		repoSince, err := window.start(repoCtx, repoClient, view)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cherry-pick since date of %s/%s: %w", view.Org, view.Repo, err))
+			continue
+		}
+		if err := fetch.RefreshCherry(repoCtx, repoClient, view, repoSince); err != nil {
+			errs = append(errs, fmt.Errorf("cherry-pick refresh of %s/%s: %w", view.Org, view.Repo, err))
+		}
+		cv.ApplyRepositoryView(i, view)
 	}
 	c.ApplyCherryView(cv)
 
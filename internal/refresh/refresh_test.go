@@ -0,0 +1,56 @@
+package refresh
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alan/cherry-picker/cmd"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowStart(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/acme/widget/commits/tags/v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"sha": "abc", "commit": {"committer": {"date": "2025-03-01T10:00:00Z"}}}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/acme/gadget/commits/tags/v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"sha": "def", "commit": {"committer": {"date": "2025-04-01T10:00:00Z"}}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client, err := github.NewEnterpriseClient(t.Context(), "test-token", server.URL+"/api/v3", github.HTTPOptions{})
+	require.NoError(t, err)
+
+	lastFetch := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	view := &cmd.Config{LastFetchDate: &lastFetch}
+
+	t.Run("tag is looked up in each repository", func(t *testing.T) {
+		window := Window{Tag: "v1.0.0"}
+
+		since, err := window.start(t.Context(), client.WithRepository("acme", "widget"), view)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), since.UTC())
+
+		since, err = window.start(t.Context(), client.WithRepository("acme", "gadget"), view)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC), since.UTC())
+
+		_, err = window.start(t.Context(), client.WithRepository("acme", "other"), view)
+		require.Error(t, err, "a repository without the tag fails on its own")
+	})
+
+	t.Run("fixed date and default", func(t *testing.T) {
+		fixed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		since, err := Window{Since: fixed}.start(t.Context(), client, view)
+		require.NoError(t, err)
+		assert.Equal(t, fixed, since)
+
+		since, err = Window{}.start(t.Context(), client, view)
+		require.NoError(t, err)
+		assert.Equal(t, lastFetch, since)
+	})
+}
@@ -2,6 +2,7 @@ package state
 
 import (
 	"maps"
+	"slices"
 	"time"
 
 	"github.com/alan/cherry-picker/cmd"
@@ -12,14 +13,17 @@ import (
 // full fetch snapshot from the daemon, or a single-subsystem view mutated by a
 // CLI command) onto the freshly-reloaded on-disk state. They are monotonic:
 // terminal user actions (a merged branch, an approved dep PR) are never
-// regressed by a stale-but-concurrent writer. CI freshness follows rank — the
-// incoming data wins when its status rank is at least the current one, so the
-// daemon keeps refreshing CI while never downgrading an advanced PR.
+// regressed by a stale-but-concurrent writer. The one step back is a queued
+// branch whose PR left the merge queue (see leftMergeQueue). CI freshness
+// follows rank — the incoming data wins when its status rank is at least the
+// current one, so the daemon keeps refreshing CI while never downgrading an
+// advanced PR.
 //
 // Deletions are handled asymmetrically. A fetch snapshot (MergeFetched) is a
 // full GitHub scrape, so a pending/failed branch absent from it means the
-// cherry-pick label was removed upstream — those are deleted, and PRs left
-// with no branches are dropped. Branches at picked or beyond are never
+// cherry-pick label was removed upstream — those are deleted (unless the
+// on_label_removed policy is "keep"), and PRs left with no branches are
+// dropped. Branches at picked or beyond are never
 // deleted, so a stale snapshot cannot erase a user action that landed
 // mid-tick. Command views (MergeCherryView) never remove entries, so their
 // merge stays purely additive and a concurrent daemon write survives a
@@ -33,10 +37,12 @@ func branchRank(s cmd.BranchStatusType) int {
 		return 1
 	case cmd.BranchStatusPicked:
 		return 2
-	case cmd.BranchStatusMerged:
+	case cmd.BranchStatusQueued:
 		return 3
-	case cmd.BranchStatusReleased:
+	case cmd.BranchStatusMerged:
 		return 4
+	case cmd.BranchStatusReleased:
+		return 5
 	default:
 		return 0
 	}
@@ -57,9 +63,12 @@ func (c *Config) MergeCherryView(v *cmd.Config) {
 	mergeCherrySection(&c.CherryPicks, CherryPickSection{
 		SourceBranch:       v.SourceBranch,
 		AIAssistantCommand: v.AIAssistantCommand,
+		OnLabelRemoved:     v.OnLabelRemoved,
 		LastCheckedRelease: v.LastCheckedRelease,
+		UnscannedReleases:  v.UnscannedReleases,
 		TrackerIssues:      v.TrackerIssues,
 		TrackedPRs:         v.TrackedPRs,
+		Repositories:       v.Repositories,
 	}, false)
 }
 
@@ -69,6 +78,10 @@ func (c *Config) MergeDepView(v *depmerger.Config) {
 	mergeDepSection(&c.Dependencies, DependencySection{TrackedPRs: v.TrackedPRs})
 }
 
+// applyShared copies the shared fields a view may have changed. token_env_var,
+// base_url, http_timeout, http_retries, rate_limit_*, ignored_ci_contexts and
+// graphql_ci_status are only ever edited by hand, so they are deliberately
+// left alone.
 func (c *Config) applyShared(org, repo string, date *time.Time) {
 	if org != "" {
 		c.Org = org
@@ -88,9 +101,55 @@ func mergeCherrySection(cur *CherryPickSection, in CherryPickSection, authoritat
 	if in.AIAssistantCommand != "" {
 		cur.AIAssistantCommand = in.AIAssistantCommand
 	}
+	if in.OnLabelRemoved != "" {
+		cur.OnLabelRemoved = in.OnLabelRemoved
+	}
+	// use_merge_queue, merge_method, delete_merged_branches, commit_trailers,
+	// the initial_history_* limits, new_branch_base, head_branch_pattern,
+	// label_prefix, branch_template, title_format, post_fetch_command, fetch_concurrency,
+	// ignored_checks, pending_grace_period, stale_after, manual_search_max_candidates,
+	// ai_assistant_auto_launch, signoff, signoff_identity, release_label,
+	// slack_webhook_url, required_checks, conflict_strategy and
+	// conflict_auto_resolve are only ever edited by hand, so the on-disk value
+	// wins over whatever a view loaded earlier.
 	cur.LastCheckedRelease = mergeStringMap(cur.LastCheckedRelease, in.LastCheckedRelease)
+	// Only a fetch rescans releases, and it clears ranges it managed to scan,
+	// so its snapshot replaces the list rather than being unioned into it.
+	if authoritative {
+		cur.UnscannedReleases = in.UnscannedReleases
+	}
 	cur.TrackerIssues = mergeIntMap(cur.TrackerIssues, in.TrackerIssues)
-	cur.TrackedPRs = mergeCherryTracked(cur.TrackedPRs, in.TrackedPRs, authoritative)
+	// Under the "keep" policy fetch never drops a branch, so the snapshot is
+	// not authoritative for deletions.
+	dropRemoved := authoritative && cur.OnLabelRemoved != cmd.LabelRemovedKeep
+	cur.TrackedPRs = mergeCherryTracked(cur.TrackedPRs, in.TrackedPRs, dropRemoved)
+	cur.Repositories = mergeRepositories(cur.Repositories, in.Repositories, authoritative, dropRemoved)
+}
+
+// mergeRepositories merges the further repositories' tracking state entry by
+// entry, matched on org/repo, with the same rules as the top-level section.
+// Entries are only ever added; removing a repository is a hand edit.
+func mergeRepositories(cur, in []cmd.RepoConfig, authoritative, dropRemoved bool) []cmd.RepoConfig {
+	for _, inRepo := range in {
+		i := slices.IndexFunc(cur, func(r cmd.RepoConfig) bool {
+			return r.Org == inRepo.Org && r.Repo == inRepo.Repo
+		})
+		if i < 0 {
+			cur = append(cur, inRepo)
+			continue
+		}
+		curRepo := &cur[i]
+		if inRepo.SourceBranch != "" {
+			curRepo.SourceBranch = inRepo.SourceBranch
+		}
+		curRepo.LastCheckedRelease = mergeStringMap(curRepo.LastCheckedRelease, inRepo.LastCheckedRelease)
+		if authoritative {
+			curRepo.UnscannedReleases = inRepo.UnscannedReleases
+		}
+		curRepo.TrackerIssues = mergeIntMap(curRepo.TrackerIssues, inRepo.TrackerIssues)
+		curRepo.TrackedPRs = mergeCherryTracked(curRepo.TrackedPRs, inRepo.TrackedPRs, dropRemoved)
+	}
+	return cur
 }
 
 func mergeCherryTracked(cur, in []cmd.TrackedPR, authoritative bool) []cmd.TrackedPR {
@@ -115,6 +174,12 @@ func mergeCherryTracked(cur, in []cmd.TrackedPR, authoritative bool) []cmd.Track
 		if inPR.Title != "" {
 			curPR.Title = inPR.Title
 		}
+		if inPR.Author != "" {
+			curPR.Author = inPR.Author
+		}
+		if inPR.MergedAt != nil {
+			curPR.MergedAt = inPR.MergedAt
+		}
 		if curPR.Branches == nil && len(inPR.Branches) > 0 {
 			curPR.Branches = make(map[string]cmd.BranchStatus, len(inPR.Branches))
 		}
@@ -122,10 +187,23 @@ func mergeCherryTracked(cur, in []cmd.TrackedPR, authoritative bool) []cmd.Track
 			curBranch, exists := curPR.Branches[name]
 			// Take the incoming branch when it is at least as advanced as the
 			// current one; keep the current (more advanced) one otherwise.
-			if !exists || branchRank(inBranch.Status) >= branchRank(curBranch.Status) {
-				curPR.Branches[name] = inBranch
+			if !exists || branchRank(inBranch.Status) >= branchRank(curBranch.Status) || leftMergeQueue(inBranch, curBranch) {
+				curPR.Branches[name] = withLastUpdated(withResolutionMethod(inBranch, curBranch), curBranch, exists)
 			}
 		}
+		// Only ignore/unignore (and rename-branch) change the ignore list, and
+		// only set-release the release annotation; each stamps its change, so
+		// a writer that loaded the state before it carries an older stamp and
+		// leaves it alone.
+		if inPR.IgnoredBranchesUpdated.After(curPR.IgnoredBranchesUpdated) {
+			curPR.IgnoredBranches = inPR.IgnoredBranches
+			curPR.IgnoredBranchesUpdated = inPR.IgnoredBranchesUpdated
+		}
+		if inPR.ReleaseUpdated.After(curPR.ReleaseUpdated) {
+			curPR.Release = inPR.Release
+			curPR.ReleaseUpdated = inPR.ReleaseUpdated
+		}
+		dropIgnoredBranches(curPR)
 	}
 
 	if !authoritative {
@@ -160,6 +238,60 @@ func mergeCherryTracked(cur, in []cmd.TrackedPR, authoritative bool) []cmd.Track
 	return kept
 }
 
+// dropIgnoredBranches removes pending/failed branches the PR now ignores. The
+// monotonic merge never deletes on its own, so without this an ignore would be
+// undone by the on-disk state it is merged onto.
+func dropIgnoredBranches(pr *cmd.TrackedPR) {
+	for _, name := range pr.IgnoredBranches {
+		if branch, ok := pr.Branches[name]; ok && branchRank(branch.Status) <= branchRank(cmd.BranchStatusFailed) {
+			delete(pr.Branches, name)
+		}
+	}
+}
+
+// leftMergeQueue reports whether the incoming branch records its queued
+// cherry-pick PR dropping out of the merge queue: the same PR back at picked,
+// stamped after the current branch was queued. A snapshot taken before the
+// enqueue carries an older stamp, so it can't undo it.
+func leftMergeQueue(in, cur cmd.BranchStatus) bool {
+	return cur.Status == cmd.BranchStatusQueued && in.Status == cmd.BranchStatusPicked &&
+		in.PR != nil && cur.PR != nil && in.PR.Number == cur.PR.Number &&
+		in.LastUpdated.After(cur.LastUpdated)
+}
+
+// withResolutionMethod carries the current branch's resolution method onto an
+// incoming branch for the same cherry-pick PR that does not record one. Only
+// the pick command knows how a PR was produced, so a daemon snapshot taken
+// before the pick saved must not erase it.
+func withResolutionMethod(in, cur cmd.BranchStatus) cmd.BranchStatus {
+	if in.PR == nil || cur.PR == nil || in.PR.Number != cur.PR.Number ||
+		in.PR.ResolutionMethod != "" || cur.PR.ResolutionMethod == "" {
+		return in
+	}
+	pr := *in.PR
+	pr.ResolutionMethod = cur.PR.ResolutionMethod
+	in.PR = &pr
+	return in
+}
+
+// withLastUpdated settles when the incoming branch's status last changed. The
+// commands that change a status without stamping it (merge, abort, reopen and
+// the like) get it stamped now; an unchanged status keeps the later of the two
+// times, so a view loaded before a fetch stamped it doesn't erase the stamp.
+func withLastUpdated(in, cur cmd.BranchStatus, exists bool) cmd.BranchStatus {
+	if !exists {
+		return in
+	}
+	if in.Status != cur.Status {
+		if in.LastUpdated.IsZero() {
+			in.LastUpdated = time.Now()
+		}
+	} else if cur.LastUpdated.After(in.LastUpdated) {
+		in.LastUpdated = cur.LastUpdated
+	}
+	return in
+}
+
 func mergeDepSection(cur *DependencySection, in DependencySection) {
 	cur.TrackedPRs = mergeDepTracked(cur.TrackedPRs, in.TrackedPRs)
 }
@@ -17,20 +17,62 @@ import (
 
 // Config is the unified on-disk representation.
 type Config struct {
-	Org           string            `yaml:"org"`
-	Repo          string            `yaml:"repo"`
-	LastFetchDate *time.Time        `yaml:"last_fetch_date,omitempty"`
-	CherryPicks   CherryPickSection `yaml:"cherry_picks"`
-	Dependencies  DependencySection `yaml:"dependencies"`
+	Org               string            `yaml:"org"`
+	Repo              string            `yaml:"repo"`
+	LastFetchDate     *time.Time        `yaml:"last_fetch_date,omitempty"`
+	TokenEnvVar       string            `yaml:"token_env_var,omitempty"`       // env var holding this repo's GitHub token (default GITHUB_TOKEN)
+	BaseURL           string            `yaml:"base_url,omitempty"`            // GitHub Enterprise Server API root; github.com if unset
+	HTTPTimeout       time.Duration     `yaml:"http_timeout,omitempty"`        // per-attempt GitHub request timeout (no limit if unset)
+	HTTPRetries       int               `yaml:"http_retries,omitempty"`        // transport retries of reads that fail to connect or get a 5xx
+	RateLimitRetries  int               `yaml:"rate_limit_retries,omitempty"`  // retries of rate limited requests (default github.DefaultMaxRetries)
+	RateLimitDelay    time.Duration     `yaml:"rate_limit_delay,omitempty"`    // first rate limit backoff (default github.DefaultRetryBaseDelay)
+	IgnoredCIContexts []string          `yaml:"ignored_ci_contexts,omitempty"` // CI contexts (e.g. CLA bots) ignored by both subsystems
+	GraphQLCIStatus   bool              `yaml:"graphql_ci_status,omitempty"`   // read PR CI via the GraphQL rollup in both subsystems
+	CherryPicks       CherryPickSection `yaml:"cherry_picks"`
+	Dependencies      DependencySection `yaml:"dependencies"`
 }
 
 // CherryPickSection holds the cherry-pick subsystem's config and tracked PRs.
 type CherryPickSection struct {
-	SourceBranch       string            `yaml:"source_branch"`
-	AIAssistantCommand string            `yaml:"ai_assistant_command"`
-	LastCheckedRelease map[string]string `yaml:"last_checked_release,omitempty"` // branch -> last checked release tag
-	TrackerIssues      map[string]int    `yaml:"tracker_issues,omitempty"`       // branch -> tracker issue number
-	TrackedPRs         []cmd.TrackedPR   `yaml:"tracked_prs,omitempty"`
+	SourceBranch              string                        `yaml:"source_branch"`
+	AIAssistantCommand        string                        `yaml:"ai_assistant_command"`
+	OnLabelRemoved            cmd.LabelRemovedPolicy        `yaml:"on_label_removed,omitempty"`
+	UseMergeQueue             bool                          `yaml:"use_merge_queue,omitempty"`
+	MergeMethod               cmd.MergeMethod               `yaml:"merge_method,omitempty"`
+	DeleteMergedBranches      bool                          `yaml:"delete_merged_branches,omitempty"`
+	StaleAfter                time.Duration                 `yaml:"stale_after,omitempty"`
+	ManualSearchMaxCandidates int                           `yaml:"manual_search_max_candidates,omitempty"`
+	CommitTrailers            []string                      `yaml:"commit_trailers,omitempty"`
+	InitialHistoryMaxCommits  int                           `yaml:"initial_history_max_commits,omitempty"`
+	InitialHistorySince       *time.Time                    `yaml:"initial_history_since,omitempty"`
+	NewBranchBase             cmd.NewBranchBasePolicy       `yaml:"new_branch_base,omitempty"`
+	HeadBranchPattern         string                        `yaml:"head_branch_pattern,omitempty"`
+	LabelPrefix               string                        `yaml:"label_prefix,omitempty"`
+	BranchTemplate            string                        `yaml:"branch_template,omitempty"`
+	TitleFormat               string                        `yaml:"title_format,omitempty"`
+	PostFetchCommand          string                        `yaml:"post_fetch_command,omitempty"`
+	FetchConcurrency          int                           `yaml:"fetch_concurrency,omitempty"`
+	IgnoredChecks             []string                      `yaml:"ignored_checks,omitempty"`
+	PendingGracePeriod        time.Duration                 `yaml:"pending_grace_period,omitempty"`
+	AIAssistantAutoLaunch     bool                          `yaml:"ai_assistant_auto_launch,omitempty"`
+	Signoff                   *bool                         `yaml:"signoff,omitempty"`
+	SignoffIdentity           string                        `yaml:"signoff_identity,omitempty"`
+	ReleaseLabel              string                        `yaml:"release_label,omitempty"`
+	SlackWebhookURL           string                        `yaml:"slack_webhook_url,omitempty"`
+	RequiredChecks            map[string][]string           `yaml:"required_checks,omitempty"`
+	ConflictStrategy          []cmd.ConflictStep            `yaml:"conflict_strategy,omitempty"`
+	ConflictAutoResolve       map[string]cmd.ConflictSide   `yaml:"conflict_auto_resolve,omitempty"`
+	LastCheckedRelease        map[string]string             `yaml:"last_checked_release,omitempty"` // branch -> last checked release tag
+	UnscannedReleases         map[string][]cmd.ReleaseRange `yaml:"unscanned_releases,omitempty"`   // branch -> release ranges a scan failed on
+	TrackerIssues             map[string]int                `yaml:"tracker_issues,omitempty"`       // branch -> tracker issue number
+	TrackedPRs                []cmd.TrackedPR               `yaml:"tracked_prs,omitempty"`
+	Repositories              []cmd.RepoConfig              `yaml:"repositories,omitempty"` // further repos tracked with these settings
+}
+
+// Configured reports whether the config has a cherry_picks section to act on,
+// as opposed to a dependency-only config
+func (s *CherryPickSection) Configured() bool {
+	return s.SourceBranch != "" || len(s.TrackedPRs) > 0 || len(s.Repositories) > 0
 }
 
 // DependencySection holds the dependency subsystem's tracked PRs.
@@ -44,14 +86,50 @@ type DependencySection struct {
 // ApplyCherryView / MergeCherryView.
 func (c *Config) CherryView() *cmd.Config {
 	return &cmd.Config{
-		Org:                c.Org,
-		Repo:               c.Repo,
-		SourceBranch:       c.CherryPicks.SourceBranch,
-		AIAssistantCommand: c.CherryPicks.AIAssistantCommand,
-		LastFetchDate:      c.LastFetchDate,
-		LastCheckedRelease: c.CherryPicks.LastCheckedRelease,
-		TrackerIssues:      c.CherryPicks.TrackerIssues,
-		TrackedPRs:         c.CherryPicks.TrackedPRs,
+		Org:                       c.Org,
+		Repo:                      c.Repo,
+		SourceBranch:              c.CherryPicks.SourceBranch,
+		AIAssistantCommand:        c.CherryPicks.AIAssistantCommand,
+		OnLabelRemoved:            c.CherryPicks.OnLabelRemoved,
+		UseMergeQueue:             c.CherryPicks.UseMergeQueue,
+		MergeMethod:               c.CherryPicks.MergeMethod,
+		DeleteMergedBranches:      c.CherryPicks.DeleteMergedBranches,
+		StaleAfter:                c.CherryPicks.StaleAfter,
+		ManualSearchMaxCandidates: c.CherryPicks.ManualSearchMaxCandidates,
+		CommitTrailers:            c.CherryPicks.CommitTrailers,
+		InitialHistoryMaxCommits:  c.CherryPicks.InitialHistoryMaxCommits,
+		InitialHistorySince:       c.CherryPicks.InitialHistorySince,
+		NewBranchBase:             c.CherryPicks.NewBranchBase,
+		HeadBranchPattern:         c.CherryPicks.HeadBranchPattern,
+		LabelPrefix:               c.CherryPicks.LabelPrefix,
+		BranchTemplate:            c.CherryPicks.BranchTemplate,
+		TitleFormat:               c.CherryPicks.TitleFormat,
+		PostFetchCommand:          c.CherryPicks.PostFetchCommand,
+		FetchConcurrency:          c.CherryPicks.FetchConcurrency,
+		IgnoredChecks:             c.CherryPicks.IgnoredChecks,
+		PendingGracePeriod:        c.CherryPicks.PendingGracePeriod,
+		AIAssistantAutoLaunch:     c.CherryPicks.AIAssistantAutoLaunch,
+		Signoff:                   c.CherryPicks.Signoff,
+		SignoffIdentity:           c.CherryPicks.SignoffIdentity,
+		ReleaseLabel:              c.CherryPicks.ReleaseLabel,
+		SlackWebhookURL:           c.CherryPicks.SlackWebhookURL,
+		RequiredChecks:            c.CherryPicks.RequiredChecks,
+		ConflictStrategy:          c.CherryPicks.ConflictStrategy,
+		ConflictAutoResolve:       c.CherryPicks.ConflictAutoResolve,
+		LastFetchDate:             c.LastFetchDate,
+		TokenEnvVar:               c.TokenEnvVar,
+		BaseURL:                   c.BaseURL,
+		HTTPTimeout:               c.HTTPTimeout,
+		HTTPRetries:               c.HTTPRetries,
+		RateLimitRetries:          c.RateLimitRetries,
+		RateLimitDelay:            c.RateLimitDelay,
+		IgnoredCIContexts:         c.IgnoredCIContexts,
+		GraphQLCIStatus:           c.GraphQLCIStatus,
+		LastCheckedRelease:        c.CherryPicks.LastCheckedRelease,
+		UnscannedReleases:         c.CherryPicks.UnscannedReleases,
+		TrackerIssues:             c.CherryPicks.TrackerIssues,
+		TrackedPRs:                c.CherryPicks.TrackedPRs,
+		Repositories:              c.CherryPicks.Repositories,
 	}
 }
 
@@ -62,11 +140,47 @@ func (c *Config) ApplyCherryView(v *cmd.Config) {
 	c.Org = v.Org
 	c.Repo = v.Repo
 	c.LastFetchDate = v.LastFetchDate
+	c.TokenEnvVar = v.TokenEnvVar
+	c.BaseURL = v.BaseURL
+	c.HTTPTimeout = v.HTTPTimeout
+	c.HTTPRetries = v.HTTPRetries
+	c.RateLimitRetries = v.RateLimitRetries
+	c.RateLimitDelay = v.RateLimitDelay
+	c.IgnoredCIContexts = v.IgnoredCIContexts
+	c.GraphQLCIStatus = v.GraphQLCIStatus
 	c.CherryPicks.SourceBranch = v.SourceBranch
 	c.CherryPicks.AIAssistantCommand = v.AIAssistantCommand
+	c.CherryPicks.OnLabelRemoved = v.OnLabelRemoved
+	c.CherryPicks.UseMergeQueue = v.UseMergeQueue
+	c.CherryPicks.MergeMethod = v.MergeMethod
+	c.CherryPicks.DeleteMergedBranches = v.DeleteMergedBranches
+	c.CherryPicks.StaleAfter = v.StaleAfter
+	c.CherryPicks.ManualSearchMaxCandidates = v.ManualSearchMaxCandidates
+	c.CherryPicks.CommitTrailers = v.CommitTrailers
+	c.CherryPicks.InitialHistoryMaxCommits = v.InitialHistoryMaxCommits
+	c.CherryPicks.InitialHistorySince = v.InitialHistorySince
+	c.CherryPicks.NewBranchBase = v.NewBranchBase
+	c.CherryPicks.HeadBranchPattern = v.HeadBranchPattern
+	c.CherryPicks.LabelPrefix = v.LabelPrefix
+	c.CherryPicks.BranchTemplate = v.BranchTemplate
+	c.CherryPicks.TitleFormat = v.TitleFormat
+	c.CherryPicks.PostFetchCommand = v.PostFetchCommand
+	c.CherryPicks.FetchConcurrency = v.FetchConcurrency
+	c.CherryPicks.IgnoredChecks = v.IgnoredChecks
+	c.CherryPicks.PendingGracePeriod = v.PendingGracePeriod
+	c.CherryPicks.AIAssistantAutoLaunch = v.AIAssistantAutoLaunch
+	c.CherryPicks.Signoff = v.Signoff
+	c.CherryPicks.SignoffIdentity = v.SignoffIdentity
+	c.CherryPicks.ReleaseLabel = v.ReleaseLabel
+	c.CherryPicks.SlackWebhookURL = v.SlackWebhookURL
+	c.CherryPicks.RequiredChecks = v.RequiredChecks
+	c.CherryPicks.ConflictStrategy = v.ConflictStrategy
+	c.CherryPicks.ConflictAutoResolve = v.ConflictAutoResolve
 	c.CherryPicks.LastCheckedRelease = v.LastCheckedRelease
+	c.CherryPicks.UnscannedReleases = v.UnscannedReleases
 	c.CherryPicks.TrackerIssues = v.TrackerIssues
 	c.CherryPicks.TrackedPRs = v.TrackedPRs
+	c.CherryPicks.Repositories = v.Repositories
 }
 
 // DepView projects the shared fields plus the dependency section into the
@@ -7,6 +7,7 @@ import (
 
 	"github.com/alan/cherry-picker/cmd"
 	"github.com/alan/cherry-picker/internal/depmerger"
+	"github.com/alan/cherry-picker/internal/lockfile"
 	"github.com/alan/cherry-picker/internal/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -64,6 +65,34 @@ func TestUpdateReloadMerge(t *testing.T) {
 	assert.Equal(t, "acme", out.Org)
 }
 
+func TestUpdateLocking(t *testing.T) {
+	path := tmpConfigPath(t)
+	require.NoError(t, Save(path, &Config{Org: "acme", Repo: "widget"}))
+	t.Cleanup(func() { SetLocking(false, DefaultLockTimeout) })
+
+	held, err := lockfile.Acquire(path)
+	require.NoError(t, err)
+	defer func() { _ = held.Release() }()
+
+	SetLocking(false, 100*time.Millisecond)
+	err = Update(path, func(c *Config) error {
+		c.Repo = "gadget"
+		return nil
+	})
+	require.ErrorIs(t, err, lockfile.ErrTimeout)
+	require.ErrorContains(t, err, "--no-lock")
+
+	SetLocking(true, 100*time.Millisecond)
+	require.NoError(t, Update(path, func(c *Config) error {
+		c.Repo = "gadget"
+		return nil
+	}), "--no-lock saves while another process holds the lock")
+
+	out, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "gadget", out.Repo)
+}
+
 func TestMergeFetchedDoesNotRegressCherryBranch(t *testing.T) {
 	// User advanced the branch to merged; a stale fetch snapshot still shows picked.
 	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
@@ -79,6 +108,53 @@ func TestMergeFetchedDoesNotRegressCherryBranch(t *testing.T) {
 	assert.Equal(t, cmd.BranchStatusMerged, cur.CherryPicks.TrackedPRs[0].Branches["release-3.6"].Status)
 }
 
+func TestMergeFetchedDoesNotRegressQueuedBranch(t *testing.T) {
+	// merge added the PR to the merge queue; a stale snapshot still shows picked.
+	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number:   1,
+		Branches: map[string]cmd.BranchStatus{"release-3.6": {Status: cmd.BranchStatusQueued}},
+	}}}}
+	fetched := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number:   1,
+		Branches: map[string]cmd.BranchStatus{"release-3.6": {Status: cmd.BranchStatusPicked}},
+	}}}}
+
+	cur.MergeFetched(fetched)
+	assert.Equal(t, cmd.BranchStatusQueued, cur.CherryPicks.TrackedPRs[0].Branches["release-3.6"].Status)
+}
+
+func TestMergeFetchedTakesDequeuedBranch(t *testing.T) {
+	queuedAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{"release-3.6": {
+			Status: cmd.BranchStatusQueued, PR: &cmd.PickPR{Number: 10}, LastUpdated: queuedAt,
+		}},
+	}}}}
+
+	// A snapshot from before the enqueue doesn't undo it
+	stale := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{"release-3.6": {
+			Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 10}, LastUpdated: queuedAt.Add(-time.Hour),
+		}},
+	}}}}
+	cur.MergeFetched(stale)
+	assert.Equal(t, cmd.BranchStatusQueued, cur.CherryPicks.TrackedPRs[0].Branches["release-3.6"].Status)
+
+	// A fetch that saw the PR leave the queue moves it back to picked
+	dequeued := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{"release-3.6": {
+			Status: cmd.BranchStatusPicked, PR: &cmd.PickPR{Number: 10, CIStatus: cmd.CIStatusFailing}, LastUpdated: queuedAt.Add(time.Hour),
+		}},
+	}}}}
+	cur.MergeFetched(dequeued)
+	branch := cur.CherryPicks.TrackedPRs[0].Branches["release-3.6"]
+	assert.Equal(t, cmd.BranchStatusPicked, branch.Status)
+	assert.Equal(t, cmd.CIStatusFailing, branch.PR.CIStatus)
+}
+
 func TestMergeFetchedAdvancesCherryBranch(t *testing.T) {
 	// Fetch sees the branch advance from picked to merged; it should apply.
 	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
@@ -94,6 +170,74 @@ func TestMergeFetchedAdvancesCherryBranch(t *testing.T) {
 	assert.Equal(t, cmd.BranchStatusMerged, cur.CherryPicks.TrackedPRs[0].Branches["release-3.6"].Status)
 }
 
+func TestMergeFetchedPreservesResolutionMethod(t *testing.T) {
+	// pick recorded how the PR was produced; a snapshot of the same PR taken
+	// before that save (or a later fetch) does not know it and must not erase it.
+	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{"release-3.6": {
+			Status: cmd.BranchStatusPicked,
+			PR:     &cmd.PickPR{Number: 10, CIStatus: types.CIStatusPending, ResolutionMethod: cmd.ResolutionAIAssisted},
+		}},
+	}}}}
+	fetched := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{"release-3.6": {
+			Status: cmd.BranchStatusMerged,
+			PR:     &cmd.PickPR{Number: 10, CIStatus: types.CIStatusPassing},
+		}},
+	}}}}
+
+	cur.MergeFetched(fetched)
+	branch := cur.CherryPicks.TrackedPRs[0].Branches["release-3.6"]
+	assert.Equal(t, cmd.BranchStatusMerged, branch.Status)
+	assert.Equal(t, types.CIStatusPassing, branch.PR.CIStatus)
+	assert.Equal(t, cmd.ResolutionAIAssisted, branch.PR.ResolutionMethod)
+	assert.Empty(t, fetched.CherryPicks.TrackedPRs[0].Branches["release-3.6"].PR.ResolutionMethod, "snapshot must not be mutated")
+}
+
+func TestMergeCherryViewTracksLastUpdated(t *testing.T) {
+	// fetch stamped the picked branch; a command's view loaded before that
+	// save keeps its status and must not erase the stamp, while a status
+	// change the command didn't stamp (e.g. merge) is stamped on save.
+	picked := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{
+			"release-3.6": {Status: cmd.BranchStatusPicked, LastUpdated: picked},
+			"release-3.7": {Status: cmd.BranchStatusPicked, LastUpdated: picked},
+		},
+	}}}}
+	view := cur.CherryView()
+	view.TrackedPRs = []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{
+			"release-3.6": {Status: cmd.BranchStatusPicked},
+			"release-3.7": {Status: cmd.BranchStatusMerged},
+		},
+	}}
+
+	before := time.Now()
+	cur.MergeCherryView(view)
+	branches := cur.CherryPicks.TrackedPRs[0].Branches
+	assert.Equal(t, picked, branches["release-3.6"].LastUpdated)
+	assert.False(t, branches["release-3.7"].LastUpdated.Before(before), "status change is stamped")
+}
+
+func TestMergeFetchedReplacesUnscannedReleases(t *testing.T) {
+	// A fetch that rescanned a range successfully drops it; a view from any
+	// other command must leave the on-disk list alone.
+	cur := &Config{CherryPicks: CherryPickSection{UnscannedReleases: map[string][]cmd.ReleaseRange{
+		"release-3.6": {{From: "v3.6.1", To: "v3.6.2"}},
+	}}}
+
+	cur.MergeCherryView(&cmd.Config{})
+	assert.Len(t, cur.CherryPicks.UnscannedReleases["release-3.6"], 1)
+
+	cur.MergeFetched(&Config{})
+	assert.Empty(t, cur.CherryPicks.UnscannedReleases)
+}
+
 func TestMergeFetchedRemovesBranchWhenLabelRemoved(t *testing.T) {
 	// The cherry-pick/3.6 label was removed upstream, so the fetch snapshot no
 	// longer carries the pending branch; it must not be resurrected from disk.
@@ -135,6 +279,28 @@ func TestMergeFetchedRemovesFailedBranchWhenLabelRemoved(t *testing.T) {
 	assert.Contains(t, branches, "release-3.5")
 }
 
+func TestMergeFetchedKeepPolicyRetainsBranchWhenLabelRemoved(t *testing.T) {
+	cur := &Config{CherryPicks: CherryPickSection{
+		OnLabelRemoved: cmd.LabelRemovedKeep,
+		TrackedPRs: []cmd.TrackedPR{{
+			Number: 1,
+			Branches: map[string]cmd.BranchStatus{
+				"release-3.6": {Status: cmd.BranchStatusPending},
+				"release-3.5": {Status: cmd.BranchStatusMerged},
+			},
+		}},
+	}}
+	fetched := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number:   1,
+		Branches: map[string]cmd.BranchStatus{"release-3.5": {Status: cmd.BranchStatusMerged}},
+	}}}}
+
+	cur.MergeFetched(fetched)
+	branches := cur.CherryPicks.TrackedPRs[0].Branches
+	assert.Contains(t, branches, "release-3.6", "keep policy must retain pending branch with removed label")
+	assert.Contains(t, branches, "release-3.5")
+}
+
 func TestMergeFetchedDropsPRAbsentFromSnapshot(t *testing.T) {
 	// All labels were removed from PR 1, so the fetch removed it entirely; PR 2
 	// is still tracked and must survive.
@@ -218,3 +384,117 @@ func TestViewsRoundTrip(t *testing.T) {
 	dv := c.DepView()
 	assert.Equal(t, "widget", dv.Repo)
 }
+
+func TestMergeIgnoredBranches(t *testing.T) {
+	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{
+			"release-3.6": {Status: cmd.BranchStatusPending},
+			"release-3.5": {Status: cmd.BranchStatusMerged},
+		},
+	}}}}
+
+	// The ignore command's view drops the branch and records the decision
+	view := cur.CherryView()
+	ignoredAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	view.TrackedPRs = []cmd.TrackedPR{{
+		Number:                 1,
+		Branches:               map[string]cmd.BranchStatus{"release-3.5": {Status: cmd.BranchStatusMerged}},
+		IgnoredBranches:        []string{"release-3.6"},
+		IgnoredBranchesUpdated: ignoredAt,
+	}}
+	cur.MergeCherryView(view)
+	pr := cur.CherryPicks.TrackedPRs[0]
+	assert.Equal(t, []string{"release-3.6"}, pr.IgnoredBranches)
+	assert.NotContains(t, pr.Branches, "release-3.6", "ignored branch must not be resurrected from disk")
+
+	// A fetch snapshot taken before the ignore neither reverts it nor re-adds the branch
+	fetched := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{
+		Number: 1,
+		Branches: map[string]cmd.BranchStatus{
+			"release-3.6": {Status: cmd.BranchStatusPending},
+			"release-3.5": {Status: cmd.BranchStatusMerged},
+		},
+	}}}}
+	cur.MergeFetched(fetched)
+	pr = cur.CherryPicks.TrackedPRs[0]
+	assert.Equal(t, []string{"release-3.6"}, pr.IgnoredBranches)
+	assert.NotContains(t, pr.Branches, "release-3.6")
+
+	// A command view loaded before the ignore carries no stamp and doesn't revert it
+	view = cur.CherryView()
+	view.TrackedPRs = []cmd.TrackedPR{{Number: 1, Title: "Fix"}}
+	cur.MergeCherryView(view)
+	assert.Equal(t, []string{"release-3.6"}, cur.CherryPicks.TrackedPRs[0].IgnoredBranches)
+
+	// unignore clears the list through a view
+	view = cur.CherryView()
+	view.TrackedPRs = []cmd.TrackedPR{{Number: 1, IgnoredBranchesUpdated: ignoredAt.Add(time.Minute)}}
+	cur.MergeCherryView(view)
+	assert.Empty(t, cur.CherryPicks.TrackedPRs[0].IgnoredBranches)
+}
+
+func TestMergeReleaseAnnotation(t *testing.T) {
+	cur := &Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{Number: 1}}}}
+	setAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	view := &cmd.Config{TrackedPRs: []cmd.TrackedPR{{Number: 1, Release: "v3.7.5", ReleaseUpdated: setAt}}}
+	cur.MergeCherryView(view)
+	assert.Equal(t, "v3.7.5", cur.CherryPicks.TrackedPRs[0].Release)
+
+	// A fetch snapshot taken before set-release doesn't revert it
+	cur.MergeFetched(&Config{CherryPicks: CherryPickSection{TrackedPRs: []cmd.TrackedPR{{Number: 1, Title: "Fix"}}}})
+	assert.Equal(t, "v3.7.5", cur.CherryPicks.TrackedPRs[0].Release)
+
+	// Nor does a command that loaded the state before it
+	cur.MergeCherryView(&cmd.Config{TrackedPRs: []cmd.TrackedPR{{Number: 1, Title: "Fix"}}})
+	assert.Equal(t, "v3.7.5", cur.CherryPicks.TrackedPRs[0].Release)
+
+	view = &cmd.Config{TrackedPRs: []cmd.TrackedPR{{Number: 1, ReleaseUpdated: setAt.Add(time.Minute)}}}
+	cur.MergeCherryView(view)
+	assert.Empty(t, cur.CherryPicks.TrackedPRs[0].Release, "set-release --clear")
+}
+
+func TestMergeRepositories(t *testing.T) {
+	cur := &Config{CherryPicks: CherryPickSection{Repositories: []cmd.RepoConfig{{
+		Org: "acme", Repo: "gadget", SourceBranch: "main",
+		TrackedPRs: []cmd.TrackedPR{{Number: 7, Branches: map[string]cmd.BranchStatus{
+			"release-1.0": {Status: cmd.BranchStatusMerged},
+			"release-2.0": {Status: cmd.BranchStatusPending},
+		}}},
+	}}}}
+	fetched := &Config{CherryPicks: CherryPickSection{Repositories: []cmd.RepoConfig{
+		{
+			Org: "acme", Repo: "gadget",
+			LastCheckedRelease: map[string]string{"release-1.0": "v1.0.1"},
+			TrackedPRs: []cmd.TrackedPR{{Number: 7, Branches: map[string]cmd.BranchStatus{
+				"release-1.0": {Status: cmd.BranchStatusPicked},
+			}}},
+		},
+		{Org: "acme", Repo: "gizmo", TrackedPRs: []cmd.TrackedPR{{Number: 9}}},
+	}}}
+
+	cur.MergeFetched(fetched)
+
+	require.Len(t, cur.CherryPicks.Repositories, 2, "a new repository entry is added")
+	gadget := cur.CherryPicks.Repositories[0]
+	assert.Equal(t, "main", gadget.SourceBranch, "an empty incoming source branch doesn't clear it")
+	assert.Equal(t, "v1.0.1", gadget.LastCheckedRelease["release-1.0"])
+	branches := gadget.TrackedPRs[0].Branches
+	assert.Equal(t, cmd.BranchStatusMerged, branches["release-1.0"].Status, "merged must not be regressed")
+	assert.NotContains(t, branches, "release-2.0", "a pending branch missing from the fetch is dropped, as at the top level")
+	assert.Equal(t, "gizmo", cur.CherryPicks.Repositories[1].Repo)
+}
+
+func TestMergeCherryViewRepositories(t *testing.T) {
+	cur := &Config{CherryPicks: CherryPickSection{Repositories: []cmd.RepoConfig{{Org: "acme", Repo: "gadget"}}}}
+	view := cur.CherryView()
+	views := view.RepositoryViews()
+	views[1].TrackedPRs = []cmd.TrackedPR{{Number: 3, Branches: map[string]cmd.BranchStatus{"release-1.0": {Status: cmd.BranchStatusPicked}}}}
+	view.ApplyRepositoryView(1, views[1])
+
+	saved := &Config{CherryPicks: CherryPickSection{Repositories: []cmd.RepoConfig{{Org: "acme", Repo: "gadget"}}}}
+	saved.MergeCherryView(view)
+	require.Len(t, saved.CherryPicks.Repositories[0].TrackedPRs, 1)
+	assert.Empty(t, saved.CherryPicks.TrackedPRs, "the view's PRs land in their repository, not the top level")
+}
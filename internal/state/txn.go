@@ -2,22 +2,54 @@ package state
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"time"
 
 	"github.com/alan/cherry-picker/internal/lockfile"
 )
 
+// DefaultLockTimeout is how long Update waits for another writer to release
+// the lock before giving up
+const DefaultLockTimeout = 30 * time.Second
+
+// Process-wide writer lock settings, set from the global --no-lock and
+// --lock-timeout flags
+var (
+	lockDisabled bool
+	lockTimeout  = DefaultLockTimeout
+)
+
+// SetLocking configures the writer lock Update takes: disabled skips it, for
+// filesystems without flock support, and timeout bounds the wait for it (0
+// waits forever).
+func SetLocking(disabled bool, timeout time.Duration) {
+	lockDisabled = disabled
+	lockTimeout = timeout
+}
+
 // Update is the transactional primitive every writer uses. It acquires the
 // exclusive writer lock, reloads the current on-disk state (so it picks up any
 // changes made by another writer since this process last read the file),
 // applies mutate, and saves atomically. Reloading inside the lock is what
 // prevents read-modify-write clobbering between the daemon and CLI commands.
+//
+// With locking disabled (SetLocking) concurrent writers can lose each other's
+// changes, so that is only safe when a single process writes the file.
 func Update(path string, mutate func(*Config) error) error {
-	lk, err := lockfile.Acquire(path)
-	if err != nil {
-		return err
+	if lockDisabled {
+		slog.Debug("Saving without the writer lock", "file", path)
+	} else {
+		lk, err := lockfile.AcquireTimeout(path, lockTimeout)
+		if errors.Is(err, lockfile.ErrTimeout) {
+			return fmt.Errorf("another cherry-picker process is still writing %s: %w (raise --lock-timeout, or pass --no-lock if no other process is running)", path, err)
+		}
+		if err != nil {
+			return err
+		}
+		defer func() { _ = lk.Release() }()
 	}
-	defer func() { _ = lk.Release() }()
 
 	c, err := Load(path)
 	if err != nil {
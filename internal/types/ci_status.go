@@ -19,6 +19,8 @@ const (
 	CIStatusPending CIStatus = "pending"
 	// CIStatusUnknown indicates CI status could not be determined
 	CIStatusUnknown CIStatus = "unknown"
+	// CIStatusNoChecks indicates the commit has no status checks or check runs at all
+	CIStatusNoChecks CIStatus = "no_checks"
 )
 
 // ParseCIStatus converts a string to CIStatus
@@ -30,6 +32,8 @@ func ParseCIStatus(s string) CIStatus {
 		return CIStatusFailing
 	case "pending":
 		return CIStatusPending
+	case "no_checks":
+		return CIStatusNoChecks
 	default:
 		return CIStatusUnknown
 	}
@@ -2,6 +2,24 @@ package types
 
 import "testing"
 
+func TestParseCIStatus(t *testing.T) {
+	tests := map[string]CIStatus{
+		"passing":   CIStatusPassing,
+		"failing":   CIStatusFailing,
+		"pending":   CIStatusPending,
+		"no_checks": CIStatusNoChecks,
+		"unknown":   CIStatusUnknown,
+		"":          CIStatusUnknown,
+		"bogus":     CIStatusUnknown,
+	}
+
+	for in, want := range tests {
+		if got := ParseCIStatus(in); got != want {
+			t.Errorf("ParseCIStatus(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
 func TestIsCriticalCheck(t *testing.T) {
 	tests := []struct {
 		name  string
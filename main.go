@@ -2,12 +2,34 @@
 package main
 
 import (
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"time"
 
+	"github.com/alan/cherry-picker/cmd/abort"
 	configcmd "github.com/alan/cherry-picker/cmd/config"
+	"github.com/alan/cherry-picker/cmd/diff"
+	"github.com/alan/cherry-picker/cmd/export"
+	"github.com/alan/cherry-picker/cmd/ignore"
+	"github.com/alan/cherry-picker/cmd/markmerged"
+	"github.com/alan/cherry-picker/cmd/open"
 	"github.com/alan/cherry-picker/cmd/pick"
+	"github.com/alan/cherry-picker/cmd/propagate"
+	"github.com/alan/cherry-picker/cmd/reconcile"
+	"github.com/alan/cherry-picker/cmd/renamebranch"
+	"github.com/alan/cherry-picker/cmd/reopen"
+	"github.com/alan/cherry-picker/cmd/review"
+	"github.com/alan/cherry-picker/cmd/setrelease"
 	"github.com/alan/cherry-picker/cmd/summary"
+	"github.com/alan/cherry-picker/cmd/verifylinks"
+	"github.com/alan/cherry-picker/cmd/wait"
+	"github.com/alan/cherry-picker/internal/commands"
+	"github.com/alan/cherry-picker/internal/github"
+	"github.com/alan/cherry-picker/internal/output"
+	"github.com/alan/cherry-picker/internal/redact"
+	"github.com/alan/cherry-picker/internal/state"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +37,10 @@ func main() {
 	var configFile string
 	var logLevel string
 	var logFormat string
+	var githubToken string
+	var dryRun bool
+	var noLock bool
+	var lockTimeout time.Duration
 
 	rootCmd := &cobra.Command{
 		Use:   "cherry-picker",
@@ -24,19 +50,48 @@ PRs for a GitHub repository, tracking their state in a single YAML file. Run the
 daemon to keep that state fresh in the background so interactive commands are
 instant.`,
 		PersistentPreRun: func(_ *cobra.Command, _ []string) {
-			setupLogger(logLevel, logFormat)
+			// Logs are progress chatter: keep them off stdout so piping a
+			// command's output (summary, status, fetch --jsonl) captures only data.
+			// output.Stderr keeps lines from parallel goroutines whole.
+			setupLogger(logLevel, logFormat, output.Stderr)
+			commands.SetGitHubToken(githubToken)
+			commands.SetDryRun(dryRun)
+			state.SetLocking(noLock, lockTimeout)
+			if dryRun {
+				printDryRunBanner(output.Stderr)
+			}
 		},
 	}
 
 	// Add global flags
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", defaultConfigFile, "Configuration file path")
+	rootCmd.PersistentFlags().StringVar(&configOutFile, "config-out", "", "Write results to this file instead of --config (which is still read)")
 	rootCmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "info", "Log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().StringVarP(&logFormat, "log-format", "f", "text", "Log format (text, json)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Read from GitHub but only log PR creation, merges, approvals, comments, workflow retries and pushes; never save the config")
+	rootCmd.PersistentFlags().BoolVar(&noLock, "no-lock", false, "Save the config without taking its lock file; only safe when no other cherry-picker process writes it")
+	rootCmd.PersistentFlags().DurationVar(&lockTimeout, "lock-timeout", state.DefaultLockTimeout, "How long to wait for another cherry-picker process to release the config's lock file (0 waits forever)")
+	rootCmd.PersistentFlags().StringVar(&githubToken, "github-token", "", "GitHub token to use instead of GITHUB_TOKEN/token_env_var (the env var is safer: flags show up in shell history and ps)")
 
 	// Cherry-pick-only commands, wired to the unified state via adapters.
 	rootCmd.AddCommand(configcmd.NewConfigCmd(&configFile, loadCherry, saveCherry))
 	rootCmd.AddCommand(pick.NewPickCmd(&configFile, loadCherry, saveCherry))
 	rootCmd.AddCommand(summary.NewSummaryCmd(&configFile, loadCherry))
+	rootCmd.AddCommand(wait.NewWaitCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(propagate.NewPropagateCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(ignore.NewIgnoreCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(ignore.NewUnignoreCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(setrelease.NewSetReleaseCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(reopen.NewReopenCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(abort.NewAbortCmd(&configFile, loadCherry, replaceCherry))
+	rootCmd.AddCommand(markmerged.NewMarkMergedCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(reconcile.NewReconcileReleasesCmd(&configFile, loadCherry, saveCherry))
+	rootCmd.AddCommand(verifylinks.NewVerifyLinksCmd(&configFile, loadCherry))
+	rootCmd.AddCommand(review.NewReviewCmd(&configFile, loadCherry))
+	rootCmd.AddCommand(open.NewOpenCmd(&configFile, loadCherry))
+	rootCmd.AddCommand(diff.NewDiffCmd(&configFile, loadCherry))
+	rootCmd.AddCommand(export.NewExportCmd(&configFile, loadCherry))
+	rootCmd.AddCommand(renamebranch.NewRenameBranchCmd(&configFile, loadCherry, replaceCherry))
 
 	// Unified commands spanning both subsystems.
 	rootCmd.AddCommand(newFetchCmd(&configFile))
@@ -48,11 +103,33 @@ instant.`,
 	rootCmd.AddCommand(newDaemonCmd(&configFile))
 
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
-func setupLogger(level, format string) {
+// Exit codes, so automation can tell "fix your credentials" apart from a
+// failure worth retrying
+const (
+	exitFailure     = 1 // any other error
+	exitAuthFailure = 3 // no GitHub token, or GitHub rejected it (401)
+)
+
+// exitCode returns the process exit code for a command's error
+func exitCode(err error) int {
+	if github.IsAuthError(err) {
+		return exitAuthFailure
+	}
+	return exitFailure
+}
+
+// printDryRunBanner warns that this run changes nothing, so its output isn't
+// mistaken for the real thing
+func printDryRunBanner(out io.Writer) {
+	const rule = "================================================================"
+	fmt.Fprintf(out, "%s\n  DRY RUN: nothing is created, merged, pushed or saved\n%s\n", rule, rule)
+}
+
+func setupLogger(level, format string, out io.Writer) {
 	var logLevel slog.Level
 	switch level {
 	case "debug":
@@ -67,11 +144,14 @@ func setupLogger(level, format string) {
 		logLevel = slog.LevelInfo
 	}
 
+	// Scrub tokens from every record so debug logs are safe to share
+	opts := &slog.HandlerOptions{Level: logLevel, ReplaceAttr: redact.ReplaceAttr}
+
 	var handler slog.Handler
 	if format == "json" {
-		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel})
+		handler = slog.NewJSONHandler(out, opts)
 	} else {
-		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel})
+		handler = slog.NewTextHandler(out, opts)
 	}
 
 	slog.SetDefault(slog.New(handler))